@@ -3,16 +3,31 @@ package main
 import (
 	"comu/config"
 	"comu/internal/modules/auth"
+	"comu/internal/modules/notifications"
 	"comu/internal/modules/post"
 	"comu/internal/modules/users"
+	"comu/internal/modules/webhooks"
 	"comu/internal/shared/logger"
+	"comu/internal/shared/mailer"
+	"comu/internal/shared/sms"
+	"context"
 	"database/sql"
+	"errors"
+	"expvar"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 
 	logger := logger.NewLogger()
@@ -29,12 +44,57 @@ func main() {
 	}
 	defer db.Close()
 
+	mailSender, err := mailer.NewSender(mailer.DriverConfig{
+		Driver: config.MailDriver,
+		From:   config.MailFrom,
+		Host:   config.MailHost,
+		Port:   config.MailPort,
+		Auth: mailer.SmtpAuth{
+			Username: config.MailUserName,
+			Password: config.MailPassword,
+		},
+		EnableTLS: config.AppEnv == "production" || config.AppEnv == "prod",
+		FileDir:   config.MailFileDir,
+	}, logger)
+
+	if err != nil {
+		logger.Error.Fatalln(err)
+	}
+
+	mailOutbox := mailer.NewOutbox(
+		mailer.NewMysqlOutboxRepository(db), mailSender,
+		mailer.OutboxOptions{
+			Workers:     config.MailOutboxWorkers,
+			MaxAttempts: config.MailOutboxMaxAttempts,
+		},
+		logger,
+	)
+	// The outbox workers and the jobs stop once the server has shut down.
+	outboxCtx, stopOutbox := context.WithCancel(context.Background())
+	mailOutbox.Start(outboxCtx)
+	expvar.Publish("mail_outbox", expvar.Func(func() any { return mailOutbox.Metrics() }))
+
+	smsSender, err := sms.NewSender(sms.DriverConfig{
+		Driver:       config.SmsDriver,
+		From:         config.SmsFrom,
+		GatewayURL:   config.SmsGatewayURL,
+		GatewayToken: config.SmsGatewayToken,
+	}, logger)
+
+	if err != nil {
+		logger.Error.Fatalln(err)
+	}
+
 	// Initialize modules and inject db and logging dependencies
-	usersModule := users.NewModule(db)
-	authModule := auth.NewModule(db, config, usersModule.GetPublicApi(), logger)
-	postModule := post.NewModule(db, authModule.GetPublicApi(), logger)
+	webhooksModule := webhooks.NewModule(db, config, logger)
+	usersModule := users.NewModule(db, webhooksModule.GetPublicApi(), logger)
+	notificationsModule := notifications.NewModule(db, config, usersModule.GetPublicApi(), mailOutbox, logger)
+	authModule := auth.NewModule(db, config, usersModule.GetPublicApi(), notificationsModule.GetPublicApi(), smsSender, logger)
+	postModule := post.NewModule(db, config, authModule.GetPublicApi(), notificationsModule.GetPublicApi(), webhooksModule.GetPublicApi(), logger)
+	notificationsModule.RegisterDigestSource(postModule.GetDigestSource())
 
 	e := echo.New()
+	e.IPExtractor = ipExtractor(config.TrustedProxies)
 	e.Use(
 		middleware.Secure(),
 		middleware.Recover(),
@@ -42,11 +102,75 @@ func main() {
 		middleware.RemoveTrailingSlash(),
 	)
 
+	// Exposes the runtime metrics, like the mail outbox ones, when explicitly enabled.
+	if config.DebugVarsEnabled {
+		e.GET("/debug/vars", echo.WrapHandler(expvar.Handler()))
+	}
+
 	// Register modules routes
 	authModule.RegisterRoutes(e)
 	postModule.RegisterRoutes(e)
+	notificationsModule.RegisterRoutes(
+		e,
+		authModule.GetPublicApi().AuthMiddleware,
+		authModule.GetPublicApi().VerifiedMiddleware,
+	)
+
+	webhooksModule.RegisterRoutes(
+		e,
+		authModule.GetPublicApi().AuthMiddleware,
+		authModule.GetPublicApi().VerifiedMiddleware,
+	)
+
+	postModule.StartJobs(outboxCtx)
+	notificationsModule.StartJobs(outboxCtx)
+	webhooksModule.StartJobs(outboxCtx)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := e.Start(config.AppAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.Logger.Fatal(err)
+		}
+	}()
+	<-ctx.Done()
+
+	// The notification streams never end on their own, so they are closed first
+	// to let the server finish the in-flight requests.
+	notificationsModule.Shutdown()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		logger.Error.Println(err)
+	}
+	stopOutbox()
+	postModule.WaitJobs()
+	notificationsModule.WaitJobs()
+	webhooksModule.WaitJobs()
+	mailOutbox.Wait()
+}
+
+// ipExtractor returns how the client ip address of the requests is found. The
+// X-Forwarded-For header can be set by anyone, so it is only read when the
+// request comes from one of the trusted proxies.
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, ipRange := range trustedProxies {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
 
-	e.Logger.Fatal(e.Start(config.AppAddr))
+	return echo.ExtractIPFromXFFHeader(options...)
 }
 
 func openDB(driver, dsn string) (*sql.DB, error) {
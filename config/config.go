@@ -1,6 +1,10 @@
 package config
 
 import (
+	"comu/internal/modules/auth/domain"
+	"errors"
+	"fmt"
+	"net"
 	"strings"
 
 	"github.com/mazen160/go-random"
@@ -12,13 +16,69 @@ type Config struct {
 	AppEnv       string `mapstructure:"APP_ENV"`
 	AppKey       string `mapstructure:"APP_KEY"`
 	AppAddr      string `mapstructure:"APP_ADDR"`
+	AppURL       string `mapstructure:"APP_URL"`
+	SessionMode  string `mapstructure:"SESSION_MODE"`
 	DBDriver     string `mapstructure:"DB_DRIVER"`
 	DBSource     string `mapstructure:"DB_SOURCE"`
+	MailDriver   string `mapstructure:"MAIL_DRIVER"`
 	MailHost     string `mapstructure:"MAIL_HOST"`
 	MailPort     int    `mapstructure:"MAIL_PORT"`
 	MailFrom     string `mapstructure:"MAIL_FROM"`
 	MailUserName string `mapstructure:"MAIL_USERNAME"`
 	MailPassword string `mapstructure:"MAIL_PASSWORD"`
+
+	// VerificationRedirectURL is the frontend page the verification links redirect to.
+	VerificationRedirectURL string `mapstructure:"VERIFICATION_REDIRECT_URL"`
+
+	// MailTemplatesDir overrides the embedded email templates with the ones it contains.
+	MailTemplatesDir string `mapstructure:"MAIL_TEMPLATES_DIR"`
+	// MailFileDir is the directory the file mail driver writes the emails to.
+	MailFileDir string `mapstructure:"MAIL_FILE_DIR"`
+
+	// DebugVarsEnabled exposes the runtime metrics on /debug/vars. It must stay off
+	// when the server is reachable by anyone, since the route isn't authenticated.
+	DebugVarsEnabled bool `mapstructure:"DEBUG_VARS_ENABLED"`
+
+	MailOutboxWorkers     int `mapstructure:"MAIL_OUTBOX_WORKERS"`
+	MailOutboxMaxAttempts int `mapstructure:"MAIL_OUTBOX_MAX_ATTEMPTS"`
+
+	SmsDriver string `mapstructure:"SMS_DRIVER"`
+	SmsFrom   string `mapstructure:"SMS_FROM"`
+	// SmsGatewayURL is the endpoint the http sms driver posts the messages to.
+	SmsGatewayURL   string `mapstructure:"SMS_GATEWAY_URL"`
+	SmsGatewayToken string `mapstructure:"SMS_GATEWAY_TOKEN"`
+
+	PowEnabled    bool `mapstructure:"POW_ENABLED"`
+	PowDifficulty int  `mapstructure:"POW_DIFFICULTY"`
+
+	RegistrationMode           string   `mapstructure:"REGISTRATION_MODE"`
+	RegistrationAllowedDomains []string `mapstructure:"-"`
+
+	OidcProviders []OidcProviderConfig `mapstructure:"-"`
+
+	// CommentMaxDepth is the number of reply levels allowed under a top-level comment.
+	CommentMaxDepth int `mapstructure:"COMMENT_MAX_DEPTH"`
+	// PostMaxTags is the number of tags a post can have.
+	PostMaxTags int `mapstructure:"POST_MAX_TAGS"`
+
+	// AdminUserIDs are the ids of the users allowed to manage every webhook and the tags.
+	AdminUserIDs []string `mapstructure:"-"`
+
+	// TrustedProxies are the ip ranges of the reverse proxies allowed to set the
+	// X-Forwarded-For header. Without any, the client ip is the connection one.
+	TrustedProxies []*net.IPNet `mapstructure:"-"`
+}
+
+// OidcProviderConfig holds the settings of an OpenID Connect identity provider.
+// Providers are enabled by listing their names in OIDC_PROVIDERS and each one is
+// configured through OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET
+// and optionally OIDC_<NAME>_SCOPES.
+type OidcProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
 }
 
 func NewConfig() (*Config, error) {
@@ -48,10 +108,66 @@ func NewConfig() (*Config, error) {
 	if !strings.HasPrefix(config.AppAddr, ":") {
 		config.AppAddr = ":" + config.AppAddr
 	}
+	config.AppURL = strings.TrimSuffix(config.AppURL, "/")
+
+	if config.VerificationRedirectURL == "" {
+		config.VerificationRedirectURL = config.AppURL
+	}
+	config.RegistrationMode = strings.ToLower(strings.TrimSpace(config.RegistrationMode))
+	config.RegistrationAllowedDomains = splitList(viper.GetString("REGISTRATION_ALLOWED_DOMAINS"))
+
+	if !domain.ValidRegistrationMode(config.RegistrationMode) {
+		return nil, fmt.Errorf("invalid REGISTRATION_MODE %q, expected one of open, invite or domain", config.RegistrationMode)
+	}
+	if config.RegistrationMode == domain.DomainRegistration && len(config.RegistrationAllowedDomains) == 0 {
+		return nil, errors.New("REGISTRATION_MODE domain requires REGISTRATION_ALLOWED_DOMAINS")
+	}
+	config.OidcProviders = loadOidcProviders()
+	config.AdminUserIDs = splitList(viper.GetString("ADMIN_USER_IDS"))
+
+	for _, ipRange := range splitList(viper.GetString("TRUSTED_PROXIES")) {
+		_, network, err := net.ParseCIDR(ipRange)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES range %q: %w", ipRange, err)
+		}
+		config.TrustedProxies = append(config.TrustedProxies, network)
+	}
 
 	return &config, nil
 }
 
+func loadOidcProviders() []OidcProviderConfig {
+	providers := []OidcProviderConfig{}
+
+	for _, name := range splitList(viper.GetString("OIDC_PROVIDERS")) {
+		prefix := fmt.Sprintf("OIDC_%s_", strings.ToUpper(name))
+
+		providers = append(providers, OidcProviderConfig{
+			Name:         name,
+			Issuer:       viper.GetString(prefix + "ISSUER"),
+			ClientID:     viper.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: viper.GetString(prefix + "CLIENT_SECRET"),
+			Scopes:       strings.Fields(viper.GetString(prefix + "SCOPES")),
+		})
+	}
+
+	return providers
+}
+
+// splitList parses a comma separated list of values into a list of lowercased values.
+func splitList(value string) []string {
+	values := []string{}
+
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
+
 func setEnvDefaultVariables() {
 	appKey, _ := random.String(64)
 
@@ -59,11 +175,33 @@ func setEnvDefaultVariables() {
 	viper.SetDefault("APP_ENV", "development")
 	viper.SetDefault("APP_ADDR", ":4000")
 	viper.SetDefault("APP_KEY", appKey)
+	viper.SetDefault("APP_URL", "http://localhost:4000")
+	viper.SetDefault("SESSION_MODE", "token")
+	viper.SetDefault("VERIFICATION_REDIRECT_URL", "")
 	viper.SetDefault("DB_DRIVER", "mysql")
 	viper.SetDefault("DB_SOURCE", "root:secret@/comu_db?parseTime=true")
+	viper.SetDefault("MAIL_DRIVER", "smtp")
 	viper.SetDefault("MAIL_HOST", "localhost")
 	viper.SetDefault("MAIL_PORT", "465")
 	viper.SetDefault("MAIL_FROM", "norepy@comu.com")
 	viper.SetDefault("MAIL_USERNAME", "")
 	viper.SetDefault("MAIL_PASSWORD", "")
+	viper.SetDefault("MAIL_TEMPLATES_DIR", "")
+	viper.SetDefault("MAIL_FILE_DIR", "storage/mails")
+	viper.SetDefault("DEBUG_VARS_ENABLED", false)
+	viper.SetDefault("MAIL_OUTBOX_WORKERS", 2)
+	viper.SetDefault("MAIL_OUTBOX_MAX_ATTEMPTS", 8)
+	viper.SetDefault("SMS_DRIVER", "log")
+	viper.SetDefault("SMS_FROM", "Comu")
+	viper.SetDefault("SMS_GATEWAY_URL", "")
+	viper.SetDefault("SMS_GATEWAY_TOKEN", "")
+	viper.SetDefault("POW_ENABLED", false)
+	viper.SetDefault("POW_DIFFICULTY", 18)
+	viper.SetDefault("REGISTRATION_MODE", "open")
+	viper.SetDefault("REGISTRATION_ALLOWED_DOMAINS", "")
+	viper.SetDefault("OIDC_PROVIDERS", "")
+	viper.SetDefault("COMMENT_MAX_DEPTH", 5)
+	viper.SetDefault("POST_MAX_TAGS", 5)
+	viper.SetDefault("ADMIN_USER_IDS", "")
+	viper.SetDefault("TRUSTED_PROXIES", "")
 }
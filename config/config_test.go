@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+
+	t.Run("it should load the supported registration modes", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		for _, mode := range []string{"open", "invite", "Domain"} {
+			t.Setenv("REGISTRATION_MODE", mode)
+			t.Setenv("REGISTRATION_ALLOWED_DOMAINS", "comu.com")
+
+			config, err := NewConfig()
+
+			if _assert.NoError(err, mode) {
+				_assert.Contains([]string{"open", "invite", "domain"}, config.RegistrationMode)
+			}
+		}
+	})
+
+	t.Run("it should fail when the registration mode is unknown", func(t *testing.T) {
+		for _, mode := range []string{"invites", "closed", " "} {
+			t.Setenv("REGISTRATION_MODE", mode)
+
+			config, err := NewConfig()
+
+			assert.Nil(t, config)
+			assert.ErrorContains(t, err, "REGISTRATION_MODE", mode)
+		}
+	})
+
+	t.Run("it should fail when the domain registration mode has no allowed domain", func(t *testing.T) {
+		t.Setenv("REGISTRATION_MODE", "domain")
+		t.Setenv("REGISTRATION_ALLOWED_DOMAINS", "")
+
+		config, err := NewConfig()
+
+		assert.Nil(t, config)
+		assert.ErrorContains(t, err, "REGISTRATION_ALLOWED_DOMAINS")
+	})
+
+	t.Run("it should parse the trusted proxies ranges", func(t *testing.T) {
+		_assert := assert.New(t)
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 2001:db8::/32")
+
+		config, err := NewConfig()
+
+		if _assert.NoError(err) && _assert.Len(config.TrustedProxies, 2) {
+			_assert.Equal("10.0.0.0/8", config.TrustedProxies[0].String())
+			_assert.Equal("2001:db8::/32", config.TrustedProxies[1].String())
+		}
+	})
+
+	t.Run("it should fail when a trusted proxies range is invalid", func(t *testing.T) {
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.1")
+
+		config, err := NewConfig()
+
+		assert.Nil(t, config)
+		assert.ErrorContains(t, err, "TRUSTED_PROXIES")
+	})
+
+	t.Run("it should keep the debug vars disabled unless enabled", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		config, err := NewConfig()
+
+		if _assert.NoError(err) {
+			_assert.False(config.DebugVarsEnabled)
+		}
+		t.Setenv("DEBUG_VARS_ENABLED", "true")
+
+		config, err = NewConfig()
+
+		if _assert.NoError(err) {
+			_assert.True(config.DebugVarsEnabled)
+		}
+	})
+}
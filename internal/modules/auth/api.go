@@ -2,7 +2,10 @@ package auth
 
 import (
 	"comu/internal/modules/auth/application/tokens"
+	"comu/internal/modules/auth/presentation/session"
 	echoRes "comu/internal/shared/utils/echo_res"
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -20,21 +23,23 @@ var (
 
 type publicApi struct {
 	verifyTokenUC *tokens.VerifyAccessTokenUC
+	sessions      *session.Manager
 }
 
-func newApi(verifyTokenUC *tokens.VerifyAccessTokenUC) *publicApi {
+func newApi(verifyTokenUC *tokens.VerifyAccessTokenUC, sessions *session.Manager) *publicApi {
 	return &publicApi{
 		verifyTokenUC: verifyTokenUC,
+		sessions:      sessions,
 	}
 }
 
-func (api *publicApi) getAuthToken(ctx echo.Context) string {
-	return ctx.Request().Header.Get("Authorization")
+func (api *publicApi) getAuthToken(ctx echo.Context) (token string, fromCookie bool) {
+	return api.sessions.AccessToken(ctx)
 }
 
 func (api *publicApi) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
-		token := api.getAuthToken(ctx)
+		token, fromCookie := api.getAuthToken(ctx)
 
 		if token == "" {
 			return echoRes.JsonUnauthorizedResponse(
@@ -43,6 +48,15 @@ func (api *publicApi) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			)
 		}
 
+		// Browsers send cookies along with cross-site requests, the csrf token proves
+		// the request was issued by our own client.
+		if fromCookie && !session.ValidCsrfToken(ctx) {
+			return echoRes.JsonErrorMessageResponse(
+				ctx, http.StatusForbidden, session.InvalidCsrfToken,
+				session.ErrInvalidCsrfToken.Error(),
+			)
+		}
+
 		user, err := api.verifyTokenUC.Execute(ctx.Request().Context(), token)
 
 		if err != nil {
@@ -58,7 +72,7 @@ func (api *publicApi) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 
 func (api *publicApi) GuestMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
-		token := api.getAuthToken(ctx)
+		token, _ := api.getAuthToken(ctx)
 
 		if token == "" {
 			return next(ctx)
@@ -1,8 +1,13 @@
 package application
 
 import (
+	"comu/internal/modules/auth/application/challenge"
+	"comu/internal/modules/auth/application/invites"
 	"comu/internal/modules/auth/application/login"
+	loginHistory "comu/internal/modules/auth/application/login_history"
+	"comu/internal/modules/auth/application/oidc"
 	"comu/internal/modules/auth/application/otp"
+	"comu/internal/modules/auth/application/phone"
 	"comu/internal/modules/auth/application/register"
 	resetPassword "comu/internal/modules/auth/application/reset_password"
 	"comu/internal/modules/auth/application/tokens"
@@ -10,18 +15,30 @@ import (
 )
 
 type UseCases struct {
-	LoginUC                   *login.LoginUC
-	RegisterUC                *register.RegisterUC
-	MarkUserAsVerifiedUC      *register.MarkUserAsVerifiedUC
-	ResetPasswordUC           *resetPassword.ResetPasswordUC
-	NewPasswordUC             *resetPassword.SetNewPasswordUC
-	VerifyOtpUC               *otp.VerifyOtpUC
-	ResendOtpUC               *otp.ResendOtpUC
-	GenResendRequestUC        *otp.GenResendOtpRequestUC
-	GenAuthTokenUC            *tokens.GenerateAuthTokensUC
-	GenResetTokenUC           *tokens.GenerateResetTokenUC
-	VerifyAccessToken         *tokens.VerifyAccessTokenUC
-	GenAccessTokenFromRefresh *tokens.GenAccessTokenFromRefreshUC
+	LoginUC                    *login.LoginUC
+	RegisterUC                 *register.RegisterUC
+	MarkUserAsVerifiedUC       *register.MarkUserAsVerifiedUC
+	ResetPasswordUC            *resetPassword.ResetPasswordUC
+	NewPasswordUC              *resetPassword.SetNewPasswordUC
+	VerifyOtpUC                *otp.VerifyOtpUC
+	VerifyOtpLinkUC            *otp.VerifyOtpLinkUC
+	ResendOtpUC                *otp.ResendOtpUC
+	GenResendRequestUC         *otp.GenResendOtpRequestUC
+	GenAuthTokenUC             *tokens.GenerateAuthTokensUC
+	GenResetTokenUC            *tokens.GenerateResetTokenUC
+	VerifyAccessToken          *tokens.VerifyAccessTokenUC
+	GenAccessTokenFromRefresh  *tokens.GenAccessTokenFromRefreshUC
+	RevokeRefreshTokenUC       *tokens.RevokeRefreshTokenUC
+	StartOidcLoginUC           *oidc.StartOidcLoginUC
+	OidcCallbackUC             *oidc.OidcCallbackUC
+	CreateInviteUC             *invites.CreateInviteUC
+	ListInvitesUC              *invites.ListInvitesUC
+	IssueChallengeUC           *challenge.IssueChallengeUC
+	RecordLoginUC              *loginHistory.RecordLoginUC
+	ListLoginsUC               *loginHistory.ListLoginsUC
+	RevokeSessionUC            *loginHistory.RevokeSessionUC
+	RequestPhoneVerificationUC *phone.RequestPhoneVerificationUC
+	VerifyPhoneUC              *phone.VerifyPhoneUC
 }
 
 func InitUseCases(
@@ -29,23 +46,42 @@ func InitUseCases(
 	resetTokensRepo domain.ResetTokensRepository,
 	refreshTokensRepo domain.RefreshTokensRepository,
 	resendRequestsRepo domain.ResendOtpRequestsRepository,
+	oidcStatesRepo domain.OidcStatesRepository,
+	oidcIdentitiesRepo domain.OidcIdentitiesRepository,
+	invitesRepo domain.InvitesRepository,
+	loginEventsRepo domain.LoginEventsRepository,
+	phoneVerificationsRepo domain.PhoneVerificationsRepository,
 
 	jwtService domain.JwtService,
 	userService domain.UserService,
 	passwordService domain.PasswordService,
 	notificationService domain.NotificationService,
+	otpSenders domain.OtpSenders,
+	verificationLinks domain.VerificationLinks,
+	oidcService domain.OidcService,
+	disposableEmailChecker domain.DisposableEmailChecker,
+	challengeService domain.ChallengeService,
+	transactor domain.Transactor,
+	registrationPolicy domain.RegistrationPolicy,
 ) UseCases {
 	loginUC := login.NewUseCase(
 		userService,
 		passwordService,
 		otpCodesRepo,
-		notificationService,
+		otpSenders,
+		challengeService,
+		transactor,
 	)
 	registerUC := register.NewRegisterUseCase(
 		userService,
 		passwordService,
 		otpCodesRepo,
+		invitesRepo,
 		notificationService,
+		disposableEmailChecker,
+		challengeService,
+		transactor,
+		registrationPolicy,
 	)
 
 	markUserAsVerifiedUC := register.NewMarkUserAsVerifiedUseCase(userService)
@@ -54,6 +90,8 @@ func InitUseCases(
 		userService,
 		otpCodesRepo,
 		notificationService,
+		challengeService,
+		transactor,
 	)
 
 	newPasswordUC := resetPassword.NewSetNewPasswordUseCase(
@@ -64,30 +102,74 @@ func InitUseCases(
 	)
 
 	verifyOtpUC := otp.NewVerifyOtpUseCase(otpCodesRepo, resendRequestsRepo)
+	verifyOtpLinkUC := otp.NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepo, resendRequestsRepo)
 	genResendRequestUC := otp.NewGenResendRequestUseCase(resendRequestsRepo)
 	resendOtpUC := otp.NewResendOtpUseCase(
 		otpCodesRepo,
-		notificationService,
+		userService,
+		otpSenders,
 		resendRequestsRepo,
+		transactor,
 	)
 
 	genResetTokenUC := tokens.NewGenResetTokenUseCase(userService, resetTokensRepo)
 	genAuthTokenUC := tokens.NewGenAuthTokensUseCase(jwtService, userService, refreshTokensRepo)
 	verifyAccessTokenUC := tokens.NewVerifyAccessTokenUseCase(jwtService, userService)
 	genAccessFromTokenRefreshUC := tokens.NewGenAccessTokenFromRefreshUseCase(jwtService, userService, refreshTokensRepo)
+	revokeRefreshTokenUC := tokens.NewRevokeRefreshTokenUseCase(refreshTokensRepo)
+
+	startOidcLoginUC := oidc.NewStartOidcLoginUseCase(oidcService, oidcStatesRepo)
+	oidcCallbackUC := oidc.NewOidcCallbackUseCase(
+		oidcService,
+		oidcStatesRepo,
+		oidcIdentitiesRepo,
+		userService,
+		passwordService,
+		disposableEmailChecker,
+		registrationPolicy,
+	)
+
+	createInviteUC := invites.NewCreateInviteUseCase(invitesRepo)
+	listInvitesUC := invites.NewListInvitesUseCase(invitesRepo)
+	issueChallengeUC := challenge.NewIssueChallengeUseCase(challengeService)
+
+	recordLoginUC := loginHistory.NewRecordLoginUseCase(loginEventsRepo, userService, notificationService)
+	listLoginsUC := loginHistory.NewListLoginsUseCase(loginEventsRepo)
+	revokeSessionUC := loginHistory.NewRevokeSessionUseCase(loginEventsRepo, refreshTokensRepo)
+
+	requestPhoneVerificationUC := phone.NewRequestPhoneVerificationUseCase(
+		userService,
+		otpCodesRepo,
+		phoneVerificationsRepo,
+		otpSenders,
+		transactor,
+	)
+	verifyPhoneUC := phone.NewVerifyPhoneUseCase(userService, phoneVerificationsRepo, verifyOtpUC)
 
 	return UseCases{
-		LoginUC:                   loginUC,
-		RegisterUC:                registerUC,
-		MarkUserAsVerifiedUC:      markUserAsVerifiedUC,
-		ResetPasswordUC:           resetPasswordUC,
-		NewPasswordUC:             newPasswordUC,
-		VerifyOtpUC:               verifyOtpUC,
-		ResendOtpUC:               resendOtpUC,
-		GenAuthTokenUC:            genAuthTokenUC,
-		GenResetTokenUC:           genResetTokenUC,
-		VerifyAccessToken:         verifyAccessTokenUC,
-		GenResendRequestUC:        genResendRequestUC,
-		GenAccessTokenFromRefresh: genAccessFromTokenRefreshUC,
+		LoginUC:                    loginUC,
+		RegisterUC:                 registerUC,
+		MarkUserAsVerifiedUC:       markUserAsVerifiedUC,
+		ResetPasswordUC:            resetPasswordUC,
+		NewPasswordUC:              newPasswordUC,
+		VerifyOtpUC:                verifyOtpUC,
+		VerifyOtpLinkUC:            verifyOtpLinkUC,
+		ResendOtpUC:                resendOtpUC,
+		GenAuthTokenUC:             genAuthTokenUC,
+		GenResetTokenUC:            genResetTokenUC,
+		VerifyAccessToken:          verifyAccessTokenUC,
+		GenResendRequestUC:         genResendRequestUC,
+		GenAccessTokenFromRefresh:  genAccessFromTokenRefreshUC,
+		RevokeRefreshTokenUC:       revokeRefreshTokenUC,
+		StartOidcLoginUC:           startOidcLoginUC,
+		OidcCallbackUC:             oidcCallbackUC,
+		CreateInviteUC:             createInviteUC,
+		ListInvitesUC:              listInvitesUC,
+		IssueChallengeUC:           issueChallengeUC,
+		RecordLoginUC:              recordLoginUC,
+		ListLoginsUC:               listLoginsUC,
+		RevokeSessionUC:            revokeSessionUC,
+		RequestPhoneVerificationUC: requestPhoneVerificationUC,
+		VerifyPhoneUC:              verifyPhoneUC,
 	}
 }
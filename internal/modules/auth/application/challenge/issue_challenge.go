@@ -0,0 +1,24 @@
+package challenge
+
+import (
+	"comu/internal/modules/auth/domain"
+)
+
+type IssueChallengeUC struct {
+	challengeService domain.ChallengeService
+}
+
+func NewIssueChallengeUseCase(challengeService domain.ChallengeService) *IssueChallengeUC {
+	return &IssueChallengeUC{
+		challengeService: challengeService,
+	}
+}
+
+// Execute returns a new challenge, or nil when challenges are disabled.
+func (useCase *IssueChallengeUC) Execute() (*domain.Challenge, error) {
+	if !useCase.challengeService.Enabled() {
+		return nil, nil
+	}
+
+	return useCase.challengeService.Issue()
+}
@@ -0,0 +1,47 @@
+package challenge
+
+import (
+	"comu/internal/modules/auth/domain"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueChallengeUseCase(t *testing.T) {
+
+	t.Run("it should issue a new challenge", func(t *testing.T) {
+		challengeService := mockService.NewChallengeServiceMock()
+		challenge := &domain.Challenge{
+			Token:      "gdSR5Y3bKzvJ4o3x.18.1760000000.signature",
+			Difficulty: 18,
+			ExpiredAt:  time.Now().Add(domain.DefaultChallengeTTL),
+		}
+
+		challengeService.On("Enabled").Return(true).Once()
+		challengeService.On("Issue").Return(challenge, nil).Once()
+
+		useCase := NewIssueChallengeUseCase(challengeService)
+
+		issuedChallenge, err := useCase.Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, challenge, issuedChallenge)
+		challengeService.AssertExpectations(t)
+	})
+
+	t.Run("it should not issue any challenge when challenges are disabled", func(t *testing.T) {
+		challengeService := mockService.NewChallengeServiceMock()
+
+		challengeService.On("Enabled").Return(false).Once()
+
+		useCase := NewIssueChallengeUseCase(challengeService)
+
+		issuedChallenge, err := useCase.Execute()
+
+		assert.NoError(t, err)
+		assert.Nil(t, issuedChallenge)
+		challengeService.AssertNotCalled(t, "Issue")
+	})
+}
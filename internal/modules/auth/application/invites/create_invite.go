@@ -0,0 +1,43 @@
+package invites
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateInviteInput struct {
+	CreatedBy uuid.UUID
+	MaxUses   int
+	TTL       time.Duration
+}
+
+type CreateInviteUC struct {
+	invitesRepository domain.InvitesRepository
+}
+
+func NewCreateInviteUseCase(invitesRepository domain.InvitesRepository) *CreateInviteUC {
+	return &CreateInviteUC{
+		invitesRepository: invitesRepository,
+	}
+}
+
+// Execute creates a new invite. Usage limit and lifetime fall back to
+// a single use valid for a week and are capped to their maximum value.
+func (useCase *CreateInviteUC) Execute(ctx context.Context, input CreateInviteInput) (*domain.Invite, error) {
+	maxUses := min(max(input.MaxUses, 1), domain.MaxInviteUses)
+	ttl := input.TTL
+
+	if ttl <= 0 {
+		ttl = domain.DefaultInviteTTL
+	}
+	invite := domain.NewInvite(input.CreatedBy, maxUses, min(ttl, domain.MaxInviteTTL))
+
+	if err := useCase.invitesRepository.Store(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
@@ -0,0 +1,60 @@
+package invites
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateInviteUseCase(t *testing.T) {
+
+	t.Run("it should create and store the invite", func(t *testing.T) {
+		repository := memory.NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		useCase := NewCreateInviteUseCase(repository)
+
+		invite, err := useCase.Execute(ctx, CreateInviteInput{CreatedBy: userID, MaxUses: 5, TTL: time.Hour})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			storedInvite, err := repository.Find(ctx, invite.Code)
+
+			if _assert.NoError(err) {
+				_assert.Equal(userID, storedInvite.CreatedBy)
+				_assert.Equal(5, storedInvite.MaxUses)
+				_assert.WithinDuration(time.Now().Add(time.Hour), storedInvite.ExpiredAt, time.Second)
+			}
+		}
+	})
+
+	t.Run("it should apply the default and maximum limits", func(t *testing.T) {
+		repository := memory.NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+
+		useCase := NewCreateInviteUseCase(repository)
+		_assert := assert.New(t)
+
+		invite, err := useCase.Execute(ctx, CreateInviteInput{CreatedBy: uuid.New()})
+
+		if _assert.NoError(err) {
+			_assert.Equal(1, invite.MaxUses)
+			_assert.WithinDuration(time.Now().Add(domain.DefaultInviteTTL), invite.ExpiredAt, time.Second)
+		}
+
+		invite, err = useCase.Execute(ctx, CreateInviteInput{
+			CreatedBy: uuid.New(), MaxUses: 1000, TTL: domain.MaxInviteTTL * 2,
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal(domain.MaxInviteUses, invite.MaxUses)
+			_assert.WithinDuration(time.Now().Add(domain.MaxInviteTTL), invite.ExpiredAt, time.Second)
+		}
+	})
+}
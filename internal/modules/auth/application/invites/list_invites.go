@@ -0,0 +1,22 @@
+package invites
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ListInvitesUC struct {
+	invitesRepository domain.InvitesRepository
+}
+
+func NewListInvitesUseCase(invitesRepository domain.InvitesRepository) *ListInvitesUC {
+	return &ListInvitesUC{
+		invitesRepository: invitesRepository,
+	}
+}
+
+func (useCase *ListInvitesUC) Execute(ctx context.Context, userID uuid.UUID) ([]domain.Invite, error) {
+	return useCase.invitesRepository.FindByCreator(ctx, userID)
+}
@@ -7,27 +7,40 @@ import (
 )
 
 type LoginUC struct {
-	userService         domain.UserService
-	otpCodeRepository   domain.OtpCodesRepository
-	notificationService domain.NotificationService
-	passwordService     domain.PasswordService
+	userService       domain.UserService
+	otpCodeRepository domain.OtpCodesRepository
+	otpSenders        domain.OtpSenders
+	passwordService   domain.PasswordService
+	challengeService  domain.ChallengeService
+	transactor        domain.Transactor
 }
 
 func NewUseCase(
 	userService domain.UserService,
 	passwordService domain.PasswordService,
 	otpCodeRepository domain.OtpCodesRepository,
-	notificationService domain.NotificationService,
+	otpSenders domain.OtpSenders,
+	challengeService domain.ChallengeService,
+	transactor domain.Transactor,
 ) *LoginUC {
 	return &LoginUC{
-		userService:         userService,
-		passwordService:     passwordService,
-		otpCodeRepository:   otpCodeRepository,
-		notificationService: notificationService,
+		userService:       userService,
+		passwordService:   passwordService,
+		otpCodeRepository: otpCodeRepository,
+		otpSenders:        otpSenders,
+		challengeService:  challengeService,
+		transactor:        transactor,
 	}
 }
 
-func (useCase *LoginUC) Execute(ctx context.Context, email, password string) error {
+// Execute sends a login otp code through the channel, the default one when it is empty.
+func (useCase *LoginUC) Execute(
+	ctx context.Context, email, password string,
+	channel domain.OtpChannel, solution domain.ChallengeSolution,
+) error {
+	if err := useCase.challengeService.Verify(ctx, solution); err != nil {
+		return err
+	}
 	user, err := useCase.userService.GetUserByEmail(ctx, email)
 
 	if err != nil {
@@ -42,18 +55,19 @@ func (useCase *LoginUC) Execute(ctx context.Context, email, password string) err
 	if err != nil {
 		return domain.ErrInvalidCredentials
 	}
-	otpCode, err := useCase.otpCodeRepository.CreateWithUserEmail(ctx, domain.LoginOTP, user.Email)
+	sender, err := useCase.otpSenders.Select(channel, user)
 
 	if err != nil {
 		return err
 	}
 
-	err = useCase.notificationService.SendOtpCodeMessage(otpCode)
+	return useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		otpCode, err := useCase.otpCodeRepository.CreateWithUserEmail(ctx, domain.LoginOTP, user.Email)
 
-	if err != nil {
-		useCase.otpCodeRepository.Delete(ctx, otpCode)
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return sender.SendOtpCode(ctx, user, otpCode)
+	})
 }
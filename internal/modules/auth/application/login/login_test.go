@@ -2,13 +2,18 @@ package login
 
 import (
 	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/service"
 	mockRepository "comu/internal/modules/auth/mocks/mock_repository"
 	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"comu/internal/shared/database"
+	"comu/internal/shared/sms"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -18,6 +23,8 @@ func TestLoginUseCase(t *testing.T) {
 		userService := mockService.NewUserServiceMock()
 		passwordService := mockService.NewPasswordServiceMock()
 		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		ctx := context.Background()
 
@@ -37,16 +44,18 @@ func TestLoginUseCase(t *testing.T) {
 		userService.On("GetUserByEmail", ctx, userEmail).Return(&user, nil).Once()
 		passwordService.On("Compare", hashedPassword, userPassword).Return(nil).Once()
 		otpCodesRepository.On("CreateWithUserEmail", ctx, domain.LoginOTP, userEmail).Return(otpCode, nil).Once()
-		notificationService.On("SendOtpCodeMessage", otpCode).Return(nil).Once()
+		notificationService.On("SendOtpCodeMessage", ctx, otpCode).Return(nil).Once()
 
 		useCase := NewUseCase(
 			userService,
 			passwordService,
 			otpCodesRepository,
-			notificationService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
+			challengeService,
+			database.NoopTransactor{},
 		)
 
-		err := useCase.Execute(ctx, userEmail, userPassword)
+		err := useCase.Execute(ctx, userEmail, userPassword, "", domain.ChallengeSolution{})
 
 		assert.NoError(t, err)
 		userService.AssertExpectations(t)
@@ -59,6 +68,8 @@ func TestLoginUseCase(t *testing.T) {
 		userService := mockService.NewUserServiceMock()
 		passwordService := mockService.NewPasswordServiceMock()
 		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		ctx := context.Background()
 
@@ -71,10 +82,12 @@ func TestLoginUseCase(t *testing.T) {
 			userService,
 			passwordService,
 			otpCodesRepository,
-			notificationService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
+			challengeService,
+			database.NoopTransactor{},
 		)
 
-		err := useCase.Execute(ctx, userEmail, userPassword)
+		err := useCase.Execute(ctx, userEmail, userPassword, "", domain.ChallengeSolution{})
 
 		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
 		userService.AssertExpectations(t)
@@ -87,6 +100,8 @@ func TestLoginUseCase(t *testing.T) {
 		userService := mockService.NewUserServiceMock()
 		passwordService := mockService.NewPasswordServiceMock()
 		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		ctx := context.Background()
 
@@ -108,10 +123,12 @@ func TestLoginUseCase(t *testing.T) {
 			userService,
 			passwordService,
 			otpCodesRepository,
-			notificationService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
+			challengeService,
+			database.NoopTransactor{},
 		)
 
-		err := useCase.Execute(ctx, userEmail, userPassword)
+		err := useCase.Execute(ctx, userEmail, userPassword, "", domain.ChallengeSolution{})
 
 		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
 		userService.AssertExpectations(t)
@@ -119,4 +136,89 @@ func TestLoginUseCase(t *testing.T) {
 		otpCodesRepository.AssertNotCalled(t, "CreateWithUserEmail")
 		notificationService.AssertNotCalled(t, "SendOtpCodeMessage")
 	})
+
+	t.Run("it should fail and return the challenge error before checking the credentials", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		ctx := context.Background()
+
+		solution := domain.ChallengeSolution{Token: "gdSR5Y3bKzvJ4o3x.18.1760000000.signature", Nonce: "42"}
+		challengeService.On("Verify", ctx, solution).Return(domain.ErrInvalidChallenge).Once()
+
+		useCase := NewUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
+			challengeService,
+			database.NoopTransactor{},
+		)
+
+		err := useCase.Execute(ctx, "johndoe@gmail.com", "BhVmqUnb6m1upSh", "", solution)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidChallenge)
+		challengeService.AssertExpectations(t)
+		userService.AssertNotCalled(t, "GetUserByEmail")
+		notificationService.AssertNotCalled(t, "SendOtpCodeMessage")
+	})
+
+	t.Run("it should text the code when the sms channel is chosen, once the phone is verified", func(t *testing.T) {
+		_assert := assert.New(t)
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		smsSender := sms.NewMemorySender()
+		ctx := context.Background()
+
+		userEmail := "johndoe@gmail.com"
+		userPassword := "BhVmqUnb6m1upSh"
+		hashedPassword := "ixReNPXoBPxP9bIBQ6FziHj/9UG5wwzLbxP3vwpSZGo="
+
+		user := domain.AuthUser{
+			ID:       uuid.New(),
+			Email:    userEmail,
+			Password: hashedPassword,
+			Phone:    "+33601020304",
+		}
+		otpCode := domain.NewOtpCode(domain.LoginOTP, userEmail, domain.DefaultOtpCodeTTL)
+
+		userService.On("GetUserByEmail", ctx, userEmail).Return(&user, nil)
+		passwordService.On("Compare", hashedPassword, userPassword).Return(nil)
+		otpCodesRepository.On("CreateWithUserEmail", ctx, domain.LoginOTP, userEmail).Return(otpCode, nil).Once()
+
+		useCase := NewUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			domain.OtpSenders{
+				service.NewEmailOtpSender(notificationService),
+				service.NewSmsOtpSender(smsSender, "Comu"),
+			},
+			challengeService,
+			database.NoopTransactor{},
+		)
+
+		err := useCase.Execute(ctx, userEmail, userPassword, domain.SmsOtpChannel, domain.ChallengeSolution{})
+		_assert.ErrorIs(err, domain.ErrOtpChannelUnavailable)
+
+		verifiedAt := time.Now()
+		user.PhoneVerifiedAt = &verifiedAt
+
+		err = useCase.Execute(ctx, userEmail, userPassword, domain.SmsOtpChannel, domain.ChallengeSolution{})
+
+		if _assert.NoError(err) && _assert.Len(smsSender.Sent(), 1) {
+			_assert.Equal(user.Phone, smsSender.Sent()[0].To)
+			_assert.Contains(smsSender.Sent()[0].Body, otpCode.Value)
+		}
+		notificationService.AssertNotCalled(t, "SendOtpCodeMessage")
+
+		err = useCase.Execute(ctx, userEmail, userPassword, "pigeon", domain.ChallengeSolution{})
+		_assert.ErrorIs(err, domain.ErrUnknownOtpChannel)
+	})
 }
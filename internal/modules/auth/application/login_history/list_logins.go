@@ -0,0 +1,22 @@
+package loginHistory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ListLoginsUC struct {
+	loginEventsRepository domain.LoginEventsRepository
+}
+
+func NewListLoginsUseCase(loginEventsRepository domain.LoginEventsRepository) *ListLoginsUC {
+	return &ListLoginsUC{
+		loginEventsRepository: loginEventsRepository,
+	}
+}
+
+func (useCase *ListLoginsUC) Execute(ctx context.Context, userID uuid.UUID) ([]domain.LoginEvent, error) {
+	return useCase.loginEventsRepository.FindByUser(ctx, userID, domain.DefaultLoginHistoryLimit)
+}
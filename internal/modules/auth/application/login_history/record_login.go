@@ -0,0 +1,71 @@
+package loginHistory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type RecordLoginInput struct {
+	Email        string
+	Success      bool
+	Method       domain.LoginMethod
+	IP           string
+	UserAgent    string
+	RefreshToken string
+}
+
+type RecordLoginUC struct {
+	loginEventsRepository domain.LoginEventsRepository
+	userService           domain.UserService
+	notificationService   domain.NotificationService
+}
+
+func NewRecordLoginUseCase(
+	loginEventsRepository domain.LoginEventsRepository,
+	userService domain.UserService,
+	notificationService domain.NotificationService,
+) *RecordLoginUC {
+	return &RecordLoginUC{
+		loginEventsRepository: loginEventsRepository,
+		userService:           userService,
+		notificationService:   notificationService,
+	}
+}
+
+// Execute records a login attempt. When a user signs in from an ip address or a device
+// not used by their previous logins, they are sent an email allowing to revoke the session.
+func (useCase *RecordLoginUC) Execute(ctx context.Context, input RecordLoginInput) error {
+	userID := uuid.Nil
+	user, err := useCase.userService.GetUserByEmail(ctx, input.Email)
+
+	if err == nil {
+		userID = user.ID
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+	event := domain.NewLoginEvent(userID, input.Email, input.Success, input.Method, input.IP, input.UserAgent)
+
+	if !input.Success || userID == uuid.Nil {
+		return useCase.loginEventsRepository.Store(ctx, event)
+	}
+	sources, err := useCase.loginEventsRepository.FindSuccessfulSources(ctx, userID, input.IP, input.UserAgent)
+
+	if err != nil {
+		return err
+	}
+	event.AttachSession(input.RefreshToken)
+
+	if err = useCase.loginEventsRepository.Store(ctx, event); err != nil {
+		return err
+	}
+
+	// The very first login of a user can't come from an unknown device.
+	if sources.HasSucceeded && (!sources.KnownIP || !sources.KnownUserAgent) {
+		useCase.notificationService.SendNewSignInMessage(ctx, user.Email, event)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package loginHistory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordLoginUseCase(t *testing.T) {
+	user := &domain.AuthUser{
+		ID:       uuid.New(),
+		Name:     "John Doe",
+		Email:    "johndoe@gmail.com",
+		Password: "secret#pass1234",
+	}
+	input := RecordLoginInput{
+		Email:        user.Email,
+		Success:      true,
+		Method:       domain.PasswordLogin,
+		IP:           "192.168.1.10",
+		UserAgent:    "Mozilla/5.0 (X11; Linux x86_64) Firefox/131.0",
+		RefreshToken: "cyb613GDg42lqkRzP2dY6pzuMhApH2NvaWRjwhbIkBA=",
+	}
+
+	t.Run("it should record a failed login of an unknown email without user", func(t *testing.T) {
+		repository := memory.NewInMemoryLoginEventsRepository(nil)
+		userService := mockService.NewUserServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		ctx := context.Background()
+
+		userService.On("GetUserByEmail", ctx, "unknown@gmail.com").Return(nil, domain.ErrUserNotFound).Once()
+
+		useCase := NewRecordLoginUseCase(repository, userService, notificationService)
+		err := useCase.Execute(ctx, RecordLoginInput{
+			Email:  "unknown@gmail.com",
+			Method: domain.PasswordLogin,
+			IP:     input.IP,
+		})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			events, _ := repository.FindByUser(ctx, uuid.Nil, domain.DefaultLoginHistoryLimit)
+
+			if _assert.Len(events, 1) {
+				_assert.False(events[0].Success)
+				_assert.Equal("unknown@gmail.com", events[0].Email)
+				_assert.Empty(events[0].RevokeToken)
+			}
+		}
+		notificationService.AssertNotCalled(t, "SendNewSignInMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should record the first successful login without alerting the user", func(t *testing.T) {
+		repository := memory.NewInMemoryLoginEventsRepository(nil)
+		userService := mockService.NewUserServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		ctx := context.Background()
+
+		userService.On("GetUserByEmail", ctx, user.Email).Return(user, nil).Once()
+
+		useCase := NewRecordLoginUseCase(repository, userService, notificationService)
+		err := useCase.Execute(ctx, input)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			events, _ := repository.FindByUser(ctx, user.ID, domain.DefaultLoginHistoryLimit)
+
+			if _assert.Len(events, 1) {
+				_assert.True(events[0].Success)
+				_assert.Equal(input.RefreshToken, events[0].RefreshToken)
+				_assert.NotEmpty(events[0].RevokeToken)
+			}
+		}
+		notificationService.AssertNotCalled(t, "SendNewSignInMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should not alert the user when signing in from a known device", func(t *testing.T) {
+		repository := memory.NewInMemoryLoginEventsRepository(nil)
+		userService := mockService.NewUserServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		ctx := context.Background()
+
+		previous := domain.NewLoginEvent(user.ID, user.Email, true, domain.PasswordLogin, input.IP, input.UserAgent)
+		repository.Store(ctx, previous)
+
+		userService.On("GetUserByEmail", ctx, user.Email).Return(user, nil).Once()
+
+		useCase := NewRecordLoginUseCase(repository, userService, notificationService)
+		err := useCase.Execute(ctx, input)
+
+		assert.NoError(t, err)
+		notificationService.AssertNotCalled(t, "SendNewSignInMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should alert the user when signing in from a new device", func(t *testing.T) {
+		repository := memory.NewInMemoryLoginEventsRepository(nil)
+		userService := mockService.NewUserServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		ctx := context.Background()
+
+		previous := domain.NewLoginEvent(user.ID, user.Email, true, domain.PasswordLogin, input.IP, "curl/8.5.0")
+		repository.Store(ctx, previous)
+
+		userService.On("GetUserByEmail", ctx, user.Email).Return(user, nil).Once()
+		notificationService.On(
+			"SendNewSignInMessage", ctx, user.Email,
+			mock.MatchedBy(func(event *domain.LoginEvent) bool {
+				return event.UserAgent == input.UserAgent && event.RevokeToken != ""
+			}),
+		).Return(nil).Once()
+
+		useCase := NewRecordLoginUseCase(repository, userService, notificationService)
+		err := useCase.Execute(ctx, input)
+
+		assert.NoError(t, err)
+		notificationService.AssertExpectations(t)
+	})
+
+	t.Run("it should not alert the user when only failed logins were made before", func(t *testing.T) {
+		repository := memory.NewInMemoryLoginEventsRepository(nil)
+		userService := mockService.NewUserServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		ctx := context.Background()
+
+		failed := domain.NewLoginEvent(user.ID, user.Email, false, domain.PasswordLogin, "10.0.0.1", "curl/8.5.0")
+		repository.Store(ctx, failed)
+
+		userService.On("GetUserByEmail", ctx, user.Email).Return(user, nil).Once()
+
+		useCase := NewRecordLoginUseCase(repository, userService, notificationService)
+		err := useCase.Execute(ctx, input)
+
+		assert.NoError(t, err)
+		notificationService.AssertNotCalled(t, "SendNewSignInMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
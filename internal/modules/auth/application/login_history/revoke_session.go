@@ -0,0 +1,42 @@
+package loginHistory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"errors"
+)
+
+type RevokeSessionUC struct {
+	loginEventsRepository   domain.LoginEventsRepository
+	refreshTokensRepository domain.RefreshTokensRepository
+}
+
+func NewRevokeSessionUseCase(
+	loginEventsRepository domain.LoginEventsRepository,
+	refreshTokensRepository domain.RefreshTokensRepository,
+) *RevokeSessionUC {
+	return &RevokeSessionUC{
+		loginEventsRepository:   loginEventsRepository,
+		refreshTokensRepository: refreshTokensRepository,
+	}
+}
+
+// Execute revokes the session opened by the login owning the given revoke token.
+func (useCase *RevokeSessionUC) Execute(ctx context.Context, revokeToken string) error {
+	event, err := useCase.loginEventsRepository.FindByRevokeToken(ctx, revokeToken)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrLoginEventNotFound) {
+			return domain.ErrInvalidToken
+		}
+
+		return err
+	}
+	err = useCase.refreshTokensRepository.Revoke(ctx, event.RefreshToken)
+
+	if err != nil && !errors.Is(err, domain.ErrTokenNotFound) {
+		return err
+	}
+
+	return nil
+}
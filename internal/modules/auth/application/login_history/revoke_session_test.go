@@ -0,0 +1,50 @@
+package loginHistory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeSessionUseCase(t *testing.T) {
+
+	t.Run("it should revoke the refresh token of the login session", func(t *testing.T) {
+		loginEventsRepository := memory.NewInMemoryLoginEventsRepository(nil)
+		refreshTokensRepository := memory.NewInMemoryRefreshTokensRepository(nil)
+		ctx := context.Background()
+
+		userID := uuid.New()
+		token := domain.NewRefreshToken(userID, domain.DefaultRefreshTokenTTL)
+		refreshTokensRepository.Store(ctx, token)
+
+		event := domain.NewLoginEvent(userID, "johndoe@gmail.com", true, domain.PasswordLogin, "192.168.1.10", "curl/8.5.0")
+		event.AttachSession(token.Token)
+		loginEventsRepository.Store(ctx, event)
+
+		useCase := NewRevokeSessionUseCase(loginEventsRepository, refreshTokensRepository)
+		err := useCase.Execute(ctx, event.RevokeToken)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			revokedToken, err := refreshTokensRepository.Find(ctx, token.Token)
+
+			if _assert.NoError(err) {
+				_assert.True(revokedToken.Revoked)
+			}
+		}
+	})
+
+	t.Run("it should fail and return ErrInvalidToken when the revoke token is unknown", func(t *testing.T) {
+		loginEventsRepository := memory.NewInMemoryLoginEventsRepository(nil)
+		refreshTokensRepository := memory.NewInMemoryRefreshTokensRepository(nil)
+
+		useCase := NewRevokeSessionUseCase(loginEventsRepository, refreshTokensRepository)
+		err := useCase.Execute(context.Background(), "m2QhYbGz0x8wJd4kLpN7sR1tVuXyZaBcDeFgHiJkLmNoPqRs")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
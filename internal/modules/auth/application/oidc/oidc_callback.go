@@ -0,0 +1,177 @@
+package oidc
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/mazen160/go-random"
+)
+
+type OidcCallbackInput struct {
+	Provider string
+	State    string
+	Code     string
+}
+
+type OidcCallbackUC struct {
+	oidcService              domain.OidcService
+	oidcStatesRepository     domain.OidcStatesRepository
+	oidcIdentitiesRepository domain.OidcIdentitiesRepository
+	userService              domain.UserService
+	passwordService          domain.PasswordService
+	disposableEmailChecker   domain.DisposableEmailChecker
+	policy                   domain.RegistrationPolicy
+}
+
+func NewOidcCallbackUseCase(
+	oidcService domain.OidcService,
+	oidcStatesRepository domain.OidcStatesRepository,
+	oidcIdentitiesRepository domain.OidcIdentitiesRepository,
+	userService domain.UserService,
+	passwordService domain.PasswordService,
+	disposableEmailChecker domain.DisposableEmailChecker,
+	policy domain.RegistrationPolicy,
+) *OidcCallbackUC {
+	return &OidcCallbackUC{
+		oidcService:              oidcService,
+		oidcStatesRepository:     oidcStatesRepository,
+		oidcIdentitiesRepository: oidcIdentitiesRepository,
+		userService:              userService,
+		passwordService:          passwordService,
+		disposableEmailChecker:   disposableEmailChecker,
+		policy:                   policy,
+	}
+}
+
+// Execute completes the authorization code flow and returns the signed in user.
+// The identity is linked to the user owning the same email address, or to a new
+// user when there is none, as long as the provider verified that email address.
+func (useCase *OidcCallbackUC) Execute(ctx context.Context, input OidcCallbackInput) (*domain.AuthUser, error) {
+	state, err := useCase.oidcStatesRepository.Find(ctx, input.State)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrOidcStateNotFound) {
+			return nil, domain.ErrInvalidOidcState
+		}
+
+		return nil, err
+	}
+	// A state can only be used once.
+	if err = useCase.oidcStatesRepository.Delete(ctx, state.Value); err != nil {
+		return nil, err
+	}
+
+	if state.Provider != input.Provider || state.Expired() {
+		return nil, domain.ErrInvalidOidcState
+	}
+	claims, err := useCase.oidcService.Exchange(ctx, input.Provider, input.Code, state.Nonce, state.CodeVerifier)
+
+	if err != nil {
+		return nil, err
+	}
+	identity, err := useCase.oidcIdentitiesRepository.Find(ctx, claims.Provider, claims.Subject)
+
+	if err == nil {
+		return useCase.userService.GetUserByID(ctx, identity.UserID)
+	}
+
+	if !errors.Is(err, domain.ErrOidcIdentityNotFound) {
+		return nil, err
+	}
+
+	if !claims.EmailVerified || claims.Email == "" {
+		return nil, domain.ErrOidcEmailNotVerified
+	}
+	user, err := useCase.findOrCreateUser(ctx, claims)
+
+	if err != nil {
+		return nil, err
+	}
+	identity = domain.NewOidcIdentity(claims.Provider, claims.Subject, user.ID)
+
+	if err = useCase.oidcIdentitiesRepository.Store(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (useCase *OidcCallbackUC) findOrCreateUser(ctx context.Context, claims *domain.OidcClaims) (*domain.AuthUser, error) {
+	user, err := useCase.userService.GetUserByEmail(ctx, claims.Email)
+
+	if err == nil {
+		if user.EmailVerifiedAt == nil {
+			return useCase.claimUnverifiedUser(ctx, user)
+		}
+
+		return user, nil
+	}
+
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+	// New accounts follow the registration policy. Invite codes can't go through
+	// the provider, so invited users must register before linking an identity.
+	if useCase.policy.InviteRequired() {
+		return nil, domain.ErrInviteRequired
+	}
+
+	if useCase.disposableEmailChecker.IsDisposable(claims.Email) {
+		return nil, domain.ErrDisposableEmail
+	}
+
+	if err = useCase.policy.CheckEmail(claims.Email); err != nil {
+		return nil, err
+	}
+	hashedPassword, err := useCase.randomPasswordHash()
+
+	if err != nil {
+		return nil, err
+	}
+	name := claims.Name
+
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	if _, err = useCase.userService.CreateNewVerifiedUser(ctx, name, claims.Email, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	return useCase.userService.GetUserByEmail(ctx, claims.Email)
+}
+
+// claimUnverifiedUser hands an account whose email was never verified over to the
+// provider identity. Its password is replaced, since it may have been set by someone
+// who registered with an email address they don't own.
+func (useCase *OidcCallbackUC) claimUnverifiedUser(ctx context.Context, user *domain.AuthUser) (*domain.AuthUser, error) {
+	hashedPassword, err := useCase.randomPasswordHash()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = useCase.userService.UpdateUserPassword(ctx, user.ID, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	if err = useCase.userService.MarkUserEmailAsVerified(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	return useCase.userService.GetUserByID(ctx, user.ID)
+}
+
+// Users created through an identity provider get a random password. They can
+// still set their own one with the reset password flow.
+func (useCase *OidcCallbackUC) randomPasswordHash() (string, error) {
+	password, err := random.String(32)
+
+	if err != nil {
+		return "", domain.ErrInternal
+	}
+
+	return useCase.passwordService.Hash(password)
+}
@@ -0,0 +1,295 @@
+package oidc
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"comu/internal/modules/auth/infra/service"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOidcCallbackUseCase(t *testing.T) {
+	openPolicy := domain.RegistrationPolicy{Mode: domain.OpenRegistration}
+	hashedPassword := "$2a$10$4yqEZcNxsxYMsEdZVgXyWOGxFLnOoqYvlJ9gs0Nj2zfdMYoYPQ3TO"
+
+	newClaims := func() *domain.OidcClaims {
+		return &domain.OidcClaims{
+			Provider:      "google",
+			Subject:       "248289761001",
+			Email:         "johndoe@gmail.com",
+			EmailVerified: true,
+			Name:          "John Doe",
+		}
+	}
+
+	t.Run("it should fail and return ErrInvalidOidcState when the state doesn't exist", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		user, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: "unknown", Code: "code"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOidcState)
+		assert.Nil(t, user)
+		oidcService.AssertNotCalled(t, "Exchange")
+	})
+
+	t.Run("it should fail and return ErrInvalidOidcState when the state has expired", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		state := domain.NewOidcState("google", -time.Minute)
+		statesRepository.Store(ctx, state)
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		_, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOidcState)
+		oidcService.AssertNotCalled(t, "Exchange")
+	})
+
+	t.Run("it should fail and return ErrInvalidOidcState when the state was issued for another provider", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		state := domain.NewOidcState("gitlab", domain.DefaultOidcStateTTL)
+		statesRepository.Store(ctx, state)
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		_, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOidcState)
+		oidcService.AssertNotCalled(t, "Exchange")
+	})
+
+	t.Run("it should sign in the user already linked to the identity", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		claims := newClaims()
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+		linkedUser := &domain.AuthUser{ID: uuid.New(), Name: "John Doe", Email: "john.doe@outlook.com"}
+
+		statesRepository.Store(ctx, state)
+		identitiesRepository.Store(ctx, domain.NewOidcIdentity(claims.Provider, claims.Subject, linkedUser.ID))
+		oidcService.On("Exchange", ctx, "google", "code", state.Nonce, state.CodeVerifier).Return(claims, nil).Once()
+		userService.On("GetUserByID", ctx, linkedUser.ID).Return(linkedUser, nil).Once()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		user, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(linkedUser, user)
+		}
+		_, err = statesRepository.Find(ctx, state.Value)
+		_assert.ErrorIs(err, domain.ErrOidcStateNotFound)
+		oidcService.AssertExpectations(t)
+		userService.AssertExpectations(t)
+		userService.AssertNotCalled(t, "GetUserByEmail")
+	})
+
+	t.Run("it should fail and return ErrOidcEmailNotVerified when the provider didn't verify the email", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		claims := newClaims()
+		claims.EmailVerified = false
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+
+		statesRepository.Store(ctx, state)
+		oidcService.On("Exchange", ctx, "google", "code", state.Nonce, state.CodeVerifier).Return(claims, nil).Once()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		_, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+
+		assert.ErrorIs(t, err, domain.ErrOidcEmailNotVerified)
+		userService.AssertNotCalled(t, "GetUserByEmail")
+		userService.AssertNotCalled(t, "CreateNewVerifiedUser")
+	})
+
+	t.Run("it should link the identity to the verified user owning the email", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		claims := newClaims()
+		verifiedAt := time.Now()
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+		existingUser := &domain.AuthUser{ID: uuid.New(), Name: "John Doe", Email: claims.Email, EmailVerifiedAt: &verifiedAt}
+
+		statesRepository.Store(ctx, state)
+		oidcService.On("Exchange", ctx, "google", "code", state.Nonce, state.CodeVerifier).Return(claims, nil).Once()
+		userService.On("GetUserByEmail", ctx, claims.Email).Return(existingUser, nil).Once()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		user, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(existingUser, user)
+			identity, err := identitiesRepository.Find(ctx, claims.Provider, claims.Subject)
+
+			if _assert.NoError(err) {
+				_assert.Equal(existingUser.ID, identity.UserID)
+			}
+		}
+		userService.AssertExpectations(t)
+		userService.AssertNotCalled(t, "UpdateUserPassword")
+		userService.AssertNotCalled(t, "CreateNewVerifiedUser")
+	})
+
+	t.Run("it should verify the unverified user owning the email and replace its password", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		claims := newClaims()
+		verifiedAt := time.Now()
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+		existingUser := &domain.AuthUser{ID: uuid.New(), Name: "John Doe", Email: claims.Email}
+		verifiedUser := &domain.AuthUser{ID: existingUser.ID, Name: "John Doe", Email: claims.Email, EmailVerifiedAt: &verifiedAt}
+
+		statesRepository.Store(ctx, state)
+		oidcService.On("Exchange", ctx, "google", "code", state.Nonce, state.CodeVerifier).Return(claims, nil).Once()
+		userService.On("GetUserByEmail", ctx, claims.Email).Return(existingUser, nil).Once()
+		passwordService.On("Hash", mock.Anything).Return(hashedPassword, nil).Once()
+		userService.On("UpdateUserPassword", ctx, existingUser.ID, hashedPassword).Return(nil).Once()
+		userService.On("MarkUserEmailAsVerified", ctx, claims.Email).Return(nil).Once()
+		userService.On("GetUserByID", ctx, existingUser.ID).Return(verifiedUser, nil).Once()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		user, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(verifiedUser, user)
+		}
+		userService.AssertExpectations(t)
+		passwordService.AssertExpectations(t)
+	})
+
+	t.Run("it should create a verified user when no user owns the email", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		claims := newClaims()
+		verifiedAt := time.Now()
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+		createdUser := &domain.AuthUser{ID: uuid.New(), Name: claims.Name, Email: claims.Email, EmailVerifiedAt: &verifiedAt}
+
+		statesRepository.Store(ctx, state)
+		oidcService.On("Exchange", ctx, "google", "code", state.Nonce, state.CodeVerifier).Return(claims, nil).Once()
+		userService.On("GetUserByEmail", ctx, claims.Email).Return(nil, domain.ErrUserNotFound).Once()
+		passwordService.On("Hash", mock.Anything).Return(hashedPassword, nil).Once()
+		userService.On("CreateNewVerifiedUser", ctx, claims.Name, claims.Email, hashedPassword).Return(createdUser.ID, nil).Once()
+		userService.On("GetUserByEmail", ctx, claims.Email).Return(createdUser, nil).Once()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		user, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(createdUser, user)
+			identity, err := identitiesRepository.Find(ctx, claims.Provider, claims.Subject)
+
+			if _assert.NoError(err) {
+				_assert.Equal(createdUser.ID, identity.UserID)
+			}
+		}
+		userService.AssertExpectations(t)
+		passwordService.AssertExpectations(t)
+	})
+	t.Run("it should fail and return ErrInviteRequired instead of creating a user when registration is invite-only", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		claims := newClaims()
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+		invitePolicy := domain.RegistrationPolicy{Mode: domain.InviteRegistration}
+
+		statesRepository.Store(ctx, state)
+		oidcService.On("Exchange", ctx, "google", "code", state.Nonce, state.CodeVerifier).Return(claims, nil).Once()
+		userService.On("GetUserByEmail", ctx, claims.Email).Return(nil, domain.ErrUserNotFound).Once()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), invitePolicy)
+
+		_, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+
+		assert.ErrorIs(t, err, domain.ErrInviteRequired)
+		userService.AssertNotCalled(t, "CreateNewVerifiedUser")
+	})
+	t.Run("it should fail and return ErrDisposableEmail instead of creating a user for a disposable email address", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		identitiesRepository := memory.NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		claims := newClaims()
+		claims.Email = "johndoe@mailinator.com"
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+
+		statesRepository.Store(ctx, state)
+		oidcService.On("Exchange", ctx, "google", "code", state.Nonce, state.CodeVerifier).Return(claims, nil).Once()
+		userService.On("GetUserByEmail", ctx, claims.Email).Return(nil, domain.ErrUserNotFound).Once()
+
+		useCase := NewOidcCallbackUseCase(oidcService, statesRepository, identitiesRepository, userService, passwordService, service.NewDisposableEmailChecker(), openPolicy)
+
+		_, err := useCase.Execute(ctx, OidcCallbackInput{Provider: "google", State: state.Value, Code: "code"})
+
+		assert.ErrorIs(t, err, domain.ErrDisposableEmail)
+		userService.AssertNotCalled(t, "CreateNewVerifiedUser")
+		_, err = identitiesRepository.Find(ctx, claims.Provider, claims.Subject)
+		assert.ErrorIs(t, err, domain.ErrOidcIdentityNotFound)
+	})
+}
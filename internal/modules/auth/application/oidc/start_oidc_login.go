@@ -0,0 +1,38 @@
+package oidc
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+)
+
+type StartOidcLoginUC struct {
+	oidcService          domain.OidcService
+	oidcStatesRepository domain.OidcStatesRepository
+}
+
+func NewStartOidcLoginUseCase(
+	oidcService domain.OidcService,
+	oidcStatesRepository domain.OidcStatesRepository,
+) *StartOidcLoginUC {
+	return &StartOidcLoginUC{
+		oidcService:          oidcService,
+		oidcStatesRepository: oidcStatesRepository,
+	}
+}
+
+// Execute returns the provider url the user must be redirected to in order to sign in,
+// along with the state value the callback must be called with from the same browser.
+func (useCase *StartOidcLoginUC) Execute(ctx context.Context, provider string) (authURL, stateValue string, err error) {
+	state := domain.NewOidcState(provider, domain.DefaultOidcStateTTL)
+	authURL, err = useCase.oidcService.AuthCodeURL(ctx, provider, state.Value, state.Nonce, state.CodeChallenge())
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if err = useCase.oidcStatesRepository.Store(ctx, state); err != nil {
+		return "", "", err
+	}
+
+	return authURL, state.Value, nil
+}
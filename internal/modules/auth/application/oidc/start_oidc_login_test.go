@@ -0,0 +1,69 @@
+package oidc
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStartOidcLoginUseCase(t *testing.T) {
+
+	t.Run("it should store the state and return the provider authorization url", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		oidcStatesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		ctx := context.Background()
+
+		var nonce, codeChallenge string
+		authURL := "https://accounts.example.com/authorize"
+
+		oidcService.On("AuthCodeURL", ctx, "google", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				nonce = args.String(3)
+				codeChallenge = args.String(4)
+			}).
+			Return(authURL, nil).Once()
+
+		useCase := NewStartOidcLoginUseCase(oidcService, oidcStatesRepository)
+
+		url, state, err := useCase.Execute(ctx, "google")
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(authURL, url)
+			oidcService.AssertCalled(t, "AuthCodeURL", ctx, "google", state, nonce, codeChallenge)
+			storedState, err := oidcStatesRepository.Find(ctx, state)
+
+			if _assert.NoError(err) {
+				_assert.Equal("google", storedState.Provider)
+				_assert.Equal(nonce, storedState.Nonce)
+				_assert.NotEmpty(storedState.CodeVerifier)
+				_assert.Equal(storedState.CodeChallenge(), codeChallenge)
+				_assert.False(storedState.Expired())
+			}
+		}
+		oidcService.AssertExpectations(t)
+	})
+
+	t.Run("it should fail and return ErrOidcProviderNotFound for an unknown provider", func(t *testing.T) {
+		oidcService := mockService.NewOidcServiceMock()
+		oidcStatesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+		ctx := context.Background()
+
+		oidcService.On("AuthCodeURL", ctx, "unknown", mock.Anything, mock.Anything, mock.Anything).
+			Return("", domain.ErrOidcProviderNotFound).Once()
+
+		useCase := NewStartOidcLoginUseCase(oidcService, oidcStatesRepository)
+
+		url, state, err := useCase.Execute(ctx, "unknown")
+
+		assert.ErrorIs(t, err, domain.ErrOidcProviderNotFound)
+		assert.Empty(t, url)
+		assert.Empty(t, state)
+		oidcService.AssertExpectations(t)
+	})
+}
@@ -13,23 +13,31 @@ type ResendOtpInput struct {
 	ID          uuid.UUID
 	UserEmail   string
 	OtpCodeType domain.OtpType
+	// Channel is the one the new code is sent through, the default one when empty.
+	Channel domain.OtpChannel
 }
 
 type ResendOtpUC struct {
 	otpCodesRepository          domain.OtpCodesRepository
-	notificationService         domain.NotificationService
+	userService                 domain.UserService
+	otpSenders                  domain.OtpSenders
 	resendOtpRequestsRepository domain.ResendOtpRequestsRepository
+	transactor                  domain.Transactor
 }
 
 func NewResendOtpUseCase(
 	otpCodesRepository domain.OtpCodesRepository,
-	notificationService domain.NotificationService,
+	userService domain.UserService,
+	otpSenders domain.OtpSenders,
 	resendOtpRequestsRepository domain.ResendOtpRequestsRepository,
+	transactor domain.Transactor,
 ) *ResendOtpUC {
 	return &ResendOtpUC{
 		otpCodesRepository:          otpCodesRepository,
-		notificationService:         notificationService,
+		userService:                 userService,
+		otpSenders:                  otpSenders,
 		resendOtpRequestsRepository: resendOtpRequestsRepository,
+		transactor:                  transactor,
 	}
 }
 
@@ -62,15 +70,34 @@ func (useCase *ResendOtpUC) Execute(ctx context.Context, input ResendOtpInput) e
 	if req.IsCountExceeded() {
 		return domain.ErrResendRequestCountExceeded
 	}
-	useCase.otpCodesRepository.Delete(ctx, otpCode)
-	otpCode, err = useCase.otpCodesRepository.CreateWithUserEmail(ctx, otpCode.Type, otpCode.UserEmail)
+	user, err := useCase.userService.GetUserByEmail(ctx, input.UserEmail)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidResendRequest
+		}
+		return err
+	}
+	sender, err := useCase.otpSenders.Select(input.Channel, user)
 
 	if err != nil {
 		return err
 	}
-	req.Count += 1
-	req.LastSendAt = time.Now()
 
-	useCase.resendOtpRequestsRepository.Update(ctx, req)
-	return useCase.notificationService.SendOtpCodeMessage(otpCode)
+	return useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		useCase.otpCodesRepository.Delete(ctx, otpCode)
+		newOtpCode, err := useCase.otpCodesRepository.CreateWithUserEmail(ctx, otpCode.Type, otpCode.UserEmail)
+
+		if err != nil {
+			return err
+		}
+		req.Count += 1
+		req.LastSendAt = time.Now()
+
+		if err = useCase.resendOtpRequestsRepository.Update(ctx, req); err != nil {
+			return err
+		}
+
+		return sender.SendOtpCode(ctx, user, newOtpCode)
+	})
 }
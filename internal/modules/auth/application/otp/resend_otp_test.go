@@ -2,8 +2,10 @@ package otp
 
 import (
 	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/service"
 	mockRepository "comu/internal/modules/auth/mocks/mock_repository"
 	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"comu/internal/shared/database"
 	"context"
 	"testing"
 	"time"
@@ -16,6 +18,7 @@ func TestResendOtpUseCase(t *testing.T) {
 
 	t.Run("it should fail and return ErrResendRequestNotFound", func(t *testing.T) {
 		notificationService := mockService.NewNotificationServiceMock()
+		userService := mockService.NewUserServiceMock()
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		resendOtpRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
 		ctx := context.Background()
@@ -27,8 +30,10 @@ func TestResendOtpUseCase(t *testing.T) {
 
 		useCase := NewResendOtpUseCase(
 			otpCodesRepository,
-			notificationService,
+			userService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
 			resendOtpRequestsRepository,
+			database.NoopTransactor{},
 		)
 
 		err := useCase.Execute(
@@ -47,6 +52,7 @@ func TestResendOtpUseCase(t *testing.T) {
 
 	t.Run("it should fail and return ErrInvalidResendRequest when otp code is'nt found", func(t *testing.T) {
 		notificationService := mockService.NewNotificationServiceMock()
+		userService := mockService.NewUserServiceMock()
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		resendOtpRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
 		ctx := context.Background()
@@ -59,8 +65,10 @@ func TestResendOtpUseCase(t *testing.T) {
 
 		useCase := NewResendOtpUseCase(
 			otpCodesRepository,
-			notificationService,
+			userService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
 			resendOtpRequestsRepository,
+			database.NoopTransactor{},
 		)
 
 		err := useCase.Execute(
@@ -82,6 +90,7 @@ func TestResendOtpUseCase(t *testing.T) {
 
 	t.Run("it should fail and return ErrInvalidResendRequest when otp code type don't match input type", func(t *testing.T) {
 		notificationService := mockService.NewNotificationServiceMock()
+		userService := mockService.NewUserServiceMock()
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		resendOtpRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
 		ctx := context.Background()
@@ -95,8 +104,10 @@ func TestResendOtpUseCase(t *testing.T) {
 
 		useCase := NewResendOtpUseCase(
 			otpCodesRepository,
-			notificationService,
+			userService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
 			resendOtpRequestsRepository,
+			database.NoopTransactor{},
 		)
 
 		err := useCase.Execute(
@@ -118,6 +129,7 @@ func TestResendOtpUseCase(t *testing.T) {
 
 	t.Run("it should fail and return ErrResendRequestCantBeProcessed", func(t *testing.T) {
 		notificationService := mockService.NewNotificationServiceMock()
+		userService := mockService.NewUserServiceMock()
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		resendOtpRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
 		ctx := context.Background()
@@ -132,8 +144,10 @@ func TestResendOtpUseCase(t *testing.T) {
 
 		useCase := NewResendOtpUseCase(
 			otpCodesRepository,
-			notificationService,
+			userService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
 			resendOtpRequestsRepository,
+			database.NoopTransactor{},
 		)
 
 		err := useCase.Execute(
@@ -155,6 +169,7 @@ func TestResendOtpUseCase(t *testing.T) {
 
 	t.Run("it should return ErrResendRequestCountExceeded", func(t *testing.T) {
 		notificationService := mockService.NewNotificationServiceMock()
+		userService := mockService.NewUserServiceMock()
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		resendOtpRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
 		ctx := context.Background()
@@ -170,8 +185,10 @@ func TestResendOtpUseCase(t *testing.T) {
 
 		useCase := NewResendOtpUseCase(
 			otpCodesRepository,
-			notificationService,
+			userService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
 			resendOtpRequestsRepository,
+			database.NoopTransactor{},
 		)
 
 		err := useCase.Execute(
@@ -193,6 +210,7 @@ func TestResendOtpUseCase(t *testing.T) {
 
 	t.Run("it should succeed", func(t *testing.T) {
 		notificationService := mockService.NewNotificationServiceMock()
+		userService := mockService.NewUserServiceMock()
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
 		resendOtpRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
 		ctx := context.Background()
@@ -208,12 +226,15 @@ func TestResendOtpUseCase(t *testing.T) {
 		otpCodesRepository.On("FindByUserEmail", ctx, userEmail).Return(otpCode, nil).Once()
 		otpCodesRepository.On("Delete", ctx, otpCode).Return(nil)
 		otpCodesRepository.On("CreateWithUserEmail", ctx, otpCode.Type, otpCode.UserEmail).Return(otpCode, nil)
-		notificationService.On("SendOtpCodeMessage", otpCode).Return(nil).Once()
+		notificationService.On("SendOtpCodeMessage", ctx, otpCode).Return(nil).Once()
+		userService.On("GetUserByEmail", ctx, userEmail).Return(&domain.AuthUser{Email: userEmail}, nil).Once()
 
 		useCase := NewResendOtpUseCase(
 			otpCodesRepository,
-			notificationService,
+			userService,
+			domain.OtpSenders{service.NewEmailOtpSender(notificationService)},
 			resendOtpRequestsRepository,
+			database.NoopTransactor{},
 		)
 
 		err := useCase.Execute(
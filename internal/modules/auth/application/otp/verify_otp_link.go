@@ -0,0 +1,72 @@
+package otp
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"crypto/subtle"
+	"errors"
+)
+
+type VerifyOtpLinkUC struct {
+	verificationLinks        domain.VerificationLinks
+	otpCodesRepository       domain.OtpCodesRepository
+	resendRequestsRepository domain.ResendOtpRequestsRepository
+}
+
+func NewVerifyOtpLinkUseCase(
+	verificationLinks domain.VerificationLinks,
+	otpCodesRepository domain.OtpCodesRepository,
+	resendRequestsRepository domain.ResendOtpRequestsRepository,
+) *VerifyOtpLinkUC {
+	return &VerifyOtpLinkUC{
+		verificationLinks:        verificationLinks,
+		otpCodesRepository:       otpCodesRepository,
+		resendRequestsRepository: resendRequestsRepository,
+	}
+}
+
+// Execute verifies the signed link token the same way VerifyOtpUC verifies a code, the
+// otp code being found with the nonce bound to the link. The code is consumed once verified,
+// so that neither the link nor the code can be used again, and the user email is returned
+// only when this call deleted it.
+func (useCase *VerifyOtpLinkUC) Execute(ctx context.Context, otpType domain.OtpType, token string) (string, error) {
+	link, err := useCase.verificationLinks.Verify(token)
+
+	if err != nil || link.Type != otpType {
+		return "", domain.ErrInvalidOtp
+	}
+	otpCode, err := useCase.otpCodesRepository.FindByLinkNonce(ctx, link.Nonce)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrOtpNotFound) {
+			return "", domain.ErrInvalidOtp
+		}
+
+		return "", err
+	}
+
+	if otpCode.Type != otpType || otpCode.UserEmail != link.UserEmail ||
+		subtle.ConstantTimeCompare([]byte(otpCode.LinkNonce), []byte(link.Nonce)) != 1 {
+		return "", domain.ErrInvalidOtp
+	}
+
+	if otpCode.Expired() {
+		useCase.otpCodesRepository.Delete(ctx, otpCode)
+		return "", domain.ErrExpiredOtp
+	}
+
+	if err := useCase.otpCodesRepository.DeleteByLinkNonce(ctx, link.Nonce); err != nil {
+		if errors.Is(err, domain.ErrOtpNotFound) {
+			return "", domain.ErrInvalidOtp
+		}
+
+		return "", err
+	}
+
+	resendReq, _ := useCase.resendRequestsRepository.FindByUserEmail(ctx, otpCode.UserEmail)
+	if resendReq != nil {
+		useCase.resendRequestsRepository.Delete(ctx, resendReq)
+	}
+
+	return otpCode.UserEmail, nil
+}
@@ -0,0 +1,153 @@
+package otp
+
+import (
+	"comu/internal/modules/auth/domain"
+	mockRepository "comu/internal/modules/auth/mocks/mock_repository"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func linkOf(otpCode *domain.OtpCode) *domain.VerificationLink {
+	return &domain.VerificationLink{
+		Type:      otpCode.Type,
+		UserEmail: otpCode.UserEmail,
+		Nonce:     otpCode.LinkNonce,
+	}
+}
+
+func TestVerifyOtpLinkUseCase(t *testing.T) {
+	token := "signed-token"
+
+	t.Run("it should return ErrInvalidOtp when the link isn't valid", func(t *testing.T) {
+		verificationLinks := mockService.NewVerificationLinksMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+
+		verificationLinks.On("Verify", token).Return(nil, domain.ErrInvalidVerificationLink).Once()
+
+		useCase := NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepository, resendRequestsRepository)
+		_, err := useCase.Execute(context.Background(), domain.RegisterOTP, token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOtp)
+		verificationLinks.AssertExpectations(t)
+		otpCodesRepository.AssertNotCalled(t, "FindByLinkNonce")
+	})
+
+	t.Run("it should return ErrInvalidOtp when the link is of another type", func(t *testing.T) {
+		verificationLinks := mockService.NewVerificationLinksMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		otpCode := domain.NewOtpCode(domain.ResetPasswordOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+
+		verificationLinks.On("Verify", token).Return(linkOf(otpCode), nil).Once()
+
+		useCase := NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepository, resendRequestsRepository)
+		_, err := useCase.Execute(context.Background(), domain.RegisterOTP, token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOtp)
+		otpCodesRepository.AssertNotCalled(t, "FindByLinkNonce")
+	})
+
+	t.Run("it should return ErrInvalidOtp when the code was already used", func(t *testing.T) {
+		verificationLinks := mockService.NewVerificationLinksMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		ctx := context.Background()
+		otpCode := domain.NewOtpCode(domain.RegisterOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+
+		verificationLinks.On("Verify", token).Return(linkOf(otpCode), nil).Once()
+		otpCodesRepository.On("FindByLinkNonce", ctx, otpCode.LinkNonce).Return(nil, domain.ErrOtpNotFound).Once()
+
+		useCase := NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepository, resendRequestsRepository)
+		_, err := useCase.Execute(ctx, domain.RegisterOTP, token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOtp)
+		otpCodesRepository.AssertExpectations(t)
+		otpCodesRepository.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("it should return ErrInvalidOtp when the link email doesn't match the code one", func(t *testing.T) {
+		verificationLinks := mockService.NewVerificationLinksMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		ctx := context.Background()
+		otpCode := domain.NewOtpCode(domain.RegisterOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+		link := linkOf(otpCode)
+		link.UserEmail = "jeannettedoe@gmail.com"
+
+		verificationLinks.On("Verify", token).Return(link, nil).Once()
+		otpCodesRepository.On("FindByLinkNonce", ctx, otpCode.LinkNonce).Return(otpCode, nil).Once()
+
+		useCase := NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepository, resendRequestsRepository)
+		_, err := useCase.Execute(ctx, domain.RegisterOTP, token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOtp)
+		otpCodesRepository.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("it should return ErrExpiredOtp and delete the code when it expired", func(t *testing.T) {
+		verificationLinks := mockService.NewVerificationLinksMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		ctx := context.Background()
+		otpCode := domain.NewOtpCode(domain.RegisterOTP, "johndoe@gmail.com", -2*time.Minute)
+
+		verificationLinks.On("Verify", token).Return(linkOf(otpCode), nil).Once()
+		otpCodesRepository.On("FindByLinkNonce", ctx, otpCode.LinkNonce).Return(otpCode, nil).Once()
+		otpCodesRepository.On("Delete", ctx, otpCode).Return(nil).Once()
+
+		useCase := NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepository, resendRequestsRepository)
+		_, err := useCase.Execute(ctx, domain.RegisterOTP, token)
+
+		assert.ErrorIs(t, err, domain.ErrExpiredOtp)
+		otpCodesRepository.AssertExpectations(t)
+		resendRequestsRepository.AssertNotCalled(t, "FindByUserEmail")
+	})
+
+	t.Run("it should succeed, return the user email and delete the code", func(t *testing.T) {
+		verificationLinks := mockService.NewVerificationLinksMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		ctx := context.Background()
+		userEmail := "johndoe@gmail.com"
+		otpCode := domain.NewOtpCode(domain.ResetPasswordOTP, userEmail, domain.DefaultOtpCodeTTL)
+		resendReq := domain.NewResendOtpRequest(userEmail)
+
+		verificationLinks.On("Verify", token).Return(linkOf(otpCode), nil).Once()
+		otpCodesRepository.On("FindByLinkNonce", ctx, otpCode.LinkNonce).Return(otpCode, nil).Once()
+		otpCodesRepository.On("DeleteByLinkNonce", ctx, otpCode.LinkNonce).Return(nil).Once()
+		resendRequestsRepository.On("FindByUserEmail", ctx, userEmail).Return(resendReq, nil).Once()
+		resendRequestsRepository.On("Delete", ctx, resendReq).Return(nil).Once()
+
+		useCase := NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepository, resendRequestsRepository)
+		email, err := useCase.Execute(ctx, domain.ResetPasswordOTP, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userEmail, email)
+		otpCodesRepository.AssertExpectations(t)
+		resendRequestsRepository.AssertExpectations(t)
+	})
+
+	t.Run("it should return ErrInvalidOtp when the code was consumed in the meantime", func(t *testing.T) {
+		verificationLinks := mockService.NewVerificationLinksMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		ctx := context.Background()
+		otpCode := domain.NewOtpCode(domain.RegisterOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+
+		verificationLinks.On("Verify", token).Return(linkOf(otpCode), nil).Once()
+		otpCodesRepository.On("FindByLinkNonce", ctx, otpCode.LinkNonce).Return(otpCode, nil).Once()
+		otpCodesRepository.On("DeleteByLinkNonce", ctx, otpCode.LinkNonce).Return(domain.ErrOtpNotFound).Once()
+
+		useCase := NewVerifyOtpLinkUseCase(verificationLinks, otpCodesRepository, resendRequestsRepository)
+		_, err := useCase.Execute(ctx, domain.RegisterOTP, token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOtp)
+		otpCodesRepository.AssertExpectations(t)
+		resendRequestsRepository.AssertNotCalled(t, "FindByUserEmail")
+	})
+}
@@ -0,0 +1,151 @@
+package phone
+
+import (
+	"comu/internal/modules/auth/application/otp"
+	"comu/internal/modules/auth/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RequestPhoneVerificationUC struct {
+	userService                  domain.UserService
+	otpCodesRepository           domain.OtpCodesRepository
+	phoneVerificationsRepository domain.PhoneVerificationsRepository
+	otpSenders                   domain.OtpSenders
+	transactor                   domain.Transactor
+}
+
+type VerifyPhoneUC struct {
+	userService                  domain.UserService
+	phoneVerificationsRepository domain.PhoneVerificationsRepository
+	verifyOtpUC                  *otp.VerifyOtpUC
+}
+
+func NewRequestPhoneVerificationUseCase(
+	userService domain.UserService,
+	otpCodesRepository domain.OtpCodesRepository,
+	phoneVerificationsRepository domain.PhoneVerificationsRepository,
+	otpSenders domain.OtpSenders,
+	transactor domain.Transactor,
+) *RequestPhoneVerificationUC {
+	return &RequestPhoneVerificationUC{
+		userService:                  userService,
+		otpCodesRepository:           otpCodesRepository,
+		phoneVerificationsRepository: phoneVerificationsRepository,
+		otpSenders:                   otpSenders,
+		transactor:                   transactor,
+	}
+}
+
+func NewVerifyPhoneUseCase(
+	userService domain.UserService,
+	phoneVerificationsRepository domain.PhoneVerificationsRepository,
+	verifyOtpUC *otp.VerifyOtpUC,
+) *VerifyPhoneUC {
+	return &VerifyPhoneUC{
+		userService:                  userService,
+		phoneVerificationsRepository: phoneVerificationsRepository,
+		verifyOtpUC:                  verifyOtpUC,
+	}
+}
+
+// Execute texts a verification code to the phone number, kept as the pending one
+// until the code is verified, so that the user phone number is left as it is.
+// The code sent to a previous number is deleted, so that it can't verify the new one.
+func (useCase *RequestPhoneVerificationUC) Execute(ctx context.Context, userID uuid.UUID, phone string) error {
+	if !domain.IsValidPhone(phone) {
+		return domain.ErrInvalidPhone
+	}
+	sender, err := useCase.otpSenders.Find(domain.SmsOtpChannel)
+
+	if err != nil {
+		return err
+	}
+	user, err := useCase.userService.GetUserByID(ctx, userID)
+
+	if err != nil {
+		return err
+	}
+	var otpCode *domain.OtpCode
+
+	err = useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		verification, err := useCase.phoneVerificationsRepository.FindByUserID(ctx, userID)
+
+		if errors.Is(err, domain.ErrPhoneVerificationNotFound) {
+			verification = domain.NewPhoneVerification(userID)
+		} else if err != nil {
+			return err
+		}
+		now := time.Now()
+
+		if err := verification.CanCodeBeSent(now); err != nil {
+			return err
+		}
+
+		if previous, err := useCase.otpCodesRepository.FindByUserEmail(ctx, user.Email); err == nil &&
+			previous.Type == domain.PhoneVerificationOTP {
+			if err := useCase.otpCodesRepository.Delete(ctx, previous); err != nil {
+				return err
+			}
+		}
+		otpCode, err = useCase.otpCodesRepository.CreateWithUserEmail(ctx, domain.PhoneVerificationOTP, user.Email)
+
+		if err != nil {
+			return err
+		}
+		verification.CodeSent(phone, now)
+
+		return useCase.phoneVerificationsRepository.Save(ctx, verification)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// The code is texted once the transaction is committed, not to hold it
+	// during the call to the SMS gateway.
+	pendingUser := *user
+	pendingUser.Phone = phone
+
+	return sender.SendOtpCode(ctx, &pendingUser, otpCode)
+}
+
+// Execute sets the pending phone number as the user verified one.
+func (useCase *VerifyPhoneUC) Execute(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := useCase.userService.GetUserByID(ctx, userID)
+
+	if err != nil {
+		return err
+	}
+	verification, err := useCase.phoneVerificationsRepository.FindByUserID(ctx, userID)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrPhoneVerificationNotFound) {
+			return domain.ErrInvalidOtp
+		}
+
+		return err
+	}
+
+	if verification.Phone == "" {
+		return domain.ErrInvalidOtp
+	}
+
+	if err := useCase.verifyOtpUC.Execute(ctx, otp.VerifyOtpInput{
+		UserEmail:    user.Email,
+		OtpCodeType:  domain.PhoneVerificationOTP,
+		OtpCodeValue: code,
+	}); err != nil {
+		return err
+	}
+
+	if err := useCase.userService.SetUserVerifiedPhone(ctx, userID, verification.Phone); err != nil {
+		return err
+	}
+	verification.Verified()
+
+	return useCase.phoneVerificationsRepository.Save(ctx, verification)
+}
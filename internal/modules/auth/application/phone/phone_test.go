@@ -0,0 +1,194 @@
+package phone
+
+import (
+	"comu/internal/modules/auth/application/otp"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"comu/internal/modules/auth/infra/service"
+	mockRepository "comu/internal/modules/auth/mocks/mock_repository"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"comu/internal/shared/database"
+	"comu/internal/shared/sms"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRequestPhoneVerificationUseCase(t *testing.T) {
+
+	t.Run("it should fail and return ErrInvalidPhone", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		useCase := NewRequestPhoneVerificationUseCase(
+			userService,
+			mockRepository.NewOtpCodesRepositoryMock(),
+			memory.NewInMemoryPhoneVerificationsRepository(nil),
+			domain.OtpSenders{service.NewSmsOtpSender(sms.NewMemorySender(), "Comu")},
+			database.NoopTransactor{},
+		)
+
+		err := useCase.Execute(context.Background(), uuid.New(), "0601020304")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPhone)
+		userService.AssertNotCalled(t, "GetUserByID")
+	})
+
+	t.Run("it should text a verification code to the pending phone number", func(t *testing.T) {
+		_assert := assert.New(t)
+		userService := mockService.NewUserServiceMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		phoneVerificationsRepository := memory.NewInMemoryPhoneVerificationsRepository(nil)
+		smsSender := sms.NewMemorySender()
+		ctx := context.Background()
+
+		now := time.Now()
+		user := &domain.AuthUser{ID: uuid.New(), Email: "johndoe@gmail.com", Phone: "+22501020304", PhoneVerifiedAt: &now}
+		previous := domain.NewOtpCode(domain.PhoneVerificationOTP, user.Email, domain.DefaultOtpCodeTTL)
+		otpCode := domain.NewOtpCode(domain.PhoneVerificationOTP, user.Email, domain.DefaultOtpCodeTTL)
+
+		userService.On("GetUserByID", ctx, user.ID).Return(user, nil).Once()
+		otpCodesRepository.On("FindByUserEmail", ctx, user.Email).Return(previous, nil).Once()
+		otpCodesRepository.On("Delete", ctx, previous).Return(nil).Once()
+		otpCodesRepository.On("CreateWithUserEmail", ctx, domain.PhoneVerificationOTP, user.Email).Return(otpCode, nil).Once()
+
+		useCase := NewRequestPhoneVerificationUseCase(
+			userService,
+			otpCodesRepository,
+			phoneVerificationsRepository,
+			domain.OtpSenders{service.NewSmsOtpSender(smsSender, "Comu")},
+			database.NoopTransactor{},
+		)
+
+		if _assert.NoError(useCase.Execute(ctx, user.ID, "+33601020304")) && _assert.Len(smsSender.Sent(), 1) {
+			_assert.Equal("+33601020304", smsSender.Sent()[0].To)
+			_assert.Contains(smsSender.Sent()[0].Body, otpCode.Value)
+		}
+		verification, err := phoneVerificationsRepository.FindByUserID(ctx, user.ID)
+
+		if _assert.NoError(err) {
+			_assert.Equal("+33601020304", verification.Phone)
+			_assert.Equal(1, verification.Count)
+		}
+		userService.AssertNotCalled(t, "SetUserVerifiedPhone", mock.Anything, mock.Anything, mock.Anything)
+		userService.AssertExpectations(t)
+		otpCodesRepository.AssertExpectations(t)
+	})
+
+	t.Run("it should refuse to text a code before the delay or beyond the limit", func(t *testing.T) {
+		_assert := assert.New(t)
+		userID := uuid.New()
+		smsSender := sms.NewMemorySender()
+		ctx := context.Background()
+
+		userService := mockService.NewUserServiceMock()
+		phoneVerificationsRepository := memory.NewInMemoryPhoneVerificationsRepository(nil)
+		useCase := NewRequestPhoneVerificationUseCase(
+			userService,
+			mockRepository.NewOtpCodesRepositoryMock(),
+			phoneVerificationsRepository,
+			domain.OtpSenders{service.NewSmsOtpSender(smsSender, "Comu")},
+			database.NoopTransactor{},
+		)
+		userService.On("GetUserByID", ctx, userID).Return(&domain.AuthUser{ID: userID, Email: "johndoe@gmail.com"}, nil)
+
+		verification := domain.NewPhoneVerification(userID)
+		verification.CodeSent("+33601020304", time.Now())
+		phoneVerificationsRepository.Save(ctx, verification)
+
+		err := useCase.Execute(ctx, userID, "+33601020305")
+		_assert.ErrorIs(err, domain.ErrResendRequestCantBeProcessed)
+
+		verification.LastSendAt = time.Now().Add(-domain.PhoneCodeDelay)
+		verification.Count = domain.MaxPhoneCodes
+		phoneVerificationsRepository.Save(ctx, verification)
+
+		err = useCase.Execute(ctx, userID, "+33601020305")
+		_assert.ErrorIs(err, domain.ErrResendRequestCountExceeded)
+		_assert.Empty(smsSender.Sent())
+	})
+}
+
+func TestVerifyPhoneUseCase(t *testing.T) {
+
+	t.Run("it should set the pending phone number as the verified one", func(t *testing.T) {
+		_assert := assert.New(t)
+		userService := mockService.NewUserServiceMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		phoneVerificationsRepository := memory.NewInMemoryPhoneVerificationsRepository(nil)
+		ctx := context.Background()
+
+		user := &domain.AuthUser{ID: uuid.New(), Email: "johndoe@gmail.com"}
+		otpCode := domain.NewOtpCode(domain.PhoneVerificationOTP, user.Email, domain.DefaultOtpCodeTTL)
+		verification := domain.NewPhoneVerification(user.ID)
+		verification.CodeSent("+33601020304", time.Now())
+		phoneVerificationsRepository.Save(ctx, verification)
+
+		userService.On("GetUserByID", ctx, user.ID).Return(user, nil).Once()
+		userService.On("SetUserVerifiedPhone", ctx, user.ID, "+33601020304").Return(nil).Once()
+		otpCodesRepository.On("Find", ctx, otpCode.Value).Return(otpCode, nil).Once()
+		otpCodesRepository.On("Delete", ctx, otpCode).Return(nil).Once()
+		resendRequestsRepository.On("FindByUserEmail", ctx, user.Email).Return(nil, domain.ErrResendRequestNotFound).Once()
+
+		useCase := NewVerifyPhoneUseCase(
+			userService,
+			phoneVerificationsRepository,
+			otp.NewVerifyOtpUseCase(otpCodesRepository, resendRequestsRepository),
+		)
+
+		_assert.NoError(useCase.Execute(ctx, user.ID, otpCode.Value))
+		userService.AssertExpectations(t)
+
+		retrieved, _ := phoneVerificationsRepository.FindByUserID(ctx, user.ID)
+		_assert.Empty(retrieved.Phone)
+		_assert.Equal(1, retrieved.Count)
+	})
+
+	t.Run("it should fail and return ErrInvalidOtp when no phone number is pending", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		ctx := context.Background()
+
+		user := &domain.AuthUser{ID: uuid.New(), Email: "johndoe@gmail.com", Phone: "+33601020304"}
+		otpCode := domain.NewOtpCode(domain.PhoneVerificationOTP, user.Email, domain.DefaultOtpCodeTTL)
+
+		userService.On("GetUserByID", ctx, user.ID).Return(user, nil).Once()
+
+		useCase := NewVerifyPhoneUseCase(
+			userService,
+			memory.NewInMemoryPhoneVerificationsRepository(nil),
+			otp.NewVerifyOtpUseCase(otpCodesRepository, mockRepository.NewResendOtpRequestsRepositoryMock()),
+		)
+
+		assert.ErrorIs(t, useCase.Execute(ctx, user.ID, otpCode.Value), domain.ErrInvalidOtp)
+		otpCodesRepository.AssertNotCalled(t, "Find", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should fail and return ErrInvalidOtp when the code isn't a phone verification one", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		phoneVerificationsRepository := memory.NewInMemoryPhoneVerificationsRepository(nil)
+		ctx := context.Background()
+
+		user := &domain.AuthUser{ID: uuid.New(), Email: "johndoe@gmail.com"}
+		otpCode := domain.NewOtpCode(domain.LoginOTP, user.Email, domain.DefaultOtpCodeTTL)
+		verification := domain.NewPhoneVerification(user.ID)
+		verification.CodeSent("+33601020304", time.Now())
+		phoneVerificationsRepository.Save(ctx, verification)
+
+		userService.On("GetUserByID", ctx, user.ID).Return(user, nil).Once()
+		otpCodesRepository.On("Find", ctx, otpCode.Value).Return(otpCode, nil).Once()
+
+		useCase := NewVerifyPhoneUseCase(
+			userService,
+			phoneVerificationsRepository,
+			otp.NewVerifyOtpUseCase(otpCodesRepository, mockRepository.NewResendOtpRequestsRepositoryMock()),
+		)
+
+		assert.ErrorIs(t, useCase.Execute(ctx, user.ID, otpCode.Value), domain.ErrInvalidOtp)
+		userService.AssertNotCalled(t, "SetUserVerifiedPhone", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -5,48 +5,102 @@ import (
 	"context"
 )
 
+type RegisterInput struct {
+	Name       string
+	Email      string
+	Password   string
+	InviteCode string
+	Locale     string
+	Challenge  domain.ChallengeSolution
+}
+
 type RegisterUC struct {
-	userService         domain.UserService
-	passwordService     domain.PasswordService
-	otpCodeRepository   domain.OtpCodesRepository
-	notificationService domain.NotificationService
+	userService            domain.UserService
+	passwordService        domain.PasswordService
+	otpCodeRepository      domain.OtpCodesRepository
+	invitesRepository      domain.InvitesRepository
+	notificationService    domain.NotificationService
+	disposableEmailChecker domain.DisposableEmailChecker
+	challengeService       domain.ChallengeService
+	transactor             domain.Transactor
+	policy                 domain.RegistrationPolicy
 }
 
 func NewRegisterUseCase(
 	userService domain.UserService,
 	passwordService domain.PasswordService,
 	otpCodeRepository domain.OtpCodesRepository,
+	invitesRepository domain.InvitesRepository,
 	notificationService domain.NotificationService,
-
+	disposableEmailChecker domain.DisposableEmailChecker,
+	challengeService domain.ChallengeService,
+	transactor domain.Transactor,
+	policy domain.RegistrationPolicy,
 ) *RegisterUC {
 	return &RegisterUC{
-		userService:         userService,
-		passwordService:     passwordService,
-		otpCodeRepository:   otpCodeRepository,
-		notificationService: notificationService,
+		userService:            userService,
+		passwordService:        passwordService,
+		otpCodeRepository:      otpCodeRepository,
+		invitesRepository:      invitesRepository,
+		notificationService:    notificationService,
+		disposableEmailChecker: disposableEmailChecker,
+		challengeService:       challengeService,
+		transactor:             transactor,
+		policy:                 policy,
 	}
 }
 
-func (useCase *RegisterUC) Execute(ctx context.Context, name, email, password string) error {
-	hashedPassword, err := useCase.passwordService.Hash(password)
+func (useCase *RegisterUC) Execute(ctx context.Context, input RegisterInput) error {
+	if err := useCase.challengeService.Verify(ctx, input.Challenge); err != nil {
+		return err
+	}
+
+	if useCase.disposableEmailChecker.IsDisposable(input.Email) {
+		return domain.ErrDisposableEmail
+	}
 
-	if err != nil {
+	if err := useCase.policy.CheckEmail(input.Email); err != nil {
 		return err
 	}
 
-	_, err = useCase.userService.CreateNewUser(ctx, name, email, hashedPassword)
+	if useCase.policy.InviteRequired() {
+		if input.InviteCode == "" {
+			return domain.ErrInviteRequired
+		}
+
+		if err := useCase.invitesRepository.Consume(ctx, input.InviteCode); err != nil {
+			return err
+		}
+	}
+
+	if err := useCase.createUser(ctx, input); err != nil {
+		// The invite use is given back since no account was created with it.
+		if useCase.policy.InviteRequired() {
+			useCase.invitesRepository.Release(ctx, input.InviteCode)
+		}
 
-	if err != nil {
 		return err
 	}
 
-	otpCode, err := useCase.otpCodeRepository.CreateWithUserEmail(ctx, domain.RegisterOTP, email)
+	return useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		otpCode, err := useCase.otpCodeRepository.CreateWithUserEmail(ctx, domain.RegisterOTP, input.Email)
+
+		if err != nil {
+			return err
+		}
+
+		return useCase.notificationService.SendOtpCodeMessage(ctx, otpCode)
+	})
+}
+
+func (useCase *RegisterUC) createUser(ctx context.Context, input RegisterInput) error {
+	hashedPassword, err := useCase.passwordService.Hash(input.Password)
 
 	if err != nil {
 		return err
 	}
 
-	useCase.notificationService.SendOtpCodeMessage(otpCode)
+	_, err = useCase.userService.CreateNewUser(ctx, input.Name, input.Email, hashedPassword, input.Locale)
 
-	return nil
+	return err
 }
@@ -2,23 +2,31 @@ package register
 
 import (
 	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"comu/internal/modules/auth/infra/service"
 	mockRepository "comu/internal/modules/auth/mocks/mock_repository"
 	mockService "comu/internal/modules/auth/mocks/mock_service"
 	"comu/internal/modules/users"
+	"comu/internal/shared/database"
 	"context"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestRegister(t *testing.T) {
+	openPolicy := domain.RegistrationPolicy{Mode: domain.OpenRegistration}
 
 	t.Run("it should result into success", func(t *testing.T) {
 		userService := mockService.NewUserServiceMock()
 		passwordService := mockService.NewPasswordServiceMock()
 		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
 		ctx := context.Background()
 
 		userName := "John Doe"
@@ -29,18 +37,23 @@ func TestRegister(t *testing.T) {
 		otpCode := domain.NewOtpCode(domain.RegisterOTP, userEmail, domain.DefaultOtpCodeTTL)
 
 		passwordService.On("Hash", userPassword).Return(hashedPassword, nil).Once()
-		userService.On("CreateNewUser", ctx, userName, userEmail, hashedPassword).Return(uuid.New(), nil).Once()
+		userService.On("CreateNewUser", ctx, userName, userEmail, hashedPassword, "").Return(uuid.New(), nil).Once()
 		otpCodesRepository.On("CreateWithUserEmail", ctx, domain.RegisterOTP, userEmail).Return(otpCode, nil).Once()
-		notificationService.On("SendOtpCodeMessage", otpCode).Return(nil).Once()
+		notificationService.On("SendOtpCodeMessage", ctx, otpCode).Return(nil).Once()
 
 		useCase := NewRegisterUseCase(
 			userService,
 			passwordService,
 			otpCodesRepository,
+			invitesRepository,
 			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			openPolicy,
 		)
 
-		err := useCase.Execute(ctx, userName, userEmail, userPassword)
+		err := useCase.Execute(ctx, RegisterInput{Name: userName, Email: userEmail, Password: userPassword})
 
 		assert.NoError(t, err)
 		passwordService.AssertExpectations(t)
@@ -53,7 +66,10 @@ func TestRegister(t *testing.T) {
 		userService := mockService.NewUserServiceMock()
 		passwordService := mockService.NewPasswordServiceMock()
 		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
 		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
 		ctx := context.Background()
 
 		userName := "John Doe"
@@ -62,16 +78,21 @@ func TestRegister(t *testing.T) {
 		hashedPassword := "ixReNPXoBPxP9bIBQ6FziHj/9UG5wwzLbxP3vwpSZGo="
 
 		passwordService.On("Hash", userPassword).Return(hashedPassword, nil).Once()
-		userService.On("CreateNewUser", ctx, userName, userEmail, hashedPassword).Return(nil, users.ErrUserEmailTaken).Once()
+		userService.On("CreateNewUser", ctx, userName, userEmail, hashedPassword, "").Return(nil, users.ErrUserEmailTaken).Once()
 
 		useCase := NewRegisterUseCase(
 			userService,
 			passwordService,
 			otpCodesRepository,
+			invitesRepository,
 			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			openPolicy,
 		)
 
-		err := useCase.Execute(ctx, userName, userEmail, userPassword)
+		err := useCase.Execute(ctx, RegisterInput{Name: userName, Email: userEmail, Password: userPassword})
 
 		assert.ErrorIs(t, err, users.ErrUserEmailTaken)
 		passwordService.AssertExpectations(t)
@@ -79,4 +100,210 @@ func TestRegister(t *testing.T) {
 		otpCodesRepository.AssertNotCalled(t, "CreateWithUserEmail")
 		notificationService.AssertNotCalled(t, "SendOtpCodeMessage")
 	})
+
+	t.Run("it should fail and return ErrDisposableEmail for a disposable email address", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
+
+		useCase := NewRegisterUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			invitesRepository,
+			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			openPolicy,
+		)
+
+		err := useCase.Execute(context.Background(), RegisterInput{
+			Name: "John Doe", Email: "johndoe@mailinator.com", Password: "BhVmqUnb6m1upSh",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrDisposableEmail)
+		userService.AssertNotCalled(t, "CreateNewUser")
+	})
+
+	t.Run("it should fail and return ErrEmailDomainNotAllowed when the domain isn't allowed", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
+
+		useCase := NewRegisterUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			invitesRepository,
+			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			domain.RegistrationPolicy{Mode: domain.DomainRegistration, AllowedDomains: []string{"comu.com"}},
+		)
+
+		err := useCase.Execute(context.Background(), RegisterInput{
+			Name: "John Doe", Email: "johndoe@gmail.com", Password: "BhVmqUnb6m1upSh",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrEmailDomainNotAllowed)
+		userService.AssertNotCalled(t, "CreateNewUser")
+	})
+
+	t.Run("it should fail and return ErrInviteRequired when no invite code was provided", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
+
+		useCase := NewRegisterUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			invitesRepository,
+			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			domain.RegistrationPolicy{Mode: domain.InviteRegistration},
+		)
+
+		err := useCase.Execute(context.Background(), RegisterInput{
+			Name: "John Doe", Email: "johndoe@gmail.com", Password: "BhVmqUnb6m1upSh",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInviteRequired)
+		userService.AssertNotCalled(t, "CreateNewUser")
+	})
+
+	t.Run("it should consume the invite and register the user", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+
+		userEmail := "johndoe@gmail.com"
+		hashedPassword := "ixReNPXoBPxP9bIBQ6FziHj/9UG5wwzLbxP3vwpSZGo="
+		invite := domain.NewInvite(uuid.New(), 1, domain.DefaultInviteTTL)
+		otpCode := domain.NewOtpCode(domain.RegisterOTP, userEmail, domain.DefaultOtpCodeTTL)
+
+		invitesRepository.Store(ctx, invite)
+		passwordService.On("Hash", "BhVmqUnb6m1upSh").Return(hashedPassword, nil).Once()
+		userService.On("CreateNewUser", ctx, "John Doe", userEmail, hashedPassword, "").Return(uuid.New(), nil).Once()
+		otpCodesRepository.On("CreateWithUserEmail", ctx, domain.RegisterOTP, userEmail).Return(otpCode, nil).Once()
+		notificationService.On("SendOtpCodeMessage", ctx, otpCode).Return(nil).Once()
+
+		useCase := NewRegisterUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			invitesRepository,
+			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			domain.RegistrationPolicy{Mode: domain.InviteRegistration},
+		)
+
+		err := useCase.Execute(ctx, RegisterInput{
+			Name: "John Doe", Email: userEmail, Password: "BhVmqUnb6m1upSh", InviteCode: invite.Code,
+		})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			storedInvite, _ := invitesRepository.Find(ctx, invite.Code)
+			_assert.Equal(1, storedInvite.Uses)
+		}
+		userService.AssertExpectations(t)
+	})
+
+	t.Run("it should give the invite use back when the user couldn't be created", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+
+		userEmail := "johndoe@gmail.com"
+		hashedPassword := "ixReNPXoBPxP9bIBQ6FziHj/9UG5wwzLbxP3vwpSZGo="
+		invite := domain.NewInvite(uuid.New(), 1, domain.DefaultInviteTTL)
+
+		invitesRepository.Store(ctx, invite)
+		passwordService.On("Hash", "BhVmqUnb6m1upSh").Return(hashedPassword, nil).Once()
+		userService.On("CreateNewUser", ctx, "John Doe", userEmail, hashedPassword, "").Return(nil, users.ErrUserEmailTaken).Once()
+
+		useCase := NewRegisterUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			invitesRepository,
+			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			domain.RegistrationPolicy{Mode: domain.InviteRegistration},
+		)
+
+		err := useCase.Execute(ctx, RegisterInput{
+			Name: "John Doe", Email: userEmail, Password: "BhVmqUnb6m1upSh", InviteCode: invite.Code,
+		})
+		_assert := assert.New(t)
+
+		if _assert.ErrorIs(err, users.ErrUserEmailTaken) {
+			storedInvite, _ := invitesRepository.Find(ctx, invite.Code)
+			_assert.Equal(0, storedInvite.Uses)
+		}
+	})
+
+	t.Run("it should fail and return ErrChallengeRequired when no challenge was solved", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		passwordService := mockService.NewPasswordServiceMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		invitesRepository := memory.NewInMemoryInvitesRepository(nil)
+		challengeService := mockService.NewChallengeServiceMock()
+		ctx := context.Background()
+
+		challengeService.On("Verify", ctx, domain.ChallengeSolution{}).Return(domain.ErrChallengeRequired).Once()
+
+		useCase := NewRegisterUseCase(
+			userService,
+			passwordService,
+			otpCodesRepository,
+			invitesRepository,
+			notificationService,
+			service.NewDisposableEmailChecker(),
+			challengeService,
+			database.NoopTransactor{},
+			openPolicy,
+		)
+
+		err := useCase.Execute(ctx, RegisterInput{
+			Name: "John Doe", Email: "johndoe@gmail.com", Password: "BhVmqUnb6m1upSh",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrChallengeRequired)
+		challengeService.AssertExpectations(t)
+		userService.AssertNotCalled(t, "CreateNewUser")
+		notificationService.AssertNotCalled(t, "SendOtpCodeMessage")
+	})
 }
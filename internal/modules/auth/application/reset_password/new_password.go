@@ -52,7 +52,7 @@ func (useCase *SetNewPasswordUC) Execute(ctx context.Context, tokenString string
 	if err != nil {
 		return err
 	}
-	useCase.notificationService.SendPasswordChangedMessage(token.UserEmail)
+	useCase.notificationService.SendPasswordChangedMessage(ctx, token.UserEmail)
 	useCase.resetTokensRepository.Delete(ctx, tokenString)
 
 	return nil
@@ -105,7 +105,7 @@ func TestNewPasswordUseCase(t *testing.T) {
 		resetTokensRepository.Store(ctx, token)
 		passwordService.On("Hash", newPassword).Return(hashedNewPassword, nil)
 		userService.On("UpdateUserPassword", ctx, userID, hashedNewPassword).Return(nil)
-		notificationService.On("SendPasswordChangedMessage", token.UserEmail).Return(nil)
+		notificationService.On("SendPasswordChangedMessage", ctx, token.UserEmail).Return(nil)
 
 		useCase := NewSetNewPasswordUseCase(userService, passwordService, notificationService, resetTokensRepository)
 
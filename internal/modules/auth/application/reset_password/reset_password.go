@@ -9,39 +9,43 @@ type ResetPasswordUC struct {
 	userService         domain.UserService
 	otpCodesRepository  domain.OtpCodesRepository
 	notificationService domain.NotificationService
+	challengeService    domain.ChallengeService
+	transactor          domain.Transactor
 }
 
 func NewResetPasswordUseCase(
 	userService domain.UserService,
 	otpCodesRepository domain.OtpCodesRepository,
 	notificationService domain.NotificationService,
+	challengeService domain.ChallengeService,
+	transactor domain.Transactor,
 ) *ResetPasswordUC {
 	return &ResetPasswordUC{
 		userService:         userService,
 		otpCodesRepository:  otpCodesRepository,
 		notificationService: notificationService,
+		challengeService:    challengeService,
+		transactor:          transactor,
 	}
 }
 
-func (useCase *ResetPasswordUC) Execute(ctx context.Context, userEmail string) error {
-	_, err := useCase.userService.GetUserByEmail(ctx, userEmail)
-
-	if err != nil {
+func (useCase *ResetPasswordUC) Execute(ctx context.Context, userEmail string, solution domain.ChallengeSolution) error {
+	if err := useCase.challengeService.Verify(ctx, solution); err != nil {
 		return err
 	}
-
-	otpCode, err := useCase.otpCodesRepository.CreateWithUserEmail(ctx, domain.ResetPasswordOTP, userEmail)
+	_, err := useCase.userService.GetUserByEmail(ctx, userEmail)
 
 	if err != nil {
 		return err
 	}
 
-	err = useCase.notificationService.SendOtpCodeMessage(otpCode)
+	return useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		otpCode, err := useCase.otpCodesRepository.CreateWithUserEmail(ctx, domain.ResetPasswordOTP, userEmail)
 
-	if err != nil {
-		useCase.otpCodesRepository.Delete(ctx, otpCode)
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return useCase.notificationService.SendOtpCodeMessage(ctx, otpCode)
+	})
 }
@@ -4,11 +4,13 @@ import (
 	"comu/internal/modules/auth/domain"
 	mockRepository "comu/internal/modules/auth/mocks/mock_repository"
 	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"comu/internal/shared/database"
 	"context"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestResetPasswordUseCase(t *testing.T) {
@@ -17,15 +19,17 @@ func TestResetPasswordUseCase(t *testing.T) {
 		userService := mockService.NewUserServiceMock()
 		otpCodeRepository := mockRepository.NewOtpCodesRepositoryMock()
 		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
 		ctx := context.Background()
 
 		userEmail := "johndoe@gmail.com"
 
 		userService.On("GetUserByEmail", ctx, userEmail).Return(nil, domain.ErrUserNotFound).Once()
 
-		useCase := NewResetPasswordUseCase(userService, otpCodeRepository, notificationService)
+		useCase := NewResetPasswordUseCase(userService, otpCodeRepository, notificationService, challengeService, database.NoopTransactor{})
 
-		err := useCase.Execute(ctx, userEmail)
+		err := useCase.Execute(ctx, userEmail, domain.ChallengeSolution{})
 
 		assert.ErrorIs(t, err, domain.ErrUserNotFound)
 		userService.AssertExpectations(t)
@@ -37,6 +41,8 @@ func TestResetPasswordUseCase(t *testing.T) {
 		userService := mockService.NewUserServiceMock()
 		otpCodeRepository := mockRepository.NewOtpCodesRepositoryMock()
 		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		challengeService.On("Verify", mock.Anything, mock.Anything).Return(nil)
 		ctx := context.Background()
 
 		userEmail := "johndoe@gmail.com"
@@ -51,11 +57,11 @@ func TestResetPasswordUseCase(t *testing.T) {
 
 		userService.On("GetUserByEmail", ctx, userEmail).Return(user, nil).Once()
 		otpCodeRepository.On("CreateWithUserEmail", ctx, domain.ResetPasswordOTP, userEmail).Return(otpCode, nil).Once()
-		notificationService.On("SendOtpCodeMessage", otpCode).Return(nil).Once()
+		notificationService.On("SendOtpCodeMessage", ctx, otpCode).Return(nil).Once()
 
-		useCase := NewResetPasswordUseCase(userService, otpCodeRepository, notificationService)
+		useCase := NewResetPasswordUseCase(userService, otpCodeRepository, notificationService, challengeService, database.NoopTransactor{})
 
-		err := useCase.Execute(ctx, userEmail)
+		err := useCase.Execute(ctx, userEmail, domain.ChallengeSolution{})
 
 		assert.NoError(t, err)
 		userService.AssertExpectations(t)
@@ -63,4 +69,22 @@ func TestResetPasswordUseCase(t *testing.T) {
 		notificationService.AssertExpectations(t)
 	})
 
+	t.Run("it should fail and return ErrChallengeRequired when no challenge was solved", func(t *testing.T) {
+		userService := mockService.NewUserServiceMock()
+		otpCodeRepository := mockRepository.NewOtpCodesRepositoryMock()
+		notificationService := mockService.NewNotificationServiceMock()
+		challengeService := mockService.NewChallengeServiceMock()
+		ctx := context.Background()
+
+		challengeService.On("Verify", ctx, domain.ChallengeSolution{}).Return(domain.ErrChallengeRequired).Once()
+
+		useCase := NewResetPasswordUseCase(userService, otpCodeRepository, notificationService, challengeService, database.NoopTransactor{})
+
+		err := useCase.Execute(ctx, "johndoe@gmail.com", domain.ChallengeSolution{})
+
+		assert.ErrorIs(t, err, domain.ErrChallengeRequired)
+		challengeService.AssertExpectations(t)
+		userService.AssertNotCalled(t, "GetUserByEmail")
+		notificationService.AssertNotCalled(t, "SendOtpCodeMessage")
+	})
 }
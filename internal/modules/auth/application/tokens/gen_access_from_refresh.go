@@ -30,6 +30,10 @@ func (useCase *GenAccessTokenFromRefreshUC) Execute(ctx context.Context, tokenSt
 		return "", err
 	}
 
+	if token.Revoked {
+		return "", domain.ErrInvalidToken
+	}
+
 	if token.Expired() {
 		return "", domain.ErrExpiredToken
 	}
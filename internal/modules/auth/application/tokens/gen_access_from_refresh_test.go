@@ -46,6 +46,24 @@ func TestGenAccessTokenFromRefreshUseCase(t *testing.T) {
 		jwtService.AssertNotCalled(t, "GenerateToken")
 	})
 
+	t.Run("it should fail and return ErrInvalidToken when the token was revoked", func(t *testing.T) {
+		repository := memory.NewInMemoryRefreshTokensRepository(nil)
+		jwtService := mockService.NewJwtServiceMock()
+		userService := mockService.NewUserServiceMock()
+		ctx := context.Background()
+
+		token := domain.NewRefreshToken(uuid.New(), domain.DefaultRefreshTokenTTL)
+		repository.Store(ctx, token)
+		repository.Revoke(ctx, token.Token)
+
+		useCase := NewGenAccessTokenFromRefreshUseCase(jwtService, userService, repository)
+
+		_, err := useCase.Execute(ctx, token.Token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		userService.AssertNotCalled(t, "GetUserByID")
+		jwtService.AssertNotCalled(t, "GenerateToken")
+	})
+
 	t.Run("it should fail and return ErrUserNotFound", func(t *testing.T) {
 		repository := memory.NewInMemoryRefreshTokensRepository(nil)
 		jwtService := mockService.NewJwtServiceMock()
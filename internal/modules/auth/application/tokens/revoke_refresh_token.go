@@ -0,0 +1,29 @@
+package tokens
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"errors"
+)
+
+type RevokeRefreshTokenUC struct {
+	refreshTokensRepository domain.RefreshTokensRepository
+}
+
+func NewRevokeRefreshTokenUseCase(refreshTokensRepository domain.RefreshTokensRepository) *RevokeRefreshTokenUC {
+	return &RevokeRefreshTokenUC{
+		refreshTokensRepository: refreshTokensRepository,
+	}
+}
+
+// Execute revokes the given refresh token. Unknown tokens are ignored
+// since there is nothing left to revoke.
+func (useCase *RevokeRefreshTokenUC) Execute(ctx context.Context, tokenString string) error {
+	err := useCase.refreshTokensRepository.Revoke(ctx, tokenString)
+
+	if err != nil && !errors.Is(err, domain.ErrTokenNotFound) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package tokens
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeRefreshTokenUseCase(t *testing.T) {
+
+	t.Run("it should revoke the refresh token", func(t *testing.T) {
+		repository := memory.NewInMemoryRefreshTokensRepository(nil)
+		ctx := context.Background()
+
+		token := domain.NewRefreshToken(uuid.New(), domain.DefaultRefreshTokenTTL)
+		repository.Store(ctx, token)
+
+		useCase := NewRevokeRefreshTokenUseCase(repository)
+		err := useCase.Execute(ctx, token.Token)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			revokedToken, err := repository.Find(ctx, token.Token)
+
+			if _assert.NoError(err) {
+				_assert.True(revokedToken.Revoked)
+			}
+		}
+	})
+
+	t.Run("it should ignore unknown refresh tokens", func(t *testing.T) {
+		repository := memory.NewInMemoryRefreshTokensRepository(nil)
+
+		useCase := NewRevokeRefreshTokenUseCase(repository)
+		err := useCase.Execute(context.Background(), "eC9FIPQgybcC6tCItpKMxZyPrW2qNKP8vxoeWE8Vw/s=")
+
+		assert.NoError(t, err)
+	})
+}
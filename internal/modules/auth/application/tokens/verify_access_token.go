@@ -52,8 +52,9 @@ func (useCase *VerifyAccessTokenUC) Execute(ctx context.Context, token string) (
 }
 
 func (useCase *VerifyAccessTokenUC) getClaimsFromToken(token string) (jwt.MapClaims, error) {
-	if strings.HasPrefix(token, "Bearer ") {
-		token = strings.Replace(token, "Bearer ", "", 1)
+	// The authorization scheme is case-insensitive.
+	if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+		token = token[7:]
 	}
 
 	claims, err := useCase.jwtService.ValidateToken(token)
@@ -16,6 +16,7 @@ const (
 	LoginOTP OtpType = iota
 	RegisterOTP
 	ResetPasswordOTP
+	PhoneVerificationOTP
 )
 
 const (
@@ -51,6 +52,9 @@ type AuthUser struct {
 	Avatar          string
 	Active          bool
 	Password        string
+	Locale          string
+	Phone           string
+	PhoneVerifiedAt *time.Time
 	CreatedAt       time.Time
 	DeletedAt       *time.Time
 }
@@ -61,6 +65,8 @@ type OtpCode struct {
 	Value     string
 	ExpiredAt time.Time
 	CreatedAt time.Time
+	// LinkNonce identifies the code in the verification link sent along with it.
+	LinkNonce string
 }
 
 type RefreshToken struct {
@@ -102,6 +108,7 @@ func NewRefreshToken(userID uuid.UUID, ttl time.Duration) *RefreshToken {
 
 func NewOtpCode(otpType OtpType, userEmail string, ttl time.Duration) *OtpCode {
 	code, _ := random.Random(6, random.Digits, true)
+	linkNonce, _ := random.String(32)
 	expiredAt := time.Now().Add(ttl)
 
 	return &OtpCode{
@@ -110,6 +117,7 @@ func NewOtpCode(otpType OtpType, userEmail string, ttl time.Duration) *OtpCode {
 		Value:     code,
 		ExpiredAt: expiredAt,
 		CreatedAt: time.Now(),
+		LinkNonce: linkNonce,
 	}
 }
 
@@ -164,9 +172,13 @@ func (token *ResetToken) Expired() bool {
 type OtpCodesRepository interface {
 	Find(context.Context, string) (*OtpCode, error)
 	FindByUserEmail(context.Context, string) (*OtpCode, error)
+	FindByLinkNonce(context.Context, string) (*OtpCode, error)
 	Store(context.Context, *OtpCode) error
 	Exists(context.Context, string) bool
 	Delete(context.Context, *OtpCode) error
+	// DeleteByLinkNonce fails with ErrOtpNotFound when no code was deleted, so that
+	// a link used twice at the same time is only accepted once.
+	DeleteByLinkNonce(ctx context.Context, nonce string) error
 	CreateWithUserEmail(ctx context.Context, otpType OtpType, email string) (*OtpCode, error)
 }
 
@@ -194,9 +206,12 @@ type ResendOtpRequestsRepository interface {
 type UserService interface {
 	GetUserByID(context.Context, uuid.UUID) (*AuthUser, error)
 	GetUserByEmail(context.Context, string) (*AuthUser, error)
-	CreateNewUser(ctx context.Context, name, email, password string) (uuid.UUID, error)
+	CreateNewUser(ctx context.Context, name, email, password, locale string) (uuid.UUID, error)
+	CreateNewVerifiedUser(ctx context.Context, name, email, password string) (uuid.UUID, error)
 	MarkUserEmailAsVerified(ctx context.Context, userEmail string) error
 	UpdateUserPassword(ctx context.Context, userID uuid.UUID, newPassword string) error
+	// SetUserVerifiedPhone replaces the user phone number with a number which was just verified.
+	SetUserVerifiedPhone(ctx context.Context, userID uuid.UUID, phone string) error
 }
 
 type PasswordService interface {
@@ -209,7 +224,16 @@ type JwtService interface {
 	ValidateToken(string) (jwt.MapClaims, error)
 }
 
+// NotificationService queues the messages sent to the users. The messages are
+// queued within the transaction carried by the context, if any.
 type NotificationService interface {
-	SendOtpCodeMessage(code *OtpCode) error
-	SendPasswordChangedMessage(userEmail string) error
+	SendOtpCodeMessage(ctx context.Context, code *OtpCode) error
+	SendPasswordChangedMessage(ctx context.Context, userEmail string) error
+	SendNewSignInMessage(ctx context.Context, userEmail string, event *LoginEvent) error
+}
+
+// Transactor runs a function inside a transaction, committed when the function succeeds.
+// The repositories join the transaction through the context given to the function.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
 }
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	DefaultChallengeTTL        = time.Minute * 5
+	DefaultChallengeDifficulty = 18
+)
+
+var (
+	ErrChallengeRequired    = errors.New("a solved challenge is required")
+	ErrInvalidChallenge     = errors.New("the provided challenge solution is invalid")
+	ErrExpiredChallenge     = errors.New("the provided challenge has expired")
+	ErrChallengeAlreadyUsed = errors.New("the provided challenge has already been used")
+)
+
+// Challenge is a hashcash-style puzzle. It is solved by finding a nonce for which
+// the SHA-256 hash of "<token>:<nonce>" starts with Difficulty zero bits.
+type Challenge struct {
+	Token      string
+	Difficulty int
+	ExpiredAt  time.Time
+}
+
+type ChallengeSolution struct {
+	Token string
+	Nonce string
+}
+
+type ChallengeService interface {
+	Enabled() bool
+	Issue() (*Challenge, error)
+	// Verify checks the solution and marks its challenge as spent.
+	// It always succeeds when challenges are disabled.
+	Verify(context.Context, ChallengeSolution) error
+}
+
+type SpentChallengesRepository interface {
+	// Store records a spent challenge until it expires. It fails with
+	// ErrChallengeAlreadyUsed when the challenge was already spent.
+	Store(ctx context.Context, id string, expiredAt time.Time) error
+}
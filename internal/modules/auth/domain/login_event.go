@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mazen160/go-random"
+)
+
+type LoginMethod = string
+
+const (
+	PasswordLogin LoginMethod = "password"
+	OidcLogin     LoginMethod = "oidc"
+)
+
+const (
+	DefaultLoginHistoryLimit = 50
+	maxUserAgentLength       = 512
+)
+
+var ErrLoginEventNotFound = errors.New("no login event was found")
+
+// LoginEvent records a login attempt. Failed attempts made with an unknown
+// email address have no UserID. Successful ones keep the refresh token of the
+// session they opened and a token allowing to revoke it from an email link.
+type LoginEvent struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Email        string
+	Success      bool
+	Method       LoginMethod
+	IP           string
+	UserAgent    string
+	RefreshToken string
+	RevokeToken  string
+	CreatedAt    time.Time
+}
+
+func NewLoginEvent(userID uuid.UUID, email string, success bool, method LoginMethod, ip, userAgent string) *LoginEvent {
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return &LoginEvent{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Email:     email,
+		Success:   success,
+		Method:    method,
+		IP:        ip,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+	}
+}
+
+// AttachSession binds the refresh token of the session opened by the login to the event.
+func (event *LoginEvent) AttachSession(refreshToken string) {
+	event.RefreshToken = refreshToken
+	event.RevokeToken, _ = random.String(48)
+}
+
+type LoginSources struct {
+	HasSucceeded   bool
+	KnownIP        bool
+	KnownUserAgent bool
+}
+
+type LoginEventsRepository interface {
+	Store(context.Context, *LoginEvent) error
+	FindByUser(ctx context.Context, userID uuid.UUID, limit int) ([]LoginEvent, error)
+	FindByRevokeToken(context.Context, string) (*LoginEvent, error)
+	// FindSuccessfulSources reports whether the user already logged in successfully,
+	// and whether the given ip address and user agent were used by one of those logins.
+	FindSuccessfulSources(ctx context.Context, userID uuid.UUID, ip, userAgent string) (LoginSources, error)
+}
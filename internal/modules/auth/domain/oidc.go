@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mazen160/go-random"
+)
+
+const DefaultOidcStateTTL = time.Minute * 10
+
+var (
+	ErrOidcProviderNotFound = errors.New("the requested identity provider is not supported")
+	ErrOidcStateNotFound    = errors.New("no oidc authentication state was found")
+	ErrInvalidOidcState     = errors.New("the authentication request is invalid or has expired")
+	ErrOidcExchangeFailed   = errors.New("the identity provider rejected the authentication request")
+	ErrOidcEmailNotVerified = errors.New("the identity provider did not confirm your email address")
+	ErrOidcIdentityNotFound = errors.New("no linked identity was found")
+)
+
+// OidcState is created when a user starts signing in with an identity provider.
+// Its value is sent as the "state" parameter and its nonce is bound to the ID token
+// so that the callback can't be forged nor replayed. Its code verifier binds the
+// authorization code to this server through PKCE.
+type OidcState struct {
+	Value        string
+	Nonce        string
+	CodeVerifier string
+	Provider     string
+	ExpiredAt    time.Time
+	CreatedAt    time.Time
+}
+
+// OidcIdentity links an identity provider account to a local user.
+type OidcIdentity struct {
+	Provider  string
+	Subject   string
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+// OidcClaims are the verified claims extracted from a provider ID token.
+type OidcClaims struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+func NewOidcState(provider string, ttl time.Duration) *OidcState {
+	value, _ := random.String(48)
+	nonce, _ := random.String(48)
+	codeVerifier, _ := random.String(64)
+
+	return &OidcState{
+		Value:        value,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		Provider:     provider,
+		ExpiredAt:    time.Now().Add(ttl),
+		CreatedAt:    time.Now(),
+	}
+}
+
+func NewOidcIdentity(provider, subject string, userID uuid.UUID) *OidcIdentity {
+	return &OidcIdentity{
+		Provider:  provider,
+		Subject:   subject,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (state *OidcState) Expired() bool {
+	return time.Now().After(state.ExpiredAt)
+}
+
+// CodeChallenge returns the S256 PKCE challenge of the state code verifier.
+func (state *OidcState) CodeChallenge() string {
+	sum := sha256.Sum256([]byte(state.CodeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+type OidcStatesRepository interface {
+	Find(context.Context, string) (*OidcState, error)
+	Store(context.Context, *OidcState) error
+	Delete(context.Context, string) error
+}
+
+type OidcIdentitiesRepository interface {
+	Find(ctx context.Context, provider, subject string) (*OidcIdentity, error)
+	Store(context.Context, *OidcIdentity) error
+}
+
+type OidcService interface {
+	// AuthCodeURL returns the provider authorization endpoint url the user should be redirected to.
+	AuthCodeURL(ctx context.Context, provider, state, nonce, codeChallenge string) (string, error)
+	// Exchange trades the authorization code for an ID token, verifies it and returns its claims.
+	Exchange(ctx context.Context, provider, code, nonce, codeVerifier string) (*OidcClaims, error)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+type OtpChannel = string
+
+const (
+	EmailOtpChannel OtpChannel = "email"
+	SmsOtpChannel   OtpChannel = "sms"
+)
+
+var (
+	ErrUnknownOtpChannel     = errors.New("this otp delivery channel doesn't exist")
+	ErrOtpChannelUnavailable = errors.New("you can't receive codes through this channel. Please verify your phone number first")
+	ErrInvalidPhone          = errors.New("the phone number must be in the international format, like +33601020304")
+
+	phoneRegexp = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+)
+
+// OtpSender delivers the otp codes through a channel.
+type OtpSender interface {
+	Channel() OtpChannel
+	// CanSendTo reports whether the user can receive codes through the channel.
+	CanSendTo(user *AuthUser) bool
+	SendOtpCode(ctx context.Context, user *AuthUser, code *OtpCode) error
+}
+
+// OtpSenders are the available delivery channels, the first one being the default.
+type OtpSenders []OtpSender
+
+// Find returns the sender of the channel, or the default one when the channel is empty.
+func (senders OtpSenders) Find(channel OtpChannel) (OtpSender, error) {
+	for _, sender := range senders {
+		if channel == "" || sender.Channel() == channel {
+			return sender, nil
+		}
+	}
+
+	return nil, ErrUnknownOtpChannel
+}
+
+// Select returns the sender of the channel, provided the user can receive codes through it.
+func (senders OtpSenders) Select(channel OtpChannel, user *AuthUser) (OtpSender, error) {
+	sender, err := senders.Find(channel)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !sender.CanSendTo(user) {
+		return nil, ErrOtpChannelUnavailable
+	}
+
+	return sender, nil
+}
+
+func IsValidPhone(phone string) bool {
+	return phoneRegexp.MatchString(phone)
+}
+
+func (user *AuthUser) PhoneIsVerified() bool {
+	return user.Phone != "" && user.PhoneVerifiedAt != nil
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PhoneCodeDelay is the time to wait between two phone verification codes.
+	PhoneCodeDelay = time.Minute
+	// MaxPhoneCodes is the number of phone verification codes a user can request per PhoneCodesWindow.
+	MaxPhoneCodes    = 5
+	PhoneCodesWindow = time.Hour * 24
+)
+
+var ErrPhoneVerificationNotFound = errors.New("no phone number is waiting to be verified")
+
+// PhoneVerification holds the phone number the user is verifying, kept apart from
+// the user phone number until the code texted to it is verified. It also counts the
+// codes sent to the user, whatever the number, which are limited per window.
+type PhoneVerification struct {
+	UserID     uuid.UUID
+	Phone      string
+	Count      int
+	LastSendAt time.Time
+	// CreatedAt is the start of the current window.
+	CreatedAt time.Time
+}
+
+func NewPhoneVerification(userID uuid.UUID) *PhoneVerification {
+	return &PhoneVerification{
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// CanCodeBeSent fails with ErrResendRequestCantBeProcessed when the last code was sent
+// less than PhoneCodeDelay ago, and with ErrResendRequestCountExceeded when the user
+// requested MaxPhoneCodes codes within the current window.
+func (verification *PhoneVerification) CanCodeBeSent(now time.Time) error {
+	if now.Before(verification.LastSendAt.Add(PhoneCodeDelay)) {
+		return ErrResendRequestCantBeProcessed
+	}
+
+	if verification.Count >= MaxPhoneCodes && now.Before(verification.CreatedAt.Add(PhoneCodesWindow)) {
+		return ErrResendRequestCountExceeded
+	}
+
+	return nil
+}
+
+// CodeSent records a code sent to the phone number, which becomes the pending one.
+func (verification *PhoneVerification) CodeSent(phone string, now time.Time) {
+	if !now.Before(verification.CreatedAt.Add(PhoneCodesWindow)) {
+		verification.Count = 0
+		verification.CreatedAt = now
+	}
+	verification.Phone = phone
+	verification.Count++
+	verification.LastSendAt = now
+}
+
+// Verified clears the pending phone number, keeping the count of the codes sent.
+func (verification *PhoneVerification) Verified() {
+	verification.Phone = ""
+}
+
+type PhoneVerificationsRepository interface {
+	// FindByUserID locks the verification until the end of the transaction carried by the context, if any.
+	FindByUserID(context.Context, uuid.UUID) (*PhoneVerification, error)
+	// Save stores the verification, or updates it when the user already has one.
+	Save(context.Context, *PhoneVerification) error
+}
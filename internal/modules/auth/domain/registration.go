@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mazen160/go-random"
+)
+
+type RegistrationMode = string
+
+const (
+	OpenRegistration   RegistrationMode = "open"
+	InviteRegistration RegistrationMode = "invite"
+	DomainRegistration RegistrationMode = "domain"
+)
+
+// ValidRegistrationMode reports whether mode is one of the supported registration modes.
+func ValidRegistrationMode(mode string) bool {
+	return slices.Contains([]RegistrationMode{OpenRegistration, InviteRegistration, DomainRegistration}, mode)
+}
+
+const (
+	DefaultInviteTTL = time.Hour * 24 * 7
+	MaxInviteTTL     = time.Hour * 24 * 30
+	MaxInviteUses    = 100
+)
+
+var (
+	ErrInviteRequired        = errors.New("registration is by invitation only")
+	ErrInviteNotFound        = errors.New("no invite was found")
+	ErrInvalidInvite         = errors.New("the provided invite code is invalid or has expired")
+	ErrEmailDomainNotAllowed = errors.New("registration is not allowed for this email domain")
+	ErrDisposableEmail       = errors.New("disposable email addresses are not allowed")
+)
+
+// RegistrationPolicy decides who is allowed to create an account.
+type RegistrationPolicy struct {
+	Mode           RegistrationMode
+	AllowedDomains []string
+}
+
+// CheckEmail verifies that an account can be created with the given email address
+// under the policy mode. Invite codes are checked separately since they're consumed.
+func (policy RegistrationPolicy) CheckEmail(email string) error {
+	if policy.Mode != DomainRegistration {
+		return nil
+	}
+
+	if !slices.Contains(policy.AllowedDomains, EmailDomain(email)) {
+		return ErrEmailDomainNotAllowed
+	}
+
+	return nil
+}
+
+func (policy RegistrationPolicy) InviteRequired() bool {
+	return policy.Mode == InviteRegistration
+}
+
+// Invite is a code allowing users to register when registration is invite-only.
+type Invite struct {
+	Code      string
+	CreatedBy uuid.UUID
+	MaxUses   int
+	Uses      int
+	ExpiredAt time.Time
+	CreatedAt time.Time
+}
+
+func NewInvite(createdBy uuid.UUID, maxUses int, ttl time.Duration) *Invite {
+	code, _ := random.String(24)
+
+	return &Invite{
+		Code:      code,
+		CreatedBy: createdBy,
+		MaxUses:   maxUses,
+		Uses:      0,
+		ExpiredAt: time.Now().Add(ttl),
+		CreatedAt: time.Now(),
+	}
+}
+
+func (invite *Invite) Expired() bool {
+	return time.Now().After(invite.ExpiredAt)
+}
+
+func (invite *Invite) Usable() bool {
+	return !invite.Expired() && invite.Uses < invite.MaxUses
+}
+
+// EmailDomain returns the lowercased domain part of an email address.
+func EmailDomain(email string) string {
+	_, domain, _ := strings.Cut(email, "@")
+	return strings.ToLower(strings.TrimSpace(domain))
+}
+
+type InvitesRepository interface {
+	Find(context.Context, string) (*Invite, error)
+	FindByCreator(context.Context, uuid.UUID) ([]Invite, error)
+	Store(context.Context, *Invite) error
+	// Consume atomically uses the invite once. It fails with ErrInvalidInvite
+	// when the invite doesn't exist, has expired or has no use left.
+	Consume(context.Context, string) error
+	// Release gives back a use taken by Consume.
+	Release(context.Context, string) error
+}
+
+type DisposableEmailChecker interface {
+	IsDisposable(email string) bool
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+var ErrInvalidVerificationLink = errors.New("the verification link is invalid")
+
+// VerificationLink is the content of a signed verification link. The
+// nonce binds the link to the otp code it was sent with, so that it can't be used
+// once the code is spent.
+type VerificationLink struct {
+	Type      OtpType
+	UserEmail string
+	Nonce     string
+}
+
+// VerificationLinks signs the links sent along with the register and reset password
+// otp codes, which complete the verification without typing the code.
+type VerificationLinks interface {
+	// URL returns the link of the otp code, or an empty string when its type has no link.
+	URL(code *OtpCode) string
+	Verify(token string) (*VerificationLink, error)
+}
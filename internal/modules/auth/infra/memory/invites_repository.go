@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type inviteStore map[string]domain.Invite
+
+type inMemoryInvitesRepository struct {
+	invites inviteStore
+	sync.Mutex
+}
+
+func NewInMemoryInvitesRepository(initialStore inviteStore) *inMemoryInvitesRepository {
+	if initialStore == nil {
+		initialStore = make(inviteStore)
+	}
+
+	return &inMemoryInvitesRepository{
+		invites: initialStore,
+	}
+}
+
+func (repo *inMemoryInvitesRepository) Find(ctx context.Context, code string) (*domain.Invite, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	invite, ok := repo.invites[code]
+
+	if !ok {
+		return nil, domain.ErrInviteNotFound
+	}
+
+	return &invite, nil
+}
+
+func (repo *inMemoryInvitesRepository) FindByCreator(ctx context.Context, userID uuid.UUID) ([]domain.Invite, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	invites := []domain.Invite{}
+
+	for _, invite := range repo.invites {
+		if invite.CreatedBy == userID {
+			invites = append(invites, invite)
+		}
+	}
+
+	slices.SortFunc(invites, func(a, b domain.Invite) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return invites, nil
+}
+
+func (repo *inMemoryInvitesRepository) Store(ctx context.Context, invite *domain.Invite) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.invites[invite.Code] = *invite
+
+	return nil
+}
+
+func (repo *inMemoryInvitesRepository) Consume(ctx context.Context, code string) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	invite, ok := repo.invites[code]
+
+	if !ok || !invite.Usable() {
+		return domain.ErrInvalidInvite
+	}
+	invite.Uses++
+	repo.invites[code] = invite
+
+	return nil
+}
+
+func (repo *inMemoryInvitesRepository) Release(ctx context.Context, code string) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	invite, ok := repo.invites[code]
+
+	if !ok {
+		return domain.ErrInviteNotFound
+	}
+
+	if invite.Uses > 0 {
+		invite.Uses--
+		repo.invites[code] = invite
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryInvitesRepositoryFindByCreatorMethod(t *testing.T) {
+
+	t.Run("it should only retrieve the invites created by the given user", func(t *testing.T) {
+		repo := NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		firstInvite := domain.NewInvite(userID, 1, domain.DefaultInviteTTL)
+		secondInvite := domain.NewInvite(userID, 5, domain.DefaultInviteTTL)
+		secondInvite.CreatedAt = firstInvite.CreatedAt.Add(time.Second)
+
+		repo.Store(ctx, firstInvite)
+		repo.Store(ctx, secondInvite)
+		repo.Store(ctx, domain.NewInvite(uuid.New(), 1, domain.DefaultInviteTTL))
+
+		invites, err := repo.FindByCreator(ctx, userID)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) && _assert.Len(invites, 2) {
+			_assert.Equal(secondInvite.Code, invites[0].Code)
+			_assert.Equal(firstInvite.Code, invites[1].Code)
+		}
+	})
+}
+
+func TestInMemoryInvitesRepositoryConsumeMethod(t *testing.T) {
+
+	t.Run("it should use the invite once", func(t *testing.T) {
+		repo := NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+		invite := domain.NewInvite(uuid.New(), 2, domain.DefaultInviteTTL)
+
+		repo.Store(ctx, invite)
+
+		err := repo.Consume(ctx, invite.Code)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(1, repo.invites[invite.Code].Uses)
+		}
+	})
+
+	t.Run("it should fail and return ErrInvalidInvite when the invite has no use left", func(t *testing.T) {
+		repo := NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+		invite := domain.NewInvite(uuid.New(), 1, domain.DefaultInviteTTL)
+
+		repo.Store(ctx, invite)
+		repo.Consume(ctx, invite.Code)
+
+		assert.ErrorIs(t, repo.Consume(ctx, invite.Code), domain.ErrInvalidInvite)
+	})
+
+	t.Run("it should fail and return ErrInvalidInvite when the invite has expired", func(t *testing.T) {
+		repo := NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+		invite := domain.NewInvite(uuid.New(), 1, -time.Minute)
+
+		repo.Store(ctx, invite)
+
+		assert.ErrorIs(t, repo.Consume(ctx, invite.Code), domain.ErrInvalidInvite)
+	})
+
+	t.Run("it should never use the invite more than allowed", func(t *testing.T) {
+		repo := NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+		invite := domain.NewInvite(uuid.New(), 3, domain.DefaultInviteTTL)
+
+		repo.Store(ctx, invite)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		consumed := 0
+
+		for range 10 {
+			wg.Go(func() {
+				if repo.Consume(ctx, invite.Code) == nil {
+					mu.Lock()
+					consumed++
+					mu.Unlock()
+				}
+			})
+		}
+		wg.Wait()
+
+		assert.Equal(t, 3, consumed)
+	})
+}
+
+func TestInMemoryInvitesRepositoryReleaseMethod(t *testing.T) {
+
+	t.Run("it should give back a use of the invite", func(t *testing.T) {
+		repo := NewInMemoryInvitesRepository(nil)
+		ctx := context.Background()
+		invite := domain.NewInvite(uuid.New(), 1, domain.DefaultInviteTTL)
+
+		repo.Store(ctx, invite)
+		repo.Consume(ctx, invite.Code)
+
+		err := repo.Release(ctx, invite.Code)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.NoError(repo.Consume(ctx, invite.Code))
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type loginEventStore map[uuid.UUID]domain.LoginEvent
+
+type inMemoryLoginEventsRepository struct {
+	events loginEventStore
+	sync.Mutex
+}
+
+func NewInMemoryLoginEventsRepository(initialStore loginEventStore) *inMemoryLoginEventsRepository {
+	if initialStore == nil {
+		initialStore = make(loginEventStore)
+	}
+
+	return &inMemoryLoginEventsRepository{
+		events: initialStore,
+	}
+}
+
+func (repo *inMemoryLoginEventsRepository) Store(ctx context.Context, event *domain.LoginEvent) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.events[event.ID] = *event
+
+	return nil
+}
+
+func (repo *inMemoryLoginEventsRepository) FindByUser(ctx context.Context, userID uuid.UUID, limit int) ([]domain.LoginEvent, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	events := []domain.LoginEvent{}
+
+	for _, event := range repo.events {
+		if event.UserID == userID {
+			events = append(events, event)
+		}
+	}
+
+	slices.SortFunc(events, func(a, b domain.LoginEvent) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+func (repo *inMemoryLoginEventsRepository) FindByRevokeToken(ctx context.Context, token string) (*domain.LoginEvent, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	for _, event := range repo.events {
+		if token != "" && event.RevokeToken == token {
+			return &event, nil
+		}
+	}
+
+	return nil, domain.ErrLoginEventNotFound
+}
+
+func (repo *inMemoryLoginEventsRepository) FindSuccessfulSources(
+	ctx context.Context, userID uuid.UUID, ip, userAgent string,
+) (domain.LoginSources, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	sources := domain.LoginSources{}
+
+	for _, event := range repo.events {
+		if event.UserID != userID || !event.Success {
+			continue
+		}
+		sources.HasSucceeded = true
+		sources.KnownIP = sources.KnownIP || event.IP == ip
+		sources.KnownUserAgent = sources.KnownUserAgent || event.UserAgent == userAgent
+	}
+
+	return sources, nil
+}
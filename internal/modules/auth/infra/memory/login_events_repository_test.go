@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testUserAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0"
+
+func TestInMemoryLoginEventsRepositoryFindByUserMethod(t *testing.T) {
+
+	t.Run("it should retrieve the latest login events of the user", func(t *testing.T) {
+		repo := NewInMemoryLoginEventsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		for i := range 3 {
+			event := domain.NewLoginEvent(userID, "johndoe@gmail.com", true, domain.PasswordLogin, "127.0.0.1", testUserAgent)
+			event.CreatedAt = event.CreatedAt.Add(time.Duration(i) * time.Minute)
+			repo.Store(ctx, event)
+		}
+		repo.Store(ctx, domain.NewLoginEvent(uuid.New(), "janedoe@gmail.com", true, domain.PasswordLogin, "127.0.0.1", testUserAgent))
+
+		events, err := repo.FindByUser(ctx, userID, 2)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) && _assert.Len(events, 2) {
+			_assert.True(events[0].CreatedAt.After(events[1].CreatedAt))
+			_assert.Equal(userID, events[1].UserID)
+		}
+	})
+}
+
+func TestInMemoryLoginEventsRepositoryFindByRevokeTokenMethod(t *testing.T) {
+
+	t.Run("it should retrieve the login event owning the revoke token", func(t *testing.T) {
+		repo := NewInMemoryLoginEventsRepository(nil)
+		ctx := context.Background()
+
+		event := domain.NewLoginEvent(uuid.New(), "johndoe@gmail.com", true, domain.PasswordLogin, "127.0.0.1", testUserAgent)
+		event.AttachSession("eC9FIPQgybcC6tCItpKMxZyPrW2qNKP8vxoeWE8Vw/s=")
+		repo.Store(ctx, event)
+
+		retrievedEvent, err := repo.FindByRevokeToken(ctx, event.RevokeToken)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(event.ID, retrievedEvent.ID)
+		}
+	})
+
+	t.Run("it should fail and return ErrLoginEventNotFound", func(t *testing.T) {
+		repo := NewInMemoryLoginEventsRepository(nil)
+		repo.Store(context.Background(), domain.NewLoginEvent(uuid.New(), "johndoe@gmail.com", false, domain.PasswordLogin, "127.0.0.1", testUserAgent))
+
+		_, err := repo.FindByRevokeToken(context.Background(), "")
+		assert.ErrorIs(t, err, domain.ErrLoginEventNotFound)
+	})
+}
+
+func TestInMemoryLoginEventsRepositoryFindSuccessfulSourcesMethod(t *testing.T) {
+
+	t.Run("it should only consider the successful logins of the user", func(t *testing.T) {
+		repo := NewInMemoryLoginEventsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		repo.Store(ctx, domain.NewLoginEvent(userID, "johndoe@gmail.com", true, domain.PasswordLogin, "127.0.0.1", testUserAgent))
+		repo.Store(ctx, domain.NewLoginEvent(userID, "johndoe@gmail.com", false, domain.PasswordLogin, "10.0.0.8", "curl/8.5.0"))
+
+		sources, err := repo.FindSuccessfulSources(ctx, userID, "10.0.0.8", testUserAgent)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.True(sources.HasSucceeded)
+			_assert.False(sources.KnownIP)
+			_assert.True(sources.KnownUserAgent)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"sync"
+)
+
+type oidcIdentityKey struct {
+	provider string
+	subject  string
+}
+
+type oidcIdentityStore map[oidcIdentityKey]domain.OidcIdentity
+
+type inMemoryOidcIdentitiesRepository struct {
+	identities oidcIdentityStore
+	sync.Mutex
+}
+
+func NewInMemoryOidcIdentitiesRepository(initialStore oidcIdentityStore) *inMemoryOidcIdentitiesRepository {
+	if initialStore == nil {
+		initialStore = make(oidcIdentityStore)
+	}
+
+	return &inMemoryOidcIdentitiesRepository{
+		identities: initialStore,
+	}
+}
+
+func (repo *inMemoryOidcIdentitiesRepository) Find(ctx context.Context, provider, subject string) (*domain.OidcIdentity, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	identity, ok := repo.identities[oidcIdentityKey{provider, subject}]
+
+	if !ok {
+		return nil, domain.ErrOidcIdentityNotFound
+	}
+
+	return &identity, nil
+}
+
+func (repo *inMemoryOidcIdentitiesRepository) Store(ctx context.Context, identity *domain.OidcIdentity) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.identities[oidcIdentityKey{identity.Provider, identity.Subject}] = *identity
+
+	return nil
+}
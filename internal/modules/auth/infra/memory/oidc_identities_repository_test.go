@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryOidcIdentitiesRepositoryFindMethod(t *testing.T) {
+
+	t.Run("it should retrieve the identity linked to a provider subject", func(t *testing.T) {
+		repo := NewInMemoryOidcIdentitiesRepository(nil)
+		identity := domain.NewOidcIdentity("gitlab", "248289761001", uuid.New())
+		ctx := context.Background()
+
+		repo.Store(ctx, identity)
+
+		retrievedIdentity, err := repo.Find(ctx, "gitlab", "248289761001")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, identity.UserID, retrievedIdentity.UserID)
+		}
+	})
+
+	t.Run("it should not mix up subjects of different providers", func(t *testing.T) {
+		repo := NewInMemoryOidcIdentitiesRepository(nil)
+		ctx := context.Background()
+
+		repo.Store(ctx, domain.NewOidcIdentity("gitlab", "248289761001", uuid.New()))
+
+		retrievedIdentity, err := repo.Find(ctx, "google", "248289761001")
+
+		assert.Nil(t, retrievedIdentity)
+		assert.ErrorIs(t, err, domain.ErrOidcIdentityNotFound)
+	})
+}
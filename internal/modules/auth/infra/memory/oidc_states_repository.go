@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"sync"
+)
+
+type oidcStateStore map[string]domain.OidcState
+
+type inMemoryOidcStatesRepository struct {
+	states oidcStateStore
+	sync.Mutex
+}
+
+func NewInMemoryOidcStatesRepository(initialStore oidcStateStore) *inMemoryOidcStatesRepository {
+	if initialStore == nil {
+		initialStore = make(oidcStateStore)
+	}
+
+	return &inMemoryOidcStatesRepository{
+		states: initialStore,
+	}
+}
+
+func (repo *inMemoryOidcStatesRepository) Find(ctx context.Context, value string) (*domain.OidcState, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	state, ok := repo.states[value]
+
+	if !ok {
+		return nil, domain.ErrOidcStateNotFound
+	}
+
+	return &state, nil
+}
+
+func (repo *inMemoryOidcStatesRepository) Store(ctx context.Context, state *domain.OidcState) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.states[state.Value] = *state
+
+	return nil
+}
+
+func (repo *inMemoryOidcStatesRepository) Delete(ctx context.Context, value string) error {
+	if _, err := repo.Find(ctx, value); err != nil {
+		return err
+	}
+	repo.Lock()
+	defer repo.Unlock()
+
+	delete(repo.states, value)
+
+	return nil
+}
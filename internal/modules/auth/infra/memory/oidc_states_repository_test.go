@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryOidcStatesRepositoryFindMethod(t *testing.T) {
+
+	t.Run("it should successfully retrieve a stored state", func(t *testing.T) {
+		repo := NewInMemoryOidcStatesRepository(nil)
+		state := domain.NewOidcState("gitlab", domain.DefaultOidcStateTTL)
+		ctx := context.Background()
+
+		repo.Store(ctx, state)
+
+		retrievedState, err := repo.Find(ctx, state.Value)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal(state.Nonce, retrievedState.Nonce)
+			_assert.Equal(state.Provider, retrievedState.Provider)
+			_assert.Equal(state.ExpiredAt, retrievedState.ExpiredAt)
+		}
+	})
+
+	t.Run("it should fail and return ErrOidcStateNotFound", func(t *testing.T) {
+		repo := NewInMemoryOidcStatesRepository(nil)
+
+		retrievedState, err := repo.Find(context.Background(), "unknown-state")
+
+		assert.Nil(t, retrievedState)
+		assert.ErrorIs(t, err, domain.ErrOidcStateNotFound)
+	})
+}
+
+func TestInMemoryOidcStatesRepositoryDeleteMethod(t *testing.T) {
+
+	t.Run("it should successfully delete a given state", func(t *testing.T) {
+		repo := NewInMemoryOidcStatesRepository(nil)
+		state := domain.NewOidcState("gitlab", domain.DefaultOidcStateTTL)
+		ctx := context.Background()
+
+		repo.Store(ctx, state)
+
+		err := repo.Delete(ctx, state.Value)
+
+		if assert.NoError(t, err) {
+			_, err = repo.Find(ctx, state.Value)
+			assert.ErrorIs(t, err, domain.ErrOidcStateNotFound)
+		}
+	})
+
+	t.Run("it should fail deleting an unknown state", func(t *testing.T) {
+		repo := NewInMemoryOidcStatesRepository(nil)
+
+		err := repo.Delete(context.Background(), "unknown-state")
+
+		assert.ErrorIs(t, err, domain.ErrOidcStateNotFound)
+	})
+}
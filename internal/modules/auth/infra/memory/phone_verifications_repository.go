@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type phoneVerificationStore map[uuid.UUID]domain.PhoneVerification
+
+type inMemoryPhoneVerificationsRepository struct {
+	verifications phoneVerificationStore
+	sync.Mutex
+}
+
+func NewInMemoryPhoneVerificationsRepository(initialStore phoneVerificationStore) *inMemoryPhoneVerificationsRepository {
+	if initialStore == nil {
+		initialStore = make(phoneVerificationStore)
+	}
+
+	return &inMemoryPhoneVerificationsRepository{
+		verifications: initialStore,
+	}
+}
+
+func (repo *inMemoryPhoneVerificationsRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.PhoneVerification, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	verification, ok := repo.verifications[userID]
+
+	if !ok {
+		return nil, domain.ErrPhoneVerificationNotFound
+	}
+
+	return &verification, nil
+}
+
+func (repo *inMemoryPhoneVerificationsRepository) Save(ctx context.Context, verification *domain.PhoneVerification) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.verifications[verification.UserID] = *verification
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryPhoneVerificationsRepository(t *testing.T) {
+
+	t.Run("it should save the verification and update it", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryPhoneVerificationsRepository(nil)
+		ctx := context.Background()
+
+		verification := domain.NewPhoneVerification(uuid.New())
+		verification.CodeSent("+33601020304", time.Now())
+		_assert.NoError(repo.Save(ctx, verification))
+
+		verification.Verified()
+		_assert.NoError(repo.Save(ctx, verification))
+
+		retrieved, err := repo.FindByUserID(ctx, verification.UserID)
+
+		if _assert.NoError(err) {
+			_assert.Empty(retrieved.Phone)
+			_assert.Equal(1, retrieved.Count)
+		}
+	})
+
+	t.Run("it should fail and return ErrPhoneVerificationNotFound", func(t *testing.T) {
+		repo := NewInMemoryPhoneVerificationsRepository(nil)
+
+		_, err := repo.FindByUserID(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, domain.ErrPhoneVerificationNotFound)
+	})
+}
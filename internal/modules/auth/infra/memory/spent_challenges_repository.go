@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"sync"
+	"time"
+)
+
+type spentChallengeStore map[string]time.Time
+
+type inMemorySpentChallengesRepository struct {
+	challenges spentChallengeStore
+	sync.Mutex
+}
+
+func NewInMemorySpentChallengesRepository(initialStore spentChallengeStore) *inMemorySpentChallengesRepository {
+	if initialStore == nil {
+		initialStore = make(spentChallengeStore)
+	}
+
+	return &inMemorySpentChallengesRepository{
+		challenges: initialStore,
+	}
+}
+
+func (repo *inMemorySpentChallengesRepository) Store(ctx context.Context, id string, expiredAt time.Time) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if _, ok := repo.challenges[id]; ok {
+		return domain.ErrChallengeAlreadyUsed
+	}
+
+	for challengeID, challengeExpiredAt := range repo.challenges {
+		if time.Now().After(challengeExpiredAt) {
+			delete(repo.challenges, challengeID)
+		}
+	}
+	repo.challenges[id] = expiredAt
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemorySpentChallengesRepositoryStoreMethod(t *testing.T) {
+
+	t.Run("it should store the spent challenge", func(t *testing.T) {
+		repo := NewInMemorySpentChallengesRepository(nil)
+
+		err := repo.Store(context.Background(), "gdSR5Y3bKzvJ4o3x", time.Now().Add(time.Minute))
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Contains(repo.challenges, "gdSR5Y3bKzvJ4o3x")
+		}
+	})
+
+	t.Run("it should fail and return ErrChallengeAlreadyUsed when the challenge was already spent", func(t *testing.T) {
+		repo := NewInMemorySpentChallengesRepository(nil)
+		ctx := context.Background()
+
+		repo.Store(ctx, "gdSR5Y3bKzvJ4o3x", time.Now().Add(time.Minute))
+		err := repo.Store(ctx, "gdSR5Y3bKzvJ4o3x", time.Now().Add(time.Minute))
+
+		assert.ErrorIs(t, err, domain.ErrChallengeAlreadyUsed)
+	})
+
+	t.Run("it should forget expired challenges", func(t *testing.T) {
+		repo := NewInMemorySpentChallengesRepository(nil)
+		ctx := context.Background()
+
+		repo.Store(ctx, "gdSR5Y3bKzvJ4o3x", time.Now().Add(-time.Minute))
+		repo.Store(ctx, "Lw1dA6QqzR0Nf8Ty", time.Now().Add(time.Minute))
+
+		assert.NotContains(t, repo.challenges, "gdSR5Y3bKzvJ4o3x")
+	})
+}
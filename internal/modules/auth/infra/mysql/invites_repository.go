@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type invitesRepository struct {
+	db *sql.DB
+}
+
+func NewInvitesRepository(db *sql.DB) *invitesRepository {
+	return &invitesRepository{
+		db: db,
+	}
+}
+
+func (repo *invitesRepository) Find(ctx context.Context, code string) (*domain.Invite, error) {
+	query := "SELECT * FROM invites WHERE code = ?"
+	invite := &domain.Invite{}
+
+	err := repo.db.QueryRowContext(ctx, query, code).Scan(
+		&invite.Code, &invite.CreatedBy, &invite.MaxUses,
+		&invite.Uses, &invite.ExpiredAt, &invite.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInviteNotFound
+		}
+
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (repo *invitesRepository) FindByCreator(ctx context.Context, userID uuid.UUID) ([]domain.Invite, error) {
+	query := "SELECT * FROM invites WHERE created_by = UUID_TO_BIN(?) ORDER BY created_at DESC"
+	rows, err := repo.db.QueryContext(ctx, query, userID.String())
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invites := []domain.Invite{}
+
+	for rows.Next() {
+		var invite domain.Invite
+
+		if err := rows.Scan(
+			&invite.Code, &invite.CreatedBy, &invite.MaxUses,
+			&invite.Uses, &invite.ExpiredAt, &invite.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+
+	return invites, rows.Err()
+}
+
+func (repo *invitesRepository) Store(ctx context.Context, invite *domain.Invite) error {
+	query := `
+		INSERT INTO invites (code, created_by, max_uses, uses, expired_at, created_at)
+		VALUES (?, UUID_TO_BIN(?), ?, ?, ?, ?)
+	`
+
+	_, err := repo.db.ExecContext(
+		ctx, query, invite.Code, invite.CreatedBy.String(),
+		invite.MaxUses, invite.Uses, invite.ExpiredAt, invite.CreatedAt,
+	)
+
+	return err
+}
+
+func (repo *invitesRepository) Consume(ctx context.Context, code string) error {
+	// The conditions are part of the update so that concurrent registrations
+	// can't use the invite more than allowed.
+	query := `
+		UPDATE invites SET uses = uses + 1
+		WHERE code = ? AND uses < max_uses AND expired_at > NOW()
+	`
+	result, err := repo.db.ExecContext(ctx, query, code)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrInvalidInvite
+	}
+
+	return nil
+}
+
+func (repo *invitesRepository) Release(ctx context.Context, code string) error {
+	query := "UPDATE invites SET uses = uses - 1 WHERE code = ? AND uses > 0"
+	_, err := repo.db.ExecContext(ctx, query, code)
+
+	return err
+}
@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type loginEventsRepository struct {
+	db *sql.DB
+}
+
+func NewLoginEventsRepository(db *sql.DB) *loginEventsRepository {
+	return &loginEventsRepository{
+		db: db,
+	}
+}
+
+func (repo *loginEventsRepository) Store(ctx context.Context, event *domain.LoginEvent) error {
+	query := `
+		INSERT INTO login_events (
+			id, user_id, email, success, method, ip,
+			user_agent, refresh_token, revoke_token, created_at
+		)
+		VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	var userID, revokeToken any
+
+	if event.UserID != uuid.Nil {
+		userID = event.UserID.String()
+	}
+
+	if event.RevokeToken != "" {
+		revokeToken = event.RevokeToken
+	}
+
+	_, err := repo.db.ExecContext(
+		ctx, query, event.ID.String(), userID, event.Email, event.Success,
+		event.Method, event.IP, event.UserAgent, event.RefreshToken,
+		revokeToken, event.CreatedAt,
+	)
+
+	return err
+}
+
+func (repo *loginEventsRepository) FindByUser(ctx context.Context, userID uuid.UUID, limit int) ([]domain.LoginEvent, error) {
+	query := "SELECT * FROM login_events WHERE user_id = UUID_TO_BIN(?) ORDER BY created_at DESC LIMIT ?"
+	rows, err := repo.db.QueryContext(ctx, query, userID.String(), limit)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []domain.LoginEvent{}
+
+	for rows.Next() {
+		event, err := scanLoginEvent(rows)
+
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+
+	return events, rows.Err()
+}
+
+func (repo *loginEventsRepository) FindByRevokeToken(ctx context.Context, token string) (*domain.LoginEvent, error) {
+	query := "SELECT * FROM login_events WHERE revoke_token = ?"
+	event, err := scanLoginEvent(repo.db.QueryRowContext(ctx, query, token))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrLoginEventNotFound
+		}
+
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (repo *loginEventsRepository) FindSuccessfulSources(
+	ctx context.Context, userID uuid.UUID, ip, userAgent string,
+) (domain.LoginSources, error) {
+	query := `
+		SELECT
+			COUNT(*) > 0,
+			COALESCE(SUM(ip = ?), 0) > 0,
+			COALESCE(SUM(user_agent = ?), 0) > 0
+		FROM login_events
+		WHERE user_id = UUID_TO_BIN(?) AND success = true
+	`
+	sources := domain.LoginSources{}
+
+	err := repo.db.QueryRowContext(ctx, query, ip, userAgent, userID.String()).Scan(
+		&sources.HasSucceeded, &sources.KnownIP, &sources.KnownUserAgent,
+	)
+
+	return sources, err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLoginEvent(row rowScanner) (*domain.LoginEvent, error) {
+	event := &domain.LoginEvent{}
+	var revokeToken sql.NullString
+
+	err := row.Scan(
+		&event.ID, &event.UserID, &event.Email, &event.Success,
+		&event.Method, &event.IP, &event.UserAgent,
+		&event.RefreshToken, &revokeToken, &event.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	event.RevokeToken = revokeToken.String
+
+	return event, nil
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type oidcIdentitiesRepository struct {
+	db *sql.DB
+}
+
+func NewOidcIdentitiesRepository(db *sql.DB) *oidcIdentitiesRepository {
+	return &oidcIdentitiesRepository{
+		db: db,
+	}
+}
+
+func (repo *oidcIdentitiesRepository) Find(ctx context.Context, provider, subject string) (*domain.OidcIdentity, error) {
+	query := "SELECT * FROM oidc_identities WHERE provider = ? AND subject = ?"
+	identity := &domain.OidcIdentity{}
+
+	err := repo.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&identity.Provider, &identity.Subject,
+		&identity.UserID, &identity.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrOidcIdentityNotFound
+		}
+
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (repo *oidcIdentitiesRepository) Store(ctx context.Context, identity *domain.OidcIdentity) error {
+	query := `
+		INSERT INTO oidc_identities (provider, subject, user_id, created_at)
+		VALUES (?, ?, UUID_TO_BIN(?), ?)
+	`
+
+	_, err := repo.db.ExecContext(
+		ctx, query, identity.Provider, identity.Subject,
+		identity.UserID.String(), identity.CreatedAt,
+	)
+
+	return err
+}
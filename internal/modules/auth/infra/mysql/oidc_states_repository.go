@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type oidcStatesRepository struct {
+	db *sql.DB
+}
+
+func NewOidcStatesRepository(db *sql.DB) *oidcStatesRepository {
+	return &oidcStatesRepository{
+		db: db,
+	}
+}
+
+func (repo *oidcStatesRepository) Find(ctx context.Context, value string) (*domain.OidcState, error) {
+	query := `
+		SELECT value, nonce, code_verifier, provider, expired_at, created_at
+		FROM oidc_states WHERE value = ?
+	`
+	state := &domain.OidcState{}
+
+	err := repo.db.QueryRowContext(ctx, query, value).Scan(
+		&state.Value, &state.Nonce, &state.CodeVerifier, &state.Provider,
+		&state.ExpiredAt, &state.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrOidcStateNotFound
+		}
+
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (repo *oidcStatesRepository) Store(ctx context.Context, state *domain.OidcState) error {
+	query := `
+		INSERT INTO oidc_states (value, nonce, code_verifier, provider, expired_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := repo.db.ExecContext(
+		ctx, query, state.Value, state.Nonce, state.CodeVerifier, state.Provider,
+		state.ExpiredAt, state.CreatedAt,
+	)
+
+	return err
+}
+
+func (repo *oidcStatesRepository) Delete(ctx context.Context, value string) error {
+	query := "DELETE FROM oidc_states WHERE value = ?"
+	_, err := repo.db.ExecContext(ctx, query, value)
+
+	return err
+}
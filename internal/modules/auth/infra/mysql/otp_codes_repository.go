@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/database"
 	"context"
 	"database/sql"
 	"errors"
@@ -22,9 +23,9 @@ func (repo *otpCodesRepository) findQuery(ctx context.Context, column, value str
 	query := fmt.Sprintf("SELECT * FROM otp_codes WHERE %s = ?", column)
 	otpCode := &domain.OtpCode{}
 
-	err := repo.db.QueryRowContext(ctx, query, value).Scan(
+	err := database.Executor(ctx, repo.db).QueryRowContext(ctx, query, value).Scan(
 		&otpCode.Type, &otpCode.UserEmail,
-		&otpCode.Value, &otpCode.ExpiredAt, &otpCode.CreatedAt,
+		&otpCode.Value, &otpCode.ExpiredAt, &otpCode.CreatedAt, &otpCode.LinkNonce,
 	)
 
 	if err != nil {
@@ -46,6 +47,10 @@ func (repo *otpCodesRepository) FindByUserEmail(ctx context.Context, userEmail s
 	return repo.findQuery(ctx, "user_email", userEmail)
 }
 
+func (repo *otpCodesRepository) FindByLinkNonce(ctx context.Context, nonce string) (*domain.OtpCode, error) {
+	return repo.findQuery(ctx, "link_nonce", nonce)
+}
+
 func (repo *otpCodesRepository) Exists(ctx context.Context, value string) bool {
 	_, err := repo.Find(ctx, value)
 	return err == nil
@@ -53,13 +58,13 @@ func (repo *otpCodesRepository) Exists(ctx context.Context, value string) bool {
 
 func (repo *otpCodesRepository) Store(ctx context.Context, otpCode *domain.OtpCode) error {
 	query := `
-		INSERT INTO otp_codes (type, user_email, value, expired_at, created_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO otp_codes (type, user_email, value, expired_at, created_at, link_nonce)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := repo.db.ExecContext(
+	_, err := database.Executor(ctx, repo.db).ExecContext(
 		ctx, query, otpCode.Type, otpCode.UserEmail,
-		otpCode.Value, otpCode.ExpiredAt, otpCode.CreatedAt,
+		otpCode.Value, otpCode.ExpiredAt, otpCode.CreatedAt, otpCode.LinkNonce,
 	)
 
 	return err
@@ -78,7 +83,27 @@ func (repo *otpCodesRepository) CreateWithUserEmail(ctx context.Context, otpType
 
 func (repo *otpCodesRepository) Delete(ctx context.Context, otpCode *domain.OtpCode) error {
 	query := "DELETE FROM otp_codes WHERE value = ?"
-	_, err := repo.db.ExecContext(ctx, query, otpCode.Value)
+	_, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, otpCode.Value)
 
 	return err
 }
+
+func (repo *otpCodesRepository) DeleteByLinkNonce(ctx context.Context, nonce string) error {
+	query := "DELETE FROM otp_codes WHERE link_nonce = ?"
+	result, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, nonce)
+
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return domain.ErrOtpNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type phoneVerificationsRepository struct {
+	db *sql.DB
+}
+
+func NewPhoneVerificationsRepository(db *sql.DB) *phoneVerificationsRepository {
+	return &phoneVerificationsRepository{
+		db: db,
+	}
+}
+
+func (repo *phoneVerificationsRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.PhoneVerification, error) {
+	query := "SELECT * FROM phone_verifications WHERE user_id = UUID_TO_BIN(?) FOR UPDATE"
+	verification := &domain.PhoneVerification{}
+
+	err := database.Executor(ctx, repo.db).QueryRowContext(ctx, query, userID.String()).Scan(
+		&verification.UserID, &verification.Phone, &verification.Count,
+		&verification.LastSendAt, &verification.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPhoneVerificationNotFound
+		}
+
+		return nil, err
+	}
+
+	return verification, nil
+}
+
+func (repo *phoneVerificationsRepository) Save(ctx context.Context, verification *domain.PhoneVerification) error {
+	query := `
+		INSERT INTO phone_verifications (user_id, phone, count, last_sent_at, created_at)
+		VALUES (UUID_TO_BIN(?), ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE phone = VALUES(phone), count = VALUES(count),
+			last_sent_at = VALUES(last_sent_at), created_at = VALUES(created_at)
+	`
+
+	_, err := database.Executor(ctx, repo.db).ExecContext(
+		ctx, query, verification.UserID.String(), verification.Phone,
+		verification.Count, verification.LastSendAt, verification.CreatedAt,
+	)
+
+	return err
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/database"
 	"context"
 	"database/sql"
 	"errors"
@@ -30,7 +31,7 @@ func (repo *resendOtpRequestsRepository) findQuery(ctx context.Context, column,
 	query := fmt.Sprintf("SELECT * FROM resend_otp_requests WHERE %s = %s", column, queryVal)
 	req := &domain.ResendOtpRequest{}
 
-	err := repo.db.QueryRowContext(ctx, query, value).Scan(
+	err := database.Executor(ctx, repo.db).QueryRowContext(ctx, query, value).Scan(
 		&req.ID, &req.UserEmail, &req.Count,
 		&req.LastSendAt, &req.CreatedAt,
 	)
@@ -60,7 +61,7 @@ func (repo *resendOtpRequestsRepository) Store(ctx context.Context, req *domain.
 		VALUES (UUID_TO_BIN(?), ?, ?, ?, ?)
 	`
 
-	_, err := repo.db.ExecContext(
+	_, err := database.Executor(ctx, repo.db).ExecContext(
 		ctx, query, req.ID.String(), req.UserEmail,
 		req.Count, req.LastSendAt, req.CreatedAt,
 	)
@@ -70,14 +71,14 @@ func (repo *resendOtpRequestsRepository) Store(ctx context.Context, req *domain.
 
 func (repo *resendOtpRequestsRepository) Delete(ctx context.Context, req *domain.ResendOtpRequest) error {
 	query := "DELETE FROM resend_otp_requests WHERE id = UUID_TO_BIN(?)"
-	_, err := repo.db.ExecContext(ctx, query, req.ID.String())
+	_, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, req.ID.String())
 
 	return err
 }
 
 func (repo *resendOtpRequestsRepository) Update(ctx context.Context, req *domain.ResendOtpRequest) error {
 	query := "UPDATE resend_otp_requests SET count = ?, last_sent_at = ? WHERE id = UUID_TO_BIN(?)"
-	_, err := repo.db.ExecContext(ctx, query, req.Count, req.LastSendAt, req.ID.String())
+	_, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, req.Count, req.LastSendAt, req.ID.String())
 
 	return err
 }
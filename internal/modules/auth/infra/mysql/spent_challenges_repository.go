@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type spentChallengesRepository struct {
+	db *sql.DB
+}
+
+func NewSpentChallengesRepository(db *sql.DB) *spentChallengesRepository {
+	return &spentChallengesRepository{
+		db: db,
+	}
+}
+
+func (repo *spentChallengesRepository) Store(ctx context.Context, id string, expiredAt time.Time) error {
+	if _, err := repo.db.ExecContext(
+		ctx, "DELETE FROM spent_challenges WHERE expired_at < ?", time.Now(),
+	); err != nil {
+		return err
+	}
+
+	query := "INSERT IGNORE INTO spent_challenges (id, expired_at) VALUES (?, ?)"
+	result, err := repo.db.ExecContext(ctx, query, id, expiredAt)
+
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrChallengeAlreadyUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/logger"
+	"comu/internal/shared/signer"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mazen160/go-random"
+)
+
+type challengeService struct {
+	signer     *signer.Signer
+	enabled    bool
+	difficulty int
+	ttl        time.Duration
+	repository domain.SpentChallengesRepository
+	logger     *logger.Log
+}
+
+// NewChallengeService returns a service issuing stateless challenges. Their parameters are
+// signed with the given key, so only spent challenges need to be stored.
+func NewChallengeService(
+	key string, enabled bool, difficulty int, ttl time.Duration,
+	repository domain.SpentChallengesRepository, logger *logger.Log,
+) *challengeService {
+	if difficulty <= 0 {
+		difficulty = domain.DefaultChallengeDifficulty
+	}
+
+	return &challengeService{
+		signer:     signer.NewSigner(key, "challenge"),
+		enabled:    enabled,
+		difficulty: difficulty,
+		ttl:        ttl,
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (service *challengeService) Enabled() bool {
+	return service.enabled
+}
+
+// Issue returns a new challenge whose token is the signed "<id>.<difficulty>.<expiration>" payload.
+func (service *challengeService) Issue() (*domain.Challenge, error) {
+	id, err := random.String(16)
+
+	if err != nil {
+		service.logger.Error.Println(err)
+		return nil, domain.ErrInternal
+	}
+	expiredAt := time.Now().Add(service.ttl)
+	payload := fmt.Sprintf("%s.%d.%d", id, service.difficulty, expiredAt.Unix())
+
+	return &domain.Challenge{
+		Token:      service.signer.Sign([]byte(payload)),
+		Difficulty: service.difficulty,
+		ExpiredAt:  expiredAt,
+	}, nil
+}
+
+func (service *challengeService) Verify(ctx context.Context, solution domain.ChallengeSolution) error {
+	if !service.enabled {
+		return nil
+	}
+
+	if solution.Token == "" || solution.Nonce == "" {
+		return domain.ErrChallengeRequired
+	}
+	id, difficulty, expiredAt, err := service.parse(solution.Token)
+
+	if err != nil {
+		return err
+	}
+
+	if time.Now().After(expiredAt) {
+		return domain.ErrExpiredChallenge
+	}
+	hash := sha256.Sum256([]byte(solution.Token + ":" + solution.Nonce))
+
+	if leadingZeroBits(hash[:]) < difficulty {
+		return domain.ErrInvalidChallenge
+	}
+
+	if err := service.repository.Store(ctx, id, expiredAt); err != nil {
+		if errors.Is(err, domain.ErrChallengeAlreadyUsed) {
+			return err
+		}
+
+		service.logger.Error.Println(err)
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+func (service *challengeService) parse(token string) (id string, difficulty int, expiredAt time.Time, err error) {
+	payload, err := service.signer.Verify(token)
+
+	if err != nil {
+		return "", 0, time.Time{}, domain.ErrInvalidChallenge
+	}
+	parts := strings.Split(string(payload), ".")
+
+	if len(parts) != 3 {
+		return "", 0, time.Time{}, domain.ErrInvalidChallenge
+	}
+	difficulty, err = strconv.Atoi(parts[1])
+
+	if err != nil {
+		return "", 0, time.Time{}, domain.ErrInvalidChallenge
+	}
+	expiration, err := strconv.ParseInt(parts[2], 10, 64)
+
+	if err != nil {
+		return "", 0, time.Time{}, domain.ErrInvalidChallenge
+	}
+
+	return parts[0], difficulty, time.Unix(expiration, 0), nil
+}
+
+func leadingZeroBits(hash []byte) int {
+	count := 0
+
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+
+	return count
+}
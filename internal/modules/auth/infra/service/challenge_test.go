@@ -0,0 +1,114 @@
+package service
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"comu/internal/shared/logger"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// solveChallenge finds a nonce the way clients are expected to.
+func solveChallenge(challenge *domain.Challenge) string {
+	for nonce := 0; ; nonce++ {
+		hash := sha256.Sum256([]byte(challenge.Token + ":" + strconv.Itoa(nonce)))
+
+		if leadingZeroBits(hash[:]) >= challenge.Difficulty {
+			return strconv.Itoa(nonce)
+		}
+	}
+}
+
+func newTestChallengeService(enabled bool) *challengeService {
+	return NewChallengeService(
+		"dUHDhX3G0MJJ0v5ROoCcHpmhq8WIbIf4", enabled, 8, time.Minute,
+		memory.NewInMemorySpentChallengesRepository(nil), logger.NewSpyLogger(),
+	)
+}
+
+func TestChallengeServiceVerify(t *testing.T) {
+
+	t.Run("it should accept a solved challenge once", func(t *testing.T) {
+		service := newTestChallengeService(true)
+		ctx := context.Background()
+
+		challenge, err := service.Issue()
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			solution := domain.ChallengeSolution{Token: challenge.Token, Nonce: solveChallenge(challenge)}
+
+			_assert.NoError(service.Verify(ctx, solution))
+			_assert.ErrorIs(service.Verify(ctx, solution), domain.ErrChallengeAlreadyUsed)
+		}
+	})
+
+	t.Run("it should fail and return ErrChallengeRequired when no solution was provided", func(t *testing.T) {
+		service := newTestChallengeService(true)
+
+		err := service.Verify(context.Background(), domain.ChallengeSolution{})
+		assert.ErrorIs(t, err, domain.ErrChallengeRequired)
+	})
+
+	t.Run("it should fail and return ErrInvalidChallenge when the nonce doesn't solve the challenge", func(t *testing.T) {
+		service := newTestChallengeService(true)
+		challenge, _ := service.Issue()
+		nonce := 0
+
+		for {
+			hash := sha256.Sum256([]byte(challenge.Token + ":" + strconv.Itoa(nonce)))
+
+			if leadingZeroBits(hash[:]) < challenge.Difficulty {
+				break
+			}
+			nonce++
+		}
+
+		err := service.Verify(context.Background(), domain.ChallengeSolution{
+			Token: challenge.Token, Nonce: strconv.Itoa(nonce),
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidChallenge)
+	})
+
+	t.Run("it should fail and return ErrInvalidChallenge when the difficulty was tampered with", func(t *testing.T) {
+		service := newTestChallengeService(true)
+		challenge, _ := service.Issue()
+		id, _, expiredAt, _ := service.parse(challenge.Token)
+
+		_, signature, _ := strings.Cut(challenge.Token, ".")
+		payload := id + ".0." + strconv.FormatInt(expiredAt.Unix(), 10)
+
+		tampered := &domain.Challenge{Difficulty: 0}
+		tampered.Token = base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + signature
+
+		err := service.Verify(context.Background(), domain.ChallengeSolution{
+			Token: tampered.Token, Nonce: solveChallenge(tampered),
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidChallenge)
+	})
+
+	t.Run("it should fail and return ErrExpiredChallenge when the challenge has expired", func(t *testing.T) {
+		service := newTestChallengeService(true)
+		service.ttl = -time.Minute
+		challenge, _ := service.Issue()
+
+		err := service.Verify(context.Background(), domain.ChallengeSolution{
+			Token: challenge.Token, Nonce: solveChallenge(challenge),
+		})
+		assert.ErrorIs(t, err, domain.ErrExpiredChallenge)
+	})
+
+	t.Run("it should always succeed when challenges are disabled", func(t *testing.T) {
+		service := newTestChallengeService(false)
+
+		err := service.Verify(context.Background(), domain.ChallengeSolution{})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"bufio"
+	"comu/internal/modules/auth/domain"
+	_ "embed"
+	"strings"
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainsList string
+
+type disposableEmailChecker struct {
+	domains map[string]struct{}
+}
+
+// NewDisposableEmailChecker returns a checker backed by the bundled list of
+// disposable email providers.
+func NewDisposableEmailChecker() *disposableEmailChecker {
+	domains := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(disposableDomainsList))
+
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains[line] = struct{}{}
+	}
+
+	return &disposableEmailChecker{
+		domains: domains,
+	}
+}
+
+func (checker *disposableEmailChecker) IsDisposable(email string) bool {
+	emailDomain := domain.EmailDomain(email)
+
+	for emailDomain != "" {
+		if _, ok := checker.domains[emailDomain]; ok {
+			return true
+		}
+		_, emailDomain, _ = strings.Cut(emailDomain, ".")
+	}
+
+	return false
+}
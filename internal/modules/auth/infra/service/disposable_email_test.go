@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisposableEmailChecker(t *testing.T) {
+	checker := NewDisposableEmailChecker()
+
+	t.Run("it should detect disposable email addresses", func(t *testing.T) {
+		assert.True(t, checker.IsDisposable("johndoe@mailinator.com"))
+		assert.True(t, checker.IsDisposable("johndoe@YOPMAIL.com"))
+		assert.True(t, checker.IsDisposable("johndoe@inbox.guerrillamail.com"))
+	})
+
+	t.Run("it should accept regular email addresses", func(t *testing.T) {
+		assert.False(t, checker.IsDisposable("johndoe@gmail.com"))
+		assert.False(t, checker.IsDisposable("johndoe@notmailinator.com"))
+	})
+}
@@ -2,109 +2,86 @@ package service
 
 import (
 	"comu/internal/modules/auth/domain"
-	"fmt"
-
-	"github.com/wneessen/go-mail"
+	"comu/internal/modules/notifications"
+	"context"
+	"strings"
+	"time"
 )
 
-type SmtpNotificationAuth struct {
-	Username string
-	Password string
-}
-
-type smtpNotificationService struct {
-	client *mail.Client
-	from   string
+type notificationService struct {
+	api               notifications.PublicApi
+	userService       domain.UserService
+	verificationLinks domain.VerificationLinks
+	appURL            string
 }
 
-func NewSmtpNotificationService(host string, port int, mailFrom string, auth SmtpNotificationAuth, enableTLS bool) (*smtpNotificationService, error) {
-	mailOptions := []mail.Option{
-		mail.WithPort(port),
-		mail.WithUsername(auth.Username),
-		mail.WithPassword(auth.Password),
+func NewNotificationService(
+	api notifications.PublicApi, userService domain.UserService,
+	verificationLinks domain.VerificationLinks, appURL string,
+) *notificationService {
+	return &notificationService{
+		api:               api,
+		userService:       userService,
+		verificationLinks: verificationLinks,
+		appURL:            strings.TrimSuffix(appURL, "/"),
 	}
-
-	// The default TLSPolicy is TLSMandatory
-	if !enableTLS {
-		mailOptions = append(mailOptions, mail.WithTLSPolicy(mail.NoTLS))
-	}
-
-	client, err := mail.NewClient(host, mailOptions...)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &smtpNotificationService{
-		client: client,
-		from:   mailFrom,
-	}, nil
 }
 
-func (service *smtpNotificationService) SendOtpCodeMessage(code *domain.OtpCode) error {
-	msg, err := service.newMessage(code.UserEmail)
-
-	if err != nil {
-		return err
-	}
-
-	msg.Subject(service.getOtpCodeSubject(code.Type))
-	msg.SetBodyString(
-		mail.TypeTextPlain,
-		fmt.Sprintf(`
-			Your verification code is: %s
-
-			This code is valid for %d minutes.
-			If you did not request this code, please ignore this message.
-
-		`, code.Value, int(domain.DefaultOtpCodeTTL.Minutes())),
-	)
-
-	return service.client.DialAndSend(msg)
+// SendOtpCodeMessage emails the otp code, along with its verification
+// link for the types having one.
+func (service *notificationService) SendOtpCodeMessage(ctx context.Context, code *domain.OtpCode) error {
+	return service.send(ctx, code.UserEmail, service.getOtpCodeTemplate(code.Type), map[string]any{
+		"Code":    code.Value,
+		"Minutes": int(domain.DefaultOtpCodeTTL.Minutes()),
+		"Link":    service.verificationLinks.URL(code),
+	})
 }
 
-func (service *smtpNotificationService) SendPasswordChangedMessage(userEmail string) error {
-	msg, err := service.newMessage(userEmail)
-
-	if err != nil {
-		return err
-	}
-
-	msg.Subject("Your password has been changed")
-	msg.SetBodyString(
-		mail.TypeTextPlain,
-		`
-		Your password has been successfully changed.
-
-		If you did not make this change, please contact support immediately.
-		`,
-	)
+func (service *notificationService) SendPasswordChangedMessage(ctx context.Context, userEmail string) error {
+	return service.send(ctx, userEmail, "password_changed", nil)
+}
 
-	return service.client.DialAndSend(msg)
+func (service *notificationService) SendNewSignInMessage(ctx context.Context, userEmail string, event *domain.LoginEvent) error {
+	return service.send(ctx, userEmail, "new_sign_in", map[string]any{
+		"Date":      event.CreatedAt.UTC().Format(time.RFC1123),
+		"IP":        event.IP,
+		"UserAgent": event.UserAgent,
+		"RevokeURL": service.appURL + "/sessions/revoke/" + event.RevokeToken,
+	})
 }
 
-func (service *smtpNotificationService) newMessage(receiverEmail string) (*mail.Msg, error) {
-	msg := mail.NewMsg()
+// send asks the notifications module to email the receiver in their locale. The
+// authentication emails are security ones, which the users can't turn off.
+func (service *notificationService) send(ctx context.Context, receiverEmail, templateName string, data map[string]any) error {
+	return service.api.SendEmail(ctx, notifications.SendEmailRequest{
+		To:       receiverEmail,
+		Category: notifications.SecurityCategory,
+		Template: templateName,
+		Locale:   service.getUserLocale(ctx, receiverEmail),
+		Data:     data,
+	})
+}
 
-	if err := msg.From(service.from); err != nil {
-		return nil, err
-	}
+// getUserLocale returns the locale of the user owning the email address. The
+// default locale is used when the user can't be found.
+func (service *notificationService) getUserLocale(ctx context.Context, email string) string {
+	user, err := service.userService.GetUserByEmail(ctx, email)
 
-	if err := msg.To(receiverEmail); err != nil {
-		return nil, err
+	if err != nil {
+		return ""
 	}
 
-	return msg, nil
+	return user.Locale
 }
 
-func (service *smtpNotificationService) getOtpCodeSubject(t domain.OtpType) string {
+func (service *notificationService) getOtpCodeTemplate(t domain.OtpType) string {
 	if t == domain.LoginOTP {
-		return "Login verification"
+		return "login_otp"
 	}
 
 	if t == domain.RegisterOTP {
-		return "Confirm your registration"
+		return "register_otp"
 	}
 
-	return "Reset password confirmation"
+	return "reset_password_otp"
 }
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/logger"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var defaultOidcScopes = []string{"openid", "email", "profile"}
+
+type OidcProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type oidcDiscoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type oidcJsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type oidcTokenResponse struct {
+	IdToken string `json:"id_token"`
+}
+
+type oidcService struct {
+	providers map[string]OidcProvider
+	client    *http.Client
+	logger    *logger.Log
+
+	mu        sync.Mutex
+	documents map[string]*oidcDiscoveryDocument
+	keys      map[string]map[string]*rsa.PublicKey
+}
+
+func NewOidcService(providers []OidcProvider, client *http.Client, logger *logger.Log) *oidcService {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	providersMap := make(map[string]OidcProvider)
+
+	for _, provider := range providers {
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = defaultOidcScopes
+		}
+		provider.Issuer = strings.TrimSuffix(provider.Issuer, "/")
+		providersMap[provider.Name] = provider
+	}
+
+	return &oidcService{
+		providers: providersMap,
+		client:    client,
+		logger:    logger,
+		documents: make(map[string]*oidcDiscoveryDocument),
+		keys:      make(map[string]map[string]*rsa.PublicKey),
+	}
+}
+
+func (service *oidcService) AuthCodeURL(ctx context.Context, providerName, state, nonce, codeChallenge string) (string, error) {
+	provider, ok := service.providers[providerName]
+
+	if !ok {
+		return "", domain.ErrOidcProviderNotFound
+	}
+	document, err := service.discover(ctx, provider)
+
+	if err != nil {
+		service.logger.Error.Println(err)
+		return "", domain.ErrInternal
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {provider.ClientID},
+		"redirect_uri":          {provider.RedirectURL},
+		"scope":                 {strings.Join(provider.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+
+	if strings.Contains(document.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return document.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+func (service *oidcService) Exchange(ctx context.Context, providerName, code, nonce, codeVerifier string) (*domain.OidcClaims, error) {
+	provider, ok := service.providers[providerName]
+
+	if !ok {
+		return nil, domain.ErrOidcProviderNotFound
+	}
+	document, err := service.discover(ctx, provider)
+
+	if err != nil {
+		service.logger.Error.Println(err)
+		return nil, domain.ErrInternal
+	}
+	idToken, err := service.requestIdToken(ctx, provider, document, code, codeVerifier)
+
+	if err != nil {
+		service.logger.Error.Println(err)
+		return nil, domain.ErrOidcExchangeFailed
+	}
+
+	return service.verifyIdToken(ctx, provider, document, idToken, nonce)
+}
+
+func (service *oidcService) discover(ctx context.Context, provider OidcProvider) (*oidcDiscoveryDocument, error) {
+	service.mu.Lock()
+	document, ok := service.documents[provider.Name]
+	service.mu.Unlock()
+
+	if ok {
+		return document, nil
+	}
+	document = &oidcDiscoveryDocument{}
+	err := service.getJson(ctx, provider.Issuer+"/.well-known/openid-configuration", document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSuffix(document.Issuer, "/") != provider.Issuer {
+		return nil, fmt.Errorf("oidc %s: discovery issuer %q does not match %q", provider.Name, document.Issuer, provider.Issuer)
+	}
+
+	service.mu.Lock()
+	service.documents[provider.Name] = document
+	service.mu.Unlock()
+
+	return document, nil
+}
+
+func (service *oidcService) requestIdToken(
+	ctx context.Context, provider OidcProvider,
+	document *oidcDiscoveryDocument, code, codeVerifier string,
+) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {provider.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, document.TokenEndpoint, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(provider.ClientID), url.QueryEscape(provider.ClientSecret))
+
+	res, err := service.client.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oidc %s: token endpoint responded with status %d", provider.Name, res.StatusCode)
+	}
+	var tokenResponse oidcTokenResponse
+
+	if err := json.NewDecoder(res.Body).Decode(&tokenResponse); err != nil {
+		return "", err
+	}
+
+	if tokenResponse.IdToken == "" {
+		return "", fmt.Errorf("oidc %s: token response has no id_token", provider.Name)
+	}
+
+	return tokenResponse.IdToken, nil
+}
+
+func (service *oidcService) verifyIdToken(
+	ctx context.Context, provider OidcProvider,
+	document *oidcDiscoveryDocument, idToken, nonce string,
+) (*domain.OidcClaims, error) {
+	token, err := jwt.Parse(
+		idToken,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return service.getSigningKey(ctx, provider, document, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}),
+		jwt.WithIssuer(document.Issuer),
+		jwt.WithAudience(provider.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+
+	if err != nil {
+		service.logger.Error.Println(err)
+		return nil, domain.ErrInvalidToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+
+	if !ok || !token.Valid {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, domain.ErrInvalidToken
+	}
+	subject, err := claims.GetSubject()
+
+	if err != nil || subject == "" {
+		return nil, domain.ErrInvalidToken
+	}
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+
+	return &domain.OidcClaims{
+		Provider:      provider.Name,
+		Subject:       subject,
+		Email:         strings.ToLower(email),
+		EmailVerified: isOidcEmailVerified(claims["email_verified"]),
+		Name:          name,
+	}, nil
+}
+
+// getSigningKey looks up the key used to sign an ID token. The provider key set is
+// fetched again when the key id is unknown, which handles provider key rotations.
+func (service *oidcService) getSigningKey(
+	ctx context.Context, provider OidcProvider,
+	document *oidcDiscoveryDocument, kid string,
+) (*rsa.PublicKey, error) {
+	service.mu.Lock()
+	key, ok := service.keys[provider.Name][kid]
+	service.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	keys, err := service.fetchKeys(ctx, document.JwksURI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.mu.Lock()
+	service.keys[provider.Name] = keys
+	service.mu.Unlock()
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("oidc %s: no signing key found for kid %q", provider.Name, kid)
+}
+
+func (service *oidcService) fetchKeys(ctx context.Context, jwksURI string) (map[string]*rsa.PublicKey, error) {
+	var keySet struct {
+		Keys []oidcJsonWebKey `json:"keys"`
+	}
+
+	if err := service.getJson(ctx, jwksURI, &keySet); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+
+	for _, jwk := range keySet.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := parseRsaJsonWebKey(jwk)
+
+		if err != nil {
+			return nil, err
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (service *oidcService) getJson(ctx context.Context, endpoint string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := service.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s responded with status %d", endpoint, res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(target)
+}
+
+func parseRsaJsonWebKey(jwk oidcJsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// Some providers send email_verified as a string instead of a boolean.
+func isOidcEmailVerified(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
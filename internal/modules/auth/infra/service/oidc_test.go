@@ -0,0 +1,206 @@
+package service
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/logger"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockOidcServer is a minimal OpenID Connect provider which issues
+// ID tokens with the claims given to it for the next code exchange.
+// It only accepts the "valid-code" code along with the "the-verifier" code verifier.
+type mockOidcServer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+}
+
+func newMockOidcServer(t *testing.T) *mockOidcServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &mockOidcServer{key: key}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"jwks_uri":               server.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "test-key",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+
+		if !ok || clientID != "comu" || clientSecret != "secret" || r.FormValue("code") != "valid-code" ||
+			r.FormValue("code_verifier") != "the-verifier" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, server.claims)
+		token.Header["kid"] = "test-key"
+		idToken, _ := token.SignedString(key)
+
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (server *mockOidcServer) validClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            server.URL,
+		"sub":            "248289761001",
+		"aud":            "comu",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          "JohnDoe@gmail.com",
+		"email_verified": true,
+		"name":           "John Doe",
+	}
+}
+
+func newTestOidcService(server *mockOidcServer) *oidcService {
+	return NewOidcService([]OidcProvider{{
+		Name:         "mock",
+		Issuer:       server.URL,
+		ClientID:     "comu",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:4000/oidc/mock/callback",
+	}}, server.Client(), logger.NewSpyLogger())
+}
+
+func TestOidcServiceAuthCodeURL(t *testing.T) {
+
+	t.Run("it should build the provider authorization url", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		service := newTestOidcService(server)
+
+		authURL, err := service.AuthCodeURL(context.Background(), "mock", "the-state", "the-nonce", "the-challenge")
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			parsed, _ := url.Parse(authURL)
+			_assert.Equal(server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+			_assert.Equal("code", parsed.Query().Get("response_type"))
+			_assert.Equal("comu", parsed.Query().Get("client_id"))
+			_assert.Equal("the-state", parsed.Query().Get("state"))
+			_assert.Equal("the-nonce", parsed.Query().Get("nonce"))
+			_assert.Equal("the-challenge", parsed.Query().Get("code_challenge"))
+			_assert.Equal("S256", parsed.Query().Get("code_challenge_method"))
+			_assert.Equal("openid email profile", parsed.Query().Get("scope"))
+		}
+	})
+
+	t.Run("it should fail and return ErrOidcProviderNotFound", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		service := newTestOidcService(server)
+
+		_, err := service.AuthCodeURL(context.Background(), "unknown", "the-state", "the-nonce", "the-challenge")
+		assert.ErrorIs(t, err, domain.ErrOidcProviderNotFound)
+	})
+}
+
+func TestOidcServiceExchange(t *testing.T) {
+
+	t.Run("it should exchange the code and return the verified claims", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		server.claims = server.validClaims("the-nonce")
+		service := newTestOidcService(server)
+
+		claims, err := service.Exchange(context.Background(), "mock", "valid-code", "the-nonce", "the-verifier")
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal("mock", claims.Provider)
+			_assert.Equal("248289761001", claims.Subject)
+			_assert.Equal("johndoe@gmail.com", claims.Email)
+			_assert.True(claims.EmailVerified)
+			_assert.Equal("John Doe", claims.Name)
+		}
+	})
+
+	t.Run("it should fail and return ErrOidcExchangeFailed when the code is rejected", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		server.claims = server.validClaims("the-nonce")
+		service := newTestOidcService(server)
+
+		_, err := service.Exchange(context.Background(), "mock", "invalid-code", "the-nonce", "the-verifier")
+		assert.ErrorIs(t, err, domain.ErrOidcExchangeFailed)
+	})
+
+	t.Run("it should fail and return ErrOidcExchangeFailed when the code verifier is rejected", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		server.claims = server.validClaims("the-nonce")
+		service := newTestOidcService(server)
+
+		_, err := service.Exchange(context.Background(), "mock", "valid-code", "the-nonce", "another-verifier")
+		assert.ErrorIs(t, err, domain.ErrOidcExchangeFailed)
+	})
+
+	t.Run("it should fail and return ErrInvalidToken when the nonce doesn't match", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		server.claims = server.validClaims("another-nonce")
+		service := newTestOidcService(server)
+
+		_, err := service.Exchange(context.Background(), "mock", "valid-code", "the-nonce", "the-verifier")
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("it should fail and return ErrInvalidToken when the token targets another client", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		server.claims = server.validClaims("the-nonce")
+		server.claims["aud"] = "another-client"
+		service := newTestOidcService(server)
+
+		_, err := service.Exchange(context.Background(), "mock", "valid-code", "the-nonce", "the-verifier")
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("it should fail and return ErrInvalidToken when the token has expired", func(t *testing.T) {
+		server := newMockOidcServer(t)
+		server.claims = server.validClaims("the-nonce")
+		server.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		service := newTestOidcService(server)
+
+		_, err := service.Exchange(context.Background(), "mock", "valid-code", "the-nonce", "the-verifier")
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
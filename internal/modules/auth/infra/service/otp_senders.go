@@ -0,0 +1,74 @@
+package service
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/sms"
+	"context"
+	"fmt"
+	"strings"
+)
+
+type emailOtpSender struct {
+	notificationService domain.NotificationService
+}
+
+// NewEmailOtpSender returns the default otp sender, emailing the codes through
+// the notification service. Every user can receive codes by email.
+func NewEmailOtpSender(notificationService domain.NotificationService) *emailOtpSender {
+	return &emailOtpSender{
+		notificationService: notificationService,
+	}
+}
+
+func (sender *emailOtpSender) Channel() domain.OtpChannel {
+	return domain.EmailOtpChannel
+}
+
+func (sender *emailOtpSender) CanSendTo(user *domain.AuthUser) bool {
+	return true
+}
+
+func (sender *emailOtpSender) SendOtpCode(ctx context.Context, user *domain.AuthUser, code *domain.OtpCode) error {
+	return sender.notificationService.SendOtpCodeMessage(ctx, code)
+}
+
+type smsOtpSender struct {
+	sender  sms.Sender
+	appName string
+}
+
+// NewSmsOtpSender returns the otp sender texting the codes to the users phone number.
+// Only the users who verified their phone number can receive codes by SMS.
+func NewSmsOtpSender(sender sms.Sender, appName string) *smsOtpSender {
+	return &smsOtpSender{
+		sender:  sender,
+		appName: appName,
+	}
+}
+
+func (sender *smsOtpSender) Channel() domain.OtpChannel {
+	return domain.SmsOtpChannel
+}
+
+func (sender *smsOtpSender) CanSendTo(user *domain.AuthUser) bool {
+	return user.PhoneIsVerified()
+}
+
+// SendOtpCode texts the code to the user phone number, verified or not, so that
+// it can be used to verify a pending number.
+func (sender *smsOtpSender) SendOtpCode(ctx context.Context, user *domain.AuthUser, code *domain.OtpCode) error {
+	return sender.sender.Send(ctx, &sms.Message{
+		To:   user.Phone,
+		Body: sender.getMessageBody(user.Locale, code.Value),
+	})
+}
+
+func (sender *smsOtpSender) getMessageBody(locale, code string) string {
+	minutes := int(domain.DefaultOtpCodeTTL.Minutes())
+
+	if strings.HasPrefix(strings.ToLower(locale), "fr") {
+		return fmt.Sprintf("Votre code de vérification %s est %s. Il expire dans %d minutes.", sender.appName, code, minutes)
+	}
+
+	return fmt.Sprintf("Your %s verification code is %s. It expires in %d minutes.", sender.appName, code, minutes)
+}
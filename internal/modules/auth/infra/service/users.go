@@ -48,13 +48,26 @@ func (service *userService) GetUserByEmail(ctx context.Context, email string) (*
 	})
 }
 
-func (service *userService) CreateNewUser(ctx context.Context, name, email, password string) (uuid.UUID, error) {
-
-	response, err := service.api.CreateUser(ctx, users.CreateUserRequest{
+func (service *userService) CreateNewUser(ctx context.Context, name, email, password, locale string) (uuid.UUID, error) {
+	return service.createUser(ctx, users.CreateUserRequest{
 		Name:     name,
 		Email:    email,
 		Password: password,
+		Locale:   locale,
 	})
+}
+
+func (service *userService) CreateNewVerifiedUser(ctx context.Context, name, email, password string) (uuid.UUID, error) {
+	return service.createUser(ctx, users.CreateUserRequest{
+		Name:          name,
+		Email:         email,
+		Password:      password,
+		EmailVerified: true,
+	})
+}
+
+func (service *userService) createUser(ctx context.Context, req users.CreateUserRequest) (uuid.UUID, error) {
+	response, err := service.api.CreateUser(ctx, req)
 
 	if err != nil {
 		if !errors.Is(err, users.ErrUserEmailTaken) {
@@ -103,6 +116,19 @@ func (service *userService) UpdateUserPassword(ctx context.Context, userID uuid.
 	return nil
 }
 
+func (service *userService) SetUserVerifiedPhone(ctx context.Context, userID uuid.UUID, phone string) error {
+	if err := service.api.SetVerifiedPhone(ctx, userID, phone); err != nil {
+		if !errors.Is(err, users.ErrUserNotFound) {
+			service.logger.Error.Println(err)
+			return domain.ErrInternal
+		}
+
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (service *userService) newAuthUserFromGetUserResponse(response *users.GetUserResponse) *domain.AuthUser {
 	return &domain.AuthUser{
 		ID:              response.ID,
@@ -112,6 +138,9 @@ func (service *userService) newAuthUserFromGetUserResponse(response *users.GetUs
 		Avatar:          response.Avatar,
 		Active:          response.Active,
 		Password:        response.Password,
+		Locale:          response.Locale,
+		Phone:           response.Phone,
+		PhoneVerifiedAt: response.PhoneVerifiedAt,
 		CreatedAt:       response.CreatedAt,
 		DeletedAt:       response.DeletedAt,
 	}
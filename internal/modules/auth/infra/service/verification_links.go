@@ -0,0 +1,60 @@
+package service
+
+import (
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/signer"
+	"net/url"
+	"strings"
+)
+
+const linkNonceLength = 32
+
+var verificationLinkPaths = map[domain.OtpType]string{
+	domain.RegisterOTP:      "/register/verify_link",
+	domain.ResetPasswordOTP: "/reset_password/verify_link",
+}
+
+type verificationLinks struct {
+	signer *signer.Signer
+	appURL string
+}
+
+func NewVerificationLinks(appKey, appURL string) *verificationLinks {
+	return &verificationLinks{
+		signer: signer.NewSigner(appKey, "verification_link"),
+		appURL: strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+// URL returns the verification link, whose token is the otp type, the link nonce and
+// the user email followed by their signature. The link expires with the code.
+func (links *verificationLinks) URL(code *domain.OtpCode) string {
+	path, ok := verificationLinkPaths[code.Type]
+
+	if !ok || len(code.LinkNonce) != linkNonceLength {
+		return ""
+	}
+	payload := append([]byte{byte(code.Type)}, code.LinkNonce...)
+	payload = append(payload, code.UserEmail...)
+
+	return links.appURL + path + "?token=" + url.QueryEscape(links.signer.Sign(payload))
+}
+
+func (links *verificationLinks) Verify(token string) (*domain.VerificationLink, error) {
+	payload, err := links.signer.Verify(token)
+
+	if err != nil || len(payload) <= 1+linkNonceLength {
+		return nil, domain.ErrInvalidVerificationLink
+	}
+	otpType := domain.OtpType(payload[0])
+
+	if _, ok := verificationLinkPaths[otpType]; !ok {
+		return nil, domain.ErrInvalidVerificationLink
+	}
+
+	return &domain.VerificationLink{
+		Type:      otpType,
+		Nonce:     string(payload[1 : 1+linkNonceLength]),
+		UserEmail: string(payload[1+linkNonceLength:]),
+	}, nil
+}
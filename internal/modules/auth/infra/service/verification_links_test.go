@@ -0,0 +1,55 @@
+package service
+
+import (
+	"comu/internal/modules/auth/domain"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenFromURL(link string) string {
+	parsed, _ := url.Parse(link)
+	return parsed.Query().Get("token")
+}
+
+func TestVerificationLinks(t *testing.T) {
+	links := NewVerificationLinks("app-key", "http://localhost:4000/")
+
+	t.Run("it should verify the links it made", func(t *testing.T) {
+		_assert := assert.New(t)
+		code := domain.NewOtpCode(domain.RegisterOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+
+		link := links.URL(code)
+		_assert.Contains(link, "http://localhost:4000/register/verify_link?token=")
+
+		verified, err := links.Verify(tokenFromURL(link))
+
+		if _assert.NoError(err) {
+			_assert.Equal(domain.RegisterOTP, verified.Type)
+			_assert.Equal(code.UserEmail, verified.UserEmail)
+			_assert.Equal(code.LinkNonce, verified.Nonce)
+		}
+	})
+
+	t.Run("it should point the reset password links to their route", func(t *testing.T) {
+		code := domain.NewOtpCode(domain.ResetPasswordOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+		assert.Contains(t, links.URL(code), "http://localhost:4000/reset_password/verify_link?token=")
+	})
+
+	t.Run("it should not make links for the other otp types", func(t *testing.T) {
+		code := domain.NewOtpCode(domain.LoginOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+		assert.Empty(t, links.URL(code))
+	})
+
+	t.Run("it should refuse the tampered and foreign tokens", func(t *testing.T) {
+		code := domain.NewOtpCode(domain.RegisterOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+		token := tokenFromURL(links.URL(code))
+		foreign := tokenFromURL(NewVerificationLinks("other-key", "").URL(code))
+
+		for _, invalid := range []string{"", "abc", token[1:], token + "a", foreign} {
+			_, err := links.Verify(invalid)
+			assert.ErrorIs(t, err, domain.ErrInvalidVerificationLink, invalid)
+		}
+	})
+}
@@ -40,6 +40,16 @@ func (repoMock *otpCodesRepositoryMock) FindByUserEmail(ctx context.Context, use
 	return args.Get(0).(*domain.OtpCode), nil
 }
 
+func (repoMock *otpCodesRepositoryMock) FindByLinkNonce(ctx context.Context, nonce string) (*domain.OtpCode, error) {
+	args := repoMock.Called(ctx, nonce)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*domain.OtpCode), nil
+}
+
 func (repoMock *otpCodesRepositoryMock) Store(ctx context.Context, otpCode *domain.OtpCode) error {
 	args := repoMock.Called(ctx, otpCode)
 	return args.Error(0)
@@ -59,3 +69,8 @@ func (repoMock *otpCodesRepositoryMock) Delete(ctx context.Context, otpCode *dom
 	args := repoMock.Called(ctx, otpCode)
 	return args.Error(0)
 }
+
+func (repoMock *otpCodesRepositoryMock) DeleteByLinkNonce(ctx context.Context, nonce string) error {
+	args := repoMock.Called(ctx, nonce)
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package mockService
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type challengeServiceMock struct {
+	mock.Mock
+}
+
+func NewChallengeServiceMock() *challengeServiceMock {
+	return new(challengeServiceMock)
+}
+
+func (serviceMock *challengeServiceMock) Enabled() bool {
+	args := serviceMock.Called()
+	return args.Bool(0)
+}
+
+func (serviceMock *challengeServiceMock) Issue() (*domain.Challenge, error) {
+	args := serviceMock.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*domain.Challenge), nil
+}
+
+func (serviceMock *challengeServiceMock) Verify(ctx context.Context, solution domain.ChallengeSolution) error {
+	args := serviceMock.Called(ctx, solution)
+	return args.Error(0)
+}
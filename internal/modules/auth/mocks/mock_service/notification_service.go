@@ -2,6 +2,7 @@ package mockService
 
 import (
 	"comu/internal/modules/auth/domain"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -14,12 +15,17 @@ func NewNotificationServiceMock() *notificationServiceMock {
 	return new(notificationServiceMock)
 }
 
-func (serviceMock *notificationServiceMock) SendOtpCodeMessage(code *domain.OtpCode) error {
-	args := serviceMock.Called(code)
+func (serviceMock *notificationServiceMock) SendOtpCodeMessage(ctx context.Context, code *domain.OtpCode) error {
+	args := serviceMock.Called(ctx, code)
 	return args.Error(0)
 }
 
-func (serviceMock *notificationServiceMock) SendPasswordChangedMessage(userEmail string) error {
-	args := serviceMock.Called(userEmail)
+func (serviceMock *notificationServiceMock) SendPasswordChangedMessage(ctx context.Context, userEmail string) error {
+	args := serviceMock.Called(ctx, userEmail)
+	return args.Error(0)
+}
+
+func (serviceMock *notificationServiceMock) SendNewSignInMessage(ctx context.Context, userEmail string, event *domain.LoginEvent) error {
+	args := serviceMock.Called(ctx, userEmail, event)
 	return args.Error(0)
 }
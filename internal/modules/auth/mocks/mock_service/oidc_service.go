@@ -0,0 +1,31 @@
+package mockService
+
+import (
+	"comu/internal/modules/auth/domain"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type oidcServiceMock struct {
+	mock.Mock
+}
+
+func NewOidcServiceMock() *oidcServiceMock {
+	return new(oidcServiceMock)
+}
+
+func (serviceMock *oidcServiceMock) AuthCodeURL(ctx context.Context, provider, state, nonce, codeChallenge string) (string, error) {
+	args := serviceMock.Called(ctx, provider, state, nonce, codeChallenge)
+	return args.String(0), args.Error(1)
+}
+
+func (serviceMock *oidcServiceMock) Exchange(ctx context.Context, provider, code, nonce, codeVerifier string) (*domain.OidcClaims, error) {
+	args := serviceMock.Called(ctx, provider, code, nonce, codeVerifier)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*domain.OidcClaims), nil
+}
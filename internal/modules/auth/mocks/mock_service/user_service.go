@@ -36,7 +36,17 @@ func (serviceMock *userServiceMock) GetUserByEmail(ctx context.Context, email st
 	return args.Get(0).(*domain.AuthUser), args.Error(1)
 }
 
-func (serviceMock *userServiceMock) CreateNewUser(ctx context.Context, name, email, password string) (uuid.UUID, error) {
+func (serviceMock *userServiceMock) CreateNewUser(ctx context.Context, name, email, password, locale string) (uuid.UUID, error) {
+	args := serviceMock.Called(ctx, name, email, password, locale)
+
+	if args.Get(0) == nil {
+		return uuid.UUID{}, args.Error(1)
+	}
+
+	return args.Get(0).(uuid.UUID), nil
+}
+
+func (serviceMock *userServiceMock) CreateNewVerifiedUser(ctx context.Context, name, email, password string) (uuid.UUID, error) {
 	args := serviceMock.Called(ctx, name, email, password)
 
 	if args.Get(0) == nil {
@@ -55,3 +65,8 @@ func (serviceMock *userServiceMock) UpdateUserPassword(ctx context.Context, user
 	args := serviceMock.Called(ctx, userID, newPassword)
 	return args.Error(0)
 }
+
+func (serviceMock *userServiceMock) SetUserVerifiedPhone(ctx context.Context, userID uuid.UUID, phone string) error {
+	args := serviceMock.Called(ctx, userID, phone)
+	return args.Error(0)
+}
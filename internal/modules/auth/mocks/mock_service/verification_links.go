@@ -0,0 +1,30 @@
+package mockService
+
+import (
+	"comu/internal/modules/auth/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type verificationLinksMock struct {
+	mock.Mock
+}
+
+func NewVerificationLinksMock() *verificationLinksMock {
+	return new(verificationLinksMock)
+}
+
+func (serviceMock *verificationLinksMock) URL(code *domain.OtpCode) string {
+	args := serviceMock.Called(code)
+	return args.String(0)
+}
+
+func (serviceMock *verificationLinksMock) Verify(token string) (*domain.VerificationLink, error) {
+	args := serviceMock.Called(token)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*domain.VerificationLink), nil
+}
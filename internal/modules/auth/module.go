@@ -7,16 +7,21 @@ import (
 	"comu/internal/modules/auth/infra/mysql"
 	"comu/internal/modules/auth/infra/service"
 	"comu/internal/modules/auth/presentation/handlers"
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/modules/notifications"
 	"comu/internal/modules/users"
+	"comu/internal/shared/database"
 	"comu/internal/shared/logger"
+	"comu/internal/shared/sms"
 	"database/sql"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
 var (
-	AuthUserIdCtxKey         = "userID"
-	AuthIsUserVerifiedCtxKey = "isUserVerified"
+	AuthUserIdCtxKey         = session.UserIDCtxKey
+	AuthIsUserVerifiedCtxKey = session.IsUserVerifiedCtxKey
 )
 
 type PublicApi interface {
@@ -26,53 +31,81 @@ type PublicApi interface {
 }
 
 type authModule struct {
-	api      PublicApi
-	handlers []handlers.Handlers
+	api                PublicApi
+	handlers           []handlers.Handlers
+	sessionHandlers    []handlers.Handlers
+	authedUserHandlers []handlers.Handlers
 }
 
 func NewModule(
 	db *sql.DB, config *config.Config,
-	usersApi users.PublicApi, logger *logger.Log,
+	usersApi users.PublicApi, notificationsApi notifications.PublicApi,
+	smsSender sms.Sender, logger *logger.Log,
 ) *authModule {
 	otpCodesRepo := mysql.NewOtpCodesRepository(db)
 	resetTokensRepo := mysql.NewResetTokensRepository(db)
 	refreshTokensRepo := mysql.NewRefreshTokensRepository(db)
 	resendRequestsRepo := mysql.NewResendOtpRequestsRepository(db)
+	oidcStatesRepo := mysql.NewOidcStatesRepository(db)
+	oidcIdentitiesRepo := mysql.NewOidcIdentitiesRepository(db)
+	invitesRepo := mysql.NewInvitesRepository(db)
+	spentChallengesRepo := mysql.NewSpentChallengesRepository(db)
+	loginEventsRepo := mysql.NewLoginEventsRepository(db)
+	phoneVerificationsRepo := mysql.NewPhoneVerificationsRepository(db)
 
 	jwtService := service.NewJwtService(config.AppKey, domain.DefaultAccessTokenTTL, logger)
 	userService := service.NewUserService(usersApi, logger)
 	passwordService := service.NewPasswordService(logger)
-	notificationService, err := service.NewSmtpNotificationService(
-		config.MailHost, config.MailPort, config.MailFrom,
-		service.SmtpNotificationAuth{
-			Username: config.MailUserName,
-			Password: config.MailPassword,
-		},
-		config.AppEnv == "production" || config.AppEnv == "prod",
+	verificationLinks := service.NewVerificationLinks(config.AppKey, config.AppURL)
+	notificationService := service.NewNotificationService(
+		notificationsApi, userService, verificationLinks, config.AppURL,
 	)
-	logger.Info.Println(config.MailPort)
-
-	if err != nil {
-		logger.Error.Fatalln(err)
+	otpSenders := domain.OtpSenders{
+		service.NewEmailOtpSender(notificationService),
+		service.NewSmsOtpSender(smsSender, config.AppName),
 	}
 
+	oidcService := service.NewOidcService(getOidcProviders(config), nil, logger)
+	challengeService := service.NewChallengeService(
+		config.AppKey, config.PowEnabled, config.PowDifficulty,
+		domain.DefaultChallengeTTL, spentChallengesRepo, logger,
+	)
+
 	useCases := application.InitUseCases(
 		otpCodesRepo,
 		resetTokensRepo,
 		refreshTokensRepo,
 		resendRequestsRepo,
+		oidcStatesRepo,
+		oidcIdentitiesRepo,
+		invitesRepo,
+		loginEventsRepo,
+		phoneVerificationsRepo,
 		jwtService,
 		userService,
 		passwordService,
 		notificationService,
+		otpSenders,
+		verificationLinks,
+		oidcService,
+		service.NewDisposableEmailChecker(),
+		challengeService,
+		database.NewTransactor(db),
+		domain.RegistrationPolicy{
+			Mode:           config.RegistrationMode,
+			AllowedDomains: config.RegistrationAllowedDomains,
+		},
 	)
 
-	api := newApi(useCases.VerifyAccessToken)
-	handlers := handlers.GetHandlers(useCases, logger)
+	sessions := session.NewManager(config.SessionMode, strings.HasPrefix(config.AppURL, "https://"))
+	api := newApi(useCases.VerifyAccessToken, sessions)
 
 	return &authModule{
-		api:      api,
-		handlers: handlers,
+		api:             api,
+		handlers:        handlers.GetHandlers(useCases, sessions, logger),
+		sessionHandlers: handlers.GetSessionHandlers(useCases, sessions, config.VerificationRedirectURL, logger),
+
+		authedUserHandlers: handlers.GetAuthenticatedUserHandlers(useCases, logger),
 	}
 }
 
@@ -80,8 +113,33 @@ func (module *authModule) RegisterRoutes(echo *echo.Echo) {
 	for _, h := range module.handlers {
 		h.RegisterRoutes(echo, module.api.GuestMiddleware)
 	}
+
+	for _, h := range module.sessionHandlers {
+		h.RegisterRoutes(echo)
+	}
+
+	for _, h := range module.authedUserHandlers {
+		h.RegisterRoutes(echo, module.api.AuthMiddleware, module.api.VerifiedMiddleware)
+	}
 }
 
 func (module *authModule) GetPublicApi() PublicApi {
 	return module.api
 }
+
+func getOidcProviders(config *config.Config) []service.OidcProvider {
+	providers := []service.OidcProvider{}
+
+	for _, provider := range config.OidcProviders {
+		providers = append(providers, service.OidcProvider{
+			Name:         provider.Name,
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  config.AppURL + "/oidc/" + provider.Name + "/callback",
+			Scopes:       provider.Scopes,
+		})
+	}
+
+	return providers
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/application/challenge"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	challengeRequired echoRes.ErrorResponseType = "challenge_required"
+	invalidChallenge  echoRes.ErrorResponseType = "invalid_challenge"
+)
+
+type challengeHandlers struct {
+	issueChallengeUC *challenge.IssueChallengeUC
+
+	logger *logger.Log
+}
+
+func newChallengeHandlers(issueChallengeUC *challenge.IssueChallengeUC, logger *logger.Log) *challengeHandlers {
+	return &challengeHandlers{
+		issueChallengeUC: issueChallengeUC,
+
+		logger: logger,
+	}
+}
+
+// ChallengeFormData is embedded in the forms of the routes requiring a solved challenge.
+// It is exported because echo doesn't bind form values into unexported embedded structs.
+type ChallengeFormData struct {
+	Challenge      string `form:"challenge" json:"challenge"`
+	ChallengeNonce string `form:"challenge_nonce" json:"challenge_nonce"`
+}
+
+func (data ChallengeFormData) solution() domain.ChallengeSolution {
+	return domain.ChallengeSolution{
+		Token: data.Challenge,
+		Nonce: data.ChallengeNonce,
+	}
+}
+
+type challengeResponse struct {
+	Enabled    bool       `json:"enabled"`
+	Token      string     `json:"token,omitempty"`
+	Difficulty int        `json:"difficulty,omitempty"`
+	ExpiredAt  *time.Time `json:"expired_at,omitempty"`
+}
+
+func (h *challengeHandlers) issue(ctx echo.Context) error {
+	issuedChallenge, err := h.issueChallengeUC.Execute()
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	if issuedChallenge == nil {
+		return echoRes.JsonSuccessWithDataResponse(ctx, challengeResponse{Enabled: false})
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, challengeResponse{
+		Enabled:    true,
+		Token:      issuedChallenge.Token,
+		Difficulty: issuedChallenge.Difficulty,
+		ExpiredAt:  &issuedChallenge.ExpiredAt,
+	})
+}
+
+func (h *challengeHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	echo.GET("/challenge", h.issue, m...)
+}
+
+// challengeErrorResponse answers with the matching error when err is a challenge error.
+func challengeErrorResponse(ctx echo.Context, err error) (bool, error) {
+	switch {
+	case errors.Is(err, domain.ErrChallengeRequired):
+		return true, echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, challengeRequired, err.Error())
+
+	case errors.Is(err, domain.ErrInvalidChallenge),
+		errors.Is(err, domain.ErrExpiredChallenge),
+		errors.Is(err, domain.ErrChallengeAlreadyUsed):
+		return true, echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, invalidChallenge, err.Error())
+
+	default:
+		return false, nil
+	}
+}
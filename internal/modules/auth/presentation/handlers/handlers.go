@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"comu/internal/modules/auth/application"
+	"comu/internal/modules/auth/presentation/session"
 	"comu/internal/shared/logger"
 
 	"github.com/labstack/echo/v4"
@@ -11,24 +12,68 @@ type Handlers interface {
 	RegisterRoutes(*echo.Echo, ...echo.MiddlewareFunc)
 }
 
-func GetHandlers(ucs application.UseCases, logger *logger.Log) []Handlers {
+// GetHandlers returns the handlers of the routes reserved to guest users.
+func GetHandlers(ucs application.UseCases, sessions *session.Manager, logger *logger.Log) []Handlers {
 	otpHandlers := newOtpHandlers(ucs.VerifyOtpUC, ucs.ResendOtpUC, logger)
 	loginHandlers := newLoginHandlers(
 		ucs.LoginUC, ucs.GenAuthTokenUC, ucs.GenResendRequestUC,
-		ucs.GenAccessTokenFromRefresh, otpHandlers, logger,
+		ucs.RecordLoginUC, otpHandlers, sessions, logger,
 	)
 	registerHandlers := newRegisterHandlers(
 		ucs.RegisterUC, ucs.GenAuthTokenUC, ucs.MarkUserAsVerifiedUC,
-		ucs.GenResendRequestUC, otpHandlers, logger,
+		ucs.GenResendRequestUC, otpHandlers, sessions, logger,
 	)
 	resetPasswordHandlers := newResetPasswordHandlers(
 		ucs.NewPasswordUC, ucs.GenResetTokenUC, ucs.ResetPasswordUC,
 		ucs.GenResendRequestUC, otpHandlers, logger,
 	)
+	oidcHandlers := newOidcHandlers(
+		ucs.StartOidcLoginUC, ucs.OidcCallbackUC,
+		ucs.GenAuthTokenUC, ucs.RecordLoginUC, sessions, logger,
+	)
+
+	challengeHandlers := newChallengeHandlers(ucs.IssueChallengeUC, logger)
 
 	return []Handlers{
 		loginHandlers,
 		registerHandlers,
 		resetPasswordHandlers,
+		oidcHandlers,
+		challengeHandlers,
+	}
+}
+
+// GetSessionHandlers returns the handlers of the routes working on an existing session
+// and of the links sent by email, which are available whether the user is authenticated or not.
+func GetSessionHandlers(
+	ucs application.UseCases, sessions *session.Manager,
+	verificationRedirectURL string, logger *logger.Log,
+) []Handlers {
+	sessionHandlers := newSessionHandlers(
+		ucs.GenAccessTokenFromRefresh, ucs.RevokeRefreshTokenUC,
+		ucs.RevokeSessionUC, sessions, verificationRedirectURL, logger,
+	)
+	verificationLinkHandlers := newVerificationLinkHandlers(
+		ucs.VerifyOtpLinkUC, ucs.MarkUserAsVerifiedUC, ucs.GenResetTokenUC,
+		verificationRedirectURL, logger,
+	)
+
+	return []Handlers{
+		sessionHandlers,
+		verificationLinkHandlers,
+	}
+}
+
+// GetAuthenticatedUserHandlers returns the handlers of the routes reserved to
+// authenticated users with a verified email address.
+func GetAuthenticatedUserHandlers(ucs application.UseCases, logger *logger.Log) []Handlers {
+	invitesHandlers := newInvitesHandlers(ucs.CreateInviteUC, ucs.ListInvitesUC, logger)
+	loginHistoryHandlers := newLoginHistoryHandlers(ucs.ListLoginsUC, logger)
+	phoneHandlers := newPhoneHandlers(ucs.RequestPhoneVerificationUC, ucs.VerifyPhoneUC, logger)
+
+	return []Handlers{
+		invitesHandlers,
+		loginHistoryHandlers,
+		phoneHandlers,
 	}
 }
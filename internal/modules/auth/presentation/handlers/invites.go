@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/application/invites"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/modules/auth/presentation/validation"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	unauthenticated echoRes.ErrorResponseType = "unauthenticated"
+)
+
+type invitesHandlers struct {
+	createInviteUC *invites.CreateInviteUC
+	listInvitesUC  *invites.ListInvitesUC
+
+	logger *logger.Log
+}
+
+func newInvitesHandlers(
+	createInviteUC *invites.CreateInviteUC,
+	listInvitesUC *invites.ListInvitesUC,
+
+	logger *logger.Log,
+) *invitesHandlers {
+	return &invitesHandlers{
+		createInviteUC: createInviteUC,
+		listInvitesUC:  listInvitesUC,
+
+		logger: logger,
+	}
+}
+
+type inviteFormData struct {
+	MaxUses       int `form:"max_uses" json:"max_uses"`
+	ExpiresInDays int `form:"expires_in_days" json:"expires_in_days"`
+}
+
+type inviteResponse struct {
+	Code      string    `json:"code"`
+	MaxUses   int       `json:"max_uses"`
+	Uses      int       `json:"uses"`
+	ExpiredAt time.Time `json:"expired_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newInviteResponse(invite domain.Invite) inviteResponse {
+	return inviteResponse{
+		Code:      invite.Code,
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		ExpiredAt: invite.ExpiredAt,
+		CreatedAt: invite.CreatedAt,
+	}
+}
+
+func (h *invitesHandlers) create(ctx echo.Context) error {
+	var data inviteFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if errList := validation.InviteValidator.Validate(&data); errList != nil {
+		return echoRes.JsonValidationErrorResponse(ctx, errList)
+	}
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+
+	invite, err := h.createInviteUC.Execute(ctx.Request().Context(), invites.CreateInviteInput{
+		CreatedBy: userID,
+		MaxUses:   data.MaxUses,
+		TTL:       time.Duration(data.ExpiresInDays) * time.Hour * 24,
+	})
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, newInviteResponse(*invite))
+}
+
+func (h *invitesHandlers) list(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	invitesList, err := h.listInvitesUC.Execute(ctx.Request().Context(), userID)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+	data := []inviteResponse{}
+
+	for _, invite := range invitesList {
+		data = append(data, newInviteResponse(invite))
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, data)
+}
+
+func (h *invitesHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	groupRouter := echo.Group("/invites", m...)
+
+	groupRouter.GET("", h.list)
+	groupRouter.POST("", h.create)
+}
+
+// getAuthUserID returns the id of the user authenticated by the auth middleware.
+func getAuthUserID(ctx echo.Context) (uuid.UUID, error) {
+	id, _ := ctx.Get(session.UserIDCtxKey).(string)
+	userID, err := uuid.Parse(id)
+
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidToken
+	}
+
+	return userID, nil
+}
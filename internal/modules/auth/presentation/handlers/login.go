@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"comu/internal/modules/auth/application/login"
+	loginHistory "comu/internal/modules/auth/application/login_history"
 	"comu/internal/modules/auth/application/otp"
 	"comu/internal/modules/auth/application/tokens"
 	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/presentation/session"
 	"comu/internal/modules/auth/presentation/validation"
 	"comu/internal/shared/logger"
 	echoRes "comu/internal/shared/utils/echo_res"
@@ -17,17 +19,16 @@ var verificationSentMessage = "A verification code has been sent to your mail."
 
 var (
 	invalidCredentials echoRes.ErrorResponseType = "invalid_credentials"
-	invalidToken       echoRes.ErrorResponseType = "invalid_token"
-	expiredToken       echoRes.ErrorResponseType = "expired_token"
 )
 
 type loginHandlers struct {
-	loginUC                     *login.LoginUC
-	genAuthTokenUC              *tokens.GenerateAuthTokensUC
-	genResendRequestUC          *otp.GenResendOtpRequestUC
-	genAccessTokenFromRefreshUC *tokens.GenAccessTokenFromRefreshUC
+	loginUC            *login.LoginUC
+	genAuthTokenUC     *tokens.GenerateAuthTokensUC
+	genResendRequestUC *otp.GenResendOtpRequestUC
+	recordLoginUC      *loginHistory.RecordLoginUC
 
 	otpHandlers *otpHandlers
+	sessions    *session.Manager
 	logger      *logger.Log
 }
 
@@ -35,18 +36,20 @@ func newLoginHandlers(
 	loginUC *login.LoginUC,
 	genAuthTokenUC *tokens.GenerateAuthTokensUC,
 	genResendRequestUC *otp.GenResendOtpRequestUC,
-	genAccessTokenFromRefreshUC *tokens.GenAccessTokenFromRefreshUC,
+	recordLoginUC *loginHistory.RecordLoginUC,
 
 	otpHandler *otpHandlers,
+	sessions *session.Manager,
 	logger *logger.Log,
 ) *loginHandlers {
 	return &loginHandlers{
-		loginUC:                     loginUC,
-		genAuthTokenUC:              genAuthTokenUC,
-		genResendRequestUC:          genResendRequestUC,
-		genAccessTokenFromRefreshUC: genAccessTokenFromRefreshUC,
+		loginUC:            loginUC,
+		genAuthTokenUC:     genAuthTokenUC,
+		genResendRequestUC: genResendRequestUC,
+		recordLoginUC:      recordLoginUC,
 
 		otpHandlers: otpHandler,
+		sessions:    sessions,
 		logger:      logger,
 	}
 }
@@ -54,10 +57,9 @@ func newLoginHandlers(
 type loginFormData struct {
 	Email    string `form:"email" json:"email"`
 	Password string `form:"password" json:"password"`
-}
-
-type refreshFormData struct {
-	Token string `form:"refresh_token" json:"refresh_token"`
+	// Channel is the one the code is sent through, "email" by default.
+	Channel string `form:"channel" json:"channel"`
+	ChallengeFormData
 }
 
 func (h *loginHandlers) loginAttempt(ctx echo.Context) error {
@@ -75,10 +77,20 @@ func (h *loginHandlers) loginAttempt(ctx echo.Context) error {
 		ctx.Request().Context(),
 		data.Email,
 		data.Password,
+		data.Channel,
+		data.solution(),
 	); err != nil {
+		if handled, res := challengeErrorResponse(ctx, err); handled {
+			return res
+		}
+
+		if handled, res := otpChannelErrorResponse(ctx, err); handled {
+			return res
+		}
 
 		switch {
 		case errors.Is(err, domain.ErrInvalidCredentials):
+			recordLogin(ctx, h.recordLoginUC, h.logger, data.Email, false, domain.PasswordLogin, "")
 			return echoRes.JsonUnauthorizedResponse(ctx, invalidCredentials, err.Error())
 		default:
 			h.logger.Error.Println(err)
@@ -88,7 +100,7 @@ func (h *loginHandlers) loginAttempt(ctx echo.Context) error {
 
 	resendRequest, _ := h.genResendRequestUC.Execute(ctx.Request().Context(), data.Email)
 
-	return echoRes.JsonSuccessResponse(ctx, verificationSentMessage, map[string]string{
+	return echoRes.JsonSuccessResponse(ctx, otpSentMessage(data.Channel), map[string]string{
 		"resend_token": resendRequest.ID.String(),
 	})
 }
@@ -105,11 +117,9 @@ func (h *loginHandlers) verifyOtp(ctx echo.Context) error {
 			h.logger.Error.Println(err)
 			return echoRes.JsonInternalErrorResponse(ctx)
 		}
+		recordLogin(ctx, h.recordLoginUC, h.logger, validated.Email, true, domain.PasswordLogin, refresh)
 
-		return echoRes.JsonSuccessWithDataResponse(ctx, map[string]string{
-			"access_token":  access,
-			"refresh_token": refresh,
-		})
+		return echoRes.JsonSuccessWithDataResponse(ctx, h.sessions.AuthTokensResponse(ctx, access, refresh))
 	})
 
 	return handler(ctx)
@@ -120,49 +130,10 @@ func (h *loginHandlers) resendOtp(ctx echo.Context) error {
 	return handler(ctx)
 }
 
-func (h *loginHandlers) refreshToken(ctx echo.Context) error {
-	var data refreshFormData
-
-	if err := ctx.Bind(&data); err != nil {
-		return echoRes.JsonInvalidRequestResponse(ctx)
-	}
-
-	if data.Token == "" {
-		return echoRes.JsonUnauthorizedResponse(ctx, invalidToken, domain.ErrInvalidToken.Error())
-	}
-
-	token, err := h.genAccessTokenFromRefreshUC.Execute(
-		ctx.Request().Context(),
-		data.Token,
-	)
-
-	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrExpiredToken):
-			return echoRes.JsonUnauthorizedResponse(ctx, expiredToken, err.Error())
-
-		case errors.Is(err, domain.ErrUserEmailTaken):
-			return echoRes.JsonUnauthorizedResponse(
-				ctx, invalidToken,
-				domain.ErrInvalidToken.Error(),
-			)
-
-		default:
-			h.logger.Error.Println(err)
-			return echoRes.JsonInternalErrorResponse(ctx)
-		}
-	}
-
-	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]string{
-		"access_token": token,
-	})
-}
-
 func (h *loginHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
 	groupRouter := echo.Group("/login", m...)
 
 	groupRouter.POST("", h.loginAttempt)
 	groupRouter.POST("/verify", h.verifyOtp)
 	groupRouter.POST("/resend_otp", h.resendOtp)
-	groupRouter.POST("/refresh", h.refreshToken)
 }
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	loginHistory "comu/internal/modules/auth/application/login_history"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type loginHistoryHandlers struct {
+	listLoginsUC *loginHistory.ListLoginsUC
+
+	logger *logger.Log
+}
+
+func newLoginHistoryHandlers(
+	listLoginsUC *loginHistory.ListLoginsUC,
+
+	logger *logger.Log,
+) *loginHistoryHandlers {
+	return &loginHistoryHandlers{
+		listLoginsUC: listLoginsUC,
+
+		logger: logger,
+	}
+}
+
+type loginEventResponse struct {
+	Success   bool      `json:"success"`
+	Method    string    `json:"method"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *loginHistoryHandlers) list(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	events, err := h.listLoginsUC.Execute(ctx.Request().Context(), userID)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+	data := []loginEventResponse{}
+
+	for _, event := range events {
+		data = append(data, loginEventResponse{
+			Success:   event.Success,
+			Method:    event.Method,
+			IP:        event.IP,
+			UserAgent: event.UserAgent,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, data)
+}
+
+func (h *loginHistoryHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	echo.GET("/me/logins", h.list, m...)
+}
+
+// recordLogin adds a login attempt to the user login history. Failing to record
+// it is only logged, so that it never prevents the user from signing in.
+func recordLogin(
+	ctx echo.Context, recordLoginUC *loginHistory.RecordLoginUC, logger *logger.Log,
+	email string, success bool, method domain.LoginMethod, refreshToken string,
+) {
+	err := recordLoginUC.Execute(ctx.Request().Context(), loginHistory.RecordLoginInput{
+		Email:        email,
+		Success:      success,
+		Method:       method,
+		IP:           ctx.RealIP(),
+		UserAgent:    ctx.Request().UserAgent(),
+		RefreshToken: refreshToken,
+	})
+
+	if err != nil {
+		logger.Error.Println(err)
+	}
+}
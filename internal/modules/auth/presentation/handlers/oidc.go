@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	loginHistory "comu/internal/modules/auth/application/login_history"
+	"comu/internal/modules/auth/application/oidc"
+	"comu/internal/modules/auth/application/tokens"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	unsupportedProvider  echoRes.ErrorResponseType = "unsupported_provider"
+	invalidOidcState     echoRes.ErrorResponseType = "invalid_state"
+	oidcExchangeFailed   echoRes.ErrorResponseType = "provider_authentication_failed"
+	oidcEmailNotVerified echoRes.ErrorResponseType = "email_not_verified"
+)
+
+type oidcHandlers struct {
+	startOidcLoginUC *oidc.StartOidcLoginUC
+	oidcCallbackUC   *oidc.OidcCallbackUC
+	genAuthTokenUC   *tokens.GenerateAuthTokensUC
+	recordLoginUC    *loginHistory.RecordLoginUC
+
+	sessions *session.Manager
+	logger   *logger.Log
+}
+
+func newOidcHandlers(
+	startOidcLoginUC *oidc.StartOidcLoginUC,
+	oidcCallbackUC *oidc.OidcCallbackUC,
+	genAuthTokenUC *tokens.GenerateAuthTokensUC,
+	recordLoginUC *loginHistory.RecordLoginUC,
+
+	sessions *session.Manager,
+	logger *logger.Log,
+) *oidcHandlers {
+	return &oidcHandlers{
+		startOidcLoginUC: startOidcLoginUC,
+		oidcCallbackUC:   oidcCallbackUC,
+		genAuthTokenUC:   genAuthTokenUC,
+		recordLoginUC:    recordLoginUC,
+
+		sessions: sessions,
+		logger:   logger,
+	}
+}
+
+type oidcCallbackQueryData struct {
+	Code  string `query:"code"`
+	State string `query:"state"`
+	Error string `query:"error"`
+}
+
+func (h *oidcHandlers) startLogin(ctx echo.Context) error {
+	authURL, state, err := h.startOidcLoginUC.Execute(ctx.Request().Context(), ctx.Param("provider"))
+
+	if err != nil {
+		if errors.Is(err, domain.ErrOidcProviderNotFound) {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusNotFound, unsupportedProvider, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+	h.sessions.SetOidcStateCookie(ctx, state)
+
+	return ctx.Redirect(http.StatusFound, authURL)
+}
+
+func (h *oidcHandlers) callback(ctx echo.Context) error {
+	var data oidcCallbackQueryData
+
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	// The provider redirects with an error parameter when the user denied the request.
+	if data.Error != "" || data.Code == "" || data.State == "" {
+		return echoRes.JsonUnauthorizedResponse(ctx, oidcExchangeFailed, domain.ErrOidcExchangeFailed.Error())
+	}
+	// A callback coming from another browser than the one which started the sign in
+	// is a forged request trying to sign the user in to the attacker account.
+	if !h.sessions.ValidOidcState(ctx, data.State) {
+		return echoRes.JsonUnauthorizedResponse(ctx, invalidOidcState, domain.ErrInvalidOidcState.Error())
+	}
+
+	user, err := h.oidcCallbackUC.Execute(ctx.Request().Context(), oidc.OidcCallbackInput{
+		Provider: ctx.Param("provider"),
+		State:    data.State,
+		Code:     data.Code,
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOidcProviderNotFound):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusNotFound, unsupportedProvider, err.Error())
+
+		case errors.Is(err, domain.ErrInvalidOidcState):
+			return echoRes.JsonUnauthorizedResponse(ctx, invalidOidcState, err.Error())
+
+		case errors.Is(err, domain.ErrOidcExchangeFailed), errors.Is(err, domain.ErrInvalidToken):
+			return echoRes.JsonUnauthorizedResponse(ctx, oidcExchangeFailed, domain.ErrOidcExchangeFailed.Error())
+
+		case errors.Is(err, domain.ErrOidcEmailNotVerified):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, oidcEmailNotVerified, err.Error())
+
+		case errors.Is(err, domain.ErrInviteRequired):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, inviteRequired, err.Error())
+
+		case errors.Is(err, domain.ErrEmailDomainNotAllowed):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, emailDomainNotAllowed, err.Error())
+
+		case errors.Is(err, domain.ErrDisposableEmail):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, disposableEmail, err.Error())
+
+		default:
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	access, refresh, err := h.genAuthTokenUC.Execute(ctx.Request().Context(), user.Email)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+	recordLogin(ctx, h.recordLoginUC, h.logger, user.Email, true, domain.OidcLogin, refresh)
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, h.sessions.AuthTokensResponse(ctx, access, refresh))
+}
+
+func (h *oidcHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	groupRouter := echo.Group("/oidc", m...)
+
+	groupRouter.GET("/:provider", h.startLogin)
+	groupRouter.GET("/:provider/callback", h.callback)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/application/oidc"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"comu/internal/modules/auth/infra/service"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/shared/logger"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupOidcHandlers(oidcService domain.OidcService) (*oidcHandlers, domain.OidcStatesRepository) {
+	statesRepository := memory.NewInMemoryOidcStatesRepository(nil)
+
+	handlers := newOidcHandlers(
+		oidc.NewStartOidcLoginUseCase(oidcService, statesRepository),
+		oidc.NewOidcCallbackUseCase(
+			oidcService, statesRepository, memory.NewInMemoryOidcIdentitiesRepository(nil),
+			mockService.NewUserServiceMock(), mockService.NewPasswordServiceMock(),
+			service.NewDisposableEmailChecker(), domain.RegistrationPolicy{Mode: domain.OpenRegistration},
+		),
+		nil,
+		nil,
+		session.NewManager(session.TokenMode, true),
+		logger.NewSpyLogger(),
+	)
+
+	return handlers, statesRepository
+}
+
+// newOidcCallbackRequest returns a callback request for the given state, sending
+// the state cookie when not empty.
+func newOidcCallbackRequest(state, stateCookie string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/oidc/google/callback?code=code&state="+state, nil)
+
+	if stateCookie != "" {
+		req.AddCookie(&http.Cookie{Name: session.OidcStateCookie, Value: stateCookie})
+	}
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rec)
+	ctx.SetParamNames("provider")
+	ctx.SetParamValues("google")
+
+	return ctx, rec
+}
+
+func TestOidcHandlersStartLogin(t *testing.T) {
+
+	t.Run("it should redirect to the provider and bind the state to the browser", func(t *testing.T) {
+		_assert := assert.New(t)
+		oidcService := mockService.NewOidcServiceMock()
+		handlers, statesRepository := setupOidcHandlers(oidcService)
+
+		oidcService.On("AuthCodeURL", mock.Anything, "google", mock.Anything, mock.Anything, mock.Anything).
+			Return("https://accounts.example.com/authorize", nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/oidc/google", nil)
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+		ctx.SetParamNames("provider")
+		ctx.SetParamValues("google")
+
+		if _assert.NoError(handlers.startLogin(ctx)) {
+			_assert.Equal(http.StatusFound, rec.Code)
+			_assert.Equal("https://accounts.example.com/authorize", rec.Header().Get("Location"))
+			cookies := responseCookies(rec)
+
+			if _assert.Contains(cookies, session.OidcStateCookie) {
+				cookie := cookies[session.OidcStateCookie]
+				_assert.True(cookie.HttpOnly)
+				_assert.Equal(http.SameSiteLaxMode, cookie.SameSite)
+
+				_, err := statesRepository.Find(context.Background(), cookie.Value)
+				_assert.NoError(err)
+			}
+		}
+	})
+}
+
+func TestOidcHandlersCallback(t *testing.T) {
+
+	t.Run("it should reject the callback when the state cookie doesn't match the state", func(t *testing.T) {
+		for _, stateCookie := range []string{"", "Lw1dA6QqzR0Nf8Ty"} {
+			_assert := assert.New(t)
+			oidcService := mockService.NewOidcServiceMock()
+			handlers, statesRepository := setupOidcHandlers(oidcService)
+
+			state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+			statesRepository.Store(context.Background(), state)
+			ctx, rec := newOidcCallbackRequest(state.Value, stateCookie)
+
+			if _assert.NoError(handlers.callback(ctx)) {
+				_assert.Equal(http.StatusUnauthorized, rec.Code)
+				_assert.Contains(rec.Body.String(), string(invalidOidcState))
+			}
+			oidcService.AssertNotCalled(t, "Exchange")
+		}
+	})
+
+	t.Run("it should exchange the code with the state code verifier when the state cookie matches", func(t *testing.T) {
+		_assert := assert.New(t)
+		oidcService := mockService.NewOidcServiceMock()
+		handlers, statesRepository := setupOidcHandlers(oidcService)
+
+		state := domain.NewOidcState("google", domain.DefaultOidcStateTTL)
+		statesRepository.Store(context.Background(), state)
+		oidcService.On("Exchange", mock.Anything, "google", "code", state.Nonce, state.CodeVerifier).
+			Return(nil, domain.ErrOidcExchangeFailed).Once()
+
+		ctx, rec := newOidcCallbackRequest(state.Value, state.Value)
+
+		if _assert.NoError(handlers.callback(ctx)) {
+			_assert.Equal(http.StatusUnauthorized, rec.Code)
+			_assert.Contains(rec.Body.String(), string(oidcExchangeFailed))
+
+			if cookies := responseCookies(rec); _assert.Contains(cookies, session.OidcStateCookie) {
+				_assert.Negative(cookies[session.OidcStateCookie].MaxAge)
+			}
+		}
+		oidcService.AssertExpectations(t)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"comu/internal/shared/logger"
 	echoRes "comu/internal/shared/utils/echo_res"
 	"errors"
+	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,8 +19,15 @@ var (
 	invalidResendRequest       echoRes.ErrorResponseType = "invalid_request"
 	unprocessableResendRequest echoRes.ErrorResponseType = "unprocessable_resend_request"
 	exceededResendRequestCount echoRes.ErrorResponseType = "exceeded_resend_request_count"
+	invalidOtpChannel          echoRes.ErrorResponseType = "invalid_otp_channel"
+	unavailableOtpChannel      echoRes.ErrorResponseType = "unavailable_otp_channel"
 )
 
+var otpSentMessages = map[domain.OtpChannel]string{
+	domain.EmailOtpChannel: verificationSentMessage,
+	domain.SmsOtpChannel:   "A verification code has been sent to your phone.",
+}
+
 type otpHandlers struct {
 	verifyOtpUC *otp.VerifyOtpUC
 	resendOtpUC *otp.ResendOtpUC
@@ -48,6 +56,8 @@ type verifyOtpFormData struct {
 type resendOtpFormData struct {
 	Email       string `form:"email" json:"email"`
 	ResendToken string `form:"resend_token" json:"resend_token"`
+	// Channel is the one the new code is sent through, "email" by default.
+	Channel string `form:"channel" json:"channel"`
 }
 
 func (h *otpHandlers) verify(otpType domain.OtpType, afterFunc func(verifyOtpFormData) error) echo.HandlerFunc {
@@ -115,8 +125,12 @@ func (h *otpHandlers) resend(otpType domain.OtpType) echo.HandlerFunc {
 				ID:          id,
 				UserEmail:   data.Email,
 				OtpCodeType: otpType,
+				Channel:     data.Channel,
 			},
 		); err != nil {
+			if handled, res := otpChannelErrorResponse(ctx, err); handled {
+				return res
+			}
 
 			switch {
 
@@ -142,6 +156,27 @@ func (h *otpHandlers) resend(otpType domain.OtpType) echo.HandlerFunc {
 
 		}
 
-		return echoRes.JsonSuccessMessageResponse(ctx, "A new otp code has been sent to your mail.")
+		return echoRes.JsonSuccessMessageResponse(ctx, otpSentMessage(data.Channel))
+	}
+}
+
+func otpSentMessage(channel domain.OtpChannel) string {
+	if message, ok := otpSentMessages[channel]; ok {
+		return message
+	}
+
+	return otpSentMessages[domain.EmailOtpChannel]
+}
+
+func otpChannelErrorResponse(ctx echo.Context, err error) (bool, error) {
+	switch {
+	case errors.Is(err, domain.ErrUnknownOtpChannel):
+		return true, echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidOtpChannel, err.Error())
+
+	case errors.Is(err, domain.ErrOtpChannelUnavailable):
+		return true, echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, unavailableOtpChannel, err.Error())
+
+	default:
+		return false, nil
 	}
 }
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/application/phone"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/presentation/validation"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	invalidPhone echoRes.ErrorResponseType = "invalid_phone"
+
+	msgPhoneVerified = "Your phone number has been verified."
+)
+
+type phoneHandlers struct {
+	requestPhoneVerificationUC *phone.RequestPhoneVerificationUC
+	verifyPhoneUC              *phone.VerifyPhoneUC
+
+	logger *logger.Log
+}
+
+func newPhoneHandlers(
+	requestPhoneVerificationUC *phone.RequestPhoneVerificationUC,
+	verifyPhoneUC *phone.VerifyPhoneUC,
+
+	logger *logger.Log,
+) *phoneHandlers {
+	return &phoneHandlers{
+		requestPhoneVerificationUC: requestPhoneVerificationUC,
+		verifyPhoneUC:              verifyPhoneUC,
+
+		logger: logger,
+	}
+}
+
+type phoneFormData struct {
+	Phone string `form:"phone" json:"phone"`
+}
+
+type verifyPhoneFormData struct {
+	Code string `form:"code" json:"code"`
+}
+
+func (h *phoneHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/me/phone", m...)
+
+	group.POST("", h.update)
+	group.POST("/verify", h.verify)
+}
+
+// update texts a verification code to the phone number, which replaces the user one once verified.
+func (h *phoneHandlers) update(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	var data phoneFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if errList := validation.PhoneValidator.Validate(&data); errList != nil {
+		return echoRes.JsonValidationErrorResponse(ctx, errList)
+	}
+
+	if err := h.requestPhoneVerificationUC.Execute(ctx.Request().Context(), userID, data.Phone); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPhone):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidPhone, err.Error())
+		case errors.Is(err, domain.ErrResendRequestCantBeProcessed):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusTooManyRequests, unprocessableResendRequest, err.Error())
+		case errors.Is(err, domain.ErrResendRequestCountExceeded):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusTooManyRequests, exceededResendRequestCount, err.Error())
+		default:
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, otpSentMessage(domain.SmsOtpChannel))
+}
+
+func (h *phoneHandlers) verify(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	var data verifyPhoneFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if errList := validation.PhoneCodeValidator.Validate(&data); errList != nil {
+		return echoRes.JsonValidationErrorResponse(ctx, errList)
+	}
+
+	if err := h.verifyPhoneUC.Execute(ctx.Request().Context(), userID, data.Code); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidOtp):
+			return echoRes.JsonUnauthorizedResponse(ctx, invalidOtp, err.Error())
+		case errors.Is(err, domain.ErrExpiredOtp):
+			return echoRes.JsonUnauthorizedResponse(ctx, expiredOtp, err.Error())
+		default:
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, msgPhoneVerified)
+}
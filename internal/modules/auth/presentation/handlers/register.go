@@ -5,16 +5,23 @@ import (
 	"comu/internal/modules/auth/application/register"
 	"comu/internal/modules/auth/application/tokens"
 	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/presentation/session"
 	"comu/internal/modules/auth/presentation/validation"
 	"comu/internal/shared/logger"
 	echoRes "comu/internal/shared/utils/echo_res"
 	"errors"
+	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
 var (
-	userEmailTaken echoRes.ErrorResponseType = "user_email_taken"
+	userEmailTaken        echoRes.ErrorResponseType = "user_email_taken"
+	inviteRequired        echoRes.ErrorResponseType = "invite_required"
+	invalidInvite         echoRes.ErrorResponseType = "invalid_invite"
+	emailDomainNotAllowed echoRes.ErrorResponseType = "email_domain_not_allowed"
+	disposableEmail       echoRes.ErrorResponseType = "disposable_email"
 )
 
 type registerHandlers struct {
@@ -24,6 +31,7 @@ type registerHandlers struct {
 	genResendRequestUC   *otp.GenResendOtpRequestUC
 
 	otpHandlers *otpHandlers
+	sessions    *session.Manager
 	logger      *logger.Log
 }
 
@@ -34,6 +42,7 @@ func newRegisterHandlers(
 	genResendRequestUC *otp.GenResendOtpRequestUC,
 
 	otpHandler *otpHandlers,
+	sessions *session.Manager,
 	logger *logger.Log,
 ) *registerHandlers {
 	return &registerHandlers{
@@ -43,14 +52,17 @@ func newRegisterHandlers(
 		genResendRequestUC:   genResendRequestUC,
 
 		otpHandlers: otpHandler,
+		sessions:    sessions,
 		logger:      logger,
 	}
 }
 
 type registerFormData struct {
-	Name     string `form:"name" json:"name"`
-	Email    string `form:"email" json:"email"`
-	Password string `form:"password" json:"password"`
+	Name       string `form:"name" json:"name"`
+	Email      string `form:"email" json:"email"`
+	Password   string `form:"password" json:"password"`
+	InviteCode string `form:"invite_code" json:"invite_code"`
+	ChallengeFormData
 }
 
 func (h *registerHandlers) register(ctx echo.Context) error {
@@ -67,15 +79,39 @@ func (h *registerHandlers) register(ctx echo.Context) error {
 
 	if err := h.registerUC.Execute(
 		ctx.Request().Context(),
-		data.Name, data.Email, data.Password,
+		register.RegisterInput{
+			Name:       data.Name,
+			Email:      data.Email,
+			Password:   data.Password,
+			InviteCode: data.InviteCode,
+			Locale:     preferredLocale(ctx),
+			Challenge:  data.solution(),
+		},
 	); err != nil {
+		if handled, res := challengeErrorResponse(ctx, err); handled {
+			return res
+		}
 
-		if errors.Is(err, domain.ErrUserEmailTaken) {
+		switch {
+		case errors.Is(err, domain.ErrUserEmailTaken):
 			return echoRes.JsonUnauthorizedResponse(ctx, userEmailTaken, err.Error())
-		}
 
-		h.logger.Error.Println(err)
-		return echoRes.JsonInternalErrorResponse(ctx)
+		case errors.Is(err, domain.ErrInviteRequired):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, inviteRequired, err.Error())
+
+		case errors.Is(err, domain.ErrInvalidInvite):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, invalidInvite, err.Error())
+
+		case errors.Is(err, domain.ErrEmailDomainNotAllowed):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, emailDomainNotAllowed, err.Error())
+
+		case errors.Is(err, domain.ErrDisposableEmail):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, disposableEmail, err.Error())
+
+		default:
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
 	}
 
 	resendRequest, _ := h.genResendRequestUC.Execute(ctx.Request().Context(), data.Email)
@@ -108,10 +144,7 @@ func (h *registerHandlers) verifyOtp(ctx echo.Context) error {
 			return echoRes.JsonInternalErrorResponse(ctx)
 		}
 
-		return echoRes.JsonSuccessWithDataResponse(ctx, map[string]string{
-			"access_token":  access,
-			"refresh_token": refresh,
-		})
+		return echoRes.JsonSuccessWithDataResponse(ctx, h.sessions.AuthTokensResponse(ctx, access, refresh))
 	})
 
 	return handler(ctx)
@@ -129,3 +162,17 @@ func (h *registerHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareF
 	groupRouter.POST("/verify", h.verifyOtp)
 	groupRouter.POST("/resend_otp", h.resendOtp)
 }
+
+// preferredLocale returns the language preferred by the user agent in its
+// Accept-Language header, or an empty string when it doesn't send a valid one.
+func preferredLocale(ctx echo.Context) string {
+	tag, _, _ := strings.Cut(ctx.Request().Header.Get("Accept-Language"), ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	tag, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+	if len(tag) < 2 || len(tag) > 3 || strings.Trim(tag, "abcdefghijklmnopqrstuvwxyz") != "" {
+		return ""
+	}
+
+	return tag
+}
@@ -47,6 +47,7 @@ var msgPasswordsDoNotMatch = "Password and password confirmation don't match"
 
 type resetPasswordFormData struct {
 	Email string `form:"email" json:"email"`
+	ChallengeFormData
 }
 
 type newPasswordFormData struct {
@@ -68,8 +69,12 @@ func (h *resetPasswordHandlers) reset(ctx echo.Context) error {
 	}
 
 	if err := h.resetPasswordUC.Execute(
-		ctx.Request().Context(), data.Email,
+		ctx.Request().Context(), data.Email, data.solution(),
 	); err != nil {
+		if handled, res := challengeErrorResponse(ctx, err); handled {
+			return res
+		}
+
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return echoRes.JsonSuccessMessageResponse(ctx, verificationSentMessage)
 		}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	loginHistory "comu/internal/modules/auth/application/login_history"
+	"comu/internal/modules/auth/application/tokens"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/modules/auth/presentation/validation"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	loggedOutMessage      = "You have been logged out."
+	sessionRevokedMessage = "The session has been signed out."
+)
+
+var (
+	invalidToken echoRes.ErrorResponseType = "invalid_token"
+	expiredToken echoRes.ErrorResponseType = "expired_token"
+)
+
+type sessionHandlers struct {
+	genAccessTokenFromRefreshUC *tokens.GenAccessTokenFromRefreshUC
+	revokeRefreshTokenUC        *tokens.RevokeRefreshTokenUC
+	revokeSessionUC             *loginHistory.RevokeSessionUC
+
+	sessions    *session.Manager
+	redirectURL string
+	logger      *logger.Log
+}
+
+func newSessionHandlers(
+	genAccessTokenFromRefreshUC *tokens.GenAccessTokenFromRefreshUC,
+	revokeRefreshTokenUC *tokens.RevokeRefreshTokenUC,
+	revokeSessionUC *loginHistory.RevokeSessionUC,
+
+	sessions *session.Manager,
+	redirectURL string,
+	logger *logger.Log,
+) *sessionHandlers {
+	return &sessionHandlers{
+		genAccessTokenFromRefreshUC: genAccessTokenFromRefreshUC,
+		revokeRefreshTokenUC:        revokeRefreshTokenUC,
+		revokeSessionUC:             revokeSessionUC,
+
+		sessions:    sessions,
+		redirectURL: redirectURL,
+		logger:      logger,
+	}
+}
+
+type refreshFormData struct {
+	Token string `form:"refresh_token" json:"refresh_token"`
+}
+
+// getRefreshToken reads the refresh token from the request body or from its cookie.
+// A token read from a cookie is rejected when the request has no valid csrf token.
+func (h *sessionHandlers) getRefreshToken(ctx echo.Context) (string, error) {
+	var data refreshFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return "", err
+	}
+	token, fromCookie := h.sessions.RefreshToken(ctx, data.Token)
+
+	if fromCookie && !session.ValidCsrfToken(ctx) {
+		return "", session.ErrInvalidCsrfToken
+	}
+
+	return token, nil
+}
+
+func (h *sessionHandlers) refreshToken(ctx echo.Context) error {
+	refresh, err := h.getRefreshToken(ctx)
+
+	if err != nil {
+		if errors.Is(err, session.ErrInvalidCsrfToken) {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, session.InvalidCsrfToken, err.Error())
+		}
+
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if refresh == "" {
+		return echoRes.JsonUnauthorizedResponse(ctx, invalidToken, domain.ErrInvalidToken.Error())
+	}
+
+	access, err := h.genAccessTokenFromRefreshUC.Execute(ctx.Request().Context(), refresh)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrExpiredToken):
+			return echoRes.JsonUnauthorizedResponse(ctx, expiredToken, err.Error())
+
+		case errors.Is(err, domain.ErrTokenNotFound),
+			errors.Is(err, domain.ErrInvalidToken),
+			errors.Is(err, domain.ErrUserNotFound):
+			return echoRes.JsonUnauthorizedResponse(
+				ctx, invalidToken,
+				domain.ErrInvalidToken.Error(),
+			)
+
+		default:
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	if h.sessions.CookieMode() {
+		return echoRes.JsonSuccessWithDataResponse(ctx, map[string]string{
+			"csrf_token": h.sessions.SetAuthCookies(ctx, access, refresh),
+		})
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]string{
+		"access_token": access,
+	})
+}
+
+func (h *sessionHandlers) logout(ctx echo.Context) error {
+	refresh, err := h.getRefreshToken(ctx)
+
+	if err != nil {
+		if errors.Is(err, session.ErrInvalidCsrfToken) {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusForbidden, session.InvalidCsrfToken, err.Error())
+		}
+
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if refresh != "" {
+		if err := h.revokeRefreshTokenUC.Execute(ctx.Request().Context(), refresh); err != nil {
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	if h.sessions.CookieMode() {
+		h.sessions.ClearAuthCookies(ctx)
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, loggedOutMessage)
+}
+
+// openRevokeLink handles the link of a new sign-in email. Opening it doesn't revoke the
+// session, as mail scanners and link previews open links too: it redirects to the frontend,
+// which asks the user to confirm and posts the link token back to revoke the session.
+func (h *sessionHandlers) openRevokeLink(ctx echo.Context) error {
+	if err := redirectToConfirmation(ctx, h.redirectURL, "revoke_session", ctx.Param("token")); err != nil {
+		h.logger.Error.Println(err)
+		return ctx.NoContent(http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// revoke signs out the session opened by a login, with the token of a new sign-in email link.
+func (h *sessionHandlers) revoke(ctx echo.Context) error {
+	var data verificationLinkFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if errList := validation.VerificationLinkValidator.Validate(&data); errList != nil {
+		return echoRes.JsonValidationErrorResponse(ctx, errList)
+	}
+	err := h.revokeSessionUC.Execute(ctx.Request().Context(), data.Token)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusNotFound, invalidToken, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, sessionRevokedMessage)
+}
+
+func (h *sessionHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	echo.POST("/login/refresh", h.refreshToken, m...)
+	echo.POST("/logout", h.logout, m...)
+	echo.GET("/sessions/revoke/:token", h.openRevokeLink, m...)
+	echo.POST("/sessions/revoke", h.revoke, m...)
+}
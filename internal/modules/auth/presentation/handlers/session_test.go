@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	loginHistory "comu/internal/modules/auth/application/login_history"
+	"comu/internal/modules/auth/application/tokens"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/shared/logger"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupSessionHandlers returns session handlers with a stored refresh token, along with
+// the login event which opened it and holds its revoke token.
+func setupSessionHandlers(mode session.Mode) (*sessionHandlers, domain.RefreshTokensRepository, *domain.RefreshToken, *domain.LoginEvent) {
+	refreshTokensRepo := memory.NewInMemoryRefreshTokensRepository(nil)
+	loginEventsRepo := memory.NewInMemoryLoginEventsRepository(nil)
+	userService := mockService.NewUserServiceMock()
+	jwtService := mockService.NewJwtServiceMock()
+
+	user := &domain.AuthUser{ID: uuid.New(), Email: "johndoe@gmail.com"}
+	refreshToken := domain.NewRefreshToken(user.ID, domain.DefaultRefreshTokenTTL)
+	refreshTokensRepo.Store(context.Background(), refreshToken)
+
+	loginEvent := domain.NewLoginEvent(user.ID, user.Email, true, domain.PasswordLogin, "192.0.2.1", "Firefox")
+	loginEvent.AttachSession(refreshToken.Token)
+	loginEventsRepo.Store(context.Background(), loginEvent)
+
+	userService.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	jwtService.On("GenerateToken", user).Return("new-access-token", nil)
+
+	handlers := newSessionHandlers(
+		tokens.NewGenAccessTokenFromRefreshUseCase(jwtService, userService, refreshTokensRepo),
+		tokens.NewRevokeRefreshTokenUseCase(refreshTokensRepo),
+		loginHistory.NewRevokeSessionUseCase(loginEventsRepo, refreshTokensRepo),
+		session.NewManager(mode, true),
+		"http://localhost:3000/confirm",
+		logger.NewSpyLogger(),
+	)
+
+	return handlers, refreshTokensRepo, refreshToken, loginEvent
+}
+
+// newCookieRequest returns a request sending the refresh token cookie, along with
+// the csrf cookie and the given csrf header when not empty.
+func newCookieRequest(refreshToken, csrfHeader string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(&http.Cookie{Name: session.RefreshTokenCookie, Value: refreshToken})
+	req.AddCookie(&http.Cookie{Name: session.CsrfTokenCookie, Value: "gdSR5Y3bKzvJ4o3x"})
+
+	if csrfHeader != "" {
+		req.Header.Set(session.CsrfTokenHeader, csrfHeader)
+	}
+	rec := httptest.NewRecorder()
+
+	return echo.New().NewContext(req, rec), rec
+}
+
+func responseCookies(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := map[string]*http.Cookie{}
+
+	for _, cookie := range rec.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+
+	return cookies
+}
+
+func TestSessionHandlersRefreshToken(t *testing.T) {
+
+	t.Run("it should refresh the access token cookie when the csrf token is valid", func(t *testing.T) {
+		_assert := assert.New(t)
+		handlers, _, refreshToken, _ := setupSessionHandlers(session.CookieMode)
+		ctx, rec := newCookieRequest(refreshToken.Token, "gdSR5Y3bKzvJ4o3x")
+
+		if _assert.NoError(handlers.refreshToken(ctx)) {
+			_assert.Equal(http.StatusOK, rec.Code)
+			_assert.Contains(rec.Body.String(), `"csrf_token":"gdSR5Y3bKzvJ4o3x"`)
+			_assert.NotContains(rec.Body.String(), "new-access-token")
+
+			cookies := responseCookies(rec)
+
+			if _assert.Contains(cookies, session.AccessTokenCookie) {
+				_assert.Equal("new-access-token", cookies[session.AccessTokenCookie].Value)
+			}
+		}
+	})
+
+	t.Run("it should refuse the refresh token cookie without a valid csrf token", func(t *testing.T) {
+		for _, csrfHeader := range []string{"", "Lw1dA6QqzR0Nf8Ty"} {
+			_assert := assert.New(t)
+			handlers, _, refreshToken, _ := setupSessionHandlers(session.CookieMode)
+			ctx, rec := newCookieRequest(refreshToken.Token, csrfHeader)
+
+			if _assert.NoError(handlers.refreshToken(ctx)) {
+				_assert.Equal(http.StatusForbidden, rec.Code)
+				_assert.Contains(rec.Body.String(), string(session.InvalidCsrfToken))
+				_assert.Empty(rec.Result().Cookies())
+			}
+		}
+	})
+
+	t.Run("it should ignore the refresh token cookie in token mode", func(t *testing.T) {
+		_assert := assert.New(t)
+		handlers, _, refreshToken, _ := setupSessionHandlers(session.TokenMode)
+		ctx, rec := newCookieRequest(refreshToken.Token, "gdSR5Y3bKzvJ4o3x")
+
+		if _assert.NoError(handlers.refreshToken(ctx)) {
+			_assert.Equal(http.StatusUnauthorized, rec.Code)
+			_assert.Empty(rec.Result().Cookies())
+		}
+	})
+}
+
+func TestSessionHandlersLogout(t *testing.T) {
+
+	t.Run("it should revoke the refresh token cookie and clear the cookies", func(t *testing.T) {
+		_assert := assert.New(t)
+		handlers, refreshTokensRepo, refreshToken, _ := setupSessionHandlers(session.CookieMode)
+		ctx, rec := newCookieRequest(refreshToken.Token, "gdSR5Y3bKzvJ4o3x")
+
+		if _assert.NoError(handlers.logout(ctx)) {
+			_assert.Equal(http.StatusOK, rec.Code)
+
+			token, err := refreshTokensRepo.Find(context.Background(), refreshToken.Token)
+
+			if _assert.NoError(err) {
+				_assert.True(token.Revoked)
+			}
+			cookies := responseCookies(rec)
+			_assert.Len(cookies, 3)
+
+			for _, cookie := range cookies {
+				_assert.Empty(cookie.Value)
+				_assert.Negative(cookie.MaxAge)
+			}
+		}
+	})
+
+	t.Run("it should neither revoke nor clear the session without a valid csrf token", func(t *testing.T) {
+		_assert := assert.New(t)
+		handlers, refreshTokensRepo, refreshToken, _ := setupSessionHandlers(session.CookieMode)
+		ctx, rec := newCookieRequest(refreshToken.Token, "")
+
+		if _assert.NoError(handlers.logout(ctx)) {
+			_assert.Equal(http.StatusForbidden, rec.Code)
+			_assert.Empty(rec.Result().Cookies())
+
+			token, err := refreshTokensRepo.Find(context.Background(), refreshToken.Token)
+
+			if _assert.NoError(err) {
+				_assert.False(token.Revoked)
+			}
+		}
+	})
+}
+
+func TestSessionHandlersRevoke(t *testing.T) {
+
+	t.Run("it should only redirect to the frontend confirmation when the link is opened", func(t *testing.T) {
+		_assert := assert.New(t)
+		handlers, refreshTokensRepo, refreshToken, loginEvent := setupSessionHandlers(session.TokenMode)
+
+		req := httptest.NewRequest(http.MethodGet, "/sessions/revoke/"+loginEvent.RevokeToken, nil)
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+		ctx.SetParamNames("token")
+		ctx.SetParamValues(loginEvent.RevokeToken)
+
+		if _assert.NoError(handlers.openRevokeLink(ctx)) {
+			_assert.Equal(http.StatusFound, rec.Code)
+			_assert.Equal(
+				"http://localhost:3000/confirm?type=revoke_session#token="+loginEvent.RevokeToken,
+				rec.Header().Get("Location"),
+			)
+			token, err := refreshTokensRepo.Find(context.Background(), refreshToken.Token)
+
+			if _assert.NoError(err) {
+				_assert.False(token.Revoked)
+			}
+		}
+	})
+
+	t.Run("it should revoke the session when the link token is posted", func(t *testing.T) {
+		_assert := assert.New(t)
+		handlers, refreshTokensRepo, refreshToken, loginEvent := setupSessionHandlers(session.TokenMode)
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions/revoke", strings.NewReader(`{"token":"`+loginEvent.RevokeToken+`"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		if _assert.NoError(handlers.revoke(echo.New().NewContext(req, rec))) {
+			_assert.Equal(http.StatusOK, rec.Code)
+			token, err := refreshTokensRepo.Find(context.Background(), refreshToken.Token)
+
+			if _assert.NoError(err) {
+				_assert.True(token.Revoked)
+			}
+		}
+	})
+
+	t.Run("it should fail with a not found error for an unknown link token", func(t *testing.T) {
+		_assert := assert.New(t)
+		handlers, _, _, _ := setupSessionHandlers(session.TokenMode)
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions/revoke", strings.NewReader(`{"token":"unknown"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		if _assert.NoError(handlers.revoke(echo.New().NewContext(req, rec))) {
+			_assert.Equal(http.StatusNotFound, rec.Code)
+			_assert.Contains(rec.Body.String(), string(invalidToken))
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/application/otp"
+	"comu/internal/modules/auth/application/register"
+	"comu/internal/modules/auth/application/tokens"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/presentation/validation"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+)
+
+var msgEmailVerified = "Your email address has been verified."
+
+// verificationLinkHandlers handles the verification links sent along with the register and
+// reset password otp codes. Unlike a one-click link, opening a link doesn't verify anything:
+// mail scanners and link previews open the links of the emails they receive, which would
+// use the codes before their owners do, and a GET request isn't supposed to change state.
+// The link redirects to the frontend instead, which asks the user to confirm and posts the
+// link token back. That post completes the same verification as the otp code.
+type verificationLinkHandlers struct {
+	verifyOtpLinkUC      *otp.VerifyOtpLinkUC
+	markUserAsVerifiedUC *register.MarkUserAsVerifiedUC
+	genResetTokenUC      *tokens.GenerateResetTokenUC
+
+	redirectURL string
+	logger      *logger.Log
+}
+
+func newVerificationLinkHandlers(
+	verifyOtpLinkUC *otp.VerifyOtpLinkUC,
+	markUserAsVerifiedUC *register.MarkUserAsVerifiedUC,
+	genResetTokenUC *tokens.GenerateResetTokenUC,
+
+	redirectURL string,
+	logger *logger.Log,
+) *verificationLinkHandlers {
+	return &verificationLinkHandlers{
+		verifyOtpLinkUC:      verifyOtpLinkUC,
+		markUserAsVerifiedUC: markUserAsVerifiedUC,
+		genResetTokenUC:      genResetTokenUC,
+
+		redirectURL: redirectURL,
+		logger:      logger,
+	}
+}
+
+type verificationLinkFormData struct {
+	Token string `form:"token" json:"token"`
+}
+
+func (h *verificationLinkHandlers) openRegisterLink(ctx echo.Context) error {
+	return h.redirect(ctx, "register")
+}
+
+func (h *verificationLinkHandlers) openResetPasswordLink(ctx echo.Context) error {
+	return h.redirect(ctx, "reset_password")
+}
+
+func (h *verificationLinkHandlers) verifyRegisterLink(ctx echo.Context) error {
+	return h.verify(ctx, domain.RegisterOTP, func(email string) error {
+		if err := h.markUserAsVerifiedUC.Execute(ctx.Request().Context(), email); err != nil {
+			if errors.Is(err, domain.ErrUserEmailTaken) {
+				return echoRes.JsonUnauthorizedResponse(ctx, invalidOtp, domain.ErrInvalidOtp.Error())
+			}
+
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+
+		return echoRes.JsonSuccessMessageResponse(ctx, msgEmailVerified)
+	})
+}
+
+func (h *verificationLinkHandlers) verifyResetPasswordLink(ctx echo.Context) error {
+	return h.verify(ctx, domain.ResetPasswordOTP, func(email string) error {
+		token, err := h.genResetTokenUC.Execute(ctx.Request().Context(), email)
+
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return echoRes.JsonUnauthorizedResponse(ctx, invalidOtp, domain.ErrInvalidOtp.Error())
+			}
+
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+
+		return echoRes.JsonSuccessWithDataResponse(
+			ctx, map[string]string{
+				"reset_token": token,
+			},
+		)
+	})
+}
+
+// verify uses the posted link token, and calls onSuccess with the user email once it did.
+func (h *verificationLinkHandlers) verify(ctx echo.Context, otpType domain.OtpType, onSuccess func(email string) error) error {
+	var data verificationLinkFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if errList := validation.VerificationLinkValidator.Validate(&data); errList != nil {
+		return echoRes.JsonValidationErrorResponse(ctx, errList)
+	}
+	email, err := h.verifyOtpLinkUC.Execute(ctx.Request().Context(), otpType, data.Token)
+
+	switch {
+	case err == nil:
+		return onSuccess(email)
+
+	case errors.Is(err, domain.ErrInvalidOtp):
+		return echoRes.JsonUnauthorizedResponse(ctx, invalidOtp, err.Error())
+
+	case errors.Is(err, domain.ErrExpiredOtp):
+		return echoRes.JsonUnauthorizedResponse(ctx, expiredOtp, err.Error())
+
+	default:
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+}
+
+func (h *verificationLinkHandlers) redirect(ctx echo.Context, linkType string) error {
+	if err := redirectToConfirmation(ctx, h.redirectURL, linkType, ctx.QueryParam("token")); err != nil {
+		h.logger.Error.Println(err)
+		return ctx.NoContent(http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// redirectToConfirmation sends the user opening a link sent by email to the frontend
+// confirmation step with the link type. The link token is put in the fragment, which
+// browsers don't send to servers.
+func redirectToConfirmation(ctx echo.Context, redirectURL, linkType, token string) error {
+	target, err := url.Parse(redirectURL)
+
+	if err != nil {
+		return err
+	}
+	query := target.Query()
+	query.Set("type", linkType)
+	target.RawQuery = query.Encode()
+	target.Fragment = "token=" + url.QueryEscape(token)
+	ctx.Response().Header().Set("Referrer-Policy", "no-referrer")
+
+	return ctx.Redirect(http.StatusFound, target.String())
+}
+
+func (h *verificationLinkHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	echo.GET("/register/verify_link", h.openRegisterLink, m...)
+	echo.POST("/register/verify_link", h.verifyRegisterLink, m...)
+	echo.GET("/reset_password/verify_link", h.openResetPasswordLink, m...)
+	echo.POST("/reset_password/verify_link", h.verifyResetPasswordLink, m...)
+}
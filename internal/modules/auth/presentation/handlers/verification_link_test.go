@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/application/otp"
+	"comu/internal/modules/auth/application/register"
+	"comu/internal/modules/auth/application/tokens"
+	"comu/internal/modules/auth/domain"
+	"comu/internal/modules/auth/infra/memory"
+	"comu/internal/modules/auth/infra/service"
+	mockRepository "comu/internal/modules/auth/mocks/mock_repository"
+	mockService "comu/internal/modules/auth/mocks/mock_service"
+	"comu/internal/shared/logger"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// openLink opens a verification link and returns the token the frontend is redirected with.
+func openLink(t *testing.T, e *echo.Echo, link string) string {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, link, nil))
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	location, err := url.Parse(rec.Header().Get("Location"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "http://localhost:3000/confirm", location.Scheme+"://"+location.Host+location.Path)
+	fragment, _ := url.ParseQuery(location.Fragment)
+
+	return fragment.Get("token")
+}
+
+func postLinkToken(e *echo.Echo, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"token":"`+token+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestVerificationLinkHandlers(t *testing.T) {
+	links := service.NewVerificationLinks("secret", "http://localhost:4000")
+
+	t.Run("it should verify the email once the token the link redirected with is posted", func(t *testing.T) {
+		_assert := assert.New(t)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		userService := mockService.NewUserServiceMock()
+
+		code := domain.NewOtpCode(domain.RegisterOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+		otpCodesRepository.On("FindByLinkNonce", mock.Anything, code.LinkNonce).Return(code, nil)
+		otpCodesRepository.On("DeleteByLinkNonce", mock.Anything, code.LinkNonce).Return(nil).Once()
+		otpCodesRepository.On("DeleteByLinkNonce", mock.Anything, code.LinkNonce).Return(domain.ErrOtpNotFound)
+		resendRequestsRepository.On("FindByUserEmail", mock.Anything, code.UserEmail).Return(nil, domain.ErrResendRequestNotFound)
+		userService.On("MarkUserEmailAsVerified", mock.Anything, code.UserEmail).Return(nil).Once()
+
+		e := echo.New()
+		newVerificationLinkHandlers(
+			otp.NewVerifyOtpLinkUseCase(links, otpCodesRepository, resendRequestsRepository),
+			register.NewMarkUserAsVerifiedUseCase(userService),
+			nil,
+			"http://localhost:3000/confirm",
+			logger.NewSpyLogger(),
+		).RegisterRoutes(e)
+
+		link, _ := url.Parse(links.URL(code))
+		token := openLink(t, e, link.RequestURI())
+
+		// Opening the link, as a mail scanner would, doesn't use it.
+		otpCodesRepository.AssertNotCalled(t, "DeleteByLinkNonce", mock.Anything, mock.Anything)
+		userService.AssertNotCalled(t, "MarkUserEmailAsVerified", mock.Anything, mock.Anything)
+
+		rec := postLinkToken(e, "/register/verify_link", token)
+
+		if _assert.Equal(http.StatusOK, rec.Code) {
+			_assert.Contains(rec.Body.String(), msgEmailVerified)
+		}
+		userService.AssertExpectations(t)
+
+		rec = postLinkToken(e, "/register/verify_link", token)
+		_assert.Equal(http.StatusUnauthorized, rec.Code)
+		_assert.Contains(rec.Body.String(), string(invalidOtp))
+	})
+
+	t.Run("it should return a reset token once the token the link redirected with is posted", func(t *testing.T) {
+		_assert := assert.New(t)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+		resendRequestsRepository := mockRepository.NewResendOtpRequestsRepositoryMock()
+		userService := mockService.NewUserServiceMock()
+		resetTokensRepository := memory.NewInMemoryResetTokensRepository(nil)
+
+		user := &domain.AuthUser{ID: uuid.New(), Email: "johndoe@gmail.com"}
+		code := domain.NewOtpCode(domain.ResetPasswordOTP, user.Email, domain.DefaultOtpCodeTTL)
+		otpCodesRepository.On("FindByLinkNonce", mock.Anything, code.LinkNonce).Return(code, nil)
+		otpCodesRepository.On("DeleteByLinkNonce", mock.Anything, code.LinkNonce).Return(nil).Once()
+		resendRequestsRepository.On("FindByUserEmail", mock.Anything, code.UserEmail).Return(nil, domain.ErrResendRequestNotFound)
+		userService.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+
+		e := echo.New()
+		newVerificationLinkHandlers(
+			otp.NewVerifyOtpLinkUseCase(links, otpCodesRepository, resendRequestsRepository),
+			nil,
+			tokens.NewGenResetTokenUseCase(userService, resetTokensRepository),
+			"http://localhost:3000/confirm",
+			logger.NewSpyLogger(),
+		).RegisterRoutes(e)
+
+		link, _ := url.Parse(links.URL(code))
+		token := openLink(t, e, link.RequestURI())
+
+		otpCodesRepository.AssertNotCalled(t, "DeleteByLinkNonce", mock.Anything, mock.Anything)
+
+		rec := postLinkToken(e, "/reset_password/verify_link", token)
+
+		if _assert.Equal(http.StatusOK, rec.Code) {
+			_assert.Contains(rec.Body.String(), `"reset_token"`)
+		}
+		otpCodesRepository.AssertExpectations(t)
+		userService.AssertExpectations(t)
+	})
+
+	t.Run("it should refuse a link token posted for another verification type", func(t *testing.T) {
+		_assert := assert.New(t)
+		otpCodesRepository := mockRepository.NewOtpCodesRepositoryMock()
+
+		code := domain.NewOtpCode(domain.ResetPasswordOTP, "johndoe@gmail.com", domain.DefaultOtpCodeTTL)
+
+		e := echo.New()
+		newVerificationLinkHandlers(
+			otp.NewVerifyOtpLinkUseCase(links, otpCodesRepository, mockRepository.NewResendOtpRequestsRepositoryMock()),
+			nil,
+			nil,
+			"http://localhost:3000/confirm",
+			logger.NewSpyLogger(),
+		).RegisterRoutes(e)
+
+		link, _ := url.Parse(links.URL(code))
+		token := openLink(t, e, link.RequestURI())
+		rec := postLinkToken(e, "/register/verify_link", token)
+
+		_assert.Equal(http.StatusUnauthorized, rec.Code)
+		otpCodesRepository.AssertNotCalled(t, "DeleteByLinkNonce", mock.Anything, mock.Anything)
+	})
+}
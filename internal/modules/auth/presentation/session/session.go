@@ -0,0 +1,186 @@
+package session
+
+import (
+	"comu/internal/modules/auth/domain"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mazen160/go-random"
+)
+
+type Mode = string
+
+const (
+	// TokenMode returns the auth tokens in the response body. Clients send the
+	// access token back through the Authorization header.
+	TokenMode Mode = "token"
+	// CookieMode stores the auth tokens in httpOnly cookies, which makes them
+	// unreachable from scripts. Requests changing state must then carry the
+	// csrf cookie value in the X-CSRF-Token header (double-submit cookie).
+	CookieMode Mode = "cookie"
+)
+
+// Context keys under which the auth middleware stores the authenticated user data.
+const (
+	UserIDCtxKey         = "userID"
+	IsUserVerifiedCtxKey = "isUserVerified"
+)
+
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	CsrfTokenCookie    = "csrf_token"
+	CsrfTokenHeader    = "X-CSRF-Token"
+	OidcStateCookie    = "oidc_state"
+)
+
+var ErrInvalidCsrfToken = errors.New("the csrf token is missing or invalid")
+
+var InvalidCsrfToken echoRes.ErrorResponseType = "invalid_csrf_token"
+
+type Manager struct {
+	mode   Mode
+	secure bool
+}
+
+func NewManager(mode Mode, secure bool) *Manager {
+	if mode != CookieMode {
+		mode = TokenMode
+	}
+
+	return &Manager{
+		mode:   mode,
+		secure: secure,
+	}
+}
+
+func (manager *Manager) CookieMode() bool {
+	return manager.mode == CookieMode
+}
+
+// AccessToken returns the access token sent with the request. The Authorization
+// header always takes precedence, the cookie is only looked up in cookie mode.
+func (manager *Manager) AccessToken(ctx echo.Context) (token string, fromCookie bool) {
+	if token = ctx.Request().Header.Get("Authorization"); token != "" || !manager.CookieMode() {
+		return token, false
+	}
+
+	return readCookie(ctx, AccessTokenCookie), true
+}
+
+// RefreshToken returns the refresh token sent in the request body, or the
+// refresh token cookie when there is none and cookie mode is enabled.
+func (manager *Manager) RefreshToken(ctx echo.Context, bodyToken string) (token string, fromCookie bool) {
+	if bodyToken != "" || !manager.CookieMode() {
+		return bodyToken, false
+	}
+
+	return readCookie(ctx, RefreshTokenCookie), true
+}
+
+// SetAuthCookies stores both tokens in cookies and returns the csrf token the
+// client has to send back with its state changing requests.
+func (manager *Manager) SetAuthCookies(ctx echo.Context, accessToken, refreshToken string) string {
+	manager.setCookie(ctx, AccessTokenCookie, accessToken, domain.DefaultAccessTokenTTL, true)
+	manager.setCookie(ctx, RefreshTokenCookie, refreshToken, domain.DefaultRefreshTokenTTL, true)
+
+	csrfToken := readCookie(ctx, CsrfTokenCookie)
+
+	if csrfToken == "" {
+		csrfToken, _ = random.String(32)
+	}
+	manager.setCookie(ctx, CsrfTokenCookie, csrfToken, domain.DefaultRefreshTokenTTL, false)
+
+	return csrfToken
+}
+
+func (manager *Manager) ClearAuthCookies(ctx echo.Context) {
+	for _, name := range []string{AccessTokenCookie, RefreshTokenCookie, CsrfTokenCookie} {
+		manager.setCookie(ctx, name, "", -time.Second, name != CsrfTokenCookie)
+	}
+}
+
+// SetOidcStateCookie binds an identity provider sign in to the browser starting it.
+// The cookie is lax so that it is sent back when the provider redirects to the callback.
+func (manager *Manager) SetOidcStateCookie(ctx echo.Context, state string) {
+	manager.setCookie(ctx, OidcStateCookie, state, domain.DefaultOidcStateTTL, true)
+}
+
+// ValidOidcState reports whether the state an identity provider redirected with was
+// issued to this browser. The state cookie is cleared since a state is only used once.
+func (manager *Manager) ValidOidcState(ctx echo.Context, state string) bool {
+	cookieState := readCookie(ctx, OidcStateCookie)
+	manager.setCookie(ctx, OidcStateCookie, "", -time.Second, true)
+
+	if cookieState == "" || state == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) == 1
+}
+
+// AuthTokensResponse answers a successful authentication with the generated tokens,
+// either in the response body or in cookies depending on the session mode.
+func (manager *Manager) AuthTokensResponse(ctx echo.Context, accessToken, refreshToken string) map[string]string {
+	if !manager.CookieMode() {
+		return map[string]string{
+			"access_token":  accessToken,
+			"refresh_token": refreshToken,
+		}
+	}
+	csrfToken := manager.SetAuthCookies(ctx, accessToken, refreshToken)
+
+	return map[string]string{
+		"csrf_token": csrfToken,
+	}
+}
+
+func (manager *Manager) setCookie(ctx echo.Context, name, value string, ttl time.Duration, httpOnly bool) {
+	sameSite := http.SameSiteLaxMode
+
+	if name == RefreshTokenCookie {
+		sameSite = http.SameSiteStrictMode
+	}
+
+	ctx.SetCookie(&http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(ttl.Seconds()),
+		Secure:   manager.secure,
+		HttpOnly: httpOnly,
+		SameSite: sameSite,
+	})
+}
+
+// ValidCsrfToken reports whether the request is allowed to use the session cookies.
+// Safe methods are always allowed, others must echo the csrf cookie in a header.
+func ValidCsrfToken(ctx echo.Context) bool {
+	switch ctx.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	cookieToken := readCookie(ctx, CsrfTokenCookie)
+	headerToken := ctx.Request().Header.Get(CsrfTokenHeader)
+
+	if cookieToken == "" || headerToken == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(cookieToken), []byte(headerToken)) == 1
+}
+
+func readCookie(ctx echo.Context, name string) string {
+	cookie, err := ctx.Cookie(name)
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(cookie.Value)
+}
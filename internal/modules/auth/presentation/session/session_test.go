@@ -0,0 +1,164 @@
+package session
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext(method string, cookies ...*http.Cookie) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/", nil)
+
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+
+	return echo.New().NewContext(req, rec), rec
+}
+
+func TestValidCsrfToken(t *testing.T) {
+	csrfCookie := &http.Cookie{Name: CsrfTokenCookie, Value: "gdSR5Y3bKzvJ4o3x"}
+
+	tests := []struct {
+		name   string
+		method string
+		cookie *http.Cookie
+		header string
+		valid  bool
+	}{
+		{"it should allow the safe methods without a csrf token", http.MethodGet, nil, "", true},
+		{"it should allow the safe methods with a mismatched csrf token", http.MethodGet, csrfCookie, "Lw1dA6QqzR0Nf8Ty", true},
+		{"it should refuse a request without csrf cookie", http.MethodPost, nil, "gdSR5Y3bKzvJ4o3x", false},
+		{"it should refuse a request without csrf header", http.MethodPost, csrfCookie, "", false},
+		{"it should refuse a request with a mismatched csrf header", http.MethodPost, csrfCookie, "Lw1dA6QqzR0Nf8Ty", false},
+		{"it should allow a request echoing the csrf cookie", http.MethodPost, csrfCookie, "gdSR5Y3bKzvJ4o3x", true},
+		{"it should check the csrf token of the other unsafe methods", http.MethodDelete, csrfCookie, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cookies []*http.Cookie
+
+			if test.cookie != nil {
+				cookies = append(cookies, test.cookie)
+			}
+			ctx, _ := newContext(test.method, cookies...)
+
+			if test.header != "" {
+				ctx.Request().Header.Set(CsrfTokenHeader, test.header)
+			}
+
+			assert.Equal(t, test.valid, ValidCsrfToken(ctx))
+		})
+	}
+}
+
+func TestManagerSetAuthCookies(t *testing.T) {
+	for _, secure := range []bool{false, true} {
+		t.Run(fmt.Sprintf("it should set the auth cookies with their flags, secure: %t", secure), func(t *testing.T) {
+			_assert := assert.New(t)
+			ctx, rec := newContext(http.MethodPost)
+
+			csrfToken := NewManager(CookieMode, secure).SetAuthCookies(ctx, "access", "refresh")
+			cookies := map[string]*http.Cookie{}
+
+			for _, cookie := range rec.Result().Cookies() {
+				cookies[cookie.Name] = cookie
+			}
+
+			if _assert.Len(cookies, 3) {
+				_assert.Equal("access", cookies[AccessTokenCookie].Value)
+				_assert.True(cookies[AccessTokenCookie].HttpOnly)
+				_assert.Equal(http.SameSiteLaxMode, cookies[AccessTokenCookie].SameSite)
+
+				_assert.Equal("refresh", cookies[RefreshTokenCookie].Value)
+				_assert.True(cookies[RefreshTokenCookie].HttpOnly)
+				_assert.Equal(http.SameSiteStrictMode, cookies[RefreshTokenCookie].SameSite)
+
+				_assert.Equal(csrfToken, cookies[CsrfTokenCookie].Value)
+				_assert.False(cookies[CsrfTokenCookie].HttpOnly)
+				_assert.Equal(http.SameSiteLaxMode, cookies[CsrfTokenCookie].SameSite)
+
+				for _, cookie := range cookies {
+					_assert.Equal(secure, cookie.Secure)
+				}
+			}
+		})
+	}
+
+	t.Run("it should keep the csrf token of the session", func(t *testing.T) {
+		ctx, _ := newContext(http.MethodPost, &http.Cookie{Name: CsrfTokenCookie, Value: "gdSR5Y3bKzvJ4o3x"})
+
+		csrfToken := NewManager(CookieMode, true).SetAuthCookies(ctx, "access", "refresh")
+		assert.Equal(t, "gdSR5Y3bKzvJ4o3x", csrfToken)
+	})
+}
+
+func TestManagerAuthTokensResponse(t *testing.T) {
+
+	t.Run("it should return the tokens in the body in token mode", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx, rec := newContext(http.MethodPost)
+
+		response := NewManager(TokenMode, true).AuthTokensResponse(ctx, "access", "refresh")
+
+		_assert.Equal(map[string]string{"access_token": "access", "refresh_token": "refresh"}, response)
+		_assert.Empty(rec.Result().Cookies())
+	})
+
+	t.Run("it should only return the csrf token in cookie mode", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx, rec := newContext(http.MethodPost)
+
+		response := NewManager(CookieMode, true).AuthTokensResponse(ctx, "access", "refresh")
+
+		_assert.NotContains(response, "access_token")
+		_assert.NotEmpty(response["csrf_token"])
+		_assert.Len(rec.Result().Cookies(), 3)
+	})
+}
+
+func TestManagerTokens(t *testing.T) {
+	cookies := []*http.Cookie{
+		{Name: AccessTokenCookie, Value: "cookie-access"},
+		{Name: RefreshTokenCookie, Value: "cookie-refresh"},
+	}
+
+	t.Run("it should only read the cookies in cookie mode", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx, _ := newContext(http.MethodPost, cookies...)
+		manager := NewManager(TokenMode, true)
+
+		token, fromCookie := manager.AccessToken(ctx)
+		_assert.Empty(token)
+		_assert.False(fromCookie)
+
+		token, fromCookie = manager.RefreshToken(ctx, "")
+		_assert.Empty(token)
+		_assert.False(fromCookie)
+	})
+
+	t.Run("it should prefer the header and the body to the cookies", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx, _ := newContext(http.MethodPost, cookies...)
+		ctx.Request().Header.Set("Authorization", "header-access")
+		manager := NewManager(CookieMode, true)
+
+		token, fromCookie := manager.AccessToken(ctx)
+		_assert.Equal("header-access", token)
+		_assert.False(fromCookie)
+
+		token, fromCookie = manager.RefreshToken(ctx, "body-refresh")
+		_assert.Equal("body-refresh", token)
+		_assert.False(fromCookie)
+
+		token, fromCookie = manager.RefreshToken(ctx, "")
+		_assert.Equal("cookie-refresh", token)
+		_assert.True(fromCookie)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"comu/internal/modules/auth/domain"
 	"comu/internal/shared/utils"
 	"comu/internal/shared/validator"
+	"fmt"
 	"regexp"
 
 	"github.com/Oudwins/zog"
@@ -21,7 +22,10 @@ var (
 	msgPasswordMustHaveDigit       = "Password must contain at least one digit"
 	msgPasswordMustHaveUpperCase   = "Password must contain at least one uppercase letter"
 	msgPasswordMustHaveSpecialChar = "Password must contain at least one special character"
+	msgPhoneRequired               = "Phone number is required"
 	msgInvalidOtp                  = utils.UcFirst(domain.ErrInvalidOtp.Error())
+	msgInviteMaxUsesOutOfRange     = fmt.Sprintf("Max uses must be between 1 and %d", domain.MaxInviteUses)
+	msgInviteExpiresInOutOfRange   = fmt.Sprintf("Expiration must be between 1 and %d days", int(domain.MaxInviteTTL.Hours()/24))
 )
 
 var LoginValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
@@ -59,3 +63,24 @@ var ResendOtpValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
 	"email":       zog.String().Required(zog.Message(msgEmailRequired)).Email(zog.Message(msgInvalidEmail)),
 	"resendToken": zog.String().Required(zog.Message(msgTokenRequired)),
 }))
+
+var VerificationLinkValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
+	"token": zog.String().Required(zog.Message(msgTokenRequired)),
+}))
+
+var PhoneValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
+	"phone": zog.String().Required(zog.Message(msgPhoneRequired)),
+}))
+
+var PhoneCodeValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
+	"code": zog.String().Len(6, zog.Message(msgInvalidOtp)).
+		Match(regexp.MustCompile("[0-9]"), zog.Message(msgInvalidOtp)),
+}))
+
+// Zero values are accepted for invites and mean that the default value is used.
+var InviteValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
+	"maxUses": zog.Int().GTE(0, zog.Message(msgInviteMaxUsesOutOfRange)).
+		LTE(domain.MaxInviteUses, zog.Message(msgInviteMaxUsesOutOfRange)),
+	"expiresInDays": zog.Int().GTE(0, zog.Message(msgInviteExpiresInOutOfRange)).
+		LTE(int(domain.MaxInviteTTL.Hours()/24), zog.Message(msgInviteExpiresInOutOfRange)),
+}))
@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"comu/internal/modules/notifications/application/emails"
+	"comu/internal/modules/notifications/application/notifications"
+	"comu/internal/modules/notifications/application/stream"
+	"comu/internal/modules/notifications/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SendEmailRequest struct {
+	// UserID is the receiver id, required unless the category is the security one.
+	UserID   uuid.UUID
+	To       string
+	Category string
+	Template string
+	// Locale is the language the email is rendered in. The default one is used when empty.
+	Locale string
+	Data   map[string]any
+}
+
+// NotifyRequest is a notification of the given type, which names its email template too.
+type NotifyRequest struct {
+	UserID   uuid.UUID
+	Type     string
+	Category string
+	Data     map[string]any
+}
+
+// PublishRequest is an event pushed to the users streams. Its data is sent as JSON.
+type PublishRequest struct {
+	UserIDs []uuid.UUID
+	Type    string
+	Data    any
+}
+
+type publicApi struct {
+	sendEmailUC    *emails.SendEmailUC
+	notifyUC       *notifications.NotifyUC
+	publishEventUC *stream.PublishEventUC
+}
+
+func newApi(
+	sendEmailUC *emails.SendEmailUC,
+	notifyUC *notifications.NotifyUC,
+	publishEventUC *stream.PublishEventUC,
+) *publicApi {
+	return &publicApi{
+		sendEmailUC:    sendEmailUC,
+		notifyUC:       notifyUC,
+		publishEventUC: publishEventUC,
+	}
+}
+
+func (api *publicApi) SendEmail(ctx context.Context, req SendEmailRequest) error {
+	return api.sendEmailUC.Execute(ctx, domain.Email{
+		UserID:   req.UserID,
+		To:       req.To,
+		Category: req.Category,
+		Template: req.Template,
+		Locale:   req.Locale,
+		Data:     req.Data,
+	})
+}
+
+func (api *publicApi) Notify(ctx context.Context, req NotifyRequest) error {
+	return api.notifyUC.Execute(ctx, notifications.NotifyInput{
+		UserID:   req.UserID,
+		Type:     req.Type,
+		Category: req.Category,
+		Data:     req.Data,
+	})
+}
+
+func (api *publicApi) Publish(ctx context.Context, req PublishRequest) {
+	api.publishEventUC.Execute(ctx, stream.PublishEventInput{
+		UserIDs: req.UserIDs,
+		Type:    req.Type,
+		Data:    req.Data,
+	})
+}
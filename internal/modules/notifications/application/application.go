@@ -0,0 +1,86 @@
+package application
+
+import (
+	"comu/internal/modules/notifications/application/digests"
+	"comu/internal/modules/notifications/application/emails"
+	"comu/internal/modules/notifications/application/notifications"
+	"comu/internal/modules/notifications/application/preferences"
+	"comu/internal/modules/notifications/application/stream"
+	"comu/internal/modules/notifications/domain"
+)
+
+type UseCases struct {
+	CreateNotificationUC *notifications.CreateNotificationUC
+	NotifyUC             *notifications.NotifyUC
+	ListNotificationsUC  *notifications.ListNotificationsUC
+	MarkAsReadUC         *notifications.MarkAsReadUC
+	MarkAllAsReadUC      *notifications.MarkAllAsReadUC
+
+	GetPreferencesUC   *preferences.GetPreferencesUC
+	UpdatePreferenceUC *preferences.UpdatePreferenceUC
+	UnsubscribeUC      *preferences.UnsubscribeUC
+
+	PublishEventUC *stream.PublishEventUC
+	SubscribeUC    *stream.SubscribeUC
+
+	GetDigestFrequencyUC    *digests.GetDigestFrequencyUC
+	UpdateDigestFrequencyUC *digests.UpdateDigestFrequencyUC
+	SendDigestsUC           *digests.SendDigestsUC
+
+	SendEmailUC *emails.SendEmailUC
+}
+
+func InitUseCases(
+	notificationsRepository domain.NotificationsRepository,
+	preferencesRepository domain.PreferencesRepository,
+	digestsRepository domain.DigestsRepository,
+	digestSource domain.DigestSource,
+	emailService domain.EmailService,
+	userService domain.UserService,
+	unsubscribeLinks domain.UnsubscribeLinks,
+	hub domain.EventHub,
+	transactor domain.Transactor,
+) UseCases {
+	sendEmailUC := emails.NewSendEmailUseCase(emailService, preferencesRepository, unsubscribeLinks)
+
+	createNotificationUC := notifications.NewCreateNotificationUseCase(notificationsRepository, preferencesRepository, hub, transactor)
+	notifyUC := notifications.NewNotifyUseCase(createNotificationUC, sendEmailUC, userService)
+	listNotificationsUC := notifications.NewListNotificationsUseCase(notificationsRepository)
+	markAsReadUC := notifications.NewMarkAsReadUseCase(notificationsRepository)
+	markAllAsReadUC := notifications.NewMarkAllAsReadUseCase(notificationsRepository)
+
+	getPreferencesUC := preferences.NewGetPreferencesUseCase(preferencesRepository)
+	updatePreferenceUC := preferences.NewUpdatePreferenceUseCase(preferencesRepository)
+	unsubscribeUC := preferences.NewUnsubscribeUseCase(preferencesRepository, unsubscribeLinks)
+
+	publishEventUC := stream.NewPublishEventUseCase(hub, transactor)
+	subscribeUC := stream.NewSubscribeUseCase(hub)
+
+	getDigestFrequencyUC := digests.NewGetDigestFrequencyUseCase(digestsRepository)
+	updateDigestFrequencyUC := digests.NewUpdateDigestFrequencyUseCase(digestsRepository)
+	sendDigestsUC := digests.NewSendDigestsUseCase(
+		digestsRepository, notificationsRepository, digestSource,
+		userService, sendEmailUC, transactor,
+	)
+
+	return UseCases{
+		CreateNotificationUC: createNotificationUC,
+		NotifyUC:             notifyUC,
+		ListNotificationsUC:  listNotificationsUC,
+		MarkAsReadUC:         markAsReadUC,
+		MarkAllAsReadUC:      markAllAsReadUC,
+
+		GetPreferencesUC:   getPreferencesUC,
+		UpdatePreferenceUC: updatePreferenceUC,
+		UnsubscribeUC:      unsubscribeUC,
+
+		PublishEventUC: publishEventUC,
+		SubscribeUC:    subscribeUC,
+
+		GetDigestFrequencyUC:    getDigestFrequencyUC,
+		UpdateDigestFrequencyUC: updateDigestFrequencyUC,
+		SendDigestsUC:           sendDigestsUC,
+
+		SendEmailUC: sendEmailUC,
+	}
+}
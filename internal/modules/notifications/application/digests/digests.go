@@ -0,0 +1,40 @@
+package digests
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type GetDigestFrequencyUC struct {
+	repository domain.DigestsRepository
+}
+
+type UpdateDigestFrequencyUC struct {
+	repository domain.DigestsRepository
+}
+
+func NewGetDigestFrequencyUseCase(repository domain.DigestsRepository) *GetDigestFrequencyUC {
+	return &GetDigestFrequencyUC{
+		repository: repository,
+	}
+}
+
+func NewUpdateDigestFrequencyUseCase(repository domain.DigestsRepository) *UpdateDigestFrequencyUC {
+	return &UpdateDigestFrequencyUC{
+		repository: repository,
+	}
+}
+
+func (useCase *GetDigestFrequencyUC) Execute(ctx context.Context, userID uuid.UUID) (domain.Frequency, error) {
+	return useCase.repository.FindFrequency(ctx, userID)
+}
+
+func (useCase *UpdateDigestFrequencyUC) Execute(ctx context.Context, userID uuid.UUID, frequency domain.Frequency) error {
+	if !domain.IsFrequency(frequency) {
+		return domain.ErrUnknownFrequency
+	}
+
+	return useCase.repository.SaveFrequency(ctx, userID, frequency)
+}
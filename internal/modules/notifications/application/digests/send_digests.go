@@ -0,0 +1,147 @@
+package digests
+
+import (
+	"comu/internal/modules/notifications/application/emails"
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SendDigestsUC emails their digest of the previous period to the users subscribed
+// to a frequency. Each digest is sent once per period, however many times it runs.
+type SendDigestsUC struct {
+	digestsRepo       domain.DigestsRepository
+	notificationsRepo domain.NotificationsRepository
+	source            domain.DigestSource
+	userService       domain.UserService
+	sendEmailUC       *emails.SendEmailUC
+	transactor        domain.Transactor
+}
+
+func NewSendDigestsUseCase(
+	digestsRepository domain.DigestsRepository,
+	notificationsRepository domain.NotificationsRepository,
+	source domain.DigestSource,
+	userService domain.UserService,
+	sendEmailUC *emails.SendEmailUC,
+	transactor domain.Transactor,
+) *SendDigestsUC {
+	return &SendDigestsUC{
+		digestsRepo:       digestsRepository,
+		notificationsRepo: notificationsRepository,
+		source:            source,
+		userService:       userService,
+		sendEmailUC:       sendEmailUC,
+		transactor:        transactor,
+	}
+}
+
+// Execute returns how many digests were sent. A user digest failing doesn't
+// prevent the others from being sent, its error is joined to the returned one.
+func (useCase *SendDigestsUC) Execute(ctx context.Context, frequency domain.Frequency, now time.Time) (int, error) {
+	if frequency == domain.DigestOff || !domain.IsFrequency(frequency) {
+		return 0, domain.ErrUnknownFrequency
+	}
+	period := domain.PreviousDigestPeriod(frequency, now)
+
+	userIDs, err := useCase.digestsRepo.FindSubscribers(ctx, frequency)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	topPosts, err := useCase.source.TopNewPosts(ctx, period, domain.DigestTopPostsLimit)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	var errs []error
+
+	for _, userID := range userIDs {
+		ok, err := useCase.sendDigest(ctx, userID, period, topPosts)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (useCase *SendDigestsUC) sendDigest(
+	ctx context.Context, userID uuid.UUID,
+	period domain.DigestPeriod, topPosts []domain.DigestPost,
+) (bool, error) {
+	comments, err := useCase.source.NewCommentsOnPostsOf(ctx, userID, period)
+
+	if err != nil {
+		return false, err
+	}
+	unreadCount, err := useCase.notificationsRepo.CountUnread(ctx, userID)
+
+	if err != nil {
+		return false, err
+	}
+
+	// There is nothing worth an email.
+	if len(comments) == 0 && len(topPosts) == 0 && unreadCount == 0 {
+		return false, nil
+	}
+	unread, _, err := useCase.notificationsRepo.List(ctx, userID, domain.Paginator{
+		Limit:      domain.DigestNotificationsLimit,
+		UnreadOnly: true,
+	})
+
+	if err != nil {
+		return false, err
+	}
+	recipient, err := useCase.userService.GetRecipient(ctx, userID)
+
+	if err != nil {
+		return false, err
+	}
+
+	var sent bool
+
+	// The digest is marked as sent in the transaction the email is queued in,
+	// so that it is neither lost nor sent twice.
+	err = useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		marked, err := useCase.digestsRepo.MarkAsSent(ctx, userID, period)
+
+		if err != nil || !marked {
+			return err
+		}
+		sent = true
+
+		return useCase.sendEmailUC.Execute(ctx, domain.Email{
+			UserID:   userID,
+			To:       recipient.Email,
+			Category: domain.DigestCategory,
+			Template: domain.DigestTemplate,
+			Locale:   recipient.Locale,
+			Data: map[string]any{
+				"Frequency":     period.Frequency,
+				"Start":         period.Start.Format(time.DateOnly),
+				"Comments":      comments,
+				"Posts":         topPosts,
+				"Notifications": unread,
+				"UnreadCount":   unreadCount,
+			},
+		})
+	})
+
+	return sent && err == nil, err
+}
@@ -0,0 +1,136 @@
+package digests
+
+import (
+	"comu/internal/modules/notifications/application/emails"
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/modules/notifications/infra/memory"
+	"comu/internal/modules/notifications/infra/service"
+	"comu/internal/shared/database"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type emailServiceSpy struct {
+	sent []domain.Email
+}
+
+func (spy *emailServiceSpy) Send(ctx context.Context, email domain.Email) error {
+	spy.sent = append(spy.sent, email)
+	return nil
+}
+
+type userServiceStub struct{}
+
+func (userServiceStub) GetRecipient(ctx context.Context, userID uuid.UUID) (*domain.Recipient, error) {
+	return &domain.Recipient{Email: "john@doe.com", Locale: "en"}, nil
+}
+
+type digestSourceStub struct {
+	comments map[uuid.UUID][]domain.DigestComment
+	posts    []domain.DigestPost
+}
+
+func (stub *digestSourceStub) NewCommentsOnPostsOf(ctx context.Context, userID uuid.UUID, period domain.DigestPeriod) ([]domain.DigestComment, error) {
+	return stub.comments[userID], nil
+}
+
+func (stub *digestSourceStub) TopNewPosts(ctx context.Context, period domain.DigestPeriod, limit int) ([]domain.DigestPost, error) {
+	return stub.posts, nil
+}
+
+func TestSendDigestsUseCase(t *testing.T) {
+	links := service.NewUnsubscribeLinks("app-key", "http://localhost:4000")
+
+	newUseCase := func(digestsRepo domain.DigestsRepository, source domain.DigestSource, spy *emailServiceSpy) *SendDigestsUC {
+		preferencesRepo := memory.NewInMemoryPreferencesRepository(nil)
+
+		return NewSendDigestsUseCase(
+			digestsRepo,
+			memory.NewInMemoryNotificationsRepository(nil),
+			source,
+			userServiceStub{},
+			emails.NewSendEmailUseCase(spy, preferencesRepo, links),
+			database.NoopTransactor{},
+		)
+	}
+
+	t.Run("it should send the digest of each subscriber once per period", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		spy := &emailServiceSpy{}
+		digestsRepo := memory.NewInMemoryDigestsRepository(nil)
+		daily, weekly := uuid.New(), uuid.New()
+
+		digestsRepo.SaveFrequency(ctx, daily, domain.DailyDigest)
+		digestsRepo.SaveFrequency(ctx, weekly, domain.WeeklyDigest)
+
+		useCase := newUseCase(digestsRepo, &digestSourceStub{
+			posts: []domain.DigestPost{{Title: "Hello world", Slug: "hello-world"}},
+		}, spy)
+		now := time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC)
+
+		sent, err := useCase.Execute(ctx, domain.DailyDigest, now)
+
+		if _assert.NoError(err) && _assert.Equal(1, sent) && _assert.Len(spy.sent, 1) {
+			_assert.Equal(daily, spy.sent[0].UserID)
+			_assert.Equal(domain.DigestCategory, spy.sent[0].Category)
+			_assert.Equal(domain.DigestTemplate, spy.sent[0].Template)
+			_assert.NotEmpty(spy.sent[0].UnsubscribeURL)
+		}
+
+		// As when the job restarts later in the same day.
+		sent, err = useCase.Execute(ctx, domain.DailyDigest, now.Add(2*time.Hour))
+
+		_assert.NoError(err)
+		_assert.Zero(sent)
+		_assert.Len(spy.sent, 1)
+
+		sent, err = useCase.Execute(ctx, domain.DailyDigest, now.AddDate(0, 0, 1))
+
+		_assert.NoError(err)
+		_assert.Equal(1, sent)
+		_assert.Len(spy.sent, 2)
+	})
+
+	t.Run("it should not send empty digests", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		spy := &emailServiceSpy{}
+		digestsRepo := memory.NewInMemoryDigestsRepository(nil)
+		userID, commented := uuid.New(), uuid.New()
+
+		digestsRepo.SaveFrequency(ctx, userID, domain.WeeklyDigest)
+		digestsRepo.SaveFrequency(ctx, commented, domain.WeeklyDigest)
+
+		useCase := newUseCase(digestsRepo, &digestSourceStub{
+			comments: map[uuid.UUID][]domain.DigestComment{
+				commented: {{PostTitle: "Hello world", Content: "Nice post"}},
+			},
+		}, spy)
+
+		sent, err := useCase.Execute(ctx, domain.WeeklyDigest, time.Now())
+
+		if _assert.NoError(err) && _assert.Equal(1, sent) && _assert.Len(spy.sent, 1) {
+			_assert.Equal(commented, spy.sent[0].UserID)
+		}
+	})
+}
+
+func TestPreviousDigestPeriod(t *testing.T) {
+	_assert := assert.New(t)
+	// A wednesday
+	now := time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC)
+
+	daily := domain.PreviousDigestPeriod(domain.DailyDigest, now)
+	_assert.Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), daily.Start)
+	_assert.Equal(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), daily.End)
+
+	weekly := domain.PreviousDigestPeriod(domain.WeeklyDigest, now)
+	_assert.Equal(time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), weekly.Start)
+	_assert.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), weekly.End)
+	_assert.NotEqual(daily.Key(), weekly.Key())
+}
@@ -0,0 +1,55 @@
+package emails
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type SendEmailUC struct {
+	emailService     domain.EmailService
+	preferencesRepo  domain.PreferencesRepository
+	unsubscribeLinks domain.UnsubscribeLinks
+}
+
+func NewSendEmailUseCase(
+	emailService domain.EmailService,
+	preferencesRepository domain.PreferencesRepository,
+	unsubscribeLinks domain.UnsubscribeLinks,
+) *SendEmailUC {
+	return &SendEmailUC{
+		emailService:     emailService,
+		preferencesRepo:  preferencesRepository,
+		unsubscribeLinks: unsubscribeLinks,
+	}
+}
+
+// Execute sends the email, unless the receiver turned the emails of its category
+// off. The emails which aren't forced get a link to unsubscribe from their category.
+func (useCase *SendEmailUC) Execute(ctx context.Context, email domain.Email) error {
+	if !slices.Contains(domain.Categories, email.Category) {
+		return domain.ErrUnknownCategory
+	}
+
+	if domain.IsForced(email.Category) {
+		return useCase.emailService.Send(ctx, email)
+	}
+
+	if email.UserID == uuid.Nil {
+		return domain.ErrUnsubscribableEmail
+	}
+	preferences, err := useCase.preferencesRepo.FindByUser(ctx, email.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	if !domain.IsEnabled(preferences, email.Category, domain.EmailChannel) {
+		return nil
+	}
+	email.UnsubscribeURL = useCase.unsubscribeLinks.URL(email.UserID, email.Category)
+
+	return useCase.emailService.Send(ctx, email)
+}
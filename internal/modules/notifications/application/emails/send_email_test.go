@@ -0,0 +1,76 @@
+package emails
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/modules/notifications/infra/memory"
+	"comu/internal/modules/notifications/infra/service"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type emailServiceSpy struct {
+	sent []domain.Email
+}
+
+func (spy *emailServiceSpy) Send(ctx context.Context, email domain.Email) error {
+	spy.sent = append(spy.sent, email)
+	return nil
+}
+
+func TestSendEmailUseCase(t *testing.T) {
+	links := service.NewUnsubscribeLinks("app-key", "http://localhost:4000")
+
+	t.Run("it should always send the security emails, without unsubscribe link", func(t *testing.T) {
+		_assert := assert.New(t)
+		spy := &emailServiceSpy{}
+		useCase := NewSendEmailUseCase(spy, memory.NewInMemoryPreferencesRepository(nil), links)
+
+		err := useCase.Execute(context.Background(), domain.Email{
+			To:       "john@doe.com",
+			Category: domain.SecurityCategory,
+			Template: "password_changed",
+		})
+
+		if _assert.NoError(err) && _assert.Len(spy.sent, 1) {
+			_assert.Empty(spy.sent[0].UnsubscribeURL)
+		}
+	})
+
+	t.Run("it should fail when the category doesn't exist", func(t *testing.T) {
+		useCase := NewSendEmailUseCase(&emailServiceSpy{}, memory.NewInMemoryPreferencesRepository(nil), links)
+
+		err := useCase.Execute(context.Background(), domain.Email{To: "john@doe.com", Template: "password_changed"})
+		assert.ErrorIs(t, err, domain.ErrUnknownCategory)
+	})
+
+	t.Run("it should send the other emails with an unsubscribe link, unless turned off", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		spy := &emailServiceSpy{}
+		preferencesRepo := memory.NewInMemoryPreferencesRepository(nil)
+		useCase := NewSendEmailUseCase(spy, preferencesRepo, links)
+
+		email := domain.Email{
+			UserID:   uuid.New(),
+			To:       "john@doe.com",
+			Category: domain.CommentsCategory,
+			Template: "post_commented",
+		}
+
+		if _assert.NoError(useCase.Execute(ctx, email)) && _assert.Len(spy.sent, 1) {
+			_assert.Equal(links.URL(email.UserID, email.Category), spy.sent[0].UnsubscribeURL)
+		}
+
+		preferencesRepo.Save(ctx, email.UserID, domain.Preference{
+			Category: domain.CommentsCategory,
+			Channel:  domain.EmailChannel,
+			Enabled:  false,
+		})
+
+		_assert.NoError(useCase.Execute(ctx, email))
+		_assert.Len(spy.sent, 1)
+	})
+}
@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type CreateNotificationInput struct {
+	UserID   uuid.UUID
+	Type     string
+	Category domain.Category
+	Data     map[string]any
+}
+
+type CreateNotificationUC struct {
+	repository      domain.NotificationsRepository
+	preferencesRepo domain.PreferencesRepository
+	hub             domain.EventHub
+	transactor      domain.Transactor
+}
+
+func NewCreateNotificationUseCase(
+	repository domain.NotificationsRepository,
+	preferencesRepository domain.PreferencesRepository,
+	hub domain.EventHub,
+	transactor domain.Transactor,
+) *CreateNotificationUC {
+	return &CreateNotificationUC{
+		repository:      repository,
+		preferencesRepo: preferencesRepository,
+		hub:             hub,
+		transactor:      transactor,
+	}
+}
+
+// Execute stores the notification and pushes it to the user stream. It returns
+// no notification when the user turned the in-app ones of its category off.
+// Within a transaction, the notification is only pushed once it is committed,
+// so that the user is never sent a notification which was rolled back.
+func (useCase *CreateNotificationUC) Execute(ctx context.Context, input CreateNotificationInput) (*domain.Notification, error) {
+	if !slices.Contains(domain.Categories, input.Category) {
+		return nil, domain.ErrUnknownCategory
+	}
+	preferences, err := useCase.preferencesRepo.FindByUser(ctx, input.UserID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.IsEnabled(preferences, input.Category, domain.InAppChannel) {
+		return nil, nil
+	}
+	notification := domain.NewNotification(input.UserID, input.Type, input.Data)
+
+	if err := useCase.repository.Store(ctx, notification); err != nil {
+		return nil, err
+	}
+	useCase.transactor.AfterCommit(ctx, func() {
+		useCase.hub.Publish([]uuid.UUID{notification.UserID}, domain.NotificationEvent, *notification)
+	})
+
+	return notification, nil
+}
@@ -0,0 +1,50 @@
+package notifications
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ListNotificationsOutput struct {
+	Notifications []domain.Notification
+	Next          *domain.Cursor
+	UnreadCount   int
+}
+
+type ListNotificationsUC struct {
+	repository domain.NotificationsRepository
+}
+
+func NewListNotificationsUseCase(repository domain.NotificationsRepository) *ListNotificationsUC {
+	return &ListNotificationsUC{
+		repository: repository,
+	}
+}
+
+// Execute returns a page of the user notifications, from the newest to the oldest,
+// along with the count of the unread ones.
+func (useCase *ListNotificationsUC) Execute(ctx context.Context, userID uuid.UUID, paginator domain.Paginator) (*ListNotificationsOutput, error) {
+	if paginator.Limit <= 0 {
+		paginator.Limit = domain.DefaultPaginatorLimit
+	}
+	paginator.Limit = min(paginator.Limit, domain.MaxPaginatorLimit)
+
+	notifications, next, err := useCase.repository.List(ctx, userID, paginator)
+
+	if err != nil {
+		return nil, err
+	}
+	unreadCount, err := useCase.repository.CountUnread(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListNotificationsOutput{
+		Notifications: notifications,
+		Next:          next,
+		UnreadCount:   unreadCount,
+	}, nil
+}
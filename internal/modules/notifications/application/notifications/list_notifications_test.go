@@ -0,0 +1,172 @@
+package notifications
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/modules/notifications/infra/memory"
+	"comu/internal/modules/notifications/infra/stream"
+	"comu/internal/shared/database"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListNotificationsUseCase(t *testing.T) {
+
+	t.Run("it should return the notifications page along with the unread count", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemoryNotificationsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		createNotificationUC := NewCreateNotificationUseCase(repo, memory.NewInMemoryPreferencesRepository(nil), stream.NewHub(0, 0), database.NoopTransactor{})
+		markAsReadUC := NewMarkAsReadUseCase(repo)
+		listNotificationsUC := NewListNotificationsUseCase(repo)
+
+		for range domain.DefaultPaginatorLimit + 1 {
+			_, err := createNotificationUC.Execute(ctx, CreateNotificationInput{
+				UserID:   userID,
+				Type:     "post_commented",
+				Category: domain.CommentsCategory,
+			})
+			_assert.NoError(err)
+		}
+		output, _ := listNotificationsUC.Execute(ctx, userID, domain.Paginator{})
+		_assert.NoError(markAsReadUC.Execute(ctx, output.Notifications[0].ID, userID))
+
+		output, err := listNotificationsUC.Execute(ctx, userID, domain.Paginator{})
+
+		if _assert.NoError(err) {
+			_assert.Len(output.Notifications, domain.DefaultPaginatorLimit)
+			_assert.NotNil(output.Next)
+			_assert.Equal(domain.DefaultPaginatorLimit, output.UnreadCount)
+			_assert.True(output.Notifications[0].IsRead())
+		}
+	})
+
+	t.Run("it should mark all the user notifications as read", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemoryNotificationsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		createNotificationUC := NewCreateNotificationUseCase(repo, memory.NewInMemoryPreferencesRepository(nil), stream.NewHub(0, 0), database.NoopTransactor{})
+		markAllAsReadUC := NewMarkAllAsReadUseCase(repo)
+		listNotificationsUC := NewListNotificationsUseCase(repo)
+
+		createNotificationUC.Execute(ctx, CreateNotificationInput{UserID: userID, Type: "post_commented", Category: domain.CommentsCategory})
+		createNotificationUC.Execute(ctx, CreateNotificationInput{UserID: uuid.New(), Type: "post_commented", Category: domain.CommentsCategory})
+
+		_assert.NoError(markAllAsReadUC.Execute(ctx, userID))
+		output, err := listNotificationsUC.Execute(ctx, userID, domain.Paginator{UnreadOnly: true})
+
+		if _assert.NoError(err) {
+			_assert.Empty(output.Notifications)
+			_assert.Zero(output.UnreadCount)
+		}
+	})
+}
+
+func TestCreateNotificationUseCase(t *testing.T) {
+	_assert := assert.New(t)
+	repo := memory.NewInMemoryNotificationsRepository(nil)
+	hub := stream.NewHub(0, 0)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	sub, _ := hub.Subscribe(userID, 0)
+	defer sub.Close()
+
+	useCase := NewCreateNotificationUseCase(repo, memory.NewInMemoryPreferencesRepository(nil), hub, database.NoopTransactor{})
+	notification, err := useCase.Execute(ctx, CreateNotificationInput{
+		UserID:   userID,
+		Type:     "post_commented",
+		Category: domain.CommentsCategory,
+	})
+
+	if _assert.NoError(err) {
+		event := <-sub.Events()
+		_assert.Equal(domain.NotificationEvent, event.Type)
+		_assert.Equal(*notification, event.Data)
+	}
+}
+
+// transactorSpy runs the functions without transaction, and holds the
+// after commit ones until commit is called.
+type transactorSpy struct {
+	afterCommit []func()
+}
+
+func (spy *transactorSpy) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (spy *transactorSpy) AfterCommit(ctx context.Context, fn func()) {
+	spy.afterCommit = append(spy.afterCommit, fn)
+}
+
+func (spy *transactorSpy) commit() {
+	for _, fn := range spy.afterCommit {
+		fn()
+	}
+}
+
+func TestCreateNotificationUseCaseWithinTransaction(t *testing.T) {
+	_assert := assert.New(t)
+	repo := memory.NewInMemoryNotificationsRepository(nil)
+	hub := stream.NewHub(0, 0)
+	transactor := &transactorSpy{}
+	ctx := context.Background()
+	userID := uuid.New()
+
+	sub, _ := hub.Subscribe(userID, 0)
+	defer sub.Close()
+
+	useCase := NewCreateNotificationUseCase(repo, memory.NewInMemoryPreferencesRepository(nil), hub, transactor)
+	notification, err := useCase.Execute(ctx, CreateNotificationInput{
+		UserID:   userID,
+		Type:     "post_commented",
+		Category: domain.CommentsCategory,
+	})
+
+	if _assert.NoError(err) {
+		select {
+		case <-sub.Events():
+			_assert.Fail("the notification was published before the transaction was committed")
+		default:
+		}
+		transactor.commit()
+
+		event := <-sub.Events()
+		_assert.Equal(domain.NotificationEvent, event.Type)
+		_assert.Equal(*notification, event.Data)
+	}
+}
+
+func TestCreateNotificationUseCaseWithPreferences(t *testing.T) {
+	_assert := assert.New(t)
+	repo := memory.NewInMemoryNotificationsRepository(nil)
+	preferencesRepo := memory.NewInMemoryPreferencesRepository(nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	preferencesRepo.Save(ctx, userID, domain.Preference{
+		Category: domain.CommentsCategory,
+		Channel:  domain.InAppChannel,
+		Enabled:  false,
+	})
+	useCase := NewCreateNotificationUseCase(repo, preferencesRepo, stream.NewHub(0, 0), database.NoopTransactor{})
+
+	notification, err := useCase.Execute(ctx, CreateNotificationInput{
+		UserID:   userID,
+		Type:     "post_commented",
+		Category: domain.CommentsCategory,
+	})
+
+	if _assert.NoError(err) {
+		_assert.Nil(notification)
+		count, _ := repo.CountUnread(ctx, userID)
+		_assert.Zero(count)
+	}
+}
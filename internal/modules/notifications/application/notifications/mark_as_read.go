@@ -0,0 +1,36 @@
+package notifications
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type MarkAsReadUC struct {
+	repository domain.NotificationsRepository
+}
+
+type MarkAllAsReadUC struct {
+	repository domain.NotificationsRepository
+}
+
+func NewMarkAsReadUseCase(repository domain.NotificationsRepository) *MarkAsReadUC {
+	return &MarkAsReadUC{
+		repository: repository,
+	}
+}
+
+func NewMarkAllAsReadUseCase(repository domain.NotificationsRepository) *MarkAllAsReadUC {
+	return &MarkAllAsReadUC{
+		repository: repository,
+	}
+}
+
+func (useCase *MarkAsReadUC) Execute(ctx context.Context, notificationID, userID uuid.UUID) error {
+	return useCase.repository.MarkAsRead(ctx, notificationID, userID)
+}
+
+func (useCase *MarkAllAsReadUC) Execute(ctx context.Context, userID uuid.UUID) error {
+	return useCase.repository.MarkAllAsRead(ctx, userID)
+}
@@ -0,0 +1,61 @@
+package notifications
+
+import (
+	"comu/internal/modules/notifications/application/emails"
+	"comu/internal/modules/notifications/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotifyInput struct {
+	UserID   uuid.UUID
+	Type     string
+	Category domain.Category
+	Data     map[string]any
+}
+
+// NotifyUC notifies a user through every channel they didn't turn off for the
+// category: in the app, and by email with the template named after the type.
+type NotifyUC struct {
+	createNotificationUC *CreateNotificationUC
+	sendEmailUC          *emails.SendEmailUC
+	userService          domain.UserService
+}
+
+func NewNotifyUseCase(
+	createNotificationUC *CreateNotificationUC,
+	sendEmailUC *emails.SendEmailUC,
+	userService domain.UserService,
+) *NotifyUC {
+	return &NotifyUC{
+		createNotificationUC: createNotificationUC,
+		sendEmailUC:          sendEmailUC,
+		userService:          userService,
+	}
+}
+
+func (useCase *NotifyUC) Execute(ctx context.Context, input NotifyInput) error {
+	if _, err := useCase.createNotificationUC.Execute(ctx, CreateNotificationInput{
+		UserID:   input.UserID,
+		Type:     input.Type,
+		Category: input.Category,
+		Data:     input.Data,
+	}); err != nil {
+		return err
+	}
+	recipient, err := useCase.userService.GetRecipient(ctx, input.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	return useCase.sendEmailUC.Execute(ctx, domain.Email{
+		UserID:   input.UserID,
+		To:       recipient.Email,
+		Category: input.Category,
+		Template: input.Type,
+		Locale:   recipient.Locale,
+		Data:     input.Data,
+	})
+}
@@ -0,0 +1,84 @@
+package preferences
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UpdatePreferenceInput struct {
+	UserID   uuid.UUID
+	Category domain.Category
+	Channel  domain.Channel
+	Enabled  bool
+}
+
+type GetPreferencesUC struct {
+	repository domain.PreferencesRepository
+}
+
+type UpdatePreferenceUC struct {
+	repository domain.PreferencesRepository
+}
+
+type UnsubscribeUC struct {
+	repository       domain.PreferencesRepository
+	unsubscribeLinks domain.UnsubscribeLinks
+}
+
+func NewGetPreferencesUseCase(repository domain.PreferencesRepository) *GetPreferencesUC {
+	return &GetPreferencesUC{
+		repository: repository,
+	}
+}
+
+func NewUpdatePreferenceUseCase(repository domain.PreferencesRepository) *UpdatePreferenceUC {
+	return &UpdatePreferenceUC{
+		repository: repository,
+	}
+}
+
+func NewUnsubscribeUseCase(repository domain.PreferencesRepository, unsubscribeLinks domain.UnsubscribeLinks) *UnsubscribeUC {
+	return &UnsubscribeUC{
+		repository:       repository,
+		unsubscribeLinks: unsubscribeLinks,
+	}
+}
+
+// Execute returns the preference of every category and channel.
+func (useCase *GetPreferencesUC) Execute(ctx context.Context, userID uuid.UUID) ([]domain.Preference, error) {
+	chosen, err := useCase.repository.FindByUser(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.ResolvePreferences(chosen), nil
+}
+
+func (useCase *UpdatePreferenceUC) Execute(ctx context.Context, input UpdatePreferenceInput) error {
+	preference, err := domain.NewPreference(input.Category, input.Channel, input.Enabled)
+
+	if err != nil {
+		return err
+	}
+
+	return useCase.repository.Save(ctx, input.UserID, *preference)
+}
+
+// Execute turns off the emails of the category the unsubscribe token was made for.
+func (useCase *UnsubscribeUC) Execute(ctx context.Context, token string) error {
+	userID, category, err := useCase.unsubscribeLinks.Verify(token)
+
+	if err != nil {
+		return err
+	}
+	preference, err := domain.NewPreference(category, domain.EmailChannel, false)
+
+	if err != nil {
+		return err
+	}
+
+	return useCase.repository.Save(ctx, userID, *preference)
+}
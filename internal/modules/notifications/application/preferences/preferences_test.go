@@ -0,0 +1,88 @@
+package preferences
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/modules/notifications/infra/memory"
+	"comu/internal/modules/notifications/infra/service"
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePreferenceUseCase(t *testing.T) {
+
+	t.Run("it should refuse to turn the security notifications off", func(t *testing.T) {
+		useCase := NewUpdatePreferenceUseCase(memory.NewInMemoryPreferencesRepository(nil))
+
+		err := useCase.Execute(context.Background(), UpdatePreferenceInput{
+			UserID:   uuid.New(),
+			Category: domain.SecurityCategory,
+			Channel:  domain.EmailChannel,
+		})
+		assert.ErrorIs(t, err, domain.ErrForcedPreference)
+	})
+
+	t.Run("it should fail when the channel doesn't exist", func(t *testing.T) {
+		useCase := NewUpdatePreferenceUseCase(memory.NewInMemoryPreferencesRepository(nil))
+
+		err := useCase.Execute(context.Background(), UpdatePreferenceInput{
+			UserID:   uuid.New(),
+			Category: domain.CommentsCategory,
+			Channel:  "sms",
+		})
+		assert.ErrorIs(t, err, domain.ErrUnknownChannel)
+	})
+
+	t.Run("it should return the updated preferences", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		repo := memory.NewInMemoryPreferencesRepository(nil)
+		userID := uuid.New()
+
+		err := NewUpdatePreferenceUseCase(repo).Execute(ctx, UpdatePreferenceInput{
+			UserID:   userID,
+			Category: domain.CommentsCategory,
+			Channel:  domain.InAppChannel,
+		})
+		preferences, _ := NewGetPreferencesUseCase(repo).Execute(ctx, userID)
+
+		if _assert.NoError(err) {
+			_assert.Len(preferences, len(domain.Categories)*len(domain.Channels))
+			_assert.Contains(preferences, domain.Preference{
+				Category: domain.CommentsCategory,
+				Channel:  domain.InAppChannel,
+				Enabled:  false,
+			})
+		}
+	})
+}
+
+func TestUnsubscribeUseCase(t *testing.T) {
+	links := service.NewUnsubscribeLinks("app-key", "http://localhost:4000")
+
+	t.Run("it should fail and return ErrInvalidUnsubscribeToken", func(t *testing.T) {
+		useCase := NewUnsubscribeUseCase(memory.NewInMemoryPreferencesRepository(nil), links)
+
+		err := useCase.Execute(context.Background(), "invalid")
+		assert.ErrorIs(t, err, domain.ErrInvalidUnsubscribeToken)
+	})
+
+	t.Run("it should turn the emails of the token category off", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		repo := memory.NewInMemoryPreferencesRepository(nil)
+		userID := uuid.New()
+
+		link, _ := url.Parse(links.URL(userID, domain.CommentsCategory))
+		err := NewUnsubscribeUseCase(repo, links).Execute(ctx, link.Query().Get("token"))
+
+		if _assert.NoError(err) {
+			chosen, _ := repo.FindByUser(ctx, userID)
+			_assert.False(domain.IsEnabled(chosen, domain.CommentsCategory, domain.EmailChannel))
+			_assert.True(domain.IsEnabled(chosen, domain.CommentsCategory, domain.InAppChannel))
+		}
+	})
+}
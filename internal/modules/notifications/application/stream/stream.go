@@ -0,0 +1,52 @@
+package stream
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PublishEventInput struct {
+	UserIDs []uuid.UUID
+	Type    string
+	Data    any
+}
+
+type PublishEventUC struct {
+	hub        domain.EventHub
+	transactor domain.Transactor
+}
+
+type SubscribeUC struct {
+	hub domain.EventHub
+}
+
+func NewPublishEventUseCase(hub domain.EventHub, transactor domain.Transactor) *PublishEventUC {
+	return &PublishEventUC{
+		hub:        hub,
+		transactor: transactor,
+	}
+}
+
+func NewSubscribeUseCase(hub domain.EventHub) *SubscribeUC {
+	return &SubscribeUC{
+		hub: hub,
+	}
+}
+
+// Execute pushes the event to the users streams, once the transaction carried
+// by the context is committed if any.
+func (useCase *PublishEventUC) Execute(ctx context.Context, input PublishEventInput) {
+	if len(input.UserIDs) == 0 {
+		return
+	}
+
+	useCase.transactor.AfterCommit(ctx, func() {
+		useCase.hub.Publish(input.UserIDs, input.Type, input.Data)
+	})
+}
+
+func (useCase *SubscribeUC) Execute(userID uuid.UUID, lastEventID uint64) (domain.Subscription, error) {
+	return useCase.hub.Subscribe(userID, lastEventID)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Frequency = string
+
+const (
+	DigestOff    Frequency = "off"
+	DailyDigest  Frequency = "daily"
+	WeeklyDigest Frequency = "weekly"
+)
+
+const (
+	DigestTemplate           = "digest"
+	DigestTopPostsLimit      = 5
+	DigestNotificationsLimit = 10
+	// DigestCheckInterval is how often the job looks for the digests to send.
+	// Sending them is idempotent, so checking more often than they are due is fine.
+	DigestCheckInterval = time.Hour
+)
+
+var (
+	Frequencies = []Frequency{DigestOff, DailyDigest, WeeklyDigest}
+
+	ErrUnknownFrequency = errors.New("this digest frequency doesn't exist")
+)
+
+func IsFrequency(frequency Frequency) bool {
+	return slices.Contains(Frequencies, frequency)
+}
+
+// DigestPeriod is the time range a digest is about, from Start included to End excluded.
+type DigestPeriod struct {
+	Frequency Frequency
+	Start     time.Time
+	End       time.Time
+}
+
+// PreviousDigestPeriod returns the last period which ended before now: the previous
+// day, or the previous week starting on monday, in UTC.
+func PreviousDigestPeriod(frequency Frequency, now time.Time) DigestPeriod {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if frequency == WeeklyDigest {
+		end = end.AddDate(0, 0, -((int(end.Weekday()) + 6) % 7))
+		return DigestPeriod{Frequency: frequency, Start: end.AddDate(0, 0, -7), End: end}
+	}
+
+	return DigestPeriod{Frequency: frequency, Start: end.AddDate(0, 0, -1), End: end}
+}
+
+// Key identifies the period among the ones of its frequency.
+func (period DigestPeriod) Key() string {
+	return period.Frequency + ":" + period.Start.Format(time.DateOnly)
+}
+
+func (period DigestPeriod) Contains(t time.Time) bool {
+	return !t.Before(period.Start) && t.Before(period.End)
+}
+
+type DigestComment struct {
+	PostTitle string
+	PostSlug  string
+	Content   string
+	CreatedAt time.Time
+}
+
+type DigestPost struct {
+	Title         string
+	Slug          string
+	CommentsCount int
+	CreatedAt     time.Time
+}
+
+// DigestSource provides the community activity the digests are made of. It is
+// implemented by the modules owning that activity.
+type DigestSource interface {
+	// NewCommentsOnPostsOf returns the comments made by others on the user posts during the period.
+	NewCommentsOnPostsOf(ctx context.Context, userID uuid.UUID, period DigestPeriod) ([]DigestComment, error)
+	// TopNewPosts returns the posts created during the period, the most commented first.
+	TopNewPosts(ctx context.Context, period DigestPeriod, limit int) ([]DigestPost, error)
+}
+
+type DigestsRepository interface {
+	// FindFrequency returns DigestOff when the user never opted in.
+	FindFrequency(ctx context.Context, userID uuid.UUID) (Frequency, error)
+	SaveFrequency(ctx context.Context, userID uuid.UUID, frequency Frequency) error
+	FindSubscribers(ctx context.Context, frequency Frequency) ([]uuid.UUID, error)
+	// MarkAsSent records the user digest of the period, reporting false when it
+	// already was. It joins the transaction carried by the context, if any.
+	MarkAsSent(ctx context.Context, userID uuid.UUID, period DigestPeriod) (bool, error)
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("the notification you're looking for doesn't exist")
+	ErrInvalidCursor        = errors.New("the pagination cursor is invalid")
+)
+
+const (
+	DefaultPaginatorLimit = 20
+	MaxPaginatorLimit     = 100
+)
+
+// Notification is an in-app notification. Its type tells the clients how to display
+// it, and its data holds what they need to, like the ids of the related resources.
+type Notification struct {
+	ID        uuid.UUID      `json:"id"`
+	UserID    uuid.UUID      `json:"-"`
+	Type      string         `json:"type"`
+	Data      map[string]any `json:"data"`
+	ReadAt    *time.Time     `json:"read_at"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func NewNotification(userID uuid.UUID, notificationType string, data map[string]any) *Notification {
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	return &Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (notification *Notification) IsRead() bool {
+	return notification.ReadAt != nil
+}
+
+type Cursor struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+}
+
+func (c *Cursor) ToBase64() (string, error) {
+	rawBytes, err := json.Marshal(*c)
+
+	if err != nil {
+		return "", err
+	}
+	return base64.RawStdEncoding.EncodeToString(rawBytes), nil
+}
+
+func CursorFromBase64(value string) (*Cursor, error) {
+	rawBytes, err := base64.RawStdEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+
+	if err := json.Unmarshal(rawBytes, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+type Paginator struct {
+	Limit      int
+	After      *Cursor
+	UnreadOnly bool
+}
+
+// Email is an email rendered from a template, in the given locale. The emails
+// of the categories which aren't forced are only sent to users who want them,
+// which UserID identifies, and carry a link to unsubscribe from them.
+type Email struct {
+	UserID         uuid.UUID
+	To             string
+	Category       Category
+	Template       string
+	Locale         string
+	Data           map[string]any
+	UnsubscribeURL string
+}
+
+type NotificationsRepository interface {
+	List(ctx context.Context, userID uuid.UUID, paginator Paginator) ([]Notification, *Cursor, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	Store(context.Context, *Notification) error
+	// MarkAsRead returns ErrNotificationNotFound when the notification doesn't belong to the user.
+	MarkAsRead(ctx context.Context, id, userID uuid.UUID) error
+	MarkAllAsRead(ctx context.Context, userID uuid.UUID) error
+}
+
+// Transactor runs a function inside a transaction, committed when the function succeeds.
+// The repositories join the transaction through the context given to the function.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
+	// AfterCommit runs fn once the transaction carried by the context is committed,
+	// or right away when there is none.
+	AfterCommit(ctx context.Context, fn func())
+}
+
+type EmailService interface {
+	// Send queues the email, within the transaction carried by the context if any.
+	Send(context.Context, Email) error
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type Channel = string
+
+const (
+	EmailChannel Channel = "email"
+	InAppChannel Channel = "in_app"
+)
+
+// Category groups the notifications a user can turn on or off together.
+type Category = string
+
+const (
+	// SecurityCategory holds the security emails, like the OTP codes and the
+	// password changes, which can't be turned off.
+	SecurityCategory Category = "security"
+	CommentsCategory Category = "comments"
+	DigestCategory   Category = "digest"
+)
+
+var (
+	Channels   = []Channel{EmailChannel, InAppChannel}
+	Categories = []Category{SecurityCategory, CommentsCategory, DigestCategory}
+)
+
+var (
+	ErrUnknownChannel          = errors.New("this notification channel doesn't exist")
+	ErrUnknownCategory         = errors.New("this notification category doesn't exist")
+	ErrForcedPreference        = errors.New("security notifications can't be turned off")
+	ErrInvalidUnsubscribeToken = errors.New("the unsubscribe link is invalid")
+	ErrUnsubscribableEmail     = errors.New("an email that can be unsubscribed from needs a receiver user id")
+)
+
+// IsForced reports whether the notifications of the category are always sent.
+func IsForced(category Category) bool {
+	return category == SecurityCategory
+}
+
+type Preference struct {
+	Category Category `json:"category"`
+	Channel  Channel  `json:"channel"`
+	Enabled  bool     `json:"enabled"`
+}
+
+func NewPreference(category Category, channel Channel, enabled bool) (*Preference, error) {
+	if !slices.Contains(Categories, category) {
+		return nil, ErrUnknownCategory
+	}
+
+	if !slices.Contains(Channels, channel) {
+		return nil, ErrUnknownChannel
+	}
+
+	if IsForced(category) && !enabled {
+		return nil, ErrForcedPreference
+	}
+
+	return &Preference{Category: category, Channel: channel, Enabled: enabled}, nil
+}
+
+// ResolvePreferences returns the preference of every category and channel. The ones
+// the user didn't choose are enabled, and the forced ones are always enabled.
+func ResolvePreferences(chosen []Preference) []Preference {
+	preferences := []Preference{}
+
+	for _, category := range Categories {
+		for _, channel := range Channels {
+			preference := Preference{Category: category, Channel: channel, Enabled: true}
+
+			if !IsForced(category) {
+				for _, p := range chosen {
+					if p.Category == category && p.Channel == channel {
+						preference.Enabled = p.Enabled
+					}
+				}
+			}
+			preferences = append(preferences, preference)
+		}
+	}
+
+	return preferences
+}
+
+// IsEnabled reports whether the notifications of the category are sent through the
+// channel, given the preferences the user chose.
+func IsEnabled(chosen []Preference, category Category, channel Channel) bool {
+	for _, preference := range ResolvePreferences(chosen) {
+		if preference.Category == category && preference.Channel == channel {
+			return preference.Enabled
+		}
+	}
+
+	return false
+}
+
+type PreferencesRepository interface {
+	// FindByUser returns the preferences the user chose.
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]Preference, error)
+	// Save creates or replaces the user preference of the category and channel.
+	Save(ctx context.Context, userID uuid.UUID, preference Preference) error
+}
+
+// UnsubscribeLinks makes the signed links letting the users turn the emails of a
+// category off without logging in.
+type UnsubscribeLinks interface {
+	URL(userID uuid.UUID, category Category) string
+	Verify(token string) (uuid.UUID, Category, error)
+}
+
+// Recipient is the user a notification is emailed to.
+type Recipient struct {
+	Email  string
+	Locale string
+}
+
+type UserService interface {
+	GetRecipient(ctx context.Context, userID uuid.UUID) (*Recipient, error)
+}
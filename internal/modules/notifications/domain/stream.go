@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationEvent = "notification"
+
+	// StreamHeartbeatInterval is how often an idle stream sends a comment, keeping
+	// proxies from closing the connection.
+	StreamHeartbeatInterval = 15 * time.Second
+	// StreamRetryDelay is how long the clients wait before reconnecting to a closed stream.
+	StreamRetryDelay = 3 * time.Second
+)
+
+// StreamEvent is an event pushed to the users connected to the notification stream.
+// Its ids increase, so a client reconnecting with the last one it received can be
+// sent the events it missed.
+type StreamEvent struct {
+	ID        uint64
+	Type      string
+	Data      any
+	CreatedAt time.Time
+}
+
+type Subscription interface {
+	// Events is closed when the subscription ends, either because it is closed,
+	// because the client didn't keep up with its events or because the hub shuts down.
+	Events() <-chan StreamEvent
+	Close()
+}
+
+type EventHub interface {
+	Publish(userIDs []uuid.UUID, eventType string, data any)
+	// Subscribe starts with the events published after lastEventID to the user
+	// which are still in the hub history, when lastEventID isn't zero.
+	Subscribe(userID uuid.UUID, lastEventID uint64) (Subscription, error)
+}
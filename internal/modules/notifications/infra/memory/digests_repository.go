@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type frequencyStore map[uuid.UUID]domain.Frequency
+
+type inMemoryDigestsRepository struct {
+	frequencies frequencyStore
+	sent        map[uuid.UUID]map[string]bool
+	sync.Mutex
+}
+
+func NewInMemoryDigestsRepository(initialStore frequencyStore) *inMemoryDigestsRepository {
+	if initialStore == nil {
+		initialStore = make(frequencyStore)
+	}
+
+	return &inMemoryDigestsRepository{
+		frequencies: initialStore,
+		sent:        make(map[uuid.UUID]map[string]bool),
+	}
+}
+
+func (repo *inMemoryDigestsRepository) FindFrequency(ctx context.Context, userID uuid.UUID) (domain.Frequency, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if frequency, ok := repo.frequencies[userID]; ok {
+		return frequency, nil
+	}
+
+	return domain.DigestOff, nil
+}
+
+func (repo *inMemoryDigestsRepository) SaveFrequency(ctx context.Context, userID uuid.UUID, frequency domain.Frequency) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.frequencies[userID] = frequency
+
+	return nil
+}
+
+func (repo *inMemoryDigestsRepository) FindSubscribers(ctx context.Context, frequency domain.Frequency) ([]uuid.UUID, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	userIDs := []uuid.UUID{}
+
+	for userID, f := range repo.frequencies {
+		if f == frequency {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	return userIDs, nil
+}
+
+func (repo *inMemoryDigestsRepository) MarkAsSent(ctx context.Context, userID uuid.UUID, period domain.DigestPeriod) (bool, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if repo.sent[userID] == nil {
+		repo.sent[userID] = make(map[string]bool)
+	}
+
+	if repo.sent[userID][period.Key()] {
+		return false, nil
+	}
+	repo.sent[userID][period.Key()] = true
+
+	return true, nil
+}
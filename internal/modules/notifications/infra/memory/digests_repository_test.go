@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryDigestsRepository(t *testing.T) {
+
+	t.Run("it should return the users subscribed to the frequency", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryDigestsRepository(nil)
+		ctx := context.Background()
+		daily, weekly, unsubscribed := uuid.New(), uuid.New(), uuid.New()
+
+		repo.SaveFrequency(ctx, daily, domain.DailyDigest)
+		repo.SaveFrequency(ctx, weekly, domain.WeeklyDigest)
+		repo.SaveFrequency(ctx, unsubscribed, domain.DailyDigest)
+		repo.SaveFrequency(ctx, unsubscribed, domain.DigestOff)
+
+		userIDs, err := repo.FindSubscribers(ctx, domain.DailyDigest)
+
+		if _assert.NoError(err) {
+			_assert.Equal([]uuid.UUID{daily}, userIDs)
+		}
+
+		frequency, err := repo.FindFrequency(ctx, uuid.New())
+
+		if _assert.NoError(err) {
+			_assert.Equal(domain.DigestOff, frequency)
+		}
+	})
+
+	t.Run("it should mark the digest of a period as sent only once", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryDigestsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+		now := time.Now()
+
+		marked, err := repo.MarkAsSent(ctx, userID, domain.PreviousDigestPeriod(domain.DailyDigest, now))
+		_assert.NoError(err)
+		_assert.True(marked)
+
+		marked, err = repo.MarkAsSent(ctx, userID, domain.PreviousDigestPeriod(domain.DailyDigest, now))
+		_assert.NoError(err)
+		_assert.False(marked)
+
+		marked, err = repo.MarkAsSent(ctx, userID, domain.PreviousDigestPeriod(domain.WeeklyDigest, now))
+		_assert.NoError(err)
+		_assert.True(marked)
+	})
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type notificationStore map[uuid.UUID]domain.Notification
+
+type inMemoryNotificationsRepository struct {
+	store notificationStore
+	sync.Mutex
+}
+
+func NewInMemoryNotificationsRepository(initialStore notificationStore) *inMemoryNotificationsRepository {
+	if initialStore == nil {
+		initialStore = make(notificationStore)
+	}
+
+	return &inMemoryNotificationsRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemoryNotificationsRepository) Store(ctx context.Context, notification *domain.Notification) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return err
+	}
+
+	notification.ID = id
+	repo.store[id] = *notification
+
+	return nil
+}
+
+func (repo *inMemoryNotificationsRepository) List(ctx context.Context, userID uuid.UUID, paginator domain.Paginator) ([]domain.Notification, *domain.Cursor, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	notifications := []domain.Notification{}
+
+	for _, notification := range repo.store {
+		if notification.UserID != userID || (paginator.UnreadOnly && notification.IsRead()) {
+			continue
+		}
+
+		if paginator.After != nil && !isOlderThan(notification, paginator.After) {
+			continue
+		}
+		notifications = append(notifications, notification)
+	}
+
+	// Newest first, the ids being time ordered uuids breaking the ties.
+	slices.SortFunc(notifications, func(a, b domain.Notification) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return slices.Compare(b.ID[:], a.ID[:])
+	})
+
+	if len(notifications) <= paginator.Limit {
+		return notifications, nil, nil
+	}
+	notifications = notifications[:paginator.Limit]
+	last := notifications[len(notifications)-1]
+
+	return notifications, &domain.Cursor{ID: last.ID, CreatedAt: last.CreatedAt}, nil
+}
+
+func (repo *inMemoryNotificationsRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	count := 0
+
+	for notification := range maps.Values(repo.store) {
+		if notification.UserID == userID && !notification.IsRead() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (repo *inMemoryNotificationsRepository) MarkAsRead(ctx context.Context, id, userID uuid.UUID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	notification, ok := repo.store[id]
+
+	if !ok || notification.UserID != userID {
+		return domain.ErrNotificationNotFound
+	}
+
+	if !notification.IsRead() {
+		now := time.Now()
+		notification.ReadAt = &now
+		repo.store[id] = notification
+	}
+
+	return nil
+}
+
+func (repo *inMemoryNotificationsRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	now := time.Now()
+
+	for id, notification := range repo.store {
+		if notification.UserID == userID && !notification.IsRead() {
+			notification.ReadAt = &now
+			repo.store[id] = notification
+		}
+	}
+
+	return nil
+}
+
+func isOlderThan(notification domain.Notification, cursor *domain.Cursor) bool {
+	if notification.CreatedAt.Equal(cursor.CreatedAt) {
+		return slices.Compare(notification.ID[:], cursor.ID[:]) < 0
+	}
+
+	return notification.CreatedAt.Before(cursor.CreatedAt)
+}
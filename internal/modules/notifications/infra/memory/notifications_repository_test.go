@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryNotificationsRepositoryListMethod(t *testing.T) {
+
+	t.Run("it should list the user notifications from the newest to the oldest, page by page", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryNotificationsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		for range 5 {
+			repo.Store(ctx, domain.NewNotification(userID, "post_commented", nil))
+		}
+		repo.Store(ctx, domain.NewNotification(uuid.New(), "post_commented", nil))
+
+		firstPage, next, err := repo.List(ctx, userID, domain.Paginator{Limit: 3})
+
+		if _assert.NoError(err) && _assert.NotNil(next) {
+			_assert.Len(firstPage, 3)
+			_assert.Equal(firstPage[2].ID, next.ID)
+		}
+
+		secondPage, next, err := repo.List(ctx, userID, domain.Paginator{Limit: 3, After: next})
+
+		if _assert.NoError(err) {
+			_assert.Len(secondPage, 2)
+			_assert.Nil(next)
+			_assert.NotContains(firstPage, secondPage[0])
+		}
+	})
+
+	t.Run("it should only list unread notifications when asked to", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryNotificationsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		read := domain.NewNotification(userID, "post_commented", nil)
+		repo.Store(ctx, read)
+		repo.Store(ctx, domain.NewNotification(userID, "post_commented", nil))
+		repo.MarkAsRead(ctx, read.ID, userID)
+
+		notifications, _, err := repo.List(ctx, userID, domain.Paginator{Limit: 10, UnreadOnly: true})
+
+		if _assert.NoError(err) {
+			_assert.Len(notifications, 1)
+			_assert.NotEqual(read.ID, notifications[0].ID)
+		}
+	})
+}
+
+func TestInMemoryNotificationsRepositoryMarkAsReadMethods(t *testing.T) {
+
+	t.Run("it should fail with ErrNotificationNotFound when the notification belongs to someone else", func(t *testing.T) {
+		repo := NewInMemoryNotificationsRepository(nil)
+		ctx := context.Background()
+
+		notification := domain.NewNotification(uuid.New(), "post_commented", nil)
+		repo.Store(ctx, notification)
+
+		err := repo.MarkAsRead(ctx, notification.ID, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotificationNotFound)
+	})
+
+	t.Run("it should update the unread count", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryNotificationsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		notification := domain.NewNotification(userID, "post_commented", nil)
+		repo.Store(ctx, notification)
+		repo.Store(ctx, domain.NewNotification(userID, "post_commented", nil))
+		repo.Store(ctx, domain.NewNotification(userID, "post_commented", nil))
+
+		_assert.NoError(repo.MarkAsRead(ctx, notification.ID, userID))
+		count, _ := repo.CountUnread(ctx, userID)
+		_assert.Equal(2, count)
+
+		_assert.NoError(repo.MarkAllAsRead(ctx, userID))
+		count, _ = repo.CountUnread(ctx, userID)
+		_assert.Equal(0, count)
+	})
+}
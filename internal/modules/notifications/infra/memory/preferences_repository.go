@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type preferenceStore map[uuid.UUID][]domain.Preference
+
+type inMemoryPreferencesRepository struct {
+	store preferenceStore
+	sync.Mutex
+}
+
+func NewInMemoryPreferencesRepository(initialStore preferenceStore) *inMemoryPreferencesRepository {
+	if initialStore == nil {
+		initialStore = make(preferenceStore)
+	}
+
+	return &inMemoryPreferencesRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemoryPreferencesRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.Preference, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	return slices.Clone(repo.store[userID]), nil
+}
+
+func (repo *inMemoryPreferencesRepository) Save(ctx context.Context, userID uuid.UUID, preference domain.Preference) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	preferences := slices.DeleteFunc(repo.store[userID], func(p domain.Preference) bool {
+		return p.Category == preference.Category && p.Channel == preference.Channel
+	})
+	repo.store[userID] = append(preferences, preference)
+
+	return nil
+}
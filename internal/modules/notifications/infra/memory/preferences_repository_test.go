@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryPreferencesRepository(t *testing.T) {
+
+	t.Run("it should replace the preference of the same category and channel", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryPreferencesRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		repo.Save(ctx, userID, domain.Preference{Category: domain.CommentsCategory, Channel: domain.EmailChannel})
+		repo.Save(ctx, userID, domain.Preference{Category: domain.CommentsCategory, Channel: domain.InAppChannel})
+		repo.Save(ctx, userID, domain.Preference{Category: domain.CommentsCategory, Channel: domain.EmailChannel, Enabled: true})
+
+		preferences, err := repo.FindByUser(ctx, userID)
+
+		if _assert.NoError(err) {
+			_assert.Len(preferences, 2)
+			_assert.Contains(preferences, domain.Preference{
+				Category: domain.CommentsCategory,
+				Channel:  domain.EmailChannel,
+				Enabled:  true,
+			})
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type digestsRepository struct {
+	db *sql.DB
+}
+
+func NewDigestsRepository(db *sql.DB) *digestsRepository {
+	return &digestsRepository{
+		db: db,
+	}
+}
+
+func (repo *digestsRepository) FindFrequency(ctx context.Context, userID uuid.UUID) (domain.Frequency, error) {
+	var frequency domain.Frequency
+
+	query := "SELECT frequency FROM digest_subscriptions WHERE user_id = UUID_TO_BIN(?);"
+	err := repo.db.QueryRowContext(ctx, query, userID.String()).Scan(&frequency)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.DigestOff, nil
+	}
+
+	return frequency, err
+}
+
+func (repo *digestsRepository) SaveFrequency(ctx context.Context, userID uuid.UUID, frequency domain.Frequency) error {
+	query := `
+		INSERT INTO digest_subscriptions (user_id, frequency, updated_at)
+		VALUES (UUID_TO_BIN(?), ?, ?)
+		ON DUPLICATE KEY UPDATE frequency = VALUES(frequency), updated_at = VALUES(updated_at);
+	`
+	_, err := repo.db.ExecContext(ctx, query, userID.String(), frequency, time.Now())
+
+	return err
+}
+
+func (repo *digestsRepository) FindSubscribers(ctx context.Context, frequency domain.Frequency) ([]uuid.UUID, error) {
+	query := "SELECT user_id FROM digest_subscriptions WHERE frequency = ?;"
+	rows, err := repo.db.QueryContext(ctx, query, frequency)
+
+	if err != nil {
+		return []uuid.UUID{}, err
+	}
+	defer rows.Close()
+
+	userIDs := []uuid.UUID{}
+
+	for rows.Next() {
+		var userID uuid.UUID
+
+		if err := rows.Scan(&userID); err != nil {
+			return []uuid.UUID{}, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+func (repo *digestsRepository) MarkAsSent(ctx context.Context, userID uuid.UUID, period domain.DigestPeriod) (bool, error) {
+	query := "INSERT IGNORE INTO sent_digests (user_id, period_key, sent_at) VALUES (UUID_TO_BIN(?), ?, ?);"
+	result, err := database.Executor(ctx, repo.db).ExecContext(
+		ctx, query, userID.String(), period.Key(), time.Now(),
+	)
+
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+
+	return affected == 1, err
+}
@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type notificationsRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationsRepository(db *sql.DB) *notificationsRepository {
+	return &notificationsRepository{
+		db: db,
+	}
+}
+
+func (repo *notificationsRepository) Store(ctx context.Context, notification *domain.Notification) error {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(notification.Data)
+
+	if err != nil {
+		return err
+	}
+	notification.ID = id
+
+	query := `
+		INSERT INTO notifications (id, user_id, type, data, read_at, created_at)
+		VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?);
+	`
+	_, err = database.Executor(ctx, repo.db).ExecContext(
+		ctx, query, notification.ID.String(), notification.UserID.String(),
+		notification.Type, data, notification.ReadAt, notification.CreatedAt,
+	)
+
+	return err
+}
+
+func (repo *notificationsRepository) List(ctx context.Context, userID uuid.UUID, paginator domain.Paginator) ([]domain.Notification, *domain.Cursor, error) {
+	query := "SELECT * FROM notifications WHERE user_id = UUID_TO_BIN(?)"
+	args := []any{userID.String()}
+
+	if paginator.UnreadOnly {
+		query += " AND read_at IS NULL"
+	}
+
+	if paginator.After != nil {
+		query += " AND (created_at < ? OR (created_at = ? AND id < UUID_TO_BIN(?)))"
+		args = append(args, paginator.After.CreatedAt, paginator.After.CreatedAt, paginator.After.ID.String())
+	}
+	// One more row than asked tells whether there is a next page.
+	query += " ORDER BY created_at DESC, id DESC LIMIT ?;"
+	args = append(args, paginator.Limit+1)
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return []domain.Notification{}, nil, err
+	}
+	defer rows.Close()
+
+	notifications := []domain.Notification{}
+
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+
+		if err != nil {
+			return []domain.Notification{}, nil, err
+		}
+		notifications = append(notifications, *notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []domain.Notification{}, nil, err
+	}
+
+	if len(notifications) <= paginator.Limit {
+		return notifications, nil, nil
+	}
+	notifications = notifications[:paginator.Limit]
+	last := notifications[len(notifications)-1]
+
+	return notifications, &domain.Cursor{ID: last.ID, CreatedAt: last.CreatedAt}, nil
+}
+
+func (repo *notificationsRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := "SELECT COUNT(*) FROM notifications WHERE user_id = UUID_TO_BIN(?) AND read_at IS NULL;"
+	var count int
+
+	err := repo.db.QueryRowContext(ctx, query, userID.String()).Scan(&count)
+
+	return count, err
+}
+
+func (repo *notificationsRepository) MarkAsRead(ctx context.Context, id, userID uuid.UUID) error {
+	query := "SELECT read_at IS NOT NULL FROM notifications WHERE id = UUID_TO_BIN(?) AND user_id = UUID_TO_BIN(?);"
+	var isRead bool
+
+	err := repo.db.QueryRowContext(ctx, query, id.String(), userID.String()).Scan(&isRead)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrNotificationNotFound
+		}
+
+		return err
+	}
+
+	if isRead {
+		return nil
+	}
+	query = "UPDATE notifications SET read_at = ? WHERE id = UUID_TO_BIN(?) AND read_at IS NULL;"
+	_, err = repo.db.ExecContext(ctx, query, time.Now(), id.String())
+
+	return err
+}
+
+func (repo *notificationsRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
+	query := "UPDATE notifications SET read_at = ? WHERE user_id = UUID_TO_BIN(?) AND read_at IS NULL;"
+	_, err := repo.db.ExecContext(ctx, query, time.Now(), userID.String())
+
+	return err
+}
+
+func scanNotification(rows *sql.Rows) (*domain.Notification, error) {
+	notification := &domain.Notification{}
+	var data []byte
+	var readAt sql.NullTime
+
+	err := rows.Scan(
+		&notification.ID, &notification.UserID, &notification.Type,
+		&data, &readAt, &notification.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &notification.Data); err != nil {
+		return nil, err
+	}
+
+	if readAt.Valid {
+		notification.ReadAt = &readAt.Time
+	}
+
+	return notification, nil
+}
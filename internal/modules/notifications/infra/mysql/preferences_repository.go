@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type preferencesRepository struct {
+	db *sql.DB
+}
+
+func NewPreferencesRepository(db *sql.DB) *preferencesRepository {
+	return &preferencesRepository{
+		db: db,
+	}
+}
+
+func (repo *preferencesRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.Preference, error) {
+	query := "SELECT category, channel, enabled FROM notification_preferences WHERE user_id = UUID_TO_BIN(?);"
+	rows, err := repo.db.QueryContext(ctx, query, userID.String())
+
+	if err != nil {
+		return []domain.Preference{}, err
+	}
+	defer rows.Close()
+
+	preferences := []domain.Preference{}
+
+	for rows.Next() {
+		var preference domain.Preference
+
+		if err := rows.Scan(&preference.Category, &preference.Channel, &preference.Enabled); err != nil {
+			return []domain.Preference{}, err
+		}
+		preferences = append(preferences, preference)
+	}
+
+	return preferences, rows.Err()
+}
+
+func (repo *preferencesRepository) Save(ctx context.Context, userID uuid.UUID, preference domain.Preference) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, category, channel, enabled, updated_at)
+		VALUES (UUID_TO_BIN(?), ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE enabled = VALUES(enabled), updated_at = VALUES(updated_at);
+	`
+	_, err := repo.db.ExecContext(
+		ctx, query, userID.String(), preference.Category,
+		preference.Channel, preference.Enabled, time.Now(),
+	)
+
+	return err
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// DigestSources gathers the digest activity of the sources registered by the
+// other modules, which can't be given at construction as they depend on this one.
+type DigestSources struct {
+	sources []domain.DigestSource
+	sync.RWMutex
+}
+
+func NewDigestSources() *DigestSources {
+	return &DigestSources{}
+}
+
+func (sources *DigestSources) Register(source domain.DigestSource) {
+	sources.Lock()
+	defer sources.Unlock()
+
+	sources.sources = append(sources.sources, source)
+}
+
+func (sources *DigestSources) NewCommentsOnPostsOf(ctx context.Context, userID uuid.UUID, period domain.DigestPeriod) ([]domain.DigestComment, error) {
+	sources.RLock()
+	defer sources.RUnlock()
+
+	comments := []domain.DigestComment{}
+
+	for _, source := range sources.sources {
+		c, err := source.NewCommentsOnPostsOf(ctx, userID, period)
+
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c...)
+	}
+
+	return comments, nil
+}
+
+func (sources *DigestSources) TopNewPosts(ctx context.Context, period domain.DigestPeriod, limit int) ([]domain.DigestPost, error) {
+	sources.RLock()
+	defer sources.RUnlock()
+
+	posts := []domain.DigestPost{}
+
+	for _, source := range sources.sources {
+		p, err := source.TopNewPosts(ctx, period, limit)
+
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p...)
+	}
+
+	slices.SortStableFunc(posts, func(a, b domain.DigestPost) int {
+		return b.CommentsCount - a.CommentsCount
+	})
+
+	return posts[:min(limit, len(posts))], nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/mailer"
+	"context"
+)
+
+type mailEmailService struct {
+	outbox   *mailer.Outbox
+	renderer *mailer.Renderer
+}
+
+func NewMailEmailService(outbox *mailer.Outbox, renderer *mailer.Renderer) *mailEmailService {
+	return &mailEmailService{
+		outbox:   outbox,
+		renderer: renderer,
+	}
+}
+
+// Send renders the email template in the receiver locale and adds it to the outbox.
+// The emails with an unsubscribe link get the headers of the mail clients one-click
+// unsubscribe (RFC 8058) too.
+func (service *mailEmailService) Send(ctx context.Context, email domain.Email) error {
+	var rendered *mailer.Message
+	var err error
+	var headers map[string]string
+
+	if email.UnsubscribeURL == "" {
+		rendered, err = service.renderer.Render(email.Template, email.Locale, email.Data)
+	} else {
+		rendered, err = service.renderer.RenderUnsubscribable(email.Template, email.Locale, email.Data, email.UnsubscribeURL)
+		headers = map[string]string{
+			"List-Unsubscribe":      "<" + email.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return service.outbox.Enqueue(ctx, &mailer.Email{
+		To:      email.To,
+		Subject: rendered.Subject,
+		HTML:    rendered.HTML,
+		Text:    rendered.Text,
+		Headers: headers,
+	})
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/signer"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type unsubscribeLinks struct {
+	signer *signer.Signer
+	appURL string
+}
+
+func NewUnsubscribeLinks(appKey, appURL string) *unsubscribeLinks {
+	return &unsubscribeLinks{
+		signer: signer.NewSigner(appKey, "unsubscribe"),
+		appURL: strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+// URL returns the unsubscribe link, whose token is the user id and the category
+// followed by their signature. The links don't expire, like the emails holding them.
+func (links *unsubscribeLinks) URL(userID uuid.UUID, category domain.Category) string {
+	payload := append(userID[:], category...)
+
+	return links.appURL + "/notifications/unsubscribe?token=" + url.QueryEscape(links.signer.Sign(payload))
+}
+
+func (links *unsubscribeLinks) Verify(token string) (uuid.UUID, domain.Category, error) {
+	payload, err := links.signer.Verify(token)
+
+	if err != nil || len(payload) <= len(uuid.UUID{}) {
+		return uuid.Nil, "", domain.ErrInvalidUnsubscribeToken
+	}
+	userID, _ := uuid.FromBytes(payload[:16])
+	category := domain.Category(payload[16:])
+
+	if !slices.Contains(domain.Categories, category) {
+		return uuid.Nil, "", domain.ErrInvalidUnsubscribeToken
+	}
+
+	return userID, category, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenFromURL(link string) string {
+	parsed, _ := url.Parse(link)
+	return parsed.Query().Get("token")
+}
+
+func TestUnsubscribeLinks(t *testing.T) {
+	links := NewUnsubscribeLinks("app-key", "http://localhost:4000/")
+
+	t.Run("it should verify the links it made", func(t *testing.T) {
+		_assert := assert.New(t)
+		userID := uuid.New()
+
+		link := links.URL(userID, domain.CommentsCategory)
+		_assert.Contains(link, "http://localhost:4000/notifications/unsubscribe?token=")
+
+		verifiedUserID, category, err := links.Verify(tokenFromURL(link))
+
+		if _assert.NoError(err) {
+			_assert.Equal(userID, verifiedUserID)
+			_assert.Equal(domain.CommentsCategory, category)
+		}
+	})
+
+	t.Run("it should refuse the tampered and foreign tokens", func(t *testing.T) {
+		token := tokenFromURL(links.URL(uuid.New(), domain.CommentsCategory))
+		foreign := tokenFromURL(NewUnsubscribeLinks("other-key", "").URL(uuid.New(), domain.CommentsCategory))
+
+		for _, invalid := range []string{"", "abc", token[1:], token + "a", foreign} {
+			_, _, err := links.Verify(invalid)
+			assert.ErrorIs(t, err, domain.ErrInvalidUnsubscribeToken, invalid)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/modules/users"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type userService struct {
+	api users.PublicApi
+}
+
+func NewUserService(api users.PublicApi) *userService {
+	return &userService{
+		api: api,
+	}
+}
+
+func (service *userService) GetRecipient(ctx context.Context, userID uuid.UUID) (*domain.Recipient, error) {
+	user, err := service.api.GetUserByID(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Recipient{Email: user.Email, Locale: user.Locale}, nil
+}
@@ -0,0 +1,188 @@
+package stream
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrHubClosed = errors.New("the event hub is closed")
+
+const (
+	DefaultBufferSize  = 64
+	DefaultHistorySize = 1024
+)
+
+type userEvent struct {
+	userID uuid.UUID
+	event  domain.StreamEvent
+}
+
+// Hub is an in-process publish/subscribe hub fanning the events out to the
+// subscriptions of their users. Publishing never blocks on a subscriber: the
+// ones whose buffer is full are dropped, and can resume from their last event
+// id while it is still in the history.
+//
+// The hub only knows about the connections made to this process.
+type Hub struct {
+	mu            sync.RWMutex
+	subscriptions map[uuid.UUID]map[*subscription]struct{}
+	history       []userEvent
+	historySize   int
+	bufferSize    int
+	lastID        uint64
+	closed        bool
+}
+
+func NewHub(bufferSize, historySize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	if historySize <= 0 {
+		historySize = DefaultHistorySize
+	}
+
+	return &Hub{
+		subscriptions: make(map[uuid.UUID]map[*subscription]struct{}),
+		history:       make([]userEvent, 0, historySize),
+		historySize:   historySize,
+		bufferSize:    bufferSize,
+		// Starting from the clock keeps the ids increasing across restarts, so
+		// the clients don't resume from an id the hub hasn't reached yet.
+		lastID: uint64(time.Now().UnixMicro()),
+	}
+}
+
+func (hub *Hub) Publish(userIDs []uuid.UUID, eventType string, data any) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if hub.closed {
+		return
+	}
+	now := time.Now()
+
+	for _, userID := range userIDs {
+		hub.lastID++
+		event := domain.StreamEvent{ID: hub.lastID, Type: eventType, Data: data, CreatedAt: now}
+
+		if len(hub.history) == hub.historySize {
+			hub.history = append(hub.history[1:], userEvent{userID, event})
+		} else {
+			hub.history = append(hub.history, userEvent{userID, event})
+		}
+
+		for sub := range hub.subscriptions[userID] {
+			select {
+			case sub.events <- event:
+			default:
+				hub.remove(sub)
+			}
+		}
+	}
+}
+
+func (hub *Hub) Subscribe(userID uuid.UUID, lastEventID uint64) (domain.Subscription, error) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if hub.closed {
+		return nil, ErrHubClosed
+	}
+	sub := &subscription{
+		hub:    hub,
+		userID: userID,
+		events: make(chan domain.StreamEvent, hub.bufferSize),
+	}
+
+	if lastEventID != 0 {
+		missed := []domain.StreamEvent{}
+
+		for _, e := range hub.history {
+			if e.userID == userID && e.event.ID > lastEventID {
+				missed = append(missed, e.event)
+			}
+		}
+
+		// Only the newest events fit in the buffer.
+		for _, event := range missed[max(0, len(missed)-hub.bufferSize):] {
+			sub.events <- event
+		}
+	}
+
+	if hub.subscriptions[userID] == nil {
+		hub.subscriptions[userID] = make(map[*subscription]struct{})
+	}
+	hub.subscriptions[userID][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// Count returns the number of open subscriptions.
+func (hub *Hub) Count() int {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+
+	count := 0
+
+	for _, subs := range hub.subscriptions {
+		count += len(subs)
+	}
+
+	return count
+}
+
+// Close ends every subscription and refuses the new ones, letting the streams
+// return before the server shuts down.
+func (hub *Hub) Close() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	hub.closed = true
+
+	for _, subs := range hub.subscriptions {
+		for sub := range subs {
+			hub.remove(sub)
+		}
+	}
+}
+
+// remove must be called with the hub lock held.
+func (hub *Hub) remove(sub *subscription) {
+	subs, ok := hub.subscriptions[sub.userID]
+
+	if !ok {
+		return
+	}
+
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	close(sub.events)
+
+	if len(subs) == 0 {
+		delete(hub.subscriptions, sub.userID)
+	}
+}
+
+type subscription struct {
+	hub    *Hub
+	userID uuid.UUID
+	events chan domain.StreamEvent
+}
+
+func (sub *subscription) Events() <-chan domain.StreamEvent {
+	return sub.events
+}
+
+func (sub *subscription) Close() {
+	sub.hub.mu.Lock()
+	defer sub.hub.mu.Unlock()
+
+	sub.hub.remove(sub)
+}
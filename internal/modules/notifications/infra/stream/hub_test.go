@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"comu/internal/modules/notifications/domain"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(sub domain.Subscription) []domain.StreamEvent {
+	events := []domain.StreamEvent{}
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestHub(t *testing.T) {
+
+	t.Run("it should only send the events to the subscriptions of their users", func(t *testing.T) {
+		_assert := assert.New(t)
+		hub := NewHub(0, 0)
+		userID := uuid.New()
+
+		first, _ := hub.Subscribe(userID, 0)
+		second, _ := hub.Subscribe(userID, 0)
+		other, _ := hub.Subscribe(uuid.New(), 0)
+
+		hub.Publish([]uuid.UUID{userID}, "post_updated", "data")
+
+		_assert.Len(receive(first), 1)
+		_assert.Len(receive(second), 1)
+		_assert.Empty(receive(other))
+	})
+
+	t.Run("it should resume from the last event id", func(t *testing.T) {
+		_assert := assert.New(t)
+		hub := NewHub(0, 0)
+		userID := uuid.New()
+
+		sub, _ := hub.Subscribe(userID, 0)
+		hub.Publish([]uuid.UUID{userID}, "post_updated", 1)
+		last := receive(sub)[0]
+		sub.Close()
+
+		hub.Publish([]uuid.UUID{userID}, "post_updated", 2)
+		hub.Publish([]uuid.UUID{uuid.New()}, "post_updated", 3)
+		hub.Publish([]uuid.UUID{userID}, "post_updated", 4)
+
+		resumed, _ := hub.Subscribe(userID, last.ID)
+		events := receive(resumed)
+
+		if _assert.Len(events, 2) {
+			_assert.Equal(2, events[0].Data)
+			_assert.Equal(4, events[1].Data)
+			_assert.Greater(events[0].ID, last.ID)
+		}
+	})
+
+	t.Run("it should drop the subscriptions which don't keep up", func(t *testing.T) {
+		_assert := assert.New(t)
+		hub := NewHub(2, 0)
+		userID := uuid.New()
+
+		sub, _ := hub.Subscribe(userID, 0)
+
+		for i := range 3 {
+			hub.Publish([]uuid.UUID{userID}, "post_updated", i)
+		}
+
+		_assert.Len(receive(sub), 2)
+		_, ok := <-sub.Events()
+		_assert.False(ok)
+		_assert.Zero(hub.Count())
+	})
+
+	t.Run("it should end the subscriptions and refuse new ones once closed", func(t *testing.T) {
+		_assert := assert.New(t)
+		hub := NewHub(0, 0)
+
+		sub, _ := hub.Subscribe(uuid.New(), 0)
+		hub.Close()
+		sub.Close()
+
+		_, ok := <-sub.Events()
+		_assert.False(ok)
+
+		_, err := hub.Subscribe(uuid.New(), 0)
+		_assert.ErrorIs(err, ErrHubClosed)
+	})
+}
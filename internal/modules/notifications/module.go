@@ -0,0 +1,152 @@
+package notifications
+
+import (
+	"comu/config"
+	"comu/internal/modules/notifications/application"
+	"comu/internal/modules/notifications/application/digests"
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/modules/notifications/infra/mysql"
+	"comu/internal/modules/notifications/infra/service"
+	"comu/internal/modules/notifications/infra/stream"
+	"comu/internal/modules/notifications/presentation/handlers"
+	"comu/internal/modules/users"
+	"comu/internal/shared/database"
+	"comu/internal/shared/jobs"
+	"comu/internal/shared/logger"
+	"comu/internal/shared/mailer"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	SecurityCategory = domain.SecurityCategory
+	CommentsCategory = domain.CommentsCategory
+)
+
+// The types the other modules implement a digest source with.
+type (
+	DigestSource  = domain.DigestSource
+	DigestPeriod  = domain.DigestPeriod
+	DigestComment = domain.DigestComment
+	DigestPost    = domain.DigestPost
+)
+
+type PublicApi interface {
+	// SendEmail renders the email template and queues it, within the transaction
+	// carried by the context if any. Only the security emails ignore the preferences.
+	SendEmail(context.Context, SendEmailRequest) error
+	// Notify notifies the user through the channels they didn't turn off for the
+	// category: in the app, pushing it to their stream, and by email.
+	Notify(context.Context, NotifyRequest) error
+	// Publish pushes an event to the streams of the users, without storing it, once
+	// the transaction carried by the context is committed if any.
+	Publish(context.Context, PublishRequest)
+}
+
+type notificationsModule struct {
+	api            PublicApi
+	hub            *stream.Hub
+	digestSources  *service.DigestSources
+	digestJob      *jobs.Job
+	handlers       []handlers.Handlers
+	publicHandlers []handlers.Handlers
+}
+
+func NewModule(
+	db *sql.DB, config *config.Config, usersApi users.PublicApi,
+	mailOutbox *mailer.Outbox, logger *logger.Log,
+) *notificationsModule {
+	notificationsRepo := mysql.NewNotificationsRepository(db)
+	preferencesRepo := mysql.NewPreferencesRepository(db)
+	emailService := service.NewMailEmailService(
+		mailOutbox,
+		mailer.NewRenderer(config.AppName, config.AppURL, config.MailTemplatesDir),
+	)
+
+	hub := stream.NewHub(stream.DefaultBufferSize, stream.DefaultHistorySize)
+	digestSources := service.NewDigestSources()
+
+	useCases := application.InitUseCases(
+		notificationsRepo,
+		preferencesRepo,
+		mysql.NewDigestsRepository(db),
+		digestSources,
+		emailService,
+		service.NewUserService(usersApi),
+		service.NewUnsubscribeLinks(config.AppKey, config.AppURL),
+		hub,
+		database.NewTransactor(db),
+	)
+
+	return &notificationsModule{
+		api:            newApi(useCases.SendEmailUC, useCases.NotifyUC, useCases.PublishEventUC),
+		hub:            hub,
+		digestSources:  digestSources,
+		digestJob:      jobs.Every(domain.DigestCheckInterval, sendDigests(useCases.SendDigestsUC, logger), logger),
+		handlers:       handlers.GetHandlers(useCases, logger),
+		publicHandlers: handlers.GetPublicHandlers(useCases, logger),
+	}
+}
+
+// RegisterRoutes registers the notifications routes behind the given middlewares,
+// which must authenticate the user, except for the unsubscribe links ones.
+func (module *notificationsModule) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	for _, h := range module.handlers {
+		h.RegisterRoutes(echo, m...)
+	}
+
+	for _, h := range module.publicHandlers {
+		h.RegisterRoutes(echo)
+	}
+}
+
+// RegisterDigestSource adds the activity of a module to the digests. The modules
+// depending on this one register their source once both are built.
+func (module *notificationsModule) RegisterDigestSource(source DigestSource) {
+	module.digestSources.Register(source)
+}
+
+// StartJobs runs the digests job in the background until the context is done.
+func (module *notificationsModule) StartJobs(ctx context.Context) {
+	module.digestJob.Start(ctx)
+}
+
+// WaitJobs blocks until the jobs stopped, after their context is done.
+func (module *notificationsModule) WaitJobs() {
+	module.digestJob.Wait()
+}
+
+// Shutdown ends the open notification streams, which would otherwise keep the
+// server from shutting down.
+func (module *notificationsModule) Shutdown() {
+	module.hub.Close()
+}
+
+func (module *notificationsModule) GetPublicApi() PublicApi {
+	return module.api
+}
+
+// sendDigests returns the digests job task, sending the daily and weekly digests which are due.
+func sendDigests(sendDigestsUC *digests.SendDigestsUC, logger *logger.Log) func(context.Context) error {
+	return func(ctx context.Context) error {
+		var errs []error
+
+		for _, frequency := range []domain.Frequency{domain.DailyDigest, domain.WeeklyDigest} {
+			sent, err := sendDigestsUC.Execute(ctx, frequency, time.Now())
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+
+			if sent > 0 {
+				logger.Info.Printf("%d %s digests sent\n", sent, frequency)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
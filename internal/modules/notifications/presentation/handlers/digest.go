@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"comu/internal/modules/notifications/application/digests"
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	invalidDigestFrequency echoRes.ErrorResponseType = "invalid_digest_frequency"
+
+	msgDigestUpdated = "Your digest frequency has been updated."
+)
+
+type digestHandlers struct {
+	getDigestFrequencyUC    *digests.GetDigestFrequencyUC
+	updateDigestFrequencyUC *digests.UpdateDigestFrequencyUC
+
+	logger *logger.Log
+}
+
+func newDigestHandlers(
+	getDigestFrequencyUC *digests.GetDigestFrequencyUC,
+	updateDigestFrequencyUC *digests.UpdateDigestFrequencyUC,
+
+	logger *logger.Log,
+) *digestHandlers {
+	return &digestHandlers{
+		getDigestFrequencyUC:    getDigestFrequencyUC,
+		updateDigestFrequencyUC: updateDigestFrequencyUC,
+
+		logger: logger,
+	}
+}
+
+func (h *digestHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/notifications", m...)
+
+	group.GET("/digest", h.show)
+	group.PUT("/digest", h.update)
+}
+
+type digestFormData struct {
+	Frequency string `form:"frequency" json:"frequency"`
+}
+
+func (h *digestHandlers) show(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	frequency, err := h.getDigestFrequencyUC.Execute(ctx.Request().Context(), userID)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"frequency": frequency,
+	})
+}
+
+func (h *digestHandlers) update(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	var data digestFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if err := h.updateDigestFrequencyUC.Execute(ctx.Request().Context(), userID, data.Frequency); err != nil {
+		if errors.Is(err, domain.ErrUnknownFrequency) {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidDigestFrequency, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, msgDigestUpdated)
+}
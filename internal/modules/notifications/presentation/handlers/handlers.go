@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"comu/internal/modules/notifications/application"
+	"comu/internal/shared/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	RegisterRoutes(*echo.Echo, ...echo.MiddlewareFunc)
+}
+
+func GetHandlers(ucs application.UseCases, logger *logger.Log) []Handlers {
+	notificationHandlers := newNotificationHandlers(
+		ucs.ListNotificationsUC, ucs.MarkAsReadUC,
+		ucs.MarkAllAsReadUC, logger,
+	)
+
+	streamHandlers := newStreamHandlers(ucs.SubscribeUC, logger)
+	preferenceHandlers := newPreferenceHandlers(ucs.GetPreferencesUC, ucs.UpdatePreferenceUC, logger)
+	digestHandlers := newDigestHandlers(ucs.GetDigestFrequencyUC, ucs.UpdateDigestFrequencyUC, logger)
+
+	return []Handlers{notificationHandlers, streamHandlers, preferenceHandlers, digestHandlers}
+}
+
+// GetPublicHandlers returns the handlers of the routes which don't require to be logged in.
+func GetPublicHandlers(ucs application.UseCases, logger *logger.Log) []Handlers {
+	return []Handlers{newUnsubscribeHandlers(ucs.UnsubscribeUC, logger)}
+}
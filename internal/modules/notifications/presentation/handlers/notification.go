@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/modules/notifications/application/notifications"
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	unauthenticated echoRes.ErrorResponseType = "unauthenticated"
+	invalidCursor   echoRes.ErrorResponseType = "invalid_cursor"
+
+	msgNotificationRead      = "The notification has been marked as read."
+	msgAllNotificationsRead  = "All your notifications have been marked as read."
+	errInvalidAuthentication = errors.New("you must be authenticated")
+)
+
+type notificationHandlers struct {
+	listNotificationsUC *notifications.ListNotificationsUC
+	markAsReadUC        *notifications.MarkAsReadUC
+	markAllAsReadUC     *notifications.MarkAllAsReadUC
+
+	logger *logger.Log
+}
+
+func newNotificationHandlers(
+	listNotificationsUC *notifications.ListNotificationsUC,
+	markAsReadUC *notifications.MarkAsReadUC,
+	markAllAsReadUC *notifications.MarkAllAsReadUC,
+
+	logger *logger.Log,
+) *notificationHandlers {
+	return &notificationHandlers{
+		listNotificationsUC: listNotificationsUC,
+		markAsReadUC:        markAsReadUC,
+		markAllAsReadUC:     markAllAsReadUC,
+
+		logger: logger,
+	}
+}
+
+func (h *notificationHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/notifications", m...)
+
+	group.GET("", h.list)
+	group.POST("/:id/read", h.markAsRead)
+	group.POST("/read_all", h.markAllAsRead)
+}
+
+func (h *notificationHandlers) list(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	paginator, err := getPaginatorFromCtx(ctx)
+
+	if err != nil {
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusBadRequest, invalidCursor, err.Error())
+	}
+	output, err := h.listNotificationsUC.Execute(ctx.Request().Context(), userID, paginator)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+	cursor := ""
+
+	if output.Next != nil {
+		if cursor, err = output.Next.ToBase64(); err != nil {
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"notifications": output.Notifications,
+		"cursor":        cursor,
+		"unread_count":  output.UnreadCount,
+	})
+}
+
+func (h *notificationHandlers) markAsRead(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	notificationID, err := uuid.Parse(ctx.Param("id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrNotificationNotFound.Error())
+	}
+
+	if err := h.markAsReadUC.Execute(ctx.Request().Context(), notificationID, userID); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return echoRes.JsonNotFoundResponse(ctx, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, msgNotificationRead)
+}
+
+func (h *notificationHandlers) markAllAsRead(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+
+	if err := h.markAllAsReadUC.Execute(ctx.Request().Context(), userID); err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, msgAllNotificationsRead)
+}
+
+func getAuthUserID(ctx echo.Context) (uuid.UUID, error) {
+	id, _ := ctx.Get(session.UserIDCtxKey).(string)
+	userID, err := uuid.Parse(id)
+
+	if err != nil {
+		return uuid.Nil, errInvalidAuthentication
+	}
+
+	return userID, nil
+}
+
+func getPaginatorFromCtx(ctx echo.Context) (domain.Paginator, error) {
+	paginator := domain.Paginator{
+		UnreadOnly: ctx.QueryParam("unread") == "true" || ctx.QueryParam("unread") == "1",
+	}
+
+	if value, err := strconv.Atoi(ctx.QueryParam("limit")); err == nil {
+		paginator.Limit = value
+	}
+
+	if cursorParam := ctx.QueryParam("cursor"); cursorParam != "" {
+		cursor, err := domain.CursorFromBase64(cursorParam)
+
+		if err != nil {
+			return paginator, err
+		}
+		paginator.After = cursor
+	}
+
+	return paginator, nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"comu/internal/modules/notifications/application/preferences"
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	invalidPreference       echoRes.ErrorResponseType = "invalid_preference"
+	invalidUnsubscribeToken echoRes.ErrorResponseType = "invalid_unsubscribe_token"
+
+	msgPreferenceUpdated = "Your notification preferences have been updated."
+	msgUnsubscribed      = "You will no longer receive these emails."
+)
+
+type preferenceHandlers struct {
+	getPreferencesUC   *preferences.GetPreferencesUC
+	updatePreferenceUC *preferences.UpdatePreferenceUC
+
+	logger *logger.Log
+}
+
+type unsubscribeHandlers struct {
+	unsubscribeUC *preferences.UnsubscribeUC
+
+	logger *logger.Log
+}
+
+func newPreferenceHandlers(
+	getPreferencesUC *preferences.GetPreferencesUC,
+	updatePreferenceUC *preferences.UpdatePreferenceUC,
+
+	logger *logger.Log,
+) *preferenceHandlers {
+	return &preferenceHandlers{
+		getPreferencesUC:   getPreferencesUC,
+		updatePreferenceUC: updatePreferenceUC,
+
+		logger: logger,
+	}
+}
+
+func newUnsubscribeHandlers(unsubscribeUC *preferences.UnsubscribeUC, logger *logger.Log) *unsubscribeHandlers {
+	return &unsubscribeHandlers{
+		unsubscribeUC: unsubscribeUC,
+
+		logger: logger,
+	}
+}
+
+func (h *preferenceHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/notifications", m...)
+
+	group.GET("/preferences", h.list)
+	group.PUT("/preferences", h.update)
+}
+
+// The unsubscribe links work without logging in. The POST route is the one
+// the mail clients call for a one-click unsubscribe.
+func (h *unsubscribeHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/notifications", m...)
+
+	group.GET("/unsubscribe", h.unsubscribe)
+	group.POST("/unsubscribe", h.unsubscribe)
+}
+
+type preferenceFormData struct {
+	Category string `form:"category" json:"category"`
+	Channel  string `form:"channel" json:"channel"`
+	Enabled  bool   `form:"enabled" json:"enabled"`
+}
+
+func (h *preferenceHandlers) list(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	preferences, err := h.getPreferencesUC.Execute(ctx.Request().Context(), userID)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"preferences": preferences,
+	})
+}
+
+func (h *preferenceHandlers) update(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	var data preferenceFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if err := h.updatePreferenceUC.Execute(
+		ctx.Request().Context(),
+		preferences.UpdatePreferenceInput{
+			UserID:   userID,
+			Category: data.Category,
+			Channel:  data.Channel,
+			Enabled:  data.Enabled,
+		},
+	); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnknownCategory),
+			errors.Is(err, domain.ErrUnknownChannel),
+			errors.Is(err, domain.ErrForcedPreference):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidPreference, err.Error())
+
+		default:
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, msgPreferenceUpdated)
+}
+
+func (h *unsubscribeHandlers) unsubscribe(ctx echo.Context) error {
+	if err := h.unsubscribeUC.Execute(ctx.Request().Context(), ctx.QueryParam("token")); err != nil {
+		if errors.Is(err, domain.ErrInvalidUnsubscribeToken) {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusBadRequest, invalidUnsubscribeToken, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, msgUnsubscribed)
+}
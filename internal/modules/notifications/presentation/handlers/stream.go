@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"comu/internal/modules/notifications/application/stream"
+	"comu/internal/modules/notifications/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var streamUnavailable echoRes.ErrorResponseType = "stream_unavailable"
+
+type streamHandlers struct {
+	subscribeUC *stream.SubscribeUC
+
+	logger *logger.Log
+}
+
+func newStreamHandlers(subscribeUC *stream.SubscribeUC, logger *logger.Log) *streamHandlers {
+	return &streamHandlers{
+		subscribeUC: subscribeUC,
+
+		logger: logger,
+	}
+}
+
+func (h *streamHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/notifications", m...)
+
+	group.GET("/stream", h.stream)
+}
+
+// stream pushes the user events as Server-Sent Events until the client goes away
+// or the server shuts down. Clients resume with the Last-Event-ID header, which
+// the browsers EventSource sends on its own when reconnecting.
+func (h *streamHandlers) stream(ctx echo.Context) error {
+	userID, err := getAuthUserID(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	lastEventID, _ := strconv.ParseUint(ctx.Request().Header.Get("Last-Event-ID"), 10, 64)
+
+	subscription, err := h.subscribeUC.Execute(userID, lastEventID)
+
+	if err != nil {
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusServiceUnavailable, streamUnavailable, err.Error())
+	}
+	defer subscription.Close()
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Keeps nginx from buffering the stream.
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(res, "retry: %d\n\n", domain.StreamRetryDelay.Milliseconds())
+	res.Flush()
+
+	heartbeat := time.NewTicker(domain.StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return nil
+			}
+
+			if err := writeEvent(res, event); err != nil {
+				h.logger.Error.Println(err)
+				return nil
+			}
+			res.Flush()
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+func writeEvent(res *echo.Response, event domain.StreamEvent) error {
+	data, err := json.Marshal(event.Data)
+
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+
+	return err
+}
@@ -2,49 +2,175 @@ package application
 
 import (
 	"comu/internal/modules/post/application/comments"
+	"comu/internal/modules/post/application/digest"
+	"comu/internal/modules/post/application/follows"
 	"comu/internal/modules/post/application/posts"
+	"comu/internal/modules/post/application/reactions"
+	"comu/internal/modules/post/application/revisions"
+	"comu/internal/modules/post/application/search"
+	"comu/internal/modules/post/application/tags"
+	"comu/internal/modules/post/application/trash"
+	"comu/internal/modules/post/application/votes"
 	"comu/internal/modules/post/domain"
 )
 
 type UseCases struct {
-	ListPostsUC  *posts.ListPostsUC
-	ReadPostUC   *posts.ReadPostUC
-	CreatePostUC *posts.CreatePostUC
-	UpdatePostUC *posts.UpdatePostUC
-	DeletePostUC *posts.DeletePostUC
+	ListPostsUC   *posts.ListPostsUC
+	ReadPostUC    *posts.ReadPostUC
+	CreatePostUC  *posts.CreatePostUC
+	UpdatePostUC  *posts.UpdatePostUC
+	DeletePostUC  *posts.DeletePostUC
+	ListDraftsUC  *posts.ListDraftsUC
+	PublishDueUC  *posts.PublishDueUC
+	PreviewPostUC *posts.PreviewPostUC
+
+	ListRevisionsUC   *revisions.ListRevisionsUC
+	DiffRevisionsUC   *revisions.DiffRevisionsUC
+	RestoreRevisionUC *revisions.RestoreRevisionUC
 
 	ListCommentUC   *comments.ListCommentsUC
+	ListRepliesUC   *comments.ListRepliesUC
 	CreateCommentUC *comments.CreateCommentUC
 	UpdateCommentUC *comments.UpdateCommentUC
 	DeleteCommentUC *comments.DeleteCommentUC
+
+	ListTrashUC      *trash.ListTrashUC
+	RestorePostUC    *trash.RestorePostUC
+	RestoreCommentUC *trash.RestoreCommentUC
+	PurgeTrashUC     *trash.PurgeTrashUC
+
+	FollowPostUC   *follows.FollowPostUC
+	UnfollowPostUC *follows.UnfollowPostUC
+
+	AddReactionUC    *reactions.AddReactionUC
+	RemoveReactionUC *reactions.RemoveReactionUC
+
+	VoteUC *votes.VoteUC
+
+	ListTagsUC     *tags.ListTagsUC
+	ListTagPostsUC *tags.ListTagPostsUC
+	RenameTagUC    *tags.RenameTagUC
+	MergeTagsUC    *tags.MergeTagsUC
+
+	SearchUC *search.SearchUC
+
+	ListNewCommentsUC *digest.ListNewCommentsUC
+	ListTopNewPostsUC *digest.ListTopNewPostsUC
 }
 
 func InitUseCases(
 	postsRepository domain.PostRepository,
 	commentRepository domain.CommentRepository,
+	followsRepository domain.FollowsRepository,
+	reactionsRepository domain.ReactionsRepository,
+	votesRepository domain.VotesRepository,
+	tagsRepository domain.TagsRepository,
+	revisionsRepository domain.RevisionsRepository,
+	slugsRepository domain.SlugsRepository,
+	searchIndex domain.SearchIndex,
+	renderer domain.ContentRenderer,
+	transactor domain.Transactor,
+	notificationService domain.NotificationService,
+	webhookService domain.WebhookService,
+	commentMaxDepth int,
+	postMaxTags int,
+	admins domain.Admins,
 ) UseCases {
 
-	readPostUC := posts.NewReadPostUseCase(postsRepository)
-	listPostsUC := posts.NewListPostsUseCase(postsRepository)
-	createPostUC := posts.NewCreatePostUseCase(postsRepository)
-	updatePostUC := posts.NewUpdatePostUseCase(postsRepository)
-	deletePostUC := posts.NewDeletePostUseCase(postsRepository)
+	readPostUC := posts.NewReadPostUseCase(postsRepository, slugsRepository, reactionsRepository)
+	listPostsUC := posts.NewListPostsUseCase(postsRepository, reactionsRepository)
+	createPostUC := posts.NewCreatePostUseCase(
+		postsRepository, followsRepository, revisionsRepository, slugsRepository,
+		searchIndex, renderer, transactor, webhookService, postMaxTags,
+	)
+	updatePostUC := posts.NewUpdatePostUseCase(
+		postsRepository, followsRepository, revisionsRepository, slugsRepository,
+		searchIndex, renderer, transactor, notificationService, webhookService, postMaxTags,
+	)
+	deletePostUC := posts.NewDeletePostUseCase(postsRepository, searchIndex, webhookService)
+	listDraftsUC := posts.NewListDraftsUseCase(postsRepository)
+	publishDueUC := posts.NewPublishDueUseCase(postsRepository, searchIndex, webhookService)
+	previewPostUC := posts.NewPreviewPostUseCase(renderer)
+
+	listRevisionsUC := revisions.NewListRevisionsUseCase(revisionsRepository, postsRepository)
+	diffRevisionsUC := revisions.NewDiffRevisionsUseCase(revisionsRepository, postsRepository)
+	restoreRevisionUC := revisions.NewRestoreRevisionUseCase(
+		revisionsRepository, postsRepository, searchIndex, renderer, transactor, webhookService, admins,
+	)
+
+	listCommentsUC := comments.NewListCommentsUseCase(commentRepository, reactionsRepository)
+	createCommentUC := comments.NewCreateCommentUseCase(
+		commentRepository, postsRepository, followsRepository, searchIndex,
+		renderer, notificationService, webhookService, commentMaxDepth,
+	)
+	listRepliesUC := comments.NewListRepliesUseCase(commentRepository, reactionsRepository)
+	updateCommentUC := comments.NewUpdateCommentUseCase(commentRepository, searchIndex, renderer)
+	deleteCommentUC := comments.NewDeleteCommentUseCase(commentRepository, searchIndex)
+
+	listTrashUC := trash.NewListTrashUseCase(postsRepository, commentRepository)
+	restorePostUC := trash.NewRestorePostUseCase(postsRepository, commentRepository, searchIndex, webhookService)
+	restoreCommentUC := trash.NewRestoreCommentUseCase(commentRepository, postsRepository, searchIndex)
+	purgeTrashUC := trash.NewPurgeTrashUseCase(postsRepository, commentRepository)
+
+	followPostUC := follows.NewFollowPostUseCase(followsRepository, postsRepository)
+	unfollowPostUC := follows.NewUnfollowPostUseCase(followsRepository)
 
-	listCommentsUC := comments.NewListCommentsUseCase(commentRepository)
-	createCommentUC := comments.NewCreateCommentUseCase(commentRepository)
-	updateCommentUC := comments.NewUpdateCommentUseCase(commentRepository)
-	deleteCommentUC := comments.NewDeleteCommentUseCase(commentRepository)
+	addReactionUC := reactions.NewAddReactionUseCase(reactionsRepository, postsRepository, commentRepository)
+	removeReactionUC := reactions.NewRemoveReactionUseCase(reactionsRepository)
+
+	voteUC := votes.NewVoteUseCase(votesRepository, postsRepository, commentRepository, transactor)
+
+	listTagsUC := tags.NewListTagsUseCase(tagsRepository)
+	listTagPostsUC := tags.NewListTagPostsUseCase(tagsRepository, postsRepository, reactionsRepository)
+	renameTagUC := tags.NewRenameTagUseCase(tagsRepository, admins)
+	mergeTagsUC := tags.NewMergeTagsUseCase(tagsRepository, admins)
+
+	searchUC := search.NewSearchUseCase(searchIndex)
+
+	listNewCommentsUC := digest.NewListNewCommentsUseCase(postsRepository, commentRepository)
+	listTopNewPostsUC := digest.NewListTopNewPostsUseCase(postsRepository, commentRepository)
 
 	return UseCases{
-		ListPostsUC:  listPostsUC,
-		ReadPostUC:   readPostUC,
-		CreatePostUC: createPostUC,
-		UpdatePostUC: updatePostUC,
-		DeletePostUC: deletePostUC,
+		ListPostsUC:   listPostsUC,
+		ReadPostUC:    readPostUC,
+		CreatePostUC:  createPostUC,
+		UpdatePostUC:  updatePostUC,
+		DeletePostUC:  deletePostUC,
+		ListDraftsUC:  listDraftsUC,
+		PublishDueUC:  publishDueUC,
+		PreviewPostUC: previewPostUC,
+
+		ListRevisionsUC:   listRevisionsUC,
+		DiffRevisionsUC:   diffRevisionsUC,
+		RestoreRevisionUC: restoreRevisionUC,
 
 		ListCommentUC:   listCommentsUC,
+		ListRepliesUC:   listRepliesUC,
 		CreateCommentUC: createCommentUC,
 		UpdateCommentUC: updateCommentUC,
 		DeleteCommentUC: deleteCommentUC,
+
+		ListTrashUC:      listTrashUC,
+		RestorePostUC:    restorePostUC,
+		RestoreCommentUC: restoreCommentUC,
+		PurgeTrashUC:     purgeTrashUC,
+
+		FollowPostUC:   followPostUC,
+		UnfollowPostUC: unfollowPostUC,
+
+		AddReactionUC:    addReactionUC,
+		RemoveReactionUC: removeReactionUC,
+
+		VoteUC: voteUC,
+
+		ListTagsUC:     listTagsUC,
+		ListTagPostsUC: listTagPostsUC,
+		RenameTagUC:    renameTagUC,
+		MergeTagsUC:    mergeTagsUC,
+
+		SearchUC: searchUC,
+
+		ListNewCommentsUC: listNewCommentsUC,
+		ListTopNewPostsUC: listTopNewPostsUC,
 	}
 }
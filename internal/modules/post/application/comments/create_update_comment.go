@@ -3,48 +3,143 @@ package comments
 import (
 	"comu/internal/modules/post/domain"
 	"context"
+	"slices"
 
 	"github.com/google/uuid"
 )
 
 type CreateCommentInput struct {
-	PostID   uuid.UUID
+	PostID uuid.UUID
+	// ParentID is the comment replied to, if any.
+	ParentID *uuid.UUID
 	AuthorID uuid.UUID
 	Content  string
 }
 
 type CreateCommentUC struct {
-	repo domain.CommentRepository
+	repo                domain.CommentRepository
+	postsRepo           domain.PostRepository
+	followsRepo         domain.FollowsRepository
+	searchIndex         domain.SearchIndex
+	renderer            domain.ContentRenderer
+	notificationService domain.NotificationService
+	webhookService      domain.WebhookService
+	maxDepth            int
 }
 
 type UpdateCommentUC struct {
-	repo domain.CommentRepository
+	repo        domain.CommentRepository
+	searchIndex domain.SearchIndex
+	renderer    domain.ContentRenderer
 }
 
-func NewCreateCommentUseCase(repository domain.CommentRepository) *CreateCommentUC {
+func NewCreateCommentUseCase(
+	repository domain.CommentRepository,
+	postsRepository domain.PostRepository,
+	followsRepository domain.FollowsRepository,
+	searchIndex domain.SearchIndex,
+	renderer domain.ContentRenderer,
+	notificationService domain.NotificationService,
+	webhookService domain.WebhookService,
+	maxDepth int,
+) *CreateCommentUC {
+	if maxDepth <= 0 {
+		maxDepth = domain.DefaultCommentMaxDepth
+	}
+
 	return &CreateCommentUC{
-		repo: repository,
+		repo:                repository,
+		postsRepo:           postsRepository,
+		followsRepo:         followsRepository,
+		searchIndex:         searchIndex,
+		renderer:            renderer,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		maxDepth:            maxDepth,
 	}
 }
 
-func NewUpdateCommentUseCase(repository domain.CommentRepository) *UpdateCommentUC {
+func NewUpdateCommentUseCase(
+	repository domain.CommentRepository,
+	searchIndex domain.SearchIndex,
+	renderer domain.ContentRenderer,
+) *UpdateCommentUC {
 	return &UpdateCommentUC{
-		repo: repository,
+		repo:        repository,
+		searchIndex: searchIndex,
+		renderer:    renderer,
 	}
 }
 
 func (useCase *CreateCommentUC) Execute(ctx context.Context, input CreateCommentInput) (*domain.Comment, error) {
-	comment := domain.NewComment(input.PostID, input.AuthorID, input.Content)
+	post, err := useCase.postsRepo.FindByID(ctx, input.PostID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The unpublished posts are not open to comments yet.
+	if !post.IsPublic() {
+		return nil, domain.ErrPostNotFound
+	}
+	comment, err := useCase.newComment(ctx, post, input)
+
+	if err != nil {
+		return nil, err
+	}
+	comment.SetContent(input.Content, useCase.renderer)
+
+	if err = useCase.repo.Store(ctx, comment); err != nil {
+		return nil, err
+	}
 
-	err := useCase.repo.Store(ctx, comment)
+	if err = useCase.searchIndex.Index(ctx, domain.NewCommentSearchDocument(comment)); err != nil {
+		return nil, err
+	}
+	useCase.webhookService.CommentCreated(ctx, comment)
+
+	if post.UserID != comment.UserID {
+		useCase.notificationService.NotifyPostCommented(ctx, post, comment)
+	}
+	followers, err := useCase.followsRepo.FindFollowers(ctx, post.ID)
 
 	if err != nil {
 		return nil, err
 	}
+	followers = slices.DeleteFunc(followers, func(id uuid.UUID) bool { return id == comment.UserID })
+	useCase.notificationService.PublishCommentCreated(ctx, followers, comment)
+
+	// The commenters follow the posts they comment.
+	if err = useCase.followsRepo.Follow(ctx, post.ID, comment.UserID); err != nil {
+		return nil, err
+	}
 
 	return comment, nil
 }
 
+// newComment returns the comment, or the reply when a parent is given. The parent must be
+// a comment of the same post, not deleted and not already at the max depth.
+func (useCase *CreateCommentUC) newComment(ctx context.Context, post *domain.Post, input CreateCommentInput) (*domain.Comment, error) {
+	if input.ParentID == nil {
+		return domain.NewComment(post.ID, input.AuthorID, input.Content), nil
+	}
+	parent, err := useCase.repo.Find(ctx, *input.ParentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parent.PostID != post.ID || parent.IsDeleted() {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	if parent.Depth >= useCase.maxDepth {
+		return nil, domain.ErrMaxDepthReached
+	}
+
+	return domain.NewReply(parent, input.AuthorID, input.Content), nil
+}
+
 func (useCase *UpdateCommentUC) Execute(ctx context.Context, commentID, authorID uuid.UUID, content string) error {
 	comment, err := useCase.repo.Find(ctx, commentID)
 
@@ -52,11 +147,20 @@ func (useCase *UpdateCommentUC) Execute(ctx context.Context, commentID, authorID
 		return err
 	}
 
+	// The comments in the trash have to be restored before being edited.
+	if comment.IsDeleted() {
+		return domain.ErrCommentNotFound
+	}
+
 	if comment.UserID != authorID {
 		return domain.ErrUnauthorized
 	}
 
-	comment.Content = content
+	comment.SetContent(content, useCase.renderer)
+
+	if err := useCase.repo.Update(ctx, comment); err != nil {
+		return err
+	}
 
-	return useCase.repo.Update(ctx, comment)
+	return useCase.searchIndex.Index(ctx, domain.NewCommentSearchDocument(comment))
 }
@@ -3,32 +3,238 @@ package comments
 import (
 	"comu/internal/modules/post/domain"
 	"comu/internal/modules/post/infra/memory"
+	"comu/internal/modules/post/infra/service"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+type notificationServiceSpy struct {
+	commented        []domain.Comment
+	commentFollowers []uuid.UUID
+}
+
+func (spy *notificationServiceSpy) NotifyPostCommented(ctx context.Context, post *domain.Post, comment *domain.Comment) {
+	spy.commented = append(spy.commented, *comment)
+}
+
+func (spy *notificationServiceSpy) PublishCommentCreated(ctx context.Context, followerIDs []uuid.UUID, comment *domain.Comment) {
+	spy.commentFollowers = followerIDs
+}
+
+func (spy *notificationServiceSpy) PublishPostUpdated(ctx context.Context, followerIDs []uuid.UUID, post *domain.Post) {
+}
+
+type webhookServiceSpy struct {
+	events []string
+}
+
+func (spy *webhookServiceSpy) PostCreated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.created")
+}
+
+func (spy *webhookServiceSpy) PostUpdated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.updated")
+}
+
+func (spy *webhookServiceSpy) PostDeleted(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.deleted")
+}
+
+func (spy *webhookServiceSpy) PostRestored(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.restored")
+}
+
+func (spy *webhookServiceSpy) CommentCreated(ctx context.Context, comment *domain.Comment) {
+	spy.events = append(spy.events, "comment.created")
+}
+
 func TestCreateCommentUseCase(t *testing.T) {
-	repo := memory.NewInMemoryCommentsRepository(nil)
-	useCase := NewCreateCommentUseCase(repo)
 
-	input := CreateCommentInput{
-		PostID:   uuid.New(),
-		AuthorID: uuid.New(),
-		Content:  "Test comment",
-	}
+	t.Run("it should fail and return ErrPostNotFound", func(t *testing.T) {
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewCreateCommentUseCase(repo, postsRepo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultCommentMaxDepth)
+
+		_, err := useCase.Execute(context.Background(), CreateCommentInput{
+			PostID:   uuid.New(),
+			AuthorID: uuid.New(),
+			Content:  "Test comment",
+		})
+		assert.ErrorIs(t, err, domain.ErrPostNotFound)
+	})
+
+	t.Run("it should fail to comment an unpublished post", func(t *testing.T) {
+		ctx := context.Background()
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewCreateCommentUseCase(memory.NewInMemoryCommentsRepository(nil), postsRepo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultCommentMaxDepth)
+
+		post, _ := domain.NewPostWithStatus(uuid.New(), "Post title", "Post content", domain.DraftPostStatus, nil, time.Now())
+		postsRepo.Store(ctx, post)
+
+		_, err := useCase.Execute(ctx, CreateCommentInput{
+			PostID:   post.ID,
+			AuthorID: post.UserID,
+			Content:  "Test comment",
+		})
+		assert.ErrorIs(t, err, domain.ErrPostNotFound)
+	})
+
+	t.Run("it should store the comment and notify the post author", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		spy := &notificationServiceSpy{}
+		webhookSpy := &webhookServiceSpy{}
+		useCase := NewCreateCommentUseCase(repo, postsRepo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), spy, webhookSpy, domain.DefaultCommentMaxDepth)
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+
+		comment, err := useCase.Execute(ctx, CreateCommentInput{
+			PostID:   post.ID,
+			AuthorID: uuid.New(),
+			Content:  "Test comment",
+		})
+
+		if _assert.NoError(err) && _assert.Len(spy.commented, 1) {
+			_assert.Equal(comment.ID, spy.commented[0].ID)
+			_assert.Equal([]string{"comment.created"}, webhookSpy.events)
+		}
+	})
 
-	_, err := useCase.Execute(context.Background(), input)
-	assert.NoError(t, err)
+	t.Run("it should publish the comment to the post followers but the commenter, then make them follow", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		followsRepo := memory.NewInMemoryFollowsRepository(nil)
+		spy := &notificationServiceSpy{}
+		useCase := NewCreateCommentUseCase(repo, postsRepo, followsRepo, memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), spy, &webhookServiceSpy{}, domain.DefaultCommentMaxDepth)
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		followerID, commenterID := uuid.New(), uuid.New()
+		followsRepo.Follow(ctx, post.ID, followerID)
+		followsRepo.Follow(ctx, post.ID, commenterID)
+
+		_, err := useCase.Execute(ctx, CreateCommentInput{
+			PostID:   post.ID,
+			AuthorID: commenterID,
+			Content:  "Test comment",
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal([]uuid.UUID{followerID}, spy.commentFollowers)
+			followers, _ := followsRepo.FindFollowers(ctx, post.ID)
+			_assert.Contains(followers, commenterID)
+		}
+	})
+
+	t.Run("it should store a reply one level deeper than its parent", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewCreateCommentUseCase(repo, postsRepo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultCommentMaxDepth)
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		parent := domain.NewComment(post.ID, uuid.New(), "Parent comment")
+		repo.Store(ctx, parent)
+
+		reply, err := useCase.Execute(ctx, CreateCommentInput{
+			PostID:   post.ID,
+			ParentID: &parent.ID,
+			AuthorID: uuid.New(),
+			Content:  "Test reply",
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal(parent.ID, *reply.ParentID)
+			_assert.Equal(1, reply.Depth)
+		}
+	})
+
+	t.Run("it should fail to reply to a comment of another post or a deleted one", func(t *testing.T) {
+		ctx := context.Background()
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewCreateCommentUseCase(repo, postsRepo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultCommentMaxDepth)
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		otherPostComment := domain.NewComment(uuid.New(), uuid.New(), "Other post comment")
+		repo.Store(ctx, otherPostComment)
+		deletedComment := domain.NewComment(post.ID, uuid.New(), "Deleted comment")
+		deletedComment.Delete(time.Now())
+		repo.Store(ctx, deletedComment)
+
+		for _, parentID := range []uuid.UUID{uuid.New(), otherPostComment.ID, deletedComment.ID} {
+			_, err := useCase.Execute(ctx, CreateCommentInput{
+				PostID:   post.ID,
+				ParentID: &parentID,
+				AuthorID: uuid.New(),
+				Content:  "Test reply",
+			})
+			assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+		}
+	})
+
+	t.Run("it should fail to reply beyond the max depth", func(t *testing.T) {
+		ctx := context.Background()
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewCreateCommentUseCase(repo, postsRepo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), &notificationServiceSpy{}, &webhookServiceSpy{}, 1)
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		parent := domain.NewComment(post.ID, uuid.New(), "Parent comment")
+		repo.Store(ctx, parent)
+		reply := domain.NewReply(parent, uuid.New(), "Reply")
+		repo.Store(ctx, reply)
+
+		_, err := useCase.Execute(ctx, CreateCommentInput{
+			PostID:   post.ID,
+			ParentID: &reply.ID,
+			AuthorID: uuid.New(),
+			Content:  "Test reply",
+		})
+		assert.ErrorIs(t, err, domain.ErrMaxDepthReached)
+	})
+
+	t.Run("it should not notify the author commenting their own post", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		spy := &notificationServiceSpy{}
+		useCase := NewCreateCommentUseCase(repo, postsRepo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), spy, &webhookServiceSpy{}, domain.DefaultCommentMaxDepth)
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+
+		_, err := useCase.Execute(ctx, CreateCommentInput{
+			PostID:   post.ID,
+			AuthorID: post.UserID,
+			Content:  "Test comment",
+		})
+
+		if _assert.NoError(err) {
+			_assert.Empty(spy.commented)
+		}
+	})
 }
 
 func TestUpdateCommentUseCase(t *testing.T) {
 
 	t.Run("it should fail and return ErrCommentNotFound", func(t *testing.T) {
 		repo := memory.NewInMemoryCommentsRepository(nil)
-		useCase := NewUpdateCommentUseCase(repo)
+		useCase := NewUpdateCommentUseCase(repo, memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer())
 
 		err := useCase.Execute(context.Background(), uuid.New(), uuid.New(), "Test comment text")
 		assert.ErrorIs(t, err, domain.ErrCommentNotFound)
@@ -41,7 +247,7 @@ func TestUpdateCommentUseCase(t *testing.T) {
 		comment := domain.NewComment(uuid.New(), uuid.New(), "Comment content")
 		repo.Store(ctx, comment)
 
-		useCase := NewUpdateCommentUseCase(repo)
+		useCase := NewUpdateCommentUseCase(repo, memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer())
 		err := useCase.Execute(ctx, comment.ID, uuid.New(), "Updated comment content")
 		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 	})
@@ -54,12 +260,13 @@ func TestUpdateCommentUseCase(t *testing.T) {
 		comment := domain.NewComment(uuid.New(), uuid.New(), "Comment content")
 		repo.Store(ctx, comment)
 
-		useCase := NewUpdateCommentUseCase(repo)
-		err := useCase.Execute(ctx, comment.ID, comment.UserID, "Updated comment content")
+		useCase := NewUpdateCommentUseCase(repo, memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer())
+		err := useCase.Execute(ctx, comment.ID, comment.UserID, "Updated **comment** content")
 
 		if _assert.NoError(err) {
 			retrievedComment, _ := repo.Find(ctx, comment.ID)
 			_assert.NotEqual(comment.Content, retrievedComment.Content)
+			_assert.Equal("<p>Updated <strong>comment</strong> content</p>\n", retrievedComment.ContentHTML)
 			_assert.NotEqual(comment.UpdatedAt, retrievedComment.UpdatedAt)
 			_assert.Equal(comment.CreatedAt, retrievedComment.CreatedAt)
 		}
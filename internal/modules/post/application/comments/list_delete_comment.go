@@ -3,31 +3,50 @@ package comments
 import (
 	"comu/internal/modules/post/domain"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type ListCommentsUC struct {
-	repo domain.CommentRepository
+	repo          domain.CommentRepository
+	reactionsRepo domain.ReactionsRepository
+}
+
+type ListRepliesUC struct {
+	repo          domain.CommentRepository
+	reactionsRepo domain.ReactionsRepository
 }
 
 type DeleteCommentUC struct {
-	repo domain.CommentRepository
+	repo        domain.CommentRepository
+	searchIndex domain.SearchIndex
 }
 
-func NewListCommentsUseCase(repository domain.CommentRepository) *ListCommentsUC {
+func NewListCommentsUseCase(repository domain.CommentRepository, reactionsRepository domain.ReactionsRepository) *ListCommentsUC {
 	return &ListCommentsUC{
-		repo: repository,
+		repo:          repository,
+		reactionsRepo: reactionsRepository,
+	}
+}
+
+func NewListRepliesUseCase(repository domain.CommentRepository, reactionsRepository domain.ReactionsRepository) *ListRepliesUC {
+	return &ListRepliesUC{
+		repo:          repository,
+		reactionsRepo: reactionsRepository,
 	}
 }
 
-func NewDeleteCommentUseCase(repository domain.CommentRepository) *DeleteCommentUC {
+func NewDeleteCommentUseCase(repository domain.CommentRepository, searchIndex domain.SearchIndex) *DeleteCommentUC {
 	return &DeleteCommentUC{
-		repo: repository,
+		repo:        repository,
+		searchIndex: searchIndex,
 	}
 }
 
-func (useCase *ListCommentsUC) Execute(ctx context.Context, postID uuid.UUID, paginator domain.Paginator) ([]domain.Comment, *domain.Cursor, error) {
+// Execute returns the top-level comments of the post, with their replies count and
+// their reactions as seen by the viewer.
+func (useCase *ListCommentsUC) Execute(ctx context.Context, viewerID, postID uuid.UUID, paginator domain.Paginator) ([]domain.Comment, *domain.Cursor, error) {
 	if paginator.Limit <= 0 {
 		paginator.Limit = domain.DefaultPaginatorLimit
 	}
@@ -37,10 +56,41 @@ func (useCase *ListCommentsUC) Execute(ctx context.Context, postID uuid.UUID, pa
 	if err != nil {
 		return []domain.Comment{}, nil, err
 	}
+	hideDeletedContent(comments)
+
+	if err := domain.WithCommentsReactions(ctx, useCase.reactionsRepo, comments, viewerID); err != nil {
+		return []domain.Comment{}, nil, err
+	}
 
 	return comments, cursor, nil
 }
 
+// Execute returns the direct replies of the comment, the oldest first, with their
+// reactions as seen by the viewer.
+func (useCase *ListRepliesUC) Execute(ctx context.Context, viewerID, commentID uuid.UUID, paginator domain.Paginator) ([]domain.Comment, *domain.Cursor, error) {
+	if paginator.Limit <= 0 {
+		paginator.Limit = domain.DefaultPaginatorLimit
+	}
+
+	if _, err := useCase.repo.Find(ctx, commentID); err != nil {
+		return []domain.Comment{}, nil, err
+	}
+	replies, cursor, err := useCase.repo.ListReplies(ctx, commentID, paginator)
+
+	if err != nil {
+		return []domain.Comment{}, nil, err
+	}
+	hideDeletedContent(replies)
+
+	if err := domain.WithCommentsReactions(ctx, useCase.reactionsRepo, replies, viewerID); err != nil {
+		return []domain.Comment{}, nil, err
+	}
+
+	return replies, cursor, nil
+}
+
+// Execute moves the comment to the trash. It stays in the thread as a tombstone while
+// it has replies, and its content is kept until the purge so that it can be restored.
 func (useCase *DeleteCommentUC) Execute(ctx context.Context, commentID, authorID uuid.UUID) error {
 	comment, err := useCase.repo.Find(ctx, commentID)
 
@@ -48,9 +98,29 @@ func (useCase *DeleteCommentUC) Execute(ctx context.Context, commentID, authorID
 		return err
 	}
 
+	if comment.IsDeleted() {
+		return domain.ErrCommentNotFound
+	}
+
 	if comment.UserID != authorID {
 		return domain.ErrUnauthorized
 	}
 
-	return useCase.repo.Delete(ctx, comment)
+	// The tombstones are not searchable, like the deleted comments.
+	if err := useCase.searchIndex.Remove(ctx, domain.CommentSearchDocument, comment.ID); err != nil {
+		return err
+	}
+	comment.Delete(time.Now())
+
+	return useCase.repo.Update(ctx, comment)
+}
+
+// hideDeletedContent tombstones the listed deleted comments, whose content is only
+// kept for their author to restore them.
+func hideDeletedContent(comments []domain.Comment) {
+	for i := range comments {
+		if comments[i].IsDeleted() {
+			comments[i].Tombstone()
+		}
+	}
 }
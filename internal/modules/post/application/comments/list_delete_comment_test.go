@@ -20,9 +20,9 @@ func TestListCommentUseCase(t *testing.T) {
 		repo.FillWithRandomComments(postID, uuid.Nil, 15)
 		repo.FillWithRandomComments(uuid.Nil, uuid.Nil, 10)
 
-		useCase := NewListCommentsUseCase(repo)
+		useCase := NewListCommentsUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
 
-		comments, cursor, err := useCase.Execute(context.Background(), postID, domain.Paginator{Limit: -3})
+		comments, cursor, err := useCase.Execute(context.Background(), uuid.New(), postID, domain.Paginator{Limit: -3})
 
 		if _assert.NoError(err) {
 			_assert.Equal(10, len(comments))
@@ -36,9 +36,9 @@ func TestListCommentUseCase(t *testing.T) {
 		postID := uuid.New()
 
 		repo.FillWithRandomComments(postID, uuid.Nil, 15)
-		useCase := NewListCommentsUseCase(repo)
+		useCase := NewListCommentsUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
 
-		comments, cursor, err := useCase.Execute(context.Background(), postID, domain.Paginator{})
+		comments, cursor, err := useCase.Execute(context.Background(), uuid.New(), postID, domain.Paginator{})
 
 		if _assert.NoError(err) {
 			_assert.Equal(10, len(comments))
@@ -57,9 +57,9 @@ func TestListCommentUseCase(t *testing.T) {
 		repo.Store(ctx, comment)
 		repo.FillWithRandomComments(postID, uuid.Nil, 12)
 
-		useCase := NewListCommentsUseCase(repo)
+		useCase := NewListCommentsUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
 
-		comments, nextCursor, err := useCase.Execute(ctx, postID, domain.Paginator{
+		comments, nextCursor, err := useCase.Execute(ctx, uuid.New(), postID, domain.Paginator{
 			Limit: 8,
 			After: &domain.Cursor{
 				ID:        comment.ID,
@@ -78,11 +78,46 @@ func TestListCommentUseCase(t *testing.T) {
 
 }
 
+func TestListRepliesUseCase(t *testing.T) {
+	t.Run("it should fail and return ErrCommentNotFound", func(t *testing.T) {
+		useCase := NewListRepliesUseCase(memory.NewInMemoryCommentsRepository(nil), memory.NewInMemoryReactionsRepository(nil))
+
+		_, _, err := useCase.Execute(context.Background(), uuid.New(), uuid.New(), domain.Paginator{})
+		assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+	})
+
+	t.Run("it should return the replies of the comment", func(t *testing.T) {
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		parent := domain.NewComment(uuid.New(), uuid.New(), "Test comment")
+		repo.Store(ctx, parent)
+
+		for range 12 {
+			repo.Store(ctx, domain.NewReply(parent, uuid.New(), "Test reply"))
+		}
+		repo.FillWithRandomComments(parent.PostID, uuid.Nil, 3)
+
+		useCase := NewListRepliesUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
+		replies, cursor, err := useCase.Execute(ctx, uuid.New(), parent.ID, domain.Paginator{})
+
+		if _assert.NoError(err) && _assert.Equal(10, len(replies)) {
+			_assert.NotNil(cursor)
+
+			for _, reply := range replies {
+				_assert.Equal(parent.ID, *reply.ParentID)
+				_assert.NotNil(reply.Reactions)
+			}
+		}
+	})
+}
+
 func TestDeleteCommentUseCase(t *testing.T) {
 
 	t.Run("it should fail and return ErrCommentNotFound", func(t *testing.T) {
 		repo := memory.NewInMemoryCommentsRepository(nil)
-		useCase := NewDeleteCommentUseCase(repo)
+		useCase := NewDeleteCommentUseCase(repo, memory.NewInMemorySearchIndex(nil))
 
 		err := useCase.Execute(context.Background(), uuid.New(), uuid.New())
 		assert.ErrorIs(t, err, domain.ErrCommentNotFound)
@@ -95,26 +130,58 @@ func TestDeleteCommentUseCase(t *testing.T) {
 		comment := domain.NewComment(uuid.New(), uuid.New(), "Test comment")
 		repo.Store(ctx, comment)
 
-		useCase := NewDeleteCommentUseCase(repo)
+		useCase := NewDeleteCommentUseCase(repo, memory.NewInMemorySearchIndex(nil))
 
 		err := useCase.Execute(ctx, comment.ID, uuid.New())
 		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 	})
 
-	t.Run("it should succeed and delete the given comment", func(t *testing.T) {
+	t.Run("it should succeed and move the given comment to the trash", func(t *testing.T) {
 		repo := memory.NewInMemoryCommentsRepository(nil)
 		ctx := context.Background()
+		_assert := assert.New(t)
 
 		comment := domain.NewComment(uuid.New(), uuid.New(), "Test comment")
 		repo.Store(ctx, comment)
 
-		useCase := NewDeleteCommentUseCase(repo)
+		useCase := NewDeleteCommentUseCase(repo, memory.NewInMemorySearchIndex(nil))
+		err := useCase.Execute(ctx, comment.ID, comment.UserID)
+
+		if _assert.NoError(err) {
+			deleted, err := repo.Find(ctx, comment.ID)
+
+			if _assert.NoError(err) {
+				_assert.True(deleted.IsDeleted())
+				_assert.Equal("Test comment", deleted.Content)
+			}
+			comments, _, _ := repo.List(ctx, comment.PostID, domain.Paginator{Limit: 10})
+			_assert.Empty(comments)
+			_assert.ErrorIs(useCase.Execute(ctx, comment.ID, comment.UserID), domain.ErrCommentNotFound)
+		}
+	})
+
+	t.Run("it should keep a comment having replies as a tombstone in the thread", func(t *testing.T) {
+		repo := memory.NewInMemoryCommentsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		comment := domain.NewComment(uuid.New(), uuid.New(), "Test comment")
+		repo.Store(ctx, comment)
+		reply := domain.NewReply(comment, uuid.New(), "Test reply")
+		repo.Store(ctx, reply)
 
+		useCase := NewDeleteCommentUseCase(repo, memory.NewInMemorySearchIndex(nil))
 		err := useCase.Execute(ctx, comment.ID, comment.UserID)
 
-		if assert.NoError(t, err) {
-			_, err := repo.Find(ctx, comment.ID)
-			assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+		if _assert.NoError(err) {
+			listUseCase := NewListCommentsUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
+			comments, _, err := listUseCase.Execute(ctx, uuid.Nil, comment.PostID, domain.Paginator{})
+
+			if _assert.NoError(err) && _assert.Len(comments, 1) {
+				_assert.True(comments[0].IsDeleted())
+				_assert.Empty(comments[0].Content)
+				_assert.Equal(1, comments[0].RepliesCount)
+			}
 		}
 	})
 }
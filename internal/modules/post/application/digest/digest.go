@@ -0,0 +1,138 @@
+package digest
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDigestCandidates caps how many new posts are ranked for the digests.
+const MaxDigestCandidates = 100
+
+type PostComment struct {
+	Post    domain.Post
+	Comment domain.Comment
+}
+
+type TopPost struct {
+	Post          domain.Post
+	CommentsCount int
+}
+
+type ListNewCommentsUC struct {
+	postsRepository    domain.PostRepository
+	commentsRepository domain.CommentRepository
+}
+
+type ListTopNewPostsUC struct {
+	postsRepository    domain.PostRepository
+	commentsRepository domain.CommentRepository
+}
+
+func NewListNewCommentsUseCase(
+	postsRepository domain.PostRepository,
+	commentsRepository domain.CommentRepository,
+) *ListNewCommentsUC {
+	return &ListNewCommentsUC{
+		postsRepository:    postsRepository,
+		commentsRepository: commentsRepository,
+	}
+}
+
+func NewListTopNewPostsUseCase(
+	postsRepository domain.PostRepository,
+	commentsRepository domain.CommentRepository,
+) *ListTopNewPostsUC {
+	return &ListTopNewPostsUC{
+		postsRepository:    postsRepository,
+		commentsRepository: commentsRepository,
+	}
+}
+
+// Execute returns the comments others made on the author posts between since and until.
+// The comments of all the posts are listed at once, the deleted ones left out.
+func (useCase *ListNewCommentsUC) Execute(ctx context.Context, authorID uuid.UUID, since, until time.Time) ([]PostComment, error) {
+	posts, err := useCase.postsRepository.ListByAuthor(ctx, authorID)
+
+	if err != nil {
+		return nil, err
+	}
+	postsByID := map[uuid.UUID]domain.Post{}
+
+	for _, post := range posts {
+		if post.CreatedAt.Before(until) {
+			postsByID[post.ID] = post
+		}
+	}
+	postComments, err := useCase.commentsRepository.ListByPosts(
+		ctx, slices.Collect(maps.Keys(postsByID)), since, until,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	comments := []PostComment{}
+
+	for _, comment := range postComments {
+		if comment.UserID != authorID {
+			comments = append(comments, PostComment{Post: postsByID[comment.PostID], Comment: comment})
+		}
+	}
+
+	return comments, nil
+}
+
+// Execute returns the posts created between since and until, the most commented first.
+// List returns the newest posts first, so the paging stops at the first page
+// ending before since. The comments of the candidates are then counted at once.
+func (useCase *ListTopNewPostsUC) Execute(ctx context.Context, since, until time.Time, limit int) ([]TopPost, error) {
+	candidates := []TopPost{}
+	paginator := domain.Paginator{Limit: domain.DefaultPaginatorLimit}
+
+	for len(candidates) < MaxDigestCandidates {
+		posts, cursor, err := useCase.postsRepository.List(ctx, paginator)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, post := range posts {
+			if isBetween(post.CreatedAt, since, until) {
+				candidates = append(candidates, TopPost{Post: post})
+			}
+		}
+
+		if cursor == nil || len(posts) < paginator.Limit || posts[len(posts)-1].CreatedAt.Before(since) {
+			break
+		}
+		paginator.After = cursor
+	}
+	postIDs := make([]uuid.UUID, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		postIDs = append(postIDs, candidate.Post.ID)
+	}
+	counts, err := useCase.commentsRepository.CountByPosts(ctx, postIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		candidates[i].CommentsCount = counts[candidates[i].Post.ID]
+	}
+
+	slices.SortStableFunc(candidates, func(a, b TopPost) int {
+		return b.CommentsCount - a.CommentsCount
+	})
+
+	return candidates[:min(limit, len(candidates))], nil
+}
+
+func isBetween(t, since, until time.Time) bool {
+	return !t.Before(since) && t.Before(until)
+}
@@ -0,0 +1,78 @@
+package digest
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListNewCommentsUseCase(t *testing.T) {
+	_assert := assert.New(t)
+	ctx := context.Background()
+	postsRepo := memory.NewInMemoryPostsRepository(nil)
+	commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+	authorID := uuid.New()
+
+	post := domain.NewPost(authorID, "Post title", "Post content")
+	postsRepo.Store(ctx, post)
+	otherPost := domain.NewPost(uuid.New(), "Other post", "Other content")
+	postsRepo.Store(ctx, otherPost)
+
+	comment := domain.NewComment(post.ID, uuid.New(), "Nice post")
+	commentsRepo.Store(ctx, comment)
+	commentsRepo.Store(ctx, domain.NewComment(post.ID, authorID, "Thanks"))
+	commentsRepo.Store(ctx, domain.NewComment(otherPost.ID, uuid.New(), "Not on the author posts"))
+	deleted := domain.NewComment(post.ID, uuid.New(), "Deleted comment")
+	commentsRepo.Store(ctx, deleted)
+	deleted.Delete(time.Now())
+	commentsRepo.Update(ctx, deleted)
+
+	comments, err := NewListNewCommentsUseCase(postsRepo, commentsRepo).Execute(
+		ctx, authorID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour),
+	)
+
+	if _assert.NoError(err) && _assert.Len(comments, 1) {
+		_assert.Equal(comment.ID, comments[0].Comment.ID)
+		_assert.Equal(post.Title, comments[0].Post.Title)
+	}
+
+	comments, err = NewListNewCommentsUseCase(postsRepo, commentsRepo).Execute(
+		ctx, authorID, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour),
+	)
+
+	_assert.NoError(err)
+	_assert.Empty(comments)
+}
+
+func TestListTopNewPostsUseCase(t *testing.T) {
+	_assert := assert.New(t)
+	ctx := context.Background()
+	postsRepo := memory.NewInMemoryPostsRepository(nil)
+	commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+
+	postsRepo.FillWithRandomPosts(uuid.Nil, 2*domain.DefaultPaginatorLimit)
+	post := domain.NewPost(uuid.New(), "Most commented", "Post content")
+	postsRepo.Store(ctx, post)
+
+	for range 3 {
+		commentsRepo.Store(ctx, domain.NewComment(post.ID, uuid.New(), "Nice post"))
+	}
+	deleted := domain.NewComment(post.ID, uuid.New(), "Deleted comment")
+	commentsRepo.Store(ctx, deleted)
+	deleted.Delete(time.Now())
+	commentsRepo.Update(ctx, deleted)
+
+	posts, err := NewListTopNewPostsUseCase(postsRepo, commentsRepo).Execute(
+		ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), 5,
+	)
+
+	if _assert.NoError(err) && _assert.Len(posts, 5) {
+		_assert.Equal(post.ID, posts[0].Post.ID)
+		_assert.Equal(3, posts[0].CommentsCount)
+	}
+}
@@ -0,0 +1,48 @@
+package follows
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type FollowPostUC struct {
+	repo      domain.FollowsRepository
+	postsRepo domain.PostRepository
+}
+
+type UnfollowPostUC struct {
+	repo domain.FollowsRepository
+}
+
+func NewFollowPostUseCase(repository domain.FollowsRepository, postsRepository domain.PostRepository) *FollowPostUC {
+	return &FollowPostUC{
+		repo:      repository,
+		postsRepo: postsRepository,
+	}
+}
+
+func NewUnfollowPostUseCase(repository domain.FollowsRepository) *UnfollowPostUC {
+	return &UnfollowPostUC{
+		repo: repository,
+	}
+}
+
+func (useCase *FollowPostUC) Execute(ctx context.Context, postID, userID uuid.UUID) error {
+	post, err := useCase.postsRepo.FindByID(ctx, postID)
+
+	if err != nil {
+		return err
+	}
+
+	if !post.IsVisibleTo(userID) {
+		return domain.ErrPostNotFound
+	}
+
+	return useCase.repo.Follow(ctx, postID, userID)
+}
+
+func (useCase *UnfollowPostUC) Execute(ctx context.Context, postID, userID uuid.UUID) error {
+	return useCase.repo.Unfollow(ctx, postID, userID)
+}
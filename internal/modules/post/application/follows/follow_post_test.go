@@ -0,0 +1,43 @@
+package follows
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowPostUseCase(t *testing.T) {
+
+	t.Run("it should fail and return ErrPostNotFound", func(t *testing.T) {
+		useCase := NewFollowPostUseCase(
+			memory.NewInMemoryFollowsRepository(nil),
+			memory.NewInMemoryPostsRepository(nil),
+		)
+
+		err := useCase.Execute(context.Background(), uuid.New(), uuid.New())
+		assert.ErrorIs(t, err, domain.ErrPostNotFound)
+	})
+
+	t.Run("it should make the user follow the post, then unfollow it", func(t *testing.T) {
+		_assert := assert.New(t)
+		ctx := context.Background()
+		repo := memory.NewInMemoryFollowsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		userID := uuid.New()
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+
+		_assert.NoError(NewFollowPostUseCase(repo, postsRepo).Execute(ctx, post.ID, userID))
+		followers, _ := repo.FindFollowers(ctx, post.ID)
+		_assert.Contains(followers, userID)
+
+		_assert.NoError(NewUnfollowPostUseCase(repo).Execute(ctx, post.ID, userID))
+		followers, _ = repo.FindFollowers(ctx, post.ID)
+		_assert.NotContains(followers, userID)
+	})
+}
@@ -3,6 +3,8 @@ package posts
 import (
 	"comu/internal/modules/post/domain"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,40 +13,169 @@ type CreatePostInput struct {
 	UserID  uuid.UUID
 	Title   string
 	Content string
+	Tags    []string
+	// Slug is the one chosen by the author, made from the title when empty.
+	Slug string
+	// Status is the post status, published when empty. PublishAt
+	// is only used to schedule the post.
+	Status    domain.PostStatus
+	PublishAt *time.Time
 }
 
 type CreatePostUC struct {
-	repo domain.PostRepository
+	repo           domain.PostRepository
+	followsRepo    domain.FollowsRepository
+	revisionsRepo  domain.RevisionsRepository
+	slugsRepo      domain.SlugsRepository
+	searchIndex    domain.SearchIndex
+	renderer       domain.ContentRenderer
+	transactor     domain.Transactor
+	webhookService domain.WebhookService
+	maxTags        int
 }
 
 type DeletePostUC struct {
-	repo domain.PostRepository
+	repo           domain.PostRepository
+	searchIndex    domain.SearchIndex
+	webhookService domain.WebhookService
 }
 
-func NewCreatePostUseCase(repository domain.PostRepository) *CreatePostUC {
+func NewCreatePostUseCase(
+	repository domain.PostRepository,
+	followsRepository domain.FollowsRepository,
+	revisionsRepository domain.RevisionsRepository,
+	slugsRepository domain.SlugsRepository,
+	searchIndex domain.SearchIndex,
+	renderer domain.ContentRenderer,
+	transactor domain.Transactor,
+	webhookService domain.WebhookService,
+	maxTags int,
+) *CreatePostUC {
 	return &CreatePostUC{
-		repo: repository,
+		repo:           repository,
+		followsRepo:    followsRepository,
+		revisionsRepo:  revisionsRepository,
+		slugsRepo:      slugsRepository,
+		searchIndex:    searchIndex,
+		renderer:       renderer,
+		transactor:     transactor,
+		webhookService: webhookService,
+		maxTags:        maxTags,
 	}
 }
 
-func NewDeletePostUseCase(repository domain.PostRepository) *DeletePostUC {
+func NewDeletePostUseCase(
+	repository domain.PostRepository,
+	searchIndex domain.SearchIndex,
+	webhookService domain.WebhookService,
+) *DeletePostUC {
 	return &DeletePostUC{
-		repo: repository,
+		repo:           repository,
+		searchIndex:    searchIndex,
+		webhookService: webhookService,
 	}
 }
 
 func (useCase *CreatePostUC) Execute(ctx context.Context, input CreatePostInput) (*domain.Post, error) {
-	post := domain.NewPost(input.UserID, input.Title, input.Content)
+	tags, err := domain.NormalizeTags(input.Tags, useCase.maxTags)
 
-	err := useCase.repo.Store(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	post, err := domain.NewPostWithStatus(
+		input.UserID, input.Title, input.Content,
+		input.Status, input.PublishAt, time.Now(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	post.Tags = tags
+	post.SetContent(input.Content, useCase.renderer)
+
+	if post.Slug, err = useCase.newSlug(ctx, post.Slug, input.Slug); err != nil {
+		return nil, err
+	}
+
+	// The post is stored with its slug and first revision.
+	err = useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := useCase.repo.Store(ctx, post); err != nil {
+			return err
+		}
+
+		if err := useCase.slugsRepo.Store(ctx, post.ID, post.Slug); err != nil {
+			return err
+		}
+
+		return useCase.revisionsRepo.Store(ctx, domain.NewRevision(post, post.UserID))
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	// The authors follow their posts.
+	if err = useCase.followsRepo.Follow(ctx, post.ID, post.UserID); err != nil {
+		return nil, err
+	}
+
+	// The unpublished posts are indexed and announced once published.
+	if !post.IsPublished() {
+		return post, nil
+	}
+
+	if err = useCase.searchIndex.Index(ctx, domain.NewPostSearchDocument(post)); err != nil {
+		return nil, err
+	}
+	useCase.webhookService.PostCreated(ctx, post)
+
 	return post, nil
 }
 
+// newSlug returns the slug chosen by the author, which must be free, or the
+// one made from the title, suffixed when taken.
+func (useCase *CreatePostUC) newSlug(ctx context.Context, titleSlug, chosenSlug string) (string, error) {
+	if chosenSlug == "" {
+		return availableSlug(ctx, useCase.slugsRepo, uuid.Nil, titleSlug)
+	}
+
+	if err := domain.ValidateSlug(chosenSlug); err != nil {
+		return "", err
+	}
+	_, err := useCase.slugsRepo.FindPostID(ctx, chosenSlug)
+
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound):
+		return chosenSlug, nil
+	case err != nil:
+		return "", err
+	}
+
+	return "", domain.ErrSlugTaken
+}
+
+// availableSlug returns the slug, or the first of its suffixed versions
+// no other post than the given one has or had.
+func availableSlug(ctx context.Context, repo domain.SlugsRepository, postID uuid.UUID, slug string) (string, error) {
+	candidate := slug
+
+	for range domain.SlugAttempts {
+		ownerID, err := repo.FindPostID(ctx, candidate)
+
+		if errors.Is(err, domain.ErrPostNotFound) || (err == nil && ownerID == postID) {
+			return candidate, nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+		candidate = domain.WithSlugSuffix(slug)
+	}
+
+	return "", domain.ErrSlugTaken
+}
+
+// Execute moves the post to the trash, from which its author can restore it until the purge.
 func (useCase *DeletePostUC) Execute(ctx context.Context, postID, authorID uuid.UUID) error {
 	post, err := useCase.repo.FindByID(ctx, postID)
 
@@ -55,6 +186,21 @@ func (useCase *DeletePostUC) Execute(ctx context.Context, postID, authorID uuid.
 	if post.UserID != authorID {
 		return domain.ErrUnauthorized
 	}
+	post.Delete(time.Now())
+
+	if err = useCase.repo.Delete(ctx, post); err != nil {
+		return err
+	}
+
+	// The unpublished posts were neither indexed nor sent to the webhooks.
+	if !post.IsPublic() {
+		return nil
+	}
+
+	if err = useCase.searchIndex.Remove(ctx, domain.PostSearchDocument, post.ID); err != nil {
+		return err
+	}
+	useCase.webhookService.PostDeleted(ctx, post)
 
-	return useCase.repo.Delete(ctx, post)
+	return nil
 }
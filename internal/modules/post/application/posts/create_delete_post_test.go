@@ -3,21 +3,50 @@ package posts
 import (
 	"comu/internal/modules/post/domain"
 	"comu/internal/modules/post/infra/memory"
+	"comu/internal/modules/post/infra/service"
+	"comu/internal/shared/database"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+type webhookServiceSpy struct {
+	events []string
+}
+
+func (spy *webhookServiceSpy) PostCreated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.created")
+}
+
+func (spy *webhookServiceSpy) PostUpdated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.updated")
+}
+
+func (spy *webhookServiceSpy) PostDeleted(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.deleted")
+}
+
+func (spy *webhookServiceSpy) PostRestored(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.restored")
+}
+
+func (spy *webhookServiceSpy) CommentCreated(ctx context.Context, comment *domain.Comment) {
+	spy.events = append(spy.events, "comment.created")
+}
+
 func TestCreatePostUseCase(t *testing.T) {
 	repo := memory.NewInMemoryPostsRepository(nil)
-	useCase := NewCreatePostUseCase(repo)
+	followsRepo := memory.NewInMemoryFollowsRepository(nil)
+	spy := &webhookServiceSpy{}
+	useCase := NewCreatePostUseCase(repo, followsRepo, memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, spy, domain.DefaultMaxTagsPerPost)
 
 	input := CreatePostInput{
 		UserID:  uuid.New(),
 		Title:   "Test post",
-		Content: "This is a test post",
+		Content: "This is a *test* post",
 	}
 
 	post, err := useCase.Execute(context.Background(), input)
@@ -27,7 +56,99 @@ func TestCreatePostUseCase(t *testing.T) {
 		_assert.Equal(input.UserID, post.UserID)
 		_assert.Equal(input.Title, post.Title)
 		_assert.Equal(input.Content, post.Content)
+		_assert.Equal("<p>This is a <em>test</em> post</p>\n", post.ContentHTML)
+		_assert.Equal("This is a test post", post.Excerpt)
+		_assert.Equal(5, post.WordCount)
+		_assert.Equal(1, post.ReadingTime)
+
+		followers, _ := followsRepo.FindFollowers(context.Background(), post.ID)
+		_assert.Equal([]uuid.UUID{input.UserID}, followers)
+		_assert.Equal([]string{"post.created"}, spy.events)
+	}
+}
+
+func TestCreatePostUseCaseTags(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewInMemoryPostsRepository(nil)
+	useCase := NewCreatePostUseCase(repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, &webhookServiceSpy{}, 2)
+	input := CreatePostInput{
+		UserID:  uuid.New(),
+		Title:   "Test post",
+		Content: "This is a test post",
+	}
+
+	t.Run("it should normalize the tags and remove the duplicates", func(t *testing.T) {
+		input.Tags = []string{"Go Lang", "go_lang", "web"}
+		post, err := useCase.Execute(ctx, input)
+
+		if assert.NoError(t, err) {
+			stored, _ := repo.FindByID(ctx, post.ID)
+			assert.Equal(t, []string{"go-lang", "web"}, stored.Tags)
+		}
+	})
+
+	t.Run("it should fail with invalid or too many tags", func(t *testing.T) {
+		input.Tags = []string{"go", "web", "api"}
+		_, err := useCase.Execute(ctx, input)
+		assert.ErrorIs(t, err, domain.ErrTooManyTags)
+
+		input.Tags = []string{"c++"}
+		_, err = useCase.Execute(ctx, input)
+		assert.ErrorIs(t, err, domain.ErrInvalidTag)
+	})
+}
+
+func TestCreatePostUseCaseSlugs(t *testing.T) {
+	ctx := context.Background()
+	slugsRepo := memory.NewInMemorySlugsRepository(nil)
+	useCase := NewCreatePostUseCase(
+		memory.NewInMemoryPostsRepository(nil), memory.NewInMemoryFollowsRepository(nil),
+		memory.NewInMemoryRevisionsRepository(nil), slugsRepo, memory.NewInMemorySearchIndex(nil),
+		service.NewMarkdownRenderer(), database.NoopTransactor{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost,
+	)
+	input := CreatePostInput{
+		UserID:  uuid.New(),
+		Title:   "Ça, c'est l'été à Köln & Łódź!",
+		Content: "This is a test post",
 	}
+
+	t.Run("it should transliterate the title to the slug", func(t *testing.T) {
+		_assert := assert.New(t)
+		post, err := useCase.Execute(ctx, input)
+
+		if _assert.NoError(err) {
+			_assert.Equal("ca-c-est-l-ete-a-koln-and-lodz", post.Slug)
+
+			postID, _ := slugsRepo.FindPostID(ctx, post.Slug)
+			_assert.Equal(post.ID, postID)
+		}
+	})
+
+	t.Run("it should suffix a taken slug", func(t *testing.T) {
+		_assert := assert.New(t)
+		post, err := useCase.Execute(ctx, input)
+
+		if _assert.NoError(err) {
+			_assert.Regexp(`^ca-c-est-l-ete-a-koln-and-lodz-[a-z0-9]{6}$`, post.Slug)
+		}
+	})
+
+	t.Run("it should use the slug chosen by the author when free and valid", func(t *testing.T) {
+		_assert := assert.New(t)
+		input.Slug = "summer-in-cologne"
+		post, err := useCase.Execute(ctx, input)
+
+		if _assert.NoError(err) {
+			_assert.Equal("summer-in-cologne", post.Slug)
+		}
+
+		_, err = useCase.Execute(ctx, input)
+		_assert.ErrorIs(err, domain.ErrSlugTaken)
+
+		input.Slug = "Summer In Cologne"
+		_, err = useCase.Execute(ctx, input)
+		_assert.ErrorIs(err, domain.ErrInvalidSlug)
+	})
 }
 
 func TestDeletePostUseCase(t *testing.T) {
@@ -39,12 +160,14 @@ func TestDeletePostUseCase(t *testing.T) {
 		post := domain.NewPost(userID, "Test post", "Weird test post content")
 		repo.Store(ctx, post)
 
-		useCase := NewDeletePostUseCase(repo)
+		spy := &webhookServiceSpy{}
+		useCase := NewDeletePostUseCase(repo, memory.NewInMemorySearchIndex(nil), spy)
 		err := useCase.Execute(ctx, post.ID, userID)
 
 		if assert.NoError(t, err) {
 			_, err = repo.FindByID(ctx, post.ID)
 			assert.ErrorIs(t, err, domain.ErrPostNotFound)
+			assert.Equal(t, []string{"post.deleted"}, spy.events)
 		}
 	})
 
@@ -55,8 +178,48 @@ func TestDeletePostUseCase(t *testing.T) {
 		post := domain.NewPost(uuid.New(), "Test post", "Weird test post content")
 		repo.Store(ctx, post)
 
-		useCase := NewDeletePostUseCase(repo)
+		spy := &webhookServiceSpy{}
+		useCase := NewDeletePostUseCase(repo, memory.NewInMemorySearchIndex(nil), spy)
 		err := useCase.Execute(ctx, post.ID, uuid.New())
 		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Empty(t, spy.events)
 	})
+
+	t.Run("it should not dispatch the deletion of a draft", func(t *testing.T) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		ctx := context.Background()
+
+		post, _ := domain.NewPostWithStatus(uuid.New(), "Test post", "Secret draft content", domain.DraftPostStatus, nil, time.Now())
+		repo.Store(ctx, post)
+
+		spy := &webhookServiceSpy{}
+		err := NewDeletePostUseCase(repo, memory.NewInMemorySearchIndex(nil), spy).Execute(ctx, post.ID, post.UserID)
+
+		if assert.NoError(t, err) {
+			_, err = repo.FindInTrash(ctx, post.ID)
+			assert.NoError(t, err)
+			assert.Empty(t, spy.events)
+		}
+	})
+}
+
+func TestPostsSearchIndexSync(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewInMemoryPostsRepository(nil)
+	index := memory.NewInMemorySearchIndex(nil)
+	followsRepo := memory.NewInMemoryFollowsRepository(nil)
+	query := domain.SearchQuery{Text: "searchable", Limit: 10}
+
+	post, err := NewCreatePostUseCase(repo, followsRepo, memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), index, service.NewMarkdownRenderer(), database.NoopTransactor{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost).
+		Execute(ctx, CreatePostInput{UserID: uuid.New(), Title: "Searchable post", Content: "Some content"})
+	assert.NoError(t, err)
+
+	results, _, _ := index.Search(ctx, query)
+	assert.Len(t, results, 1)
+
+	err = NewDeletePostUseCase(repo, index, &webhookServiceSpy{}).Execute(ctx, post.ID, post.UserID)
+	assert.NoError(t, err)
+
+	results, _, _ = index.Search(ctx, query)
+	assert.Empty(t, results)
 }
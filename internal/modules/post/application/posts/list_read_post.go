@@ -3,29 +3,53 @@ package posts
 import (
 	"comu/internal/modules/post/domain"
 	"context"
+	"errors"
+
+	"github.com/google/uuid"
 )
 
 type ListPostsUC struct {
+	repo          domain.PostRepository
+	reactionsRepo domain.ReactionsRepository
+}
+
+type ListDraftsUC struct {
 	repo domain.PostRepository
 }
 
 type ReadPostUC struct {
-	repo domain.PostRepository
+	repo          domain.PostRepository
+	slugsRepo     domain.SlugsRepository
+	reactionsRepo domain.ReactionsRepository
 }
 
-func NewListPostsUseCase(repository domain.PostRepository) *ListPostsUC {
+func NewListPostsUseCase(repository domain.PostRepository, reactionsRepository domain.ReactionsRepository) *ListPostsUC {
 	return &ListPostsUC{
+		repo:          repository,
+		reactionsRepo: reactionsRepository,
+	}
+}
+
+func NewListDraftsUseCase(repository domain.PostRepository) *ListDraftsUC {
+	return &ListDraftsUC{
 		repo: repository,
 	}
 }
 
-func NewReadPostUseCase(repository domain.PostRepository) *ReadPostUC {
+func NewReadPostUseCase(
+	repository domain.PostRepository,
+	slugsRepository domain.SlugsRepository,
+	reactionsRepository domain.ReactionsRepository,
+) *ReadPostUC {
 	return &ReadPostUC{
-		repo: repository,
+		repo:          repository,
+		slugsRepo:     slugsRepository,
+		reactionsRepo: reactionsRepository,
 	}
 }
 
-func (useCase *ListPostsUC) Execute(ctx context.Context, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
+// Execute returns a page of posts with their reactions, as seen by the viewer.
+func (useCase *ListPostsUC) Execute(ctx context.Context, viewerID uuid.UUID, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
 	if paginator.Limit <= 0 {
 		paginator.Limit = domain.DefaultPaginatorLimit
 	}
@@ -36,15 +60,58 @@ func (useCase *ListPostsUC) Execute(ctx context.Context, paginator domain.Pagina
 		return []domain.Post{}, nil, err
 	}
 
+	if err := domain.WithPostsReactions(ctx, useCase.reactionsRepo, post, viewerID); err != nil {
+		return []domain.Post{}, nil, err
+	}
+
 	return post, cursor, nil
 }
 
-func (useCase *ReadPostUC) Execute(ctx context.Context, slug string) (*domain.Post, error) {
-	post, err := useCase.repo.FindBySlug(ctx, slug)
+// Execute returns a page of the author draft and scheduled posts, the newest first.
+func (useCase *ListDraftsUC) Execute(ctx context.Context, authorID uuid.UUID, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
+	if paginator.Limit <= 0 {
+		paginator.Limit = domain.DefaultPaginatorLimit
+	}
+
+	return useCase.repo.ListDrafts(ctx, authorID, paginator)
+}
+
+// Execute returns the post, as seen by the viewer. The unpublished posts are only found for their author.
+// A post found from one of its former slugs is returned with its current one, to redirect the viewer to.
+func (useCase *ReadPostUC) Execute(ctx context.Context, viewerID uuid.UUID, slug string) (*domain.Post, error) {
+	post, err := useCase.repo.FindBySlug(ctx, slug, viewerID)
+
+	if errors.Is(err, domain.ErrPostNotFound) {
+		post, err = useCase.findByFormerSlug(ctx, viewerID, slug)
+	}
 
 	if err != nil {
 		return nil, err
 	}
+	posts := []domain.Post{*post}
+
+	if err := domain.WithPostsReactions(ctx, useCase.reactionsRepo, posts, viewerID); err != nil {
+		return nil, err
+	}
+
+	return &posts[0], nil
+}
+
+func (useCase *ReadPostUC) findByFormerSlug(ctx context.Context, viewerID uuid.UUID, slug string) (*domain.Post, error) {
+	postID, err := useCase.slugsRepo.FindPostID(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+	post, err := useCase.repo.FindByID(ctx, postID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.IsVisibleTo(viewerID) {
+		return nil, domain.ErrPostNotFound
+	}
 
 	return post, nil
 }
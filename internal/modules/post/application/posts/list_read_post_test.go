@@ -17,9 +17,9 @@ func TestListPostsUseCase(t *testing.T) {
 		_assert := assert.New(t)
 
 		repo.FillWithRandomPosts(uuid.Nil, 15)
-		useCase := NewListPostsUseCase(repo)
+		useCase := NewListPostsUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
 
-		posts, cursor, err := useCase.Execute(context.Background(), domain.Paginator{Limit: -3})
+		posts, cursor, err := useCase.Execute(context.Background(), uuid.New(), domain.Paginator{Limit: -3})
 
 		if _assert.NoError(err) {
 			_assert.Equal(10, len(posts))
@@ -32,9 +32,9 @@ func TestListPostsUseCase(t *testing.T) {
 		_assert := assert.New(t)
 
 		repo.FillWithRandomPosts(uuid.Nil, 15)
-		useCase := NewListPostsUseCase(repo)
+		useCase := NewListPostsUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
 
-		posts, cursor, err := useCase.Execute(context.Background(), domain.Paginator{})
+		posts, cursor, err := useCase.Execute(context.Background(), uuid.New(), domain.Paginator{})
 
 		if _assert.NoError(err) {
 			_assert.Equal(10, len(posts))
@@ -52,9 +52,9 @@ func TestListPostsUseCase(t *testing.T) {
 		repo.Store(ctx, post)
 		repo.FillWithRandomPosts(uuid.Nil, 12)
 
-		useCase := NewListPostsUseCase(repo)
+		useCase := NewListPostsUseCase(repo, memory.NewInMemoryReactionsRepository(nil))
 
-		posts, nextCursor, err := useCase.Execute(ctx, domain.Paginator{
+		posts, nextCursor, err := useCase.Execute(ctx, uuid.New(), domain.Paginator{
 			Limit: 8,
 			After: &domain.Cursor{
 				ID:        post.ID,
@@ -75,11 +75,11 @@ func TestListPostsUseCase(t *testing.T) {
 func TestReadPostUseCase(t *testing.T) {
 	t.Run("it should fail and return ErrPostNotFound", func(t *testing.T) {
 		repo := memory.NewInMemoryPostsRepository(nil)
-		useCase := NewReadPostUseCase(repo)
+		useCase := NewReadPostUseCase(repo, memory.NewInMemorySlugsRepository(nil), memory.NewInMemoryReactionsRepository(nil))
 
 		slug := domain.MakePostSlug("Test post title")
 
-		_, err := useCase.Execute(context.Background(), slug)
+		_, err := useCase.Execute(context.Background(), uuid.New(), slug)
 		assert.Equal(t, err, domain.ErrPostNotFound)
 	})
 
@@ -91,9 +91,9 @@ func TestReadPostUseCase(t *testing.T) {
 		post := domain.NewPost(uuid.New(), "Test post", "That is test post content")
 		repo.Store(ctx, post)
 
-		useCase := NewReadPostUseCase(repo)
+		useCase := NewReadPostUseCase(repo, memory.NewInMemorySlugsRepository(nil), memory.NewInMemoryReactionsRepository(nil))
 
-		retrievedPost, err := useCase.Execute(ctx, post.Slug)
+		retrievedPost, err := useCase.Execute(ctx, uuid.New(), post.Slug)
 
 		if _assert.NoError(err) {
 			_assert.Equal(post.Title, retrievedPost.Title)
@@ -101,4 +101,52 @@ func TestReadPostUseCase(t *testing.T) {
 			_assert.Equal(post.Content, retrievedPost.Content)
 		}
 	})
+
+	t.Run("it should find the post from a former slug, with its current one", func(t *testing.T) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		slugsRepo := memory.NewInMemorySlugsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		post := domain.NewPost(uuid.New(), "Test post", "That is test post content")
+		repo.Store(ctx, post)
+		slugsRepo.Store(ctx, post.ID, "former-slug")
+		slugsRepo.Store(ctx, post.ID, post.Slug)
+
+		useCase := NewReadPostUseCase(repo, slugsRepo, memory.NewInMemoryReactionsRepository(nil))
+		retrievedPost, err := useCase.Execute(ctx, uuid.New(), "former-slug")
+
+		if _assert.NoError(err) {
+			_assert.Equal(post.ID, retrievedPost.ID)
+			_assert.Equal(post.Slug, retrievedPost.Slug)
+		}
+
+		_, err = useCase.Execute(ctx, uuid.New(), "unknown-slug")
+		_assert.ErrorIs(err, domain.ErrPostNotFound)
+	})
+
+	t.Run("it should return the post reactions as seen by the viewer", func(t *testing.T) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		reactionsRepo := memory.NewInMemoryReactionsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		post := domain.NewPost(uuid.New(), "Test post", "That is test post content")
+		repo.Store(ctx, post)
+		viewerID := uuid.New()
+
+		for _, userID := range []uuid.UUID{viewerID, uuid.New()} {
+			reaction, _ := domain.NewReaction(domain.PostReactionTarget, post.ID, userID, domain.LaughReaction)
+			reactionsRepo.Add(ctx, reaction)
+		}
+
+		useCase := NewReadPostUseCase(repo, memory.NewInMemorySlugsRepository(nil), reactionsRepo)
+
+		retrievedPost, err := useCase.Execute(ctx, viewerID, post.Slug)
+
+		if _assert.NoError(err) && _assert.NotNil(retrievedPost.Reactions) {
+			_assert.Equal(2, retrievedPost.Reactions.Counts[domain.LaughReaction])
+			_assert.Equal([]domain.ReactionKind{domain.LaughReaction}, retrievedPost.Reactions.Mine)
+		}
+	})
 }
@@ -0,0 +1,18 @@
+package posts
+
+import "comu/internal/modules/post/domain"
+
+type PreviewPostUC struct {
+	renderer domain.ContentRenderer
+}
+
+func NewPreviewPostUseCase(renderer domain.ContentRenderer) *PreviewPostUC {
+	return &PreviewPostUC{
+		renderer: renderer,
+	}
+}
+
+// Execute renders the Markdown content as it would be once posted, without storing it.
+func (useCase *PreviewPostUC) Execute(content string) domain.Rendering {
+	return domain.RenderContent(content, useCase.renderer)
+}
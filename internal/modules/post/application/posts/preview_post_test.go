@@ -0,0 +1,41 @@
+package posts
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/service"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewPostUseCase(t *testing.T) {
+	useCase := NewPreviewPostUseCase(service.NewMarkdownRenderer())
+
+	t.Run("it should render the content to sanitized html", func(t *testing.T) {
+		_assert := assert.New(t)
+		rendering := useCase.Execute("# Title\n\nSome [link](javascript:alert(1)) <script>alert(1)</script>")
+
+		_assert.Equal(
+			"<h1>Title</h1>\n<p>Some link &lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+			rendering.ContentHTML,
+		)
+		_assert.NotContains(rendering.ContentHTML, "<script>")
+		_assert.Equal(4, rendering.WordCount)
+	})
+
+	t.Run("it should cut the excerpt and count the reading time", func(t *testing.T) {
+		_assert := assert.New(t)
+		rendering := useCase.Execute(strings.Repeat("word ", 450))
+
+		_assert.Equal(450, rendering.WordCount)
+		_assert.Equal(3, rendering.ReadingTime)
+		_assert.True(strings.HasSuffix(rendering.Excerpt, "…"))
+		_assert.LessOrEqual(len([]rune(rendering.Excerpt)), domain.ExcerptLength+1)
+	})
+
+	t.Run("it should return a zero reading time for an empty content", func(t *testing.T) {
+		rendering := useCase.Execute("")
+		assert.Equal(t, domain.Rendering{}, rendering)
+	})
+}
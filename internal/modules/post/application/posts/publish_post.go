@@ -0,0 +1,58 @@
+package posts
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"time"
+)
+
+type PublishDueUC struct {
+	repo           domain.PostRepository
+	searchIndex    domain.SearchIndex
+	webhookService domain.WebhookService
+}
+
+func NewPublishDueUseCase(
+	repository domain.PostRepository,
+	searchIndex domain.SearchIndex,
+	webhookService domain.WebhookService,
+) *PublishDueUC {
+	return &PublishDueUC{
+		repo:           repository,
+		searchIndex:    searchIndex,
+		webhookService: webhookService,
+	}
+}
+
+// Execute publishes the scheduled posts whose publish date is reached, and returns
+// how many of them it published. The posts published in the meantime are skipped.
+func (useCase *PublishDueUC) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := useCase.repo.ListDue(ctx, now)
+
+	if err != nil {
+		return 0, err
+	}
+	published := 0
+
+	for _, post := range due {
+		post.Publish(now)
+		ok, err := useCase.repo.Publish(ctx, &post)
+
+		if err != nil {
+			return published, err
+		}
+
+		if !ok {
+			continue
+		}
+		published++
+
+		if err = useCase.searchIndex.Index(ctx, domain.NewPostSearchDocument(&post)); err != nil {
+			return published, err
+		}
+		useCase.webhookService.PostCreated(ctx, &post)
+	}
+
+	return published, nil
+}
@@ -0,0 +1,220 @@
+package posts
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"comu/internal/modules/post/infra/service"
+	"comu/internal/shared/database"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePostUseCaseStatus(t *testing.T) {
+	ctx := context.Background()
+	query := domain.SearchQuery{Text: "unpublished", Limit: 10}
+
+	newUseCase := func() (*CreatePostUC, domain.PostRepository, domain.SearchIndex, *webhookServiceSpy) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		index := memory.NewInMemorySearchIndex(nil)
+		spy := &webhookServiceSpy{}
+		useCase := NewCreatePostUseCase(
+			repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil),
+			memory.NewInMemorySlugsRepository(nil), index, service.NewMarkdownRenderer(),
+			database.NoopTransactor{}, spy, domain.DefaultMaxTagsPerPost,
+		)
+
+		return useCase, repo, index, spy
+	}
+
+	t.Run("it should keep the draft out of the lists and the search", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase, repo, index, spy := newUseCase()
+
+		post, err := useCase.Execute(ctx, CreatePostInput{
+			UserID:  uuid.New(),
+			Title:   "Unpublished post",
+			Content: "This is a draft",
+			Status:  domain.DraftPostStatus,
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal(domain.DraftPostStatus, post.Status)
+			_assert.Empty(spy.events)
+
+			posts, _, _ := repo.List(ctx, domain.Paginator{Limit: 10})
+			_assert.Empty(posts)
+
+			results, _, _ := index.Search(ctx, query)
+			_assert.Empty(results)
+		}
+	})
+
+	t.Run("it should fail to schedule the post without a future publish date", func(t *testing.T) {
+		useCase, _, _, _ := newUseCase()
+		input := CreatePostInput{
+			UserID:  uuid.New(),
+			Title:   "Scheduled post",
+			Content: "This is a scheduled post",
+			Status:  domain.ScheduledPostStatus,
+		}
+
+		_, err := useCase.Execute(ctx, input)
+		assert.ErrorIs(t, err, domain.ErrInvalidPublish)
+
+		past := time.Now().Add(-time.Minute)
+		input.PublishAt = &past
+		_, err = useCase.Execute(ctx, input)
+		assert.ErrorIs(t, err, domain.ErrInvalidPublish)
+	})
+
+	t.Run("it should fail with an unknown or archived status", func(t *testing.T) {
+		useCase, _, _, _ := newUseCase()
+		input := CreatePostInput{UserID: uuid.New(), Title: "Post", Content: "This is a post", Status: "hidden"}
+
+		_, err := useCase.Execute(ctx, input)
+		assert.ErrorIs(t, err, domain.ErrInvalidStatus)
+
+		input.Status = domain.ArchivedPostStatus
+		_, err = useCase.Execute(ctx, input)
+		assert.ErrorIs(t, err, domain.ErrStatusChange)
+	})
+}
+
+func TestUpdatePostUseCaseStatus(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	newUseCase := func(post *domain.Post) (*UpdatePostUC, domain.PostRepository, *webhookServiceSpy) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		repo.Store(ctx, post)
+		spy := &webhookServiceSpy{}
+		useCase := NewUpdatePostUseCase(
+			repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil),
+			memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(),
+			database.NoopTransactor{}, &notificationServiceSpy{}, spy, domain.DefaultMaxTagsPerPost,
+		)
+
+		return useCase, repo, spy
+	}
+
+	t.Run("it should publish the draft as a new post", func(t *testing.T) {
+		_assert := assert.New(t)
+		post, _ := domain.NewPostWithStatus(authorID, "Draft", "Draft content", domain.DraftPostStatus, nil, time.Now())
+		useCase, repo, spy := newUseCase(post)
+
+		_, err := useCase.Execute(ctx, UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: authorID,
+			Title:    post.Title,
+			Content:  post.Content,
+			Status:   domain.PublishedPostStatus,
+		})
+
+		if _assert.NoError(err) {
+			stored, _ := repo.FindByID(ctx, post.ID)
+			_assert.Equal(domain.PublishedPostStatus, stored.Status)
+			_assert.NotNil(stored.PublishAt)
+			_assert.Equal([]string{"post.created"}, spy.events)
+		}
+	})
+
+	t.Run("it should not turn the published post back into a draft", func(t *testing.T) {
+		post := domain.NewPost(authorID, "Post", "Post content")
+		useCase, _, spy := newUseCase(post)
+
+		_, err := useCase.Execute(ctx, UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: authorID,
+			Title:    post.Title,
+			Content:  post.Content,
+			Status:   domain.DraftPostStatus,
+		})
+		assert.ErrorIs(t, err, domain.ErrStatusChange)
+		assert.Empty(t, spy.events)
+	})
+
+	t.Run("it should archive the post and keep it readable", func(t *testing.T) {
+		_assert := assert.New(t)
+		post := domain.NewPost(authorID, "Post", "Post content")
+		useCase, repo, _ := newUseCase(post)
+
+		_, err := useCase.Execute(ctx, UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: authorID,
+			Title:    post.Title,
+			Content:  post.Content,
+			Status:   domain.ArchivedPostStatus,
+		})
+
+		if _assert.NoError(err) {
+			posts, _, _ := repo.List(ctx, domain.Paginator{Limit: 10})
+			_assert.Empty(posts)
+
+			_, err = repo.FindBySlug(ctx, post.Slug, uuid.New())
+			_assert.NoError(err)
+		}
+	})
+}
+
+func TestListDraftsUseCase(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewInMemoryPostsRepository(nil)
+	authorID := uuid.New()
+
+	repo.FillWithRandomPosts(authorID, 2)
+	draft, _ := domain.NewPostWithStatus(authorID, "Draft", "Draft content", domain.DraftPostStatus, nil, time.Now())
+	repo.Store(ctx, draft)
+
+	posts, _, err := NewListDraftsUseCase(repo).Execute(ctx, authorID, domain.Paginator{})
+
+	if assert.NoError(t, err) && assert.Len(t, posts, 1) {
+		assert.Equal(t, draft.ID, posts[0].ID)
+	}
+}
+
+func TestPublishDueUseCase(t *testing.T) {
+	ctx := context.Background()
+	_assert := assert.New(t)
+	repo := memory.NewInMemoryPostsRepository(nil)
+	index := memory.NewInMemorySearchIndex(nil)
+	spy := &webhookServiceSpy{}
+	now := time.Now()
+
+	publishAt := now.Add(time.Hour)
+	later, _ := domain.NewPostWithStatus(uuid.New(), "Later", "Later content", domain.ScheduledPostStatus, &publishAt, now)
+	repo.Store(ctx, later)
+
+	// A post can only be scheduled in the future, so the due one is set as such.
+	dueAt := now.Add(-time.Minute)
+	due, _ := domain.NewPostWithStatus(uuid.New(), "Scheduled", "Scheduled content", domain.DraftPostStatus, nil, now)
+	due.Status, due.PublishAt = domain.ScheduledPostStatus, &dueAt
+	repo.Store(ctx, due)
+
+	useCase := NewPublishDueUseCase(repo, index, spy)
+	published, err := useCase.Execute(ctx)
+
+	if _assert.NoError(err) {
+		_assert.Equal(1, published)
+		_assert.Equal([]string{"post.created"}, spy.events)
+
+		posts, _, _ := repo.List(ctx, domain.Paginator{Limit: 10})
+
+		if _assert.Len(posts, 1) {
+			_assert.Equal(due.ID, posts[0].ID)
+			_assert.False(posts[0].CreatedAt.Before(now))
+		}
+
+		results, _, _ := index.Search(ctx, domain.SearchQuery{Text: "scheduled", Limit: 10})
+		_assert.Len(results, 1)
+	}
+
+	published, err = useCase.Execute(ctx)
+
+	if _assert.NoError(err) {
+		_assert.Zero(published)
+	}
+}
@@ -3,6 +3,8 @@ package posts
 import (
 	"comu/internal/modules/post/domain"
 	"context"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,15 +14,52 @@ type UpdatePostInput struct {
 	AuthorID uuid.UUID
 	Title    string
 	Content  string
+	// Tags replace the post tags, which are kept when nil.
+	Tags []string
+	// Slug changes the post slug, which is kept when empty. The former slugs
+	// keep leading to the post.
+	Slug string
+	// Status changes the post status, which is kept when empty.
+	Status    domain.PostStatus
+	PublishAt *time.Time
 }
 
 type UpdatePostUC struct {
-	repo domain.PostRepository
+	repo                domain.PostRepository
+	followsRepo         domain.FollowsRepository
+	revisionsRepo       domain.RevisionsRepository
+	slugsRepo           domain.SlugsRepository
+	searchIndex         domain.SearchIndex
+	renderer            domain.ContentRenderer
+	transactor          domain.Transactor
+	notificationService domain.NotificationService
+	webhookService      domain.WebhookService
+	maxTags             int
 }
 
-func NewUpdatePostUseCase(repository domain.PostRepository) *UpdatePostUC {
+func NewUpdatePostUseCase(
+	repository domain.PostRepository,
+	followsRepository domain.FollowsRepository,
+	revisionsRepository domain.RevisionsRepository,
+	slugsRepository domain.SlugsRepository,
+	searchIndex domain.SearchIndex,
+	renderer domain.ContentRenderer,
+	transactor domain.Transactor,
+	notificationService domain.NotificationService,
+	webhookService domain.WebhookService,
+	maxTags int,
+) *UpdatePostUC {
 	return &UpdatePostUC{
-		repo: repository,
+		repo:                repository,
+		followsRepo:         followsRepository,
+		revisionsRepo:       revisionsRepository,
+		slugsRepo:           slugsRepository,
+		searchIndex:         searchIndex,
+		renderer:            renderer,
+		transactor:          transactor,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		maxTags:             maxTags,
 	}
 }
 
@@ -35,17 +74,88 @@ func (useCase *UpdatePostUC) Execute(ctx context.Context, input UpdatePostInput)
 		return "", domain.ErrUnauthorized
 	}
 
-	if input.Title != post.Title {
-		slug := domain.MakePostSlug(input.Title)
-		post.Title = input.Title
-		post.Slug = slug
+	if input.Tags != nil {
+		if post.Tags, err = domain.NormalizeTags(input.Tags, useCase.maxTags); err != nil {
+			return
+		}
 	}
-	post.Content = input.Content
-	err = useCase.repo.Update(ctx, post)
+
+	changed := input.Title != post.Title || input.Content != post.Content
+	wasPublic := post.IsPublic()
+	slug = post.Slug
+
+	switch {
+	case input.Slug != "" && input.Slug != post.Slug:
+		if err = domain.ValidateSlug(input.Slug); err != nil {
+			return "", err
+		}
+		slug = input.Slug
+
+	// The slug follows the title until the post is first published, its links being shared then.
+	case input.Title != post.Title && !wasPublic:
+		if slug, err = availableSlug(ctx, useCase.slugsRepo, post.ID, domain.MakePostSlug(input.Title)); err != nil {
+			return "", err
+		}
+	}
+	slugChanged := slug != post.Slug
+	post.Title = input.Title
+	post.Slug = slug
+
+	// The content is only rendered again when changed.
+	if input.Content != post.Content {
+		post.SetContent(input.Content, useCase.renderer)
+	}
+
+	if input.Status != "" {
+		if err = post.SetStatus(input.Status, input.PublishAt, time.Now()); err != nil {
+			return
+		}
+	}
+
+	// A revision keeps the new title and content, along with who changed them.
+	err = useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if slugChanged {
+			if err := useCase.slugsRepo.Store(ctx, post.ID, post.Slug); err != nil {
+				return err
+			}
+		}
+
+		if err := useCase.repo.Update(ctx, post); err != nil {
+			return err
+		}
+
+		if !changed {
+			return nil
+		}
+
+		return useCase.revisionsRepo.Store(ctx, domain.NewRevision(post, input.AuthorID))
+	})
+
+	if err != nil {
+		return
+	}
+
+	// The unpublished posts are only indexed and announced once published.
+	if !post.IsPublic() {
+		return post.Slug, nil
+	}
+
+	if err = useCase.searchIndex.Index(ctx, domain.NewPostSearchDocument(post)); err != nil {
+		return
+	}
+
+	if !wasPublic {
+		useCase.webhookService.PostCreated(ctx, post)
+		return post.Slug, nil
+	}
+	useCase.webhookService.PostUpdated(ctx, post)
+	followers, err := useCase.followsRepo.FindFollowers(ctx, post.ID)
 
 	if err != nil {
 		return
 	}
+	followers = slices.DeleteFunc(followers, func(id uuid.UUID) bool { return id == post.UserID })
+	useCase.notificationService.PublishPostUpdated(ctx, followers, post)
 
 	return post.Slug, nil
 }
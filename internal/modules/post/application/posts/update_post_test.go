@@ -3,15 +3,61 @@ package posts
 import (
 	"comu/internal/modules/post/domain"
 	"comu/internal/modules/post/infra/memory"
+	"comu/internal/modules/post/infra/service"
+	"comu/internal/shared/database"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+type notificationServiceSpy struct {
+	updateFollowers []uuid.UUID
+}
+
+func (spy *notificationServiceSpy) NotifyPostCommented(ctx context.Context, post *domain.Post, comment *domain.Comment) {
+}
+
+func (spy *notificationServiceSpy) PublishCommentCreated(ctx context.Context, followerIDs []uuid.UUID, comment *domain.Comment) {
+}
+
+func (spy *notificationServiceSpy) PublishPostUpdated(ctx context.Context, followerIDs []uuid.UUID, post *domain.Post) {
+	spy.updateFollowers = followerIDs
+}
+
 func TestUpdatePostUseCase(t *testing.T) {
 
+	t.Run("it should publish the update to the post followers but the author", func(t *testing.T) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		followsRepo := memory.NewInMemoryFollowsRepository(nil)
+		spy := &notificationServiceSpy{}
+		ctx := context.Background()
+		_assert := assert.New(t)
+		userID, followerID := uuid.New(), uuid.New()
+
+		post := domain.NewPost(userID, "Test post title", "This is test post title")
+		repo.Store(ctx, post)
+		followsRepo.Follow(ctx, post.ID, userID)
+		followsRepo.Follow(ctx, post.ID, followerID)
+
+		webhookSpy := &webhookServiceSpy{}
+		useCase := NewUpdatePostUseCase(repo, followsRepo, memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, spy, webhookSpy, domain.DefaultMaxTagsPerPost)
+
+		_, err := useCase.Execute(ctx, UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: userID,
+			Title:    post.Title,
+			Content:  "Test post updated content",
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal([]uuid.UUID{followerID}, spy.updateFollowers)
+			_assert.Equal([]string{"post.updated"}, webhookSpy.events)
+		}
+	})
+
 	t.Run("it should update both title and content", func(t *testing.T) {
 		repo := memory.NewInMemoryPostsRepository(nil)
 		ctx := context.Background()
@@ -21,7 +67,7 @@ func TestUpdatePostUseCase(t *testing.T) {
 		post := domain.NewPost(userID, "Test post title", "This is test post title")
 		repo.Store(ctx, post)
 
-		useCase := NewUpdatePostUseCase(repo)
+		useCase := NewUpdatePostUseCase(repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost)
 
 		slug, err := useCase.Execute(ctx, UpdatePostInput{
 			PostID:   post.ID,
@@ -31,7 +77,7 @@ func TestUpdatePostUseCase(t *testing.T) {
 		})
 
 		if _assert.NoError(err) {
-			retrievedPost, _ := repo.FindBySlug(ctx, slug)
+			retrievedPost, _ := repo.FindBySlug(ctx, slug, uuid.Nil)
 			_assert.Equal(post.ID, retrievedPost.ID)
 			_assert.NotEqual(post.Title, retrievedPost.Title)
 			_assert.NotEqual(post.Content, retrievedPost.Content)
@@ -49,7 +95,7 @@ func TestUpdatePostUseCase(t *testing.T) {
 		post := domain.NewPost(userID, "Test post title", "This is test post title")
 		repo.Store(ctx, post)
 
-		useCase := NewUpdatePostUseCase(repo)
+		useCase := NewUpdatePostUseCase(repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost)
 
 		slug, err := useCase.Execute(ctx, UpdatePostInput{
 			PostID:   post.ID,
@@ -59,7 +105,7 @@ func TestUpdatePostUseCase(t *testing.T) {
 		})
 
 		if _assert.NoError(err) {
-			retrievedPost, _ := repo.FindBySlug(ctx, slug)
+			retrievedPost, _ := repo.FindBySlug(ctx, slug, uuid.Nil)
 			_assert.Equal(post.ID, retrievedPost.ID)
 			_assert.Equal(post.Title, retrievedPost.Title)
 			_assert.NotEqual(post.Content, retrievedPost.Content)
@@ -68,6 +114,36 @@ func TestUpdatePostUseCase(t *testing.T) {
 		}
 	})
 
+	t.Run("it should replace the tags only when given", func(t *testing.T) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+		userID := uuid.New()
+
+		post := domain.NewPost(userID, "Test post title", "This is test post title")
+		post.Tags = []string{"go"}
+		repo.Store(ctx, post)
+
+		useCase := NewUpdatePostUseCase(repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost)
+		input := UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: userID,
+			Title:    post.Title,
+			Content:  "Test post updated content",
+		}
+
+		_, err := useCase.Execute(ctx, input)
+		_assert.NoError(err)
+		retrievedPost, _ := repo.FindByID(ctx, post.ID)
+		_assert.Equal([]string{"go"}, retrievedPost.Tags)
+
+		input.Tags = []string{"Web"}
+		_, err = useCase.Execute(ctx, input)
+		_assert.NoError(err)
+		retrievedPost, _ = repo.FindByID(ctx, post.ID)
+		_assert.Equal([]string{"web"}, retrievedPost.Tags)
+	})
+
 	t.Run("it should fail and return ErrUnauthorized", func(t *testing.T) {
 		repo := memory.NewInMemoryPostsRepository(nil)
 		ctx := context.Background()
@@ -75,7 +151,7 @@ func TestUpdatePostUseCase(t *testing.T) {
 		post := domain.NewPost(uuid.New(), "Test post title", "This is test post title")
 		repo.Store(ctx, post)
 
-		useCase := NewUpdatePostUseCase(repo)
+		useCase := NewUpdatePostUseCase(repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil), memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost)
 
 		_, err := useCase.Execute(ctx, UpdatePostInput{
 			PostID:   post.ID,
@@ -86,4 +162,131 @@ func TestUpdatePostUseCase(t *testing.T) {
 
 		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 	})
+
+	t.Run("it should store a revision when the title or content change", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemoryPostsRepository(nil)
+		revisionsRepo := memory.NewInMemoryRevisionsRepository(nil)
+		ctx := context.Background()
+
+		post := domain.NewPost(uuid.New(), "Test post title", "This is test post title")
+		repo.Store(ctx, post)
+
+		useCase := NewUpdatePostUseCase(repo, memory.NewInMemoryFollowsRepository(nil), revisionsRepo, memory.NewInMemorySlugsRepository(nil), memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(), database.NoopTransactor{}, &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost)
+		input := UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: post.UserID,
+			Title:    post.Title,
+			Content:  "Test post updated content",
+		}
+
+		_, err := useCase.Execute(ctx, input)
+		_assert.NoError(err)
+
+		input.Tags = []string{"web"}
+		_, err = useCase.Execute(ctx, input)
+		_assert.NoError(err)
+
+		revisions, _ := revisionsRepo.List(ctx, post.ID)
+
+		if _assert.Len(revisions, 1) {
+			_assert.Equal("Test post updated content", revisions[0].Content)
+			_assert.Equal(post.UserID, revisions[0].AuthorID)
+		}
+	})
+}
+
+func TestUpdatePostUseCaseSlugs(t *testing.T) {
+	ctx := context.Background()
+
+	newUseCase := func() (*UpdatePostUC, domain.PostRepository, domain.SlugsRepository) {
+		repo := memory.NewInMemoryPostsRepository(nil)
+		slugsRepo := memory.NewInMemorySlugsRepository(nil)
+		useCase := NewUpdatePostUseCase(
+			repo, memory.NewInMemoryFollowsRepository(nil), memory.NewInMemoryRevisionsRepository(nil),
+			slugsRepo, memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(),
+			database.NoopTransactor{}, &notificationServiceSpy{}, &webhookServiceSpy{}, domain.DefaultMaxTagsPerPost,
+		)
+
+		return useCase, repo, slugsRepo
+	}
+
+	t.Run("it should keep the slug of a published post when its title changes", func(t *testing.T) {
+		useCase, repo, _ := newUseCase()
+		post := domain.NewPost(uuid.New(), "Test post title", "This is test post content")
+		repo.Store(ctx, post)
+
+		slug, err := useCase.Execute(ctx, UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: post.UserID,
+			Title:    "Another title",
+			Content:  post.Content,
+		})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "test-post-title", slug)
+		}
+	})
+
+	t.Run("it should make the slug of a draft from its new title", func(t *testing.T) {
+		useCase, repo, _ := newUseCase()
+		post, _ := domain.NewPostWithStatus(uuid.New(), "Draft title", "This is test post content", domain.DraftPostStatus, nil, time.Now())
+		repo.Store(ctx, post)
+
+		slug, err := useCase.Execute(ctx, UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: post.UserID,
+			Title:    "Final title",
+			Content:  post.Content,
+		})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "final-title", slug)
+		}
+	})
+
+	t.Run("it should change the slug and keep the former one", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase, repo, slugsRepo := newUseCase()
+		post := domain.NewPost(uuid.New(), "Test post title", "This is test post content")
+		repo.Store(ctx, post)
+		slugsRepo.Store(ctx, post.ID, post.Slug)
+
+		other := domain.NewPost(uuid.New(), "Other post", "This is other post content")
+		repo.Store(ctx, other)
+		slugsRepo.Store(ctx, other.ID, other.Slug)
+
+		input := UpdatePostInput{
+			PostID:   post.ID,
+			AuthorID: post.UserID,
+			Title:    post.Title,
+			Content:  post.Content,
+			Slug:     "new-slug",
+		}
+		slug, err := useCase.Execute(ctx, input)
+
+		if _assert.NoError(err) {
+			_assert.Equal("new-slug", slug)
+
+			for _, slug := range []string{"test-post-title", "new-slug"} {
+				postID, _ := slugsRepo.FindPostID(ctx, slug)
+				_assert.Equal(post.ID, postID)
+			}
+		}
+
+		input.Slug = other.Slug
+		_, err = useCase.Execute(ctx, input)
+		_assert.ErrorIs(err, domain.ErrSlugTaken)
+
+		input.Slug = "new--slug"
+		_, err = useCase.Execute(ctx, input)
+		_assert.ErrorIs(err, domain.ErrInvalidSlug)
+
+		input.Slug = "test-post-title"
+		slug, err = useCase.Execute(ctx, input)
+
+		if _assert.NoError(err) {
+			_assert.Equal("test-post-title", slug)
+		}
+	})
 }
@@ -0,0 +1,95 @@
+package reactions
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ReactionInput struct {
+	TargetType domain.ReactionTarget
+	TargetID   uuid.UUID
+	UserID     uuid.UUID
+	Kind       domain.ReactionKind
+}
+
+type AddReactionUC struct {
+	repo         domain.ReactionsRepository
+	postsRepo    domain.PostRepository
+	commentsRepo domain.CommentRepository
+}
+
+type RemoveReactionUC struct {
+	repo domain.ReactionsRepository
+}
+
+func NewAddReactionUseCase(
+	repository domain.ReactionsRepository,
+	postsRepository domain.PostRepository,
+	commentsRepository domain.CommentRepository,
+) *AddReactionUC {
+	return &AddReactionUC{
+		repo:         repository,
+		postsRepo:    postsRepository,
+		commentsRepo: commentsRepository,
+	}
+}
+
+func NewRemoveReactionUseCase(repository domain.ReactionsRepository) *RemoveReactionUC {
+	return &RemoveReactionUC{
+		repo: repository,
+	}
+}
+
+// Execute adds the user reaction to the post or the comment, which can't be a deleted one.
+// Reacting twice with the same kind keeps a single reaction.
+func (useCase *AddReactionUC) Execute(ctx context.Context, input ReactionInput) error {
+	reaction, err := domain.NewReaction(input.TargetType, input.TargetID, input.UserID, input.Kind)
+
+	if err != nil {
+		return err
+	}
+
+	if err := useCase.findTarget(ctx, input.TargetType, input.TargetID); err != nil {
+		return err
+	}
+
+	return useCase.repo.Add(ctx, reaction)
+}
+
+func (useCase *AddReactionUC) findTarget(ctx context.Context, targetType domain.ReactionTarget, targetID uuid.UUID) error {
+	if targetType == domain.PostReactionTarget {
+		post, err := useCase.postsRepo.FindByID(ctx, targetID)
+
+		if err != nil {
+			return err
+		}
+
+		// The unpublished posts are not open to reactions yet.
+		if !post.IsPublic() {
+			return domain.ErrPostNotFound
+		}
+
+		return nil
+	}
+	comment, err := useCase.commentsRepo.Find(ctx, targetID)
+
+	if err != nil {
+		return err
+	}
+
+	if comment.IsDeleted() {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (useCase *RemoveReactionUC) Execute(ctx context.Context, input ReactionInput) error {
+	if !domain.IsReactionKind(input.Kind) {
+		return domain.ErrUnknownReaction
+	}
+
+	return useCase.repo.Remove(ctx, input.TargetType, input.TargetID, input.UserID, input.Kind)
+}
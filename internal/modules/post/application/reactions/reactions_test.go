@@ -0,0 +1,113 @@
+package reactions
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddReactionUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should fail and return ErrUnknownReaction", func(t *testing.T) {
+		useCase := NewAddReactionUseCase(
+			memory.NewInMemoryReactionsRepository(nil),
+			memory.NewInMemoryPostsRepository(nil),
+			memory.NewInMemoryCommentsRepository(nil),
+		)
+
+		err := useCase.Execute(ctx, ReactionInput{
+			TargetType: domain.PostReactionTarget,
+			TargetID:   uuid.New(),
+			UserID:     uuid.New(),
+			Kind:       "thumbs_down",
+		})
+		assert.ErrorIs(t, err, domain.ErrUnknownReaction)
+	})
+
+	t.Run("it should fail when the target doesn't exist or was deleted", func(t *testing.T) {
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+		useCase := NewAddReactionUseCase(
+			memory.NewInMemoryReactionsRepository(nil),
+			memory.NewInMemoryPostsRepository(nil),
+			commentsRepo,
+		)
+		deletedComment := domain.NewComment(uuid.New(), uuid.New(), "Deleted comment")
+		deletedComment.Delete(time.Now())
+		commentsRepo.Store(ctx, deletedComment)
+
+		err := useCase.Execute(ctx, ReactionInput{
+			TargetType: domain.PostReactionTarget,
+			TargetID:   uuid.New(),
+			UserID:     uuid.New(),
+			Kind:       domain.LikeReaction,
+		})
+		assert.ErrorIs(t, err, domain.ErrPostNotFound)
+
+		err = useCase.Execute(ctx, ReactionInput{
+			TargetType: domain.CommentReactionTarget,
+			TargetID:   deletedComment.ID,
+			UserID:     uuid.New(),
+			Kind:       domain.LikeReaction,
+		})
+		assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+	})
+
+	t.Run("it should add the reaction to the post", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemoryReactionsRepository(nil)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewAddReactionUseCase(repo, postsRepo, memory.NewInMemoryCommentsRepository(nil))
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		userID := uuid.New()
+
+		err := useCase.Execute(ctx, ReactionInput{
+			TargetType: domain.PostReactionTarget,
+			TargetID:   post.ID,
+			UserID:     userID,
+			Kind:       domain.LoveReaction,
+		})
+
+		if _assert.NoError(err) {
+			summaries, _ := repo.Summarize(ctx, domain.PostReactionTarget, []uuid.UUID{post.ID}, userID)
+			_assert.Equal(1, summaries.Of(post.ID).Counts[domain.LoveReaction])
+			_assert.Equal([]domain.ReactionKind{domain.LoveReaction}, summaries.Of(post.ID).Mine)
+		}
+	})
+}
+
+func TestRemoveReactionUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should remove the user reaction only", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemoryReactionsRepository(nil)
+		useCase := NewRemoveReactionUseCase(repo)
+		postID, userID := uuid.New(), uuid.New()
+
+		for _, id := range []uuid.UUID{userID, uuid.New()} {
+			reaction, _ := domain.NewReaction(domain.PostReactionTarget, postID, id, domain.LikeReaction)
+			repo.Add(ctx, reaction)
+		}
+
+		err := useCase.Execute(ctx, ReactionInput{
+			TargetType: domain.PostReactionTarget,
+			TargetID:   postID,
+			UserID:     userID,
+			Kind:       domain.LikeReaction,
+		})
+
+		if _assert.NoError(err) {
+			summaries, _ := repo.Summarize(ctx, domain.PostReactionTarget, []uuid.UUID{postID}, userID)
+			_assert.Equal(1, summaries.Of(postID).Counts[domain.LikeReaction])
+			_assert.Empty(summaries.Of(postID).Mine)
+		}
+	})
+}
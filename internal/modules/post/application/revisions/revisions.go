@@ -0,0 +1,157 @@
+package revisions
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RestoreRevisionInput struct {
+	PostID uuid.UUID
+	Number int
+	UserID uuid.UUID
+}
+
+type ListRevisionsUC struct {
+	repo      domain.RevisionsRepository
+	postsRepo domain.PostRepository
+}
+
+type DiffRevisionsUC struct {
+	repo      domain.RevisionsRepository
+	postsRepo domain.PostRepository
+}
+
+type RestoreRevisionUC struct {
+	repo           domain.RevisionsRepository
+	postsRepo      domain.PostRepository
+	searchIndex    domain.SearchIndex
+	renderer       domain.ContentRenderer
+	transactor     domain.Transactor
+	webhookService domain.WebhookService
+	admins         domain.Admins
+}
+
+func NewListRevisionsUseCase(repository domain.RevisionsRepository, postsRepository domain.PostRepository) *ListRevisionsUC {
+	return &ListRevisionsUC{
+		repo:      repository,
+		postsRepo: postsRepository,
+	}
+}
+
+func NewDiffRevisionsUseCase(repository domain.RevisionsRepository, postsRepository domain.PostRepository) *DiffRevisionsUC {
+	return &DiffRevisionsUC{
+		repo:      repository,
+		postsRepo: postsRepository,
+	}
+}
+
+func NewRestoreRevisionUseCase(
+	repository domain.RevisionsRepository,
+	postsRepository domain.PostRepository,
+	searchIndex domain.SearchIndex,
+	renderer domain.ContentRenderer,
+	transactor domain.Transactor,
+	webhookService domain.WebhookService,
+	admins domain.Admins,
+) *RestoreRevisionUC {
+	return &RestoreRevisionUC{
+		repo:           repository,
+		postsRepo:      postsRepository,
+		searchIndex:    searchIndex,
+		renderer:       renderer,
+		transactor:     transactor,
+		webhookService: webhookService,
+		admins:         admins,
+	}
+}
+
+// Execute returns the revisions of the post, the latest first.
+func (useCase *ListRevisionsUC) Execute(ctx context.Context, viewerID, postID uuid.UUID) ([]domain.Revision, error) {
+	if _, err := findPost(ctx, useCase.postsRepo, viewerID, postID); err != nil {
+		return nil, err
+	}
+
+	return useCase.repo.List(ctx, postID)
+}
+
+// Execute returns the line changes from a revision of the post to another.
+func (useCase *DiffRevisionsUC) Execute(ctx context.Context, viewerID, postID uuid.UUID, from, to int) (*domain.RevisionDiff, error) {
+	if _, err := findPost(ctx, useCase.postsRepo, viewerID, postID); err != nil {
+		return nil, err
+	}
+	fromRevision, err := useCase.repo.Find(ctx, postID, from)
+
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := useCase.repo.Find(ctx, postID, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewRevisionDiff(fromRevision, toRevision), nil
+}
+
+// Execute brings the post title and content back to the revision ones, for the post
+// author or the admins. The restoration is itself stored as the latest revision.
+func (useCase *RestoreRevisionUC) Execute(ctx context.Context, input RestoreRevisionInput) (*domain.Post, error) {
+	post, err := findPost(ctx, useCase.postsRepo, input.UserID, input.PostID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if post.UserID != input.UserID && !useCase.admins.Contains(input.UserID) {
+		return nil, domain.ErrUnauthorized
+	}
+	revision, err := useCase.repo.Find(ctx, post.ID, input.Number)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The slug is kept, for the links to the post to stay valid.
+	post.Title = revision.Title
+	post.SetContent(revision.Content, useCase.renderer)
+
+	err = useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := useCase.postsRepo.Update(ctx, post); err != nil {
+			return err
+		}
+
+		return useCase.repo.Store(ctx, domain.NewRevision(post, input.UserID))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.IsPublic() {
+		return post, nil
+	}
+
+	if err = useCase.searchIndex.Index(ctx, domain.NewPostSearchDocument(post)); err != nil {
+		return nil, err
+	}
+	useCase.webhookService.PostUpdated(ctx, post)
+
+	return post, nil
+}
+
+// findPost returns the post when the user can see it.
+func findPost(ctx context.Context, postsRepo domain.PostRepository, userID, postID uuid.UUID) (*domain.Post, error) {
+	post, err := postsRepo.FindByID(ctx, postID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.IsVisibleTo(userID) {
+		return nil, domain.ErrPostNotFound
+	}
+
+	return post, nil
+}
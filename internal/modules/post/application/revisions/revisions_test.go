@@ -0,0 +1,168 @@
+package revisions
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"comu/internal/modules/post/infra/service"
+	"comu/internal/shared/database"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type webhookServiceSpy struct {
+	events []string
+}
+
+func (spy *webhookServiceSpy) PostCreated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.created")
+}
+
+func (spy *webhookServiceSpy) PostUpdated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.updated")
+}
+
+func (spy *webhookServiceSpy) PostDeleted(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.deleted")
+}
+
+func (spy *webhookServiceSpy) PostRestored(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.restored")
+}
+
+func (spy *webhookServiceSpy) CommentCreated(ctx context.Context, comment *domain.Comment) {
+	spy.events = append(spy.events, "comment.created")
+}
+
+// storePostWithRevisions stores the post and a revision for each of its contents, the first one being its own.
+func storePostWithRevisions(
+	t *testing.T, postsRepo domain.PostRepository, repo domain.RevisionsRepository, post *domain.Post, contents ...string,
+) {
+	ctx := context.Background()
+	assert.NoError(t, postsRepo.Store(ctx, post))
+	assert.NoError(t, repo.Store(ctx, domain.NewRevision(post, post.UserID)))
+
+	for _, content := range contents {
+		post.Content = content
+		assert.NoError(t, postsRepo.Update(ctx, post))
+		assert.NoError(t, repo.Store(ctx, domain.NewRevision(post, post.UserID)))
+	}
+}
+
+func TestListRevisionsUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should list the revisions of the post, the latest first", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		repo := memory.NewInMemoryRevisionsRepository(nil)
+		post := domain.NewPost(uuid.New(), "Post", "First content")
+		storePostWithRevisions(t, postsRepo, repo, post, "Second content")
+
+		revisions, err := NewListRevisionsUseCase(repo, postsRepo).Execute(ctx, uuid.New(), post.ID)
+
+		if _assert.NoError(err) && _assert.Len(revisions, 2) {
+			_assert.Equal("Second content", revisions[0].Content)
+			_assert.Equal(post.UserID, revisions[0].AuthorID)
+			_assert.Equal("First content", revisions[1].Content)
+		}
+	})
+
+	t.Run("it should hide the revisions of another user draft", func(t *testing.T) {
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		repo := memory.NewInMemoryRevisionsRepository(nil)
+		post, _ := domain.NewPostWithStatus(uuid.New(), "Draft", "Draft content", domain.DraftPostStatus, nil, time.Now())
+		storePostWithRevisions(t, postsRepo, repo, post)
+
+		_, err := NewListRevisionsUseCase(repo, postsRepo).Execute(ctx, uuid.New(), post.ID)
+		assert.ErrorIs(t, err, domain.ErrPostNotFound)
+	})
+}
+
+func TestDiffRevisionsUseCase(t *testing.T) {
+	ctx := context.Background()
+	postsRepo := memory.NewInMemoryPostsRepository(nil)
+	repo := memory.NewInMemoryRevisionsRepository(nil)
+	post := domain.NewPost(uuid.New(), "Post", "first line\nsecond line\nthird line")
+	storePostWithRevisions(t, postsRepo, repo, post, "first line\nnew second line\nthird line\nfourth line")
+	useCase := NewDiffRevisionsUseCase(repo, postsRepo)
+
+	t.Run("it should return the line changes between the revisions", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		diff, err := useCase.Execute(ctx, uuid.New(), post.ID, 1, 2)
+
+		if _assert.NoError(err) {
+			_assert.Equal([]domain.DiffLine{{Op: domain.EqualDiffOp, Text: "Post"}}, diff.Title)
+			_assert.Equal([]domain.DiffLine{
+				{Op: domain.EqualDiffOp, Text: "first line"},
+				{Op: domain.DeleteDiffOp, Text: "second line"},
+				{Op: domain.InsertDiffOp, Text: "new second line"},
+				{Op: domain.EqualDiffOp, Text: "third line"},
+				{Op: domain.InsertDiffOp, Text: "fourth line"},
+			}, diff.Content)
+		}
+	})
+
+	t.Run("it should fail with an unknown revision", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, uuid.New(), post.ID, 1, 3)
+		assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+	})
+}
+
+func TestRestoreRevisionUseCase(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	newUseCase := func(t *testing.T) (*RestoreRevisionUC, domain.RevisionsRepository, *domain.Post, *webhookServiceSpy) {
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		repo := memory.NewInMemoryRevisionsRepository(nil)
+		post := domain.NewPost(uuid.New(), "Post", "First content")
+		storePostWithRevisions(t, postsRepo, repo, post, "Second content")
+		spy := &webhookServiceSpy{}
+		useCase := NewRestoreRevisionUseCase(
+			repo, postsRepo, memory.NewInMemorySearchIndex(nil), service.NewMarkdownRenderer(),
+			database.NoopTransactor{}, spy, domain.Admins{adminID},
+		)
+
+		return useCase, repo, post, spy
+	}
+
+	t.Run("it should restore the revision as the latest one", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase, repo, post, spy := newUseCase(t)
+
+		restored, err := useCase.Execute(ctx, RestoreRevisionInput{PostID: post.ID, Number: 1, UserID: adminID})
+
+		if _assert.NoError(err) {
+			_assert.Equal("First content", restored.Content)
+			_assert.Equal([]string{"post.updated"}, spy.events)
+
+			revisions, _ := repo.List(ctx, post.ID)
+
+			if _assert.Len(revisions, 3) {
+				_assert.Equal(3, revisions[0].Number)
+				_assert.Equal(adminID, revisions[0].AuthorID)
+				_assert.Equal("First content", revisions[0].Content)
+			}
+		}
+	})
+
+	t.Run("it should fail for the users other than the author and the admins", func(t *testing.T) {
+		useCase, _, post, spy := newUseCase(t)
+
+		_, err := useCase.Execute(ctx, RestoreRevisionInput{PostID: post.ID, Number: 1, UserID: uuid.New()})
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Empty(t, spy.events)
+	})
+
+	t.Run("it should fail with an unknown revision", func(t *testing.T) {
+		useCase, _, post, _ := newUseCase(t)
+
+		_, err := useCase.Execute(ctx, RestoreRevisionInput{PostID: post.ID, Number: 5, UserID: post.UserID})
+		assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+	})
+}
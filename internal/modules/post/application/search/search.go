@@ -0,0 +1,38 @@
+package search
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+)
+
+type SearchUC struct {
+	index domain.SearchIndex
+}
+
+func NewSearchUseCase(index domain.SearchIndex) *SearchUC {
+	return &SearchUC{
+		index: index,
+	}
+}
+
+// Execute returns a page of the posts and comments matching the query, the most relevant first.
+func (useCase *SearchUC) Execute(ctx context.Context, query domain.SearchQuery) ([]domain.SearchResult, *domain.Cursor, error) {
+	if len(query.Terms()) == 0 {
+		return []domain.SearchResult{}, nil, domain.ErrEmptySearch
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = domain.DefaultPaginatorLimit
+	}
+
+	if query.Tag != "" {
+		tag, err := domain.NormalizeTag(query.Tag)
+
+		if err != nil {
+			return []domain.SearchResult{}, nil, nil
+		}
+		query.Tag = tag
+	}
+
+	return useCase.index.Search(ctx, query)
+}
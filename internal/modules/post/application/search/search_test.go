@@ -0,0 +1,42 @@
+package search
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should fail and return ErrEmptySearch", func(t *testing.T) {
+		useCase := NewSearchUseCase(memory.NewInMemorySearchIndex(nil))
+
+		_, _, err := useCase.Execute(ctx, domain.SearchQuery{Text: " a ! "})
+		assert.ErrorIs(t, err, domain.ErrEmptySearch)
+	})
+
+	t.Run("it should normalize the tag filter", func(t *testing.T) {
+		_assert := assert.New(t)
+		post := domain.NewPost(uuid.New(), "Tagged post", "About the go language")
+		post.ID = uuid.New()
+		post.Tags = []string{"go-lang"}
+		useCase := NewSearchUseCase(memory.NewInMemorySearchIndex([]domain.SearchDocument{
+			domain.NewPostSearchDocument(post),
+		}))
+
+		results, _, err := useCase.Execute(ctx, domain.SearchQuery{Text: "language", Tag: "Go Lang"})
+
+		if _assert.NoError(err) && _assert.Len(results, 1) {
+			_assert.Equal(post.ID, results[0].ID)
+		}
+
+		results, _, err = useCase.Execute(ctx, domain.SearchQuery{Text: "language", Tag: "c++"})
+		_assert.NoError(err)
+		_assert.Empty(results)
+	})
+}
@@ -0,0 +1,172 @@
+package tags
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ListTagsUC struct {
+	repo domain.TagsRepository
+}
+
+type ListTagPostsUC struct {
+	repo          domain.TagsRepository
+	postsRepo     domain.PostRepository
+	reactionsRepo domain.ReactionsRepository
+}
+
+type RenameTagUC struct {
+	repo   domain.TagsRepository
+	admins domain.Admins
+}
+
+type MergeTagsUC struct {
+	repo   domain.TagsRepository
+	admins domain.Admins
+}
+
+func NewListTagsUseCase(repository domain.TagsRepository) *ListTagsUC {
+	return &ListTagsUC{
+		repo: repository,
+	}
+}
+
+func NewListTagPostsUseCase(
+	repository domain.TagsRepository,
+	postsRepository domain.PostRepository,
+	reactionsRepository domain.ReactionsRepository,
+) *ListTagPostsUC {
+	return &ListTagPostsUC{
+		repo:          repository,
+		postsRepo:     postsRepository,
+		reactionsRepo: reactionsRepository,
+	}
+}
+
+func NewRenameTagUseCase(repository domain.TagsRepository, admins domain.Admins) *RenameTagUC {
+	return &RenameTagUC{
+		repo:   repository,
+		admins: admins,
+	}
+}
+
+func NewMergeTagsUseCase(repository domain.TagsRepository, admins domain.Admins) *MergeTagsUC {
+	return &MergeTagsUC{
+		repo:   repository,
+		admins: admins,
+	}
+}
+
+// Execute returns the used tags starting with the prefix, to autocomplete them,
+// or every used tag when the prefix is empty.
+func (useCase *ListTagsUC) Execute(ctx context.Context, prefix string, limit int) ([]domain.Tag, error) {
+	if limit <= 0 {
+		limit = domain.DefaultTagsLimit
+	}
+	prefix = strings.NewReplacer(" ", "-", "_", "-").Replace(strings.ToLower(strings.TrimSpace(prefix)))
+
+	return useCase.repo.List(ctx, prefix, limit)
+}
+
+// Execute returns the tag with a page of its posts, in the paginator sort.
+func (useCase *ListTagPostsUC) Execute(
+	ctx context.Context, viewerID uuid.UUID, name string, paginator domain.Paginator,
+) (*domain.Tag, []domain.Post, *domain.Cursor, error) {
+	tag, err := findTag(ctx, useCase.repo, name)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if paginator.Limit <= 0 {
+		paginator.Limit = domain.DefaultPaginatorLimit
+	}
+	posts, cursor, err := useCase.postsRepo.ListByTag(ctx, tag.Name, paginator)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := domain.WithPostsReactions(ctx, useCase.reactionsRepo, posts, viewerID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return tag, posts, cursor, nil
+}
+
+// Execute renames the tag of every post. The admins merge the tags instead
+// of renaming one with the name of another.
+func (useCase *RenameTagUC) Execute(ctx context.Context, userID uuid.UUID, name, newName string) (*domain.Tag, error) {
+	if !useCase.admins.Contains(userID) {
+		return nil, domain.ErrUnauthorized
+	}
+	tag, err := findTag(ctx, useCase.repo, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if newName, err = domain.NormalizeTag(newName); err != nil {
+		return nil, err
+	}
+
+	if newName == tag.Name {
+		return tag, nil
+	}
+
+	if _, err := useCase.repo.Find(ctx, newName); err == nil {
+		return nil, domain.ErrTagExists
+	} else if !errors.Is(err, domain.ErrTagNotFound) {
+		return nil, err
+	}
+
+	if err := useCase.repo.Rename(ctx, tag.Name, newName); err != nil {
+		return nil, err
+	}
+	tag.Name = newName
+
+	return tag, nil
+}
+
+// Execute moves the posts of the source tag to the target one, which must exist,
+// and deletes the source tag.
+func (useCase *MergeTagsUC) Execute(ctx context.Context, userID uuid.UUID, source, target string) (*domain.Tag, error) {
+	if !useCase.admins.Contains(userID) {
+		return nil, domain.ErrUnauthorized
+	}
+	sourceTag, err := findTag(ctx, useCase.repo, source)
+
+	if err != nil {
+		return nil, err
+	}
+	targetTag, err := findTag(ctx, useCase.repo, target)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceTag.Name == targetTag.Name {
+		return targetTag, nil
+	}
+
+	if err := useCase.repo.Merge(ctx, sourceTag.Name, targetTag.Name); err != nil {
+		return nil, err
+	}
+
+	return findTag(ctx, useCase.repo, targetTag.Name)
+}
+
+// findTag finds the tag by its name, normalized first.
+func findTag(ctx context.Context, repo domain.TagsRepository, name string) (*domain.Tag, error) {
+	name, err := domain.NormalizeTag(name)
+
+	if err != nil {
+		return nil, domain.ErrTagNotFound
+	}
+
+	return repo.Find(ctx, name)
+}
@@ -0,0 +1,109 @@
+package tags
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTagPostsUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should fail and return ErrTagNotFound", func(t *testing.T) {
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewListTagPostsUseCase(
+			memory.NewInMemoryTagsRepository(postsRepo), postsRepo,
+			memory.NewInMemoryReactionsRepository(nil),
+		)
+
+		_, _, _, err := useCase.Execute(ctx, uuid.New(), "Go", domain.Paginator{})
+		assert.ErrorIs(t, err, domain.ErrTagNotFound)
+	})
+
+	t.Run("it should list the posts of the tag given by any of its spellings", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		useCase := NewListTagPostsUseCase(
+			memory.NewInMemoryTagsRepository(postsRepo), postsRepo,
+			memory.NewInMemoryReactionsRepository(nil),
+		)
+		postsRepo.FillWithRandomPosts(uuid.Nil, 3)
+		post := domain.NewPost(uuid.New(), "Tagged post", "Random content")
+		post.Tags = []string{"go-lang"}
+		postsRepo.Store(ctx, post)
+
+		tag, posts, _, err := useCase.Execute(ctx, uuid.New(), "Go Lang", domain.Paginator{})
+
+		if _assert.NoError(err) && _assert.Len(posts, 1) {
+			_assert.Equal(&domain.Tag{Name: "go-lang", PostsCount: 1}, tag)
+			_assert.Equal(post.ID, posts[0].ID)
+		}
+	})
+}
+
+func TestRenameTagUseCase(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	newUseCase := func(tags ...[]string) *RenameTagUC {
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+
+		for _, postTags := range tags {
+			post := domain.NewPost(uuid.New(), "Tagged post", "Random content")
+			post.Tags = postTags
+			postsRepo.Store(ctx, post)
+		}
+
+		return NewRenameTagUseCase(memory.NewInMemoryTagsRepository(postsRepo), domain.Admins{adminID})
+	}
+
+	t.Run("it should fail and return ErrUnauthorized", func(t *testing.T) {
+		_, err := newUseCase([]string{"golang"}).Execute(ctx, uuid.New(), "golang", "go")
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+
+	t.Run("it should fail and return ErrTagExists", func(t *testing.T) {
+		_, err := newUseCase([]string{"golang"}, []string{"go"}).Execute(ctx, adminID, "golang", "Go")
+		assert.ErrorIs(t, err, domain.ErrTagExists)
+	})
+
+	t.Run("it should rename the tag", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase := newUseCase([]string{"golang"}, []string{"golang", "web"})
+
+		tag, err := useCase.Execute(ctx, adminID, "golang", "Go")
+
+		if _assert.NoError(err) {
+			_assert.Equal(&domain.Tag{Name: "go", PostsCount: 2}, tag)
+
+			_, err = useCase.repo.Find(ctx, "golang")
+			_assert.ErrorIs(err, domain.ErrTagNotFound)
+		}
+	})
+}
+
+func TestMergeTagsUseCase(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+	postsRepo := memory.NewInMemoryPostsRepository(nil)
+	useCase := NewMergeTagsUseCase(memory.NewInMemoryTagsRepository(postsRepo), domain.Admins{adminID})
+
+	for _, tags := range [][]string{{"golang"}, {"golang", "go"}, {"go"}} {
+		post := domain.NewPost(uuid.New(), "Tagged post", "Random content")
+		post.Tags = tags
+		postsRepo.Store(ctx, post)
+	}
+
+	_, err := useCase.Execute(ctx, adminID, "golang", "rust")
+	assert.ErrorIs(t, err, domain.ErrTagNotFound)
+
+	tag, err := useCase.Execute(ctx, adminID, "golang", "go")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, &domain.Tag{Name: "go", PostsCount: 3}, tag)
+	}
+}
@@ -0,0 +1,281 @@
+package trash
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ListTrashUC struct {
+	postsRepo    domain.PostRepository
+	commentsRepo domain.CommentRepository
+}
+
+type RestorePostUC struct {
+	postsRepo      domain.PostRepository
+	commentsRepo   domain.CommentRepository
+	searchIndex    domain.SearchIndex
+	webhookService domain.WebhookService
+}
+
+type RestoreCommentUC struct {
+	commentsRepo domain.CommentRepository
+	postsRepo    domain.PostRepository
+	searchIndex  domain.SearchIndex
+}
+
+type PurgeTrashUC struct {
+	postsRepo    domain.PostRepository
+	commentsRepo domain.CommentRepository
+}
+
+func NewListTrashUseCase(postsRepository domain.PostRepository, commentsRepository domain.CommentRepository) *ListTrashUC {
+	return &ListTrashUC{
+		postsRepo:    postsRepository,
+		commentsRepo: commentsRepository,
+	}
+}
+
+func NewRestorePostUseCase(
+	postsRepository domain.PostRepository,
+	commentsRepository domain.CommentRepository,
+	searchIndex domain.SearchIndex,
+	webhookService domain.WebhookService,
+) *RestorePostUC {
+	return &RestorePostUC{
+		postsRepo:      postsRepository,
+		commentsRepo:   commentsRepository,
+		searchIndex:    searchIndex,
+		webhookService: webhookService,
+	}
+}
+
+func NewRestoreCommentUseCase(
+	commentsRepository domain.CommentRepository,
+	postsRepository domain.PostRepository,
+	searchIndex domain.SearchIndex,
+) *RestoreCommentUC {
+	return &RestoreCommentUC{
+		commentsRepo: commentsRepository,
+		postsRepo:    postsRepository,
+		searchIndex:  searchIndex,
+	}
+}
+
+func NewPurgeTrashUseCase(postsRepository domain.PostRepository, commentsRepository domain.CommentRepository) *PurgeTrashUC {
+	return &PurgeTrashUC{
+		postsRepo:    postsRepository,
+		commentsRepo: commentsRepository,
+	}
+}
+
+// Execute returns the posts and comments of the user which can still be restored,
+// the latest deleted first.
+func (useCase *ListTrashUC) Execute(ctx context.Context, userID uuid.UUID) (*domain.Trash, error) {
+	since := domain.TrashCutoff(time.Now())
+	posts, err := useCase.postsRepo.ListTrash(ctx, userID, since)
+
+	if err != nil {
+		return nil, err
+	}
+	comments, err := useCase.commentsRepo.ListTrash(ctx, userID, since)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Trash{Posts: posts, Comments: comments}, nil
+}
+
+// Execute takes the post out of the trash. A public post is searchable again,
+// along with its comments which weren't deleted.
+func (useCase *RestorePostUC) Execute(ctx context.Context, postID, userID uuid.UUID) (*domain.Post, error) {
+	post, err := useCase.postsRepo.FindInTrash(ctx, postID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if post.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if !post.CanBeRestored(time.Now()) {
+		return nil, domain.ErrRestoreExpired
+	}
+
+	if err := useCase.postsRepo.Restore(ctx, post); err != nil {
+		return nil, err
+	}
+
+	if !post.IsPublic() {
+		return post, nil
+	}
+
+	if err := useCase.searchIndex.Index(ctx, domain.NewPostSearchDocument(post)); err != nil {
+		return nil, err
+	}
+	comments, err := useCase.commentsRepo.ListAll(ctx, post.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comment := range comments {
+		if comment.IsDeleted() {
+			continue
+		}
+
+		if err := useCase.searchIndex.Index(ctx, domain.NewCommentSearchDocument(&comment)); err != nil {
+			return nil, err
+		}
+	}
+	useCase.webhookService.PostRestored(ctx, post)
+
+	return post, nil
+}
+
+// Execute takes the comment out of the trash, as long as its post wasn't deleted.
+func (useCase *RestoreCommentUC) Execute(ctx context.Context, commentID, userID uuid.UUID) (*domain.Comment, error) {
+	comment, err := useCase.commentsRepo.Find(ctx, commentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !comment.IsDeleted() {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	if comment.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if !comment.CanBeRestored(time.Now()) {
+		return nil, domain.ErrRestoreExpired
+	}
+	post, err := useCase.postsRepo.FindByID(ctx, comment.PostID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.IsPublic() {
+		return nil, domain.ErrPostNotFound
+	}
+	comment.Restore()
+
+	if err := useCase.commentsRepo.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	if err := useCase.searchIndex.Index(ctx, domain.NewCommentSearchDocument(comment)); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Execute removes for good the posts and comments deleted for longer than the trash
+// retention, and returns how many were purged. The expired comments having replies
+// are tombstoned for good instead, and the tombstones left without replies are removed.
+func (useCase *PurgeTrashUC) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+	cutoff := domain.TrashCutoff(now)
+	posts, err := useCase.postsRepo.ListExpired(ctx, cutoff)
+
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+
+	for _, post := range posts {
+		if err := useCase.postsRepo.Purge(ctx, &post); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	comments, err := useCase.commentsRepo.ListExpired(ctx, cutoff)
+
+	if err != nil {
+		return purged, err
+	}
+
+	for _, comment := range comments {
+		ok, err := useCase.purgeComment(ctx, comment.ID, now)
+
+		if err != nil {
+			return purged, err
+		}
+
+		if ok {
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// purgeComment tells false when the comment was already removed along with a reply.
+func (useCase *PurgeTrashUC) purgeComment(ctx context.Context, commentID uuid.UUID, now time.Time) (bool, error) {
+	comment, err := useCase.commentsRepo.Find(ctx, commentID)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+	repliesCount, err := useCase.commentsRepo.CountReplies(ctx, comment.ID)
+
+	if err != nil {
+		return false, err
+	}
+
+	if repliesCount > 0 {
+		comment.Tombstone()
+		return true, useCase.commentsRepo.Update(ctx, comment)
+	}
+
+	if err := useCase.commentsRepo.Delete(ctx, comment); err != nil {
+		return false, err
+	}
+
+	return true, useCase.removeEmptyTombstones(ctx, comment.ParentID, now)
+}
+
+// removeEmptyTombstones walks up the thread from the parent, deleting the tombstones
+// which have no replies left and can't be restored anymore.
+func (useCase *PurgeTrashUC) removeEmptyTombstones(ctx context.Context, parentID *uuid.UUID, now time.Time) error {
+	for parentID != nil {
+		parent, err := useCase.commentsRepo.Find(ctx, *parentID)
+
+		if err != nil {
+			if errors.Is(err, domain.ErrCommentNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !parent.IsDeleted() || parent.CanBeRestored(now) {
+			return nil
+		}
+		repliesCount, err := useCase.commentsRepo.CountReplies(ctx, parent.ID)
+
+		if err != nil || repliesCount > 0 {
+			return err
+		}
+
+		if err := useCase.commentsRepo.Delete(ctx, parent); err != nil {
+			return err
+		}
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
@@ -0,0 +1,307 @@
+package trash
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type webhookServiceSpy struct {
+	events []string
+}
+
+func (spy *webhookServiceSpy) PostCreated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.created")
+}
+
+func (spy *webhookServiceSpy) PostUpdated(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.updated")
+}
+
+func (spy *webhookServiceSpy) PostDeleted(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.deleted")
+}
+
+func (spy *webhookServiceSpy) PostRestored(ctx context.Context, post *domain.Post) {
+	spy.events = append(spy.events, "post.restored")
+}
+
+func (spy *webhookServiceSpy) CommentCreated(ctx context.Context, comment *domain.Comment) {
+	spy.events = append(spy.events, "comment.created")
+}
+
+// expired returns a date out of the trash retention.
+func expired() time.Time {
+	return time.Now().Add(-domain.TrashRetention - time.Hour)
+}
+
+func TestListTrashUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should list the posts and comments of the user which can still be restored", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+		userID := uuid.New()
+
+		deletedPost := domain.NewPost(userID, "Deleted post", "Post content")
+		postsRepo.Store(ctx, deletedPost)
+		deletedPost.Delete(time.Now())
+		postsRepo.Delete(ctx, deletedPost)
+
+		expiredPost := domain.NewPost(userID, "Expired post", "Post content")
+		postsRepo.Store(ctx, expiredPost)
+		expiredPost.Delete(expired())
+		postsRepo.Delete(ctx, expiredPost)
+
+		otherPost := domain.NewPost(uuid.New(), "Other post", "Post content")
+		postsRepo.Store(ctx, otherPost)
+		otherPost.Delete(time.Now())
+		postsRepo.Delete(ctx, otherPost)
+
+		comment := domain.NewComment(otherPost.ID, userID, "Deleted comment")
+		comment.Delete(time.Now())
+		commentsRepo.Store(ctx, comment)
+		commentsRepo.Store(ctx, domain.NewComment(otherPost.ID, userID, "Test comment"))
+
+		trash, err := NewListTrashUseCase(postsRepo, commentsRepo).Execute(ctx, userID)
+
+		if _assert.NoError(err) {
+			if _assert.Len(trash.Posts, 1) {
+				_assert.Equal(deletedPost.ID, trash.Posts[0].ID)
+			}
+
+			if _assert.Len(trash.Comments, 1) {
+				_assert.Equal(comment.ID, trash.Comments[0].ID)
+			}
+		}
+	})
+}
+
+func TestRestorePostUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (domain.PostRepository, domain.CommentRepository, domain.SearchIndex, *webhookServiceSpy, *RestorePostUC) {
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+		searchIndex := memory.NewInMemorySearchIndex(nil)
+		webhookService := &webhookServiceSpy{}
+
+		return postsRepo, commentsRepo, searchIndex, webhookService,
+			NewRestorePostUseCase(postsRepo, commentsRepo, searchIndex, webhookService)
+	}
+
+	t.Run("it should restore the post and make it searchable again with its comments", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo, commentsRepo, searchIndex, webhookService, useCase := setup()
+
+		post := domain.NewPost(uuid.New(), "Restored post", "Post content")
+		postsRepo.Store(ctx, post)
+		commentsRepo.Store(ctx, domain.NewComment(post.ID, uuid.New(), "Restored comment"))
+		deletedComment := domain.NewComment(post.ID, uuid.New(), "Restored deleted comment")
+		deletedComment.Delete(time.Now())
+		commentsRepo.Store(ctx, deletedComment)
+		post.Delete(time.Now())
+		postsRepo.Delete(ctx, post)
+
+		restored, err := useCase.Execute(ctx, post.ID, post.UserID)
+
+		if _assert.NoError(err) {
+			_assert.False(restored.IsDeleted())
+			_, err := postsRepo.FindByID(ctx, post.ID)
+			_assert.NoError(err)
+
+			results, _, err := searchIndex.Search(ctx, domain.SearchQuery{Text: "restored", Limit: 10})
+
+			if _assert.NoError(err) {
+				_assert.Len(results, 2)
+			}
+			_assert.Equal([]string{"post.restored"}, webhookService.events)
+		}
+	})
+
+	t.Run("it should fail when the post isn't in the trash or the user isn't its author", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo, _, _, _, useCase := setup()
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+
+		_, err := useCase.Execute(ctx, post.ID, post.UserID)
+		_assert.ErrorIs(err, domain.ErrPostNotFound)
+
+		post.Delete(time.Now())
+		postsRepo.Delete(ctx, post)
+
+		_, err = useCase.Execute(ctx, post.ID, uuid.New())
+		_assert.ErrorIs(err, domain.ErrUnauthorized)
+	})
+
+	t.Run("it should fail when the post was deleted for too long", func(t *testing.T) {
+		postsRepo, _, _, _, useCase := setup()
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		post.Delete(expired())
+		postsRepo.Delete(ctx, post)
+
+		_, err := useCase.Execute(ctx, post.ID, post.UserID)
+		assert.ErrorIs(t, err, domain.ErrRestoreExpired)
+	})
+}
+
+func TestRestoreCommentUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should restore the comment in its thread", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+		searchIndex := memory.NewInMemorySearchIndex(nil)
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		comment := domain.NewComment(post.ID, uuid.New(), "Restored comment")
+		comment.Delete(time.Now())
+		commentsRepo.Store(ctx, comment)
+
+		restored, err := NewRestoreCommentUseCase(commentsRepo, postsRepo, searchIndex).Execute(ctx, comment.ID, comment.UserID)
+
+		if _assert.NoError(err) {
+			_assert.False(restored.IsDeleted())
+			comments, _, _ := commentsRepo.List(ctx, post.ID, domain.Paginator{Limit: 10})
+			_assert.Len(comments, 1)
+
+			results, _, _ := searchIndex.Search(ctx, domain.SearchQuery{Text: "restored", Limit: 10})
+			_assert.Len(results, 1)
+		}
+	})
+
+	t.Run("it should fail when the comment can't be restored", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+		useCase := NewRestoreCommentUseCase(commentsRepo, postsRepo, memory.NewInMemorySearchIndex(nil))
+
+		post := domain.NewPost(uuid.New(), "Post title", "Post content")
+		postsRepo.Store(ctx, post)
+		comment := domain.NewComment(post.ID, uuid.New(), "Test comment")
+		commentsRepo.Store(ctx, comment)
+
+		_, err := useCase.Execute(ctx, comment.ID, comment.UserID)
+		_assert.ErrorIs(err, domain.ErrCommentNotFound)
+
+		comment.Delete(time.Now())
+		commentsRepo.Update(ctx, comment)
+
+		_, err = useCase.Execute(ctx, comment.ID, uuid.New())
+		_assert.ErrorIs(err, domain.ErrUnauthorized)
+
+		post.Delete(time.Now())
+		postsRepo.Delete(ctx, post)
+
+		_, err = useCase.Execute(ctx, comment.ID, comment.UserID)
+		_assert.ErrorIs(err, domain.ErrPostNotFound)
+
+		comment.Delete(expired())
+		commentsRepo.Update(ctx, comment)
+
+		_, err = useCase.Execute(ctx, comment.ID, comment.UserID)
+		_assert.ErrorIs(err, domain.ErrRestoreExpired)
+	})
+}
+
+func TestPurgeTrashUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should purge the posts deleted for too long", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+
+		expiredPost := domain.NewPost(uuid.New(), "Expired post", "Post content")
+		postsRepo.Store(ctx, expiredPost)
+		expiredPost.Delete(expired())
+		postsRepo.Delete(ctx, expiredPost)
+
+		deletedPost := domain.NewPost(uuid.New(), "Deleted post", "Post content")
+		postsRepo.Store(ctx, deletedPost)
+		deletedPost.Delete(time.Now())
+		postsRepo.Delete(ctx, deletedPost)
+
+		purged, err := NewPurgeTrashUseCase(postsRepo, commentsRepo).Execute(ctx)
+
+		if _assert.NoError(err) {
+			_assert.Equal(1, purged)
+			_, err := postsRepo.FindInTrash(ctx, expiredPost.ID)
+			_assert.ErrorIs(err, domain.ErrPostNotFound)
+			_, err = postsRepo.FindInTrash(ctx, deletedPost.ID)
+			_assert.NoError(err)
+		}
+	})
+
+	t.Run("it should tombstone the expired comments having replies and remove the others", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+
+		comment := domain.NewComment(uuid.New(), uuid.New(), "Test comment")
+		comment.Delete(expired())
+		commentsRepo.Store(ctx, comment)
+		reply := domain.NewReply(comment, uuid.New(), "Test reply")
+		commentsRepo.Store(ctx, reply)
+
+		useCase := NewPurgeTrashUseCase(postsRepo, commentsRepo)
+		purged, err := useCase.Execute(ctx)
+
+		if _assert.NoError(err) {
+			_assert.Equal(1, purged)
+			tombstone, err := commentsRepo.Find(ctx, comment.ID)
+
+			if _assert.NoError(err) {
+				_assert.Empty(tombstone.Content)
+				_assert.False(tombstone.CanBeRestored(time.Now()))
+			}
+		}
+
+		reply.Delete(expired())
+		commentsRepo.Update(ctx, reply)
+		purged, err = useCase.Execute(ctx)
+
+		if _assert.NoError(err) {
+			_assert.Equal(1, purged)
+			_, err := commentsRepo.Find(ctx, reply.ID)
+			_assert.ErrorIs(err, domain.ErrCommentNotFound)
+
+			_, err = commentsRepo.Find(ctx, comment.ID)
+			_assert.ErrorIs(err, domain.ErrCommentNotFound)
+		}
+	})
+
+	t.Run("it should keep the tombstones which can still be restored", func(t *testing.T) {
+		_assert := assert.New(t)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+
+		comment := domain.NewComment(uuid.New(), uuid.New(), "Test comment")
+		comment.Delete(time.Now())
+		commentsRepo.Store(ctx, comment)
+		reply := domain.NewReply(comment, uuid.New(), "Test reply")
+		reply.Delete(expired())
+		commentsRepo.Store(ctx, reply)
+
+		_, err := NewPurgeTrashUseCase(memory.NewInMemoryPostsRepository(nil), commentsRepo).Execute(ctx)
+
+		if _assert.NoError(err) {
+			parent, err := commentsRepo.Find(ctx, comment.ID)
+
+			if _assert.NoError(err) {
+				_assert.True(parent.CanBeRestored(time.Now()))
+			}
+		}
+	})
+}
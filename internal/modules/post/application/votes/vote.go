@@ -0,0 +1,105 @@
+package votes
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type VoteInput struct {
+	TargetType domain.VoteTarget
+	TargetID   uuid.UUID
+	UserID     uuid.UUID
+	Value      int
+}
+
+type VoteUC struct {
+	repo         domain.VotesRepository
+	postsRepo    domain.PostRepository
+	commentsRepo domain.CommentRepository
+	transactor   domain.Transactor
+}
+
+func NewVoteUseCase(
+	repository domain.VotesRepository,
+	postsRepository domain.PostRepository,
+	commentsRepository domain.CommentRepository,
+	transactor domain.Transactor,
+) *VoteUC {
+	return &VoteUC{
+		repo:         repository,
+		postsRepo:    postsRepository,
+		commentsRepo: commentsRepository,
+		transactor:   transactor,
+	}
+}
+
+// Execute casts the user vote on the post or the comment, which can't be a deleted one,
+// and returns the target new score. The vote and the score change are stored together.
+func (useCase *VoteUC) Execute(ctx context.Context, input VoteInput) (int, error) {
+	vote, err := domain.NewVote(input.TargetType, input.TargetID, input.UserID, input.Value)
+
+	if err != nil {
+		return 0, err
+	}
+	var score int
+
+	err = useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := useCase.findScore(ctx, vote.TargetType, vote.TargetID); err != nil {
+			return err
+		}
+		delta, err := useCase.repo.Cast(ctx, vote)
+
+		if err != nil {
+			return err
+		}
+
+		if delta != 0 {
+			if err := useCase.addToScore(ctx, vote.TargetType, vote.TargetID, delta); err != nil {
+				return err
+			}
+		}
+		score, err = useCase.findScore(ctx, vote.TargetType, vote.TargetID)
+
+		return err
+	})
+
+	return score, err
+}
+
+func (useCase *VoteUC) findScore(ctx context.Context, targetType domain.VoteTarget, targetID uuid.UUID) (int, error) {
+	if targetType == domain.PostVoteTarget {
+		post, err := useCase.postsRepo.FindByID(ctx, targetID)
+
+		if err != nil {
+			return 0, err
+		}
+
+		// The unpublished posts are not open to votes yet.
+		if !post.IsPublic() {
+			return 0, domain.ErrPostNotFound
+		}
+
+		return post.Score, nil
+	}
+	comment, err := useCase.commentsRepo.Find(ctx, targetID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if comment.IsDeleted() {
+		return 0, domain.ErrCommentNotFound
+	}
+
+	return comment.Score, nil
+}
+
+func (useCase *VoteUC) addToScore(ctx context.Context, targetType domain.VoteTarget, targetID uuid.UUID, delta int) error {
+	if targetType == domain.PostVoteTarget {
+		return useCase.postsRepo.AddToScore(ctx, targetID, delta)
+	}
+
+	return useCase.commentsRepo.AddToScore(ctx, targetID, delta)
+}
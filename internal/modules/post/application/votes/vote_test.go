@@ -0,0 +1,119 @@
+package votes
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/post/infra/memory"
+	"comu/internal/shared/database"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoteUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	newUseCase := func() (*VoteUC, domain.PostRepository, domain.CommentRepository) {
+		postsRepo := memory.NewInMemoryPostsRepository(nil)
+		commentsRepo := memory.NewInMemoryCommentsRepository(nil)
+		useCase := NewVoteUseCase(
+			memory.NewInMemoryVotesRepository(nil),
+			postsRepo, commentsRepo, database.NoopTransactor{},
+		)
+
+		return useCase, postsRepo, commentsRepo
+	}
+
+	t.Run("it should fail and return ErrInvalidVote", func(t *testing.T) {
+		useCase, _, _ := newUseCase()
+
+		_, err := useCase.Execute(ctx, VoteInput{
+			TargetType: domain.PostVoteTarget,
+			TargetID:   uuid.New(),
+			UserID:     uuid.New(),
+			Value:      2,
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidVote)
+	})
+
+	t.Run("it should fail when the target doesn't exist or was deleted", func(t *testing.T) {
+		useCase, _, commentsRepo := newUseCase()
+		deletedComment := domain.NewComment(uuid.New(), uuid.New(), "Deleted comment")
+		deletedComment.Delete(time.Now())
+		commentsRepo.Store(ctx, deletedComment)
+
+		_, err := useCase.Execute(ctx, VoteInput{
+			TargetType: domain.PostVoteTarget,
+			TargetID:   uuid.New(),
+			UserID:     uuid.New(),
+			Value:      domain.UpVote,
+		})
+		assert.ErrorIs(t, err, domain.ErrPostNotFound)
+
+		_, err = useCase.Execute(ctx, VoteInput{
+			TargetType: domain.CommentVoteTarget,
+			TargetID:   deletedComment.ID,
+			UserID:     uuid.New(),
+			Value:      domain.UpVote,
+		})
+		assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+	})
+
+	t.Run("it should update the post score and hot ranking", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase, postsRepo, _ := newUseCase()
+		post := domain.NewPost(uuid.New(), "Voted post", "Random content")
+		postsRepo.Store(ctx, post)
+		voterID := uuid.New()
+
+		for _, userID := range []uuid.UUID{voterID, uuid.New(), voterID} {
+			_, err := useCase.Execute(ctx, VoteInput{
+				TargetType: domain.PostVoteTarget,
+				TargetID:   post.ID,
+				UserID:     userID,
+				Value:      domain.UpVote,
+			})
+			_assert.NoError(err)
+		}
+		score, err := useCase.Execute(ctx, VoteInput{
+			TargetType: domain.PostVoteTarget,
+			TargetID:   post.ID,
+			UserID:     uuid.New(),
+			Value:      domain.DownVote,
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal(1, score)
+
+			voted, _ := postsRepo.FindByID(ctx, post.ID)
+			_assert.Equal(1, voted.Score)
+			_assert.Equal(domain.HotRank(1, post.CreatedAt), voted.Hot)
+		}
+	})
+
+	t.Run("it should withdraw the user vote on the comment", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase, _, commentsRepo := newUseCase()
+		comment := domain.NewComment(uuid.New(), uuid.New(), "Voted comment")
+		commentsRepo.Store(ctx, comment)
+		input := VoteInput{
+			TargetType: domain.CommentVoteTarget,
+			TargetID:   comment.ID,
+			UserID:     uuid.New(),
+			Value:      domain.DownVote,
+		}
+
+		score, err := useCase.Execute(ctx, input)
+		_assert.NoError(err)
+		_assert.Equal(-1, score)
+
+		input.Value = domain.NoVote
+		score, err = useCase.Execute(ctx, input)
+
+		if _assert.NoError(err) {
+			_assert.Equal(0, score)
+		}
+	})
+}
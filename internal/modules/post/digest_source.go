@@ -0,0 +1,65 @@
+package post
+
+import (
+	"comu/internal/modules/notifications"
+	"comu/internal/modules/post/application/digest"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type digestSource struct {
+	listNewCommentsUC *digest.ListNewCommentsUC
+	listTopNewPostsUC *digest.ListTopNewPostsUC
+}
+
+func newDigestSource(listNewCommentsUC *digest.ListNewCommentsUC, listTopNewPostsUC *digest.ListTopNewPostsUC) *digestSource {
+	return &digestSource{
+		listNewCommentsUC: listNewCommentsUC,
+		listTopNewPostsUC: listTopNewPostsUC,
+	}
+}
+
+func (source *digestSource) NewCommentsOnPostsOf(
+	ctx context.Context, userID uuid.UUID, period notifications.DigestPeriod,
+) ([]notifications.DigestComment, error) {
+	comments, err := source.listNewCommentsUC.Execute(ctx, userID, period.Start, period.End)
+
+	if err != nil {
+		return nil, err
+	}
+	digestComments := make([]notifications.DigestComment, len(comments))
+
+	for i, c := range comments {
+		digestComments[i] = notifications.DigestComment{
+			PostTitle: c.Post.Title,
+			PostSlug:  c.Post.Slug,
+			Content:   c.Comment.Content,
+			CreatedAt: c.Comment.CreatedAt,
+		}
+	}
+
+	return digestComments, nil
+}
+
+func (source *digestSource) TopNewPosts(
+	ctx context.Context, period notifications.DigestPeriod, limit int,
+) ([]notifications.DigestPost, error) {
+	posts, err := source.listTopNewPostsUC.Execute(ctx, period.Start, period.End, limit)
+
+	if err != nil {
+		return nil, err
+	}
+	digestPosts := make([]notifications.DigestPost, len(posts))
+
+	for i, p := range posts {
+		digestPosts[i] = notifications.DigestPost{
+			Title:         p.Post.Title,
+			Slug:          p.Post.Slug,
+			CommentsCount: p.CommentsCount,
+			CreatedAt:     p.Post.CreatedAt,
+		}
+	}
+
+	return digestPosts, nil
+}
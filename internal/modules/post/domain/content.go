@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// ExcerptLength is the max number of characters of the posts excerpts.
+	ExcerptLength = 200
+	// WordsPerMinute is the reading speed the reading times are computed with.
+	WordsPerMinute = 200
+)
+
+// ContentRenderer renders the Markdown content of the posts and comments.
+type ContentRenderer interface {
+	// Render returns the sanitized HTML of the content, along with its plain text.
+	Render(content string) (html, text string)
+}
+
+// Rendering is the HTML of a Markdown content with the fields derived from its text.
+type Rendering struct {
+	ContentHTML string `json:"content_html"`
+	Excerpt     string `json:"excerpt"`
+	WordCount   int    `json:"word_count"`
+	// ReadingTime is in minutes, at least 1 for a content with words.
+	ReadingTime int `json:"reading_time"`
+}
+
+func RenderContent(content string, renderer ContentRenderer) Rendering {
+	html, text := renderer.Render(content)
+	words := strings.Fields(text)
+
+	return Rendering{
+		ContentHTML: html,
+		Excerpt:     MakeExcerpt(words, ExcerptLength),
+		WordCount:   len(words),
+		ReadingTime: (len(words) + WordsPerMinute - 1) / WordsPerMinute,
+	}
+}
+
+// MakeExcerpt joins the first words up to length characters, cutting
+// the last one only when it's longer than the excerpt itself.
+func MakeExcerpt(words []string, length int) string {
+	var builder strings.Builder
+
+	for i, word := range words {
+		separator := ""
+
+		if i > 0 {
+			separator = " "
+		}
+
+		if utf8.RuneCountInString(builder.String()+separator+word) > length {
+			if builder.Len() == 0 {
+				builder.WriteString(string([]rune(word)[:length]))
+			}
+			return builder.String() + "…"
+		}
+		builder.WriteString(separator + word)
+	}
+
+	return builder.String()
+}
+
+// SetContent sets the Markdown content of the post with its rendering.
+func (post *Post) SetContent(content string, renderer ContentRenderer) {
+	rendering := RenderContent(content, renderer)
+	post.Content = content
+	post.ContentHTML = rendering.ContentHTML
+	post.Excerpt = rendering.Excerpt
+	post.WordCount = rendering.WordCount
+	post.ReadingTime = rendering.ReadingTime
+}
+
+// SetContent sets the Markdown content of the comment with its HTML.
+func (comment *Comment) SetContent(content string, renderer ContentRenderer) {
+	comment.Content = content
+	comment.ContentHTML, _ = renderer.Render(content)
+}
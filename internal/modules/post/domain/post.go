@@ -5,43 +5,81 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/mazen160/go-random"
 )
 
 var (
 	ErrPostNotFound    = errors.New("the post you're looking for does'nt exist")
 	ErrCommentNotFound = errors.New("the comment you're looking for does'nt exist")
 	ErrUnauthorized    = errors.New("sorry, you can't perform this action")
+	ErrMaxDepthReached = errors.New("this comment can't be replied to, the thread is too deep")
 )
 
 const DefaultPaginatorLimit = 10
 
+// DefaultCommentMaxDepth is the number of reply levels allowed under a top-level comment.
+const DefaultCommentMaxDepth = 5
+
 type Post struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Title     string    `json:"title"`
-	Slug      string    `json:"slug"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	Title  string    `json:"title"`
+	Slug   string    `json:"slug"`
+	// Content is Markdown, rendered to the sanitized ContentHTML.
+	Content     string    `json:"content"`
+	ContentHTML string    `json:"content_html"`
+	Excerpt     string    `json:"excerpt"`
+	WordCount   int       `json:"word_count"`
+	ReadingTime int       `json:"reading_time"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// Tags are the normalized names of the post tags, stored with the post.
+	Tags []string `json:"tags"`
+	// Score is the sum of the post votes.
+	Score int `json:"score"`
+	// Hot is the hot ranking of the post, updated with its score.
+	Hot    float64    `json:"-"`
+	Status PostStatus `json:"status"`
+	// PublishAt is when the scheduled post gets published, or when the post was.
+	PublishAt *time.Time `json:"publish_at"`
+	// DeletedAt is set on the posts in the trash.
+	DeletedAt *time.Time `json:"deleted_at"`
+	// Reactions is only filled in for the viewers.
+	Reactions *Reactions `json:"reactions,omitempty"`
 }
 
 type Comment struct {
-	ID        uuid.UUID `json:"id"`
-	PostID    uuid.UUID `json:"post_id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID     uuid.UUID `json:"id"`
+	PostID uuid.UUID `json:"post_id"`
+	// ParentID is the comment replied to, nil for the top-level comments.
+	ParentID *uuid.UUID `json:"parent_id"`
+	Depth    int        `json:"depth"`
+	UserID   uuid.UUID  `json:"user_id"`
+	// Content is Markdown, rendered to the sanitized ContentHTML.
+	Content     string    `json:"content"`
+	ContentHTML string    `json:"content_html"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// DeletedAt is set on the comments in the trash, kept as tombstones for their replies.
+	DeletedAt *time.Time `json:"deleted_at"`
+	// Score is the sum of the comment votes.
+	Score int `json:"score"`
+	// RepliesCount is only filled in by the listings.
+	RepliesCount int `json:"replies_count"`
+	// Reactions is only filled in for the viewers.
+	Reactions *Reactions `json:"reactions,omitempty"`
 }
 
+// Cursor points to the last item of a page. Rank holds the sort key of the item
+// for the orderings other than the chronological one, and Since the start of the
+// time window of the first page, so that the next pages keep the same window.
 type Cursor struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
+	Rank      float64   `json:",omitempty"`
+	Since     time.Time `json:",omitzero"`
 }
 
 func (c *Cursor) ToBase64() (string, error) {
@@ -56,21 +94,44 @@ func (c *Cursor) ToBase64() (string, error) {
 type Paginator struct {
 	Limit int
 	After *Cursor
+	// Sort orders the posts, the newest first by default.
+	Sort PostSort
+	// Since keeps the posts created from then on, when not zero.
+	Since time.Time
 }
 
 func NewPost(authorID uuid.UUID, title, content string) *Post {
 	slug := MakePostSlug(title)
+	now := time.Now()
 
 	return &Post{
 		UserID:    authorID,
 		Title:     title,
 		Slug:      slug,
 		Content:   content,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Tags:      []string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+		Hot:       HotRank(0, now),
+		Status:    PublishedPostStatus,
+		PublishAt: &now,
 	}
 }
 
+// CursorOf returns the cursor pointing to the post for the given sort.
+func (post *Post) CursorOf(sort PostSort) *Cursor {
+	cursor := &Cursor{ID: post.ID, CreatedAt: post.CreatedAt}
+
+	switch sort {
+	case TopPostSort:
+		cursor.Rank = float64(post.Score)
+	case HotPostSort:
+		cursor.Rank = post.Hot
+	}
+
+	return cursor
+}
+
 func NewComment(postID, authorID uuid.UUID, content string) *Comment {
 	return &Comment{
 		PostID:    postID,
@@ -81,28 +142,113 @@ func NewComment(postID, authorID uuid.UUID, content string) *Comment {
 	}
 }
 
-func MakePostSlug(title string) string {
-	fields := strings.Fields(strings.ToLower(title))
-	rand, _ := random.String(16)
+// NewReply returns a comment replying to the parent one, on the same post.
+func NewReply(parent *Comment, authorID uuid.UUID, content string) *Comment {
+	reply := NewComment(parent.PostID, authorID, content)
+	reply.ParentID = &parent.ID
+	reply.Depth = parent.Depth + 1
+
+	return reply
+}
+
+func (comment *Comment) IsDeleted() bool {
+	return comment.DeletedAt != nil
+}
 
-	return strings.Join(fields, "-") + rand
+// Tombstone clears the content of the deleted comment, which keeps its place in the thread.
+func (comment *Comment) Tombstone() {
+	comment.Content = ""
+	comment.ContentHTML = ""
 }
 
+// PostRepository only finds and lists the posts out of the trash, except for its trash methods.
 type PostRepository interface {
 	FindByID(context.Context, uuid.UUID) (*Post, error)
-	FindBySlug(context.Context, string) (*Post, error)
+	// FindBySlug only finds the unpublished posts for their author, the viewer.
+	FindBySlug(ctx context.Context, slug string, viewerID uuid.UUID) (*Post, error)
 	ListAll(context.Context) ([]Post, error)
+	ListByAuthor(context.Context, uuid.UUID) ([]Post, error)
+	// List and ListByTag only return the published posts.
 	List(context.Context, Paginator) ([]Post, *Cursor, error)
+	ListByTag(ctx context.Context, tag string, paginator Paginator) ([]Post, *Cursor, error)
+	// ListDrafts returns the draft and scheduled posts of the author, the newest first.
+	ListDrafts(ctx context.Context, authorID uuid.UUID, paginator Paginator) ([]Post, *Cursor, error)
+	// ListDue returns the scheduled posts whose publish date is reached.
+	ListDue(ctx context.Context, now time.Time) ([]Post, error)
 	Store(context.Context, *Post) error
 	Update(context.Context, *Post) error
+	// Publish saves the scheduled post as published, and tells false when it
+	// no longer was scheduled, published by someone else in the meantime.
+	Publish(context.Context, *Post) (bool, error)
+	// AddToScore changes the score of the post by delta, and its hot ranking with it.
+	AddToScore(ctx context.Context, postID uuid.UUID, delta int) error
+	// Delete moves the post to the trash, at its DeletedAt date.
 	Delete(context.Context, *Post) error
+	// FindInTrash finds the post only when it's in the trash.
+	FindInTrash(context.Context, uuid.UUID) (*Post, error)
+	// ListTrash returns the posts of the author deleted since the date, the latest deleted first.
+	ListTrash(ctx context.Context, authorID uuid.UUID, since time.Time) ([]Post, error)
+	// ListExpired returns the posts deleted before the date.
+	ListExpired(ctx context.Context, before time.Time) ([]Post, error)
+	Restore(context.Context, *Post) error
+	// Purge removes the post for good, along with its comments and their reactions and votes.
+	Purge(context.Context, *Post) error
 }
 
 type CommentRepository interface {
 	Find(context.Context, uuid.UUID) (*Comment, error)
+	// ListAll returns all the comments of the post, replies and tombstones included.
 	ListAll(context.Context, uuid.UUID) ([]Comment, error)
+	// List returns the top-level comments of the post with their replies count, which leaves
+	// out the deleted replies but the tombstones of live replies. The deleted comments are
+	// only listed when that count isn't zero.
 	List(context.Context, uuid.UUID, Paginator) ([]Comment, *Cursor, error)
+	// ListReplies returns the direct replies of the comment, the oldest first, with their
+	// replies count like List does. The deleted replies are only listed when that count
+	// isn't zero.
+	ListReplies(context.Context, uuid.UUID, Paginator) ([]Comment, *Cursor, error)
+	// ListTrash returns the comments of the author deleted since the date, the latest deleted first.
+	ListTrash(ctx context.Context, authorID uuid.UUID, since time.Time) ([]Comment, error)
+	// ListExpired returns the comments deleted before the date which still hold their content.
+	ListExpired(ctx context.Context, before time.Time) ([]Comment, error)
+	// ListByPosts returns the comments of the posts created between since and until,
+	// the oldest first, out of the deleted ones.
+	ListByPosts(ctx context.Context, postIDs []uuid.UUID, since, until time.Time) ([]Comment, error)
+	// CountByPosts counts the comments of each post, out of the deleted ones. The
+	// posts without comments are left out of the counts.
+	CountByPosts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	// CountReplies counts all the direct replies of the comment, tombstones included.
+	CountReplies(context.Context, uuid.UUID) (int, error)
 	Store(context.Context, *Comment) error
 	Update(context.Context, *Comment) error
+	AddToScore(ctx context.Context, commentID uuid.UUID, delta int) error
+	// Delete removes the comment for good, along with its reactions and votes.
 	Delete(context.Context, *Comment) error
 }
+
+// FollowsRepository keeps track of the users following a post, who are told
+// in real time about its updates and new comments.
+type FollowsRepository interface {
+	// Follow does nothing when the user already follows the post.
+	Follow(ctx context.Context, postID, userID uuid.UUID) error
+	Unfollow(ctx context.Context, postID, userID uuid.UUID) error
+	FindFollowers(ctx context.Context, postID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// NotificationService lets the users know about what happens to the posts they
+// wrote or follow. Notifying is best effort and never makes the action itself fail.
+type NotificationService interface {
+	NotifyPostCommented(ctx context.Context, post *Post, comment *Comment)
+	PublishCommentCreated(ctx context.Context, followerIDs []uuid.UUID, comment *Comment)
+	PublishPostUpdated(ctx context.Context, followerIDs []uuid.UUID, post *Post)
+}
+
+// WebhookService tells the integrators webhooks about the posts and comments.
+// Like notifying, it is best effort and never makes the action itself fail.
+type WebhookService interface {
+	PostCreated(ctx context.Context, post *Post)
+	PostUpdated(ctx context.Context, post *Post)
+	PostDeleted(ctx context.Context, post *Post)
+	PostRestored(ctx context.Context, post *Post)
+	CommentCreated(ctx context.Context, comment *Comment)
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReactionKind = string
+
+const (
+	LikeReaction      ReactionKind = "like"
+	LoveReaction      ReactionKind = "love"
+	LaughReaction     ReactionKind = "laugh"
+	WowReaction       ReactionKind = "wow"
+	SadReaction       ReactionKind = "sad"
+	CelebrateReaction ReactionKind = "celebrate"
+)
+
+// ReactionKinds is the fixed set of reactions, mapped to the emoji the clients display.
+var ReactionKinds = map[ReactionKind]string{
+	LikeReaction:      "👍",
+	LoveReaction:      "❤️",
+	LaughReaction:     "😂",
+	WowReaction:       "😮",
+	SadReaction:       "😢",
+	CelebrateReaction: "🎉",
+}
+
+type ReactionTarget = string
+
+const (
+	PostReactionTarget    ReactionTarget = "post"
+	CommentReactionTarget ReactionTarget = "comment"
+)
+
+var ErrUnknownReaction = errors.New("this reaction doesn't exist")
+
+// Reaction is a user reacting to a post or a comment. A user has at most one
+// reaction of each kind on a target.
+type Reaction struct {
+	TargetType ReactionTarget
+	TargetID   uuid.UUID
+	UserID     uuid.UUID
+	Kind       ReactionKind
+	CreatedAt  time.Time
+}
+
+func NewReaction(targetType ReactionTarget, targetID, userID uuid.UUID, kind ReactionKind) (*Reaction, error) {
+	if !IsReactionKind(kind) {
+		return nil, ErrUnknownReaction
+	}
+
+	return &Reaction{
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Kind:       kind,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func IsReactionKind(kind ReactionKind) bool {
+	_, ok := ReactionKinds[kind]
+	return ok
+}
+
+// Reactions sums up the reactions to a target, as seen by a viewer.
+type Reactions struct {
+	Counts map[ReactionKind]int `json:"counts"`
+	// Mine are the kinds the viewer reacted with.
+	Mine []ReactionKind `json:"mine"`
+}
+
+func NewReactions() *Reactions {
+	return &Reactions{
+		Counts: map[ReactionKind]int{},
+		Mine:   []ReactionKind{},
+	}
+}
+
+// Add counts count reactions of the kind, mine telling whether the viewer is among them.
+func (reactions *Reactions) Add(kind ReactionKind, count int, mine bool) {
+	reactions.Counts[kind] += count
+
+	if mine && !slices.Contains(reactions.Mine, kind) {
+		reactions.Mine = append(reactions.Mine, kind)
+		slices.Sort(reactions.Mine)
+	}
+}
+
+// ReactionsSummaries are the reactions of several targets, by target id.
+type ReactionsSummaries map[uuid.UUID]*Reactions
+
+// Of returns the reactions of the target, which are empty when it has none.
+func (summaries ReactionsSummaries) Of(targetID uuid.UUID) *Reactions {
+	if reactions, ok := summaries[targetID]; ok {
+		return reactions
+	}
+
+	return NewReactions()
+}
+
+type ReactionsRepository interface {
+	// Add does nothing when the user already reacted to the target with the same kind.
+	Add(context.Context, *Reaction) error
+	Remove(ctx context.Context, targetType ReactionTarget, targetID, userID uuid.UUID, kind ReactionKind) error
+	// Summarize returns the reactions of all the targets at once, the viewer ones included.
+	Summarize(ctx context.Context, targetType ReactionTarget, targetIDs []uuid.UUID, viewerID uuid.UUID) (ReactionsSummaries, error)
+}
+
+// WithPostsReactions fills in the reactions of the posts with a single summary.
+func WithPostsReactions(
+	ctx context.Context, repository ReactionsRepository,
+	posts []Post, viewerID uuid.UUID,
+) error {
+	ids := make([]uuid.UUID, len(posts))
+
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	summaries, err := repository.Summarize(ctx, PostReactionTarget, ids, viewerID)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		posts[i].Reactions = summaries.Of(posts[i].ID)
+	}
+
+	return nil
+}
+
+// WithCommentsReactions fills in the reactions of the comments with a single summary.
+func WithCommentsReactions(
+	ctx context.Context, repository ReactionsRepository,
+	comments []Comment, viewerID uuid.UUID,
+) error {
+	ids := make([]uuid.UUID, len(comments))
+
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+	summaries, err := repository.Summarize(ctx, CommentReactionTarget, ids, viewerID)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range comments {
+		comments[i].Reactions = summaries.Of(comments[i].ID)
+	}
+
+	return nil
+}
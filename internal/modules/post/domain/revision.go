@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrRevisionNotFound = errors.New("the revision you're looking for does'nt exist")
+
+// Revision is the title and content of a post after one of its changes,
+// numbered from 1 for the post creation.
+type Revision struct {
+	ID        uuid.UUID `json:"id"`
+	PostID    uuid.UUID `json:"post_id"`
+	Number    int       `json:"number"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	// ContentHTML is the rendering of the content, cached with the revision.
+	ContentHTML string `json:"content_html"`
+}
+
+// NewRevision returns the revision of the post as changed by the author.
+// Its number is given by the repository when stored.
+func NewRevision(post *Post, authorID uuid.UUID) *Revision {
+	return &Revision{
+		PostID:      post.ID,
+		AuthorID:    authorID,
+		Title:       post.Title,
+		Content:     post.Content,
+		CreatedAt:   time.Now(),
+		ContentHTML: post.ContentHTML,
+	}
+}
+
+type DiffOp = string
+
+const (
+	EqualDiffOp  DiffOp = "equal"
+	InsertDiffOp DiffOp = "insert"
+	DeleteDiffOp DiffOp = "delete"
+)
+
+// DiffLine is a line kept, inserted or deleted from a revision to another.
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// RevisionDiff holds the line changes of the title and content between two revisions.
+type RevisionDiff struct {
+	From    int        `json:"from"`
+	To      int        `json:"to"`
+	Title   []DiffLine `json:"title"`
+	Content []DiffLine `json:"content"`
+}
+
+func NewRevisionDiff(from, to *Revision) *RevisionDiff {
+	return &RevisionDiff{
+		From:    from.Number,
+		To:      to.Number,
+		Title:   DiffLines(from.Title, to.Title),
+		Content: DiffLines(from.Content, to.Content),
+	}
+}
+
+// DiffLines returns the line-level diff turning the from text into the to one,
+// from their longest common subsequence of lines. The deleted lines come before
+// the inserted ones where both happen.
+func DiffLines(from, to string) []DiffLine {
+	a, b := splitLines(from), splitLines(to)
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+	diff := []DiffLine{}
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			diff = append(diff, DiffLine{Op: EqualDiffOp, Text: a[i]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+			diff = append(diff, DiffLine{Op: DeleteDiffOp, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{Op: InsertDiffOp, Text: b[j]})
+			j++
+		}
+	}
+
+	return diff
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+type RevisionsRepository interface {
+	// Store numbers the revision after the last one of its post.
+	Store(context.Context, *Revision) error
+	Find(ctx context.Context, postID uuid.UUID, number int) (*Revision, error)
+	// List returns the revisions of the post, the latest first.
+	List(ctx context.Context, postID uuid.UUID) ([]Revision, error)
+}
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"html"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+type SearchDocumentType = string
+
+const (
+	PostSearchDocument    SearchDocumentType = "post"
+	CommentSearchDocument SearchDocumentType = "comment"
+)
+
+const (
+	// SearchMinTermLength is the length under which the words are not indexed.
+	SearchMinTermLength = 2
+	// SnippetLength is the number of characters of the search results snippets.
+	SnippetLength = 160
+)
+
+var ErrEmptySearch = errors.New("the search must hold at least one word of 2 characters or more")
+
+// SearchDocument is a post or a comment as stored in the search index.
+// The PostID of a post document is its own ID.
+type SearchDocument struct {
+	Type      SearchDocumentType
+	ID        uuid.UUID
+	PostID    uuid.UUID
+	AuthorID  uuid.UUID
+	Title     string
+	Content   string
+	Tags      []string
+	CreatedAt time.Time
+}
+
+func NewPostSearchDocument(post *Post) SearchDocument {
+	return SearchDocument{
+		Type:      PostSearchDocument,
+		ID:        post.ID,
+		PostID:    post.ID,
+		AuthorID:  post.UserID,
+		Title:     post.Title,
+		Content:   post.Content,
+		Tags:      post.Tags,
+		CreatedAt: post.CreatedAt,
+	}
+}
+
+func NewCommentSearchDocument(comment *Comment) SearchDocument {
+	return SearchDocument{
+		Type:      CommentSearchDocument,
+		ID:        comment.ID,
+		PostID:    comment.PostID,
+		AuthorID:  comment.UserID,
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+// SearchQuery holds the searched text and the filters, which are ignored when zero.
+// The tag filter keeps the posts having the tag and their comments.
+type SearchQuery struct {
+	Text     string
+	Type     SearchDocumentType
+	AuthorID uuid.UUID
+	Tag      string
+	From     time.Time
+	To       time.Time
+	Limit    int
+	After    *Cursor
+}
+
+// Terms returns the searched words.
+func (query SearchQuery) Terms() []string {
+	return SearchTerms(query.Text)
+}
+
+// Matches tells whether the document passes the query filters, given the tags
+// of the post it belongs to.
+func (query SearchQuery) Matches(document SearchDocument, postTags []string) bool {
+	switch {
+	case query.Type != "" && document.Type != query.Type:
+		return false
+	case query.AuthorID != uuid.Nil && document.AuthorID != query.AuthorID:
+		return false
+	case query.Tag != "" && !slices.Contains(postTags, query.Tag):
+		return false
+	case !query.From.IsZero() && document.CreatedAt.Before(query.From):
+		return false
+	case !query.To.IsZero() && !document.CreatedAt.Before(query.To):
+		return false
+	}
+
+	return true
+}
+
+// SearchResult is a document matching a search, with its relevance Score
+// and a Snippet of its content where the searched words are highlighted.
+type SearchResult struct {
+	Type      SearchDocumentType `json:"type"`
+	ID        uuid.UUID          `json:"id"`
+	PostID    uuid.UUID          `json:"post_id"`
+	AuthorID  uuid.UUID          `json:"author_id"`
+	Title     string             `json:"title,omitempty"`
+	Snippet   string             `json:"snippet"`
+	Score     float64            `json:"score"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+func NewSearchResult(document SearchDocument, score float64, terms []string) SearchResult {
+	return SearchResult{
+		Type:      document.Type,
+		ID:        document.ID,
+		PostID:    document.PostID,
+		AuthorID:  document.AuthorID,
+		Title:     document.Title,
+		Snippet:   Highlight(document.Content, terms, SnippetLength),
+		Score:     score,
+		CreatedAt: document.CreatedAt,
+	}
+}
+
+// SearchIndex stores the posts and comments documents and ranks them by relevance,
+// the most relevant first.
+type SearchIndex interface {
+	// Index adds the document, or replaces it when it was already indexed.
+	Index(context.Context, SearchDocument) error
+	// Remove removes the document, along with the comments documents when it is a post.
+	Remove(ctx context.Context, documentType SearchDocumentType, ID uuid.UUID) error
+	Search(context.Context, SearchQuery) ([]SearchResult, *Cursor, error)
+}
+
+// SearchWords splits the text into lowercased words, without the words shorter
+// than SearchMinTermLength.
+func SearchWords(text string) []string {
+	words := []string{}
+
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !isWordRune(r) }) {
+		if len([]rune(word)) >= SearchMinTermLength {
+			words = append(words, strings.ToLower(word))
+		}
+	}
+
+	return words
+}
+
+// SearchTerms returns the words of the text, without the duplicates.
+func SearchTerms(text string) []string {
+	terms := []string{}
+
+	for _, word := range SearchWords(text) {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+// Highlight returns an excerpt of the text of about length characters, starting a bit
+// before the first searched word, where the words are wrapped in <mark> tags. The text
+// is escaped, so the snippet can be displayed as HTML.
+func Highlight(text string, terms []string, length int) string {
+	runes := []rune(text)
+	start := 0
+
+	for i := range runes {
+		if i > 0 && isWordRune(runes[i-1]) {
+			continue
+		}
+		end := i
+
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+
+		if end > i && slices.Contains(terms, strings.ToLower(string(runes[i:end]))) {
+			start = max(i-length/4, 0)
+			break
+		}
+	}
+	end := min(start+length, len(runes))
+
+	var builder strings.Builder
+
+	if start > 0 {
+		builder.WriteString("…")
+	}
+
+	for i := start; i < end; {
+		if !isWordRune(runes[i]) {
+			builder.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+		wordEnd := i
+
+		for wordEnd < end && isWordRune(runes[wordEnd]) {
+			wordEnd++
+		}
+		word := html.EscapeString(string(runes[i:wordEnd]))
+
+		if slices.Contains(terms, strings.ToLower(string(runes[i:wordEnd]))) {
+			word = "<mark>" + word + "</mark>"
+		}
+		builder.WriteString(word)
+		i = wordEnd
+	}
+
+	if end < len(runes) {
+		builder.WriteString("…")
+	}
+
+	return builder.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/mazen160/go-random"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// SlugMaxLength is the max number of characters of the slugs, suffix included.
+	SlugMaxLength = 80
+	// SlugSuffixLength is the length of the random suffix added to the slugs already taken.
+	SlugSuffixLength = 6
+	// SlugAttempts is the number of suffixes tried before giving up on a taken slug.
+	SlugAttempts = 5
+	// DefaultSlug is used for the titles without any letter or digit to keep.
+	DefaultSlug = "post"
+)
+
+var (
+	ErrInvalidSlug = errors.New("the slug must only hold lowercase letters, digits and single dashes")
+	ErrSlugTaken   = errors.New("this slug is already used by another post")
+)
+
+const slugSuffixCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// transliterations are the ASCII spellings of the lowercase letters
+// which are not a latin letter with diacritics.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l", 'ı': "i", '&': "and",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Slugify transliterates the text to ASCII and joins its runs of lowercase
+// letters and digits with dashes, within SlugMaxLength characters.
+func Slugify(text string) string {
+	var builder strings.Builder
+	separate := false
+
+	// The decomposition splits the letters from their diacritics, which are dropped.
+	for _, r := range norm.NFKD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		spelling, ok := transliterations[r]
+
+		if !ok {
+			spelling = string(r)
+		}
+
+		for _, c := range spelling {
+			if c > unicode.MaxASCII || !(unicode.IsLetter(c) || unicode.IsDigit(c)) {
+				separate = true
+				continue
+			}
+
+			if separate && builder.Len() > 0 {
+				builder.WriteByte('-')
+			}
+			separate = false
+			builder.WriteRune(c)
+		}
+	}
+
+	return truncateSlug(builder.String(), SlugMaxLength)
+}
+
+// truncateSlug cuts the slug after its last full word within the length.
+func truncateSlug(slug string, length int) string {
+	if len(slug) <= length {
+		return slug
+	}
+	slug = slug[:length]
+
+	if i := strings.LastIndexByte(slug, '-'); i > 0 {
+		return slug[:i]
+	}
+
+	return slug
+}
+
+// MakePostSlug returns the slug of the title, which gets a suffix when already taken.
+func MakePostSlug(title string) string {
+	if slug := Slugify(title); slug != "" {
+		return slug
+	}
+
+	return DefaultSlug
+}
+
+// WithSlugSuffix returns the slug followed by a short random suffix.
+func WithSlugSuffix(slug string) string {
+	suffix, _ := random.Random(SlugSuffixLength, slugSuffixCharset, true)
+
+	return truncateSlug(slug, SlugMaxLength-SlugSuffixLength-1) + "-" + suffix
+}
+
+// ValidateSlug checks that the slug chosen by an author is already slugified.
+func ValidateSlug(slug string) error {
+	if slug == "" || Slugify(slug) != slug {
+		return ErrInvalidSlug
+	}
+
+	return nil
+}
+
+// SlugsRepository keeps the slugs of the posts, the current ones and those they had,
+// so that the old links keep leading to the posts. A slug belongs to a single post.
+type SlugsRepository interface {
+	Store(ctx context.Context, postID uuid.UUID, slug string) error
+	// FindPostID returns the post which has or had the slug, or ErrPostNotFound.
+	FindPostID(ctx context.Context, slug string) (uuid.UUID, error)
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PostStatus = string
+
+const (
+	// DraftPostStatus and ScheduledPostStatus are the unpublished posts, only seen by their author.
+	DraftPostStatus     PostStatus = "draft"
+	ScheduledPostStatus PostStatus = "scheduled"
+	PublishedPostStatus PostStatus = "published"
+	// ArchivedPostStatus is a post left out of the lists, but still readable.
+	ArchivedPostStatus PostStatus = "archived"
+)
+
+// PublishInterval is how often the due scheduled posts are looked for.
+const PublishInterval = time.Second * 30
+
+var (
+	ErrInvalidStatus  = errors.New("a post can be a draft, scheduled, published or archived")
+	ErrStatusChange   = errors.New("the post can't go from its status to this one")
+	ErrInvalidPublish = errors.New("a scheduled post must have a publish_at date in the future")
+)
+
+// NewPostWithStatus returns a new post with the status, published when empty.
+// A new post can't be archived right away.
+func NewPostWithStatus(
+	authorID uuid.UUID, title, content string,
+	status PostStatus, publishAt *time.Time, now time.Time,
+) (*Post, error) {
+
+	post := NewPost(authorID, title, content)
+
+	switch status {
+	case "", PublishedPostStatus:
+		return post, nil
+	case ArchivedPostStatus:
+		return nil, ErrStatusChange
+	}
+	post.Status, post.PublishAt = DraftPostStatus, nil
+
+	if err := post.SetStatus(status, publishAt, now); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// IsPublished tells whether the post is listed and open to everyone.
+func (post *Post) IsPublished() bool {
+	return post.Status == PublishedPostStatus
+}
+
+// IsPublic tells whether everyone can read the post, be it published or archived.
+func (post *Post) IsPublic() bool {
+	return post.IsPublished() || post.Status == ArchivedPostStatus
+}
+
+// IsVisibleTo tells whether the user can read the post, the unpublished posts being
+// visible only to their author.
+func (post *Post) IsVisibleTo(userID uuid.UUID) bool {
+	return post.IsPublic() || post.UserID == userID
+}
+
+// SetStatus moves the post to the status, following the lifecycle: the drafts and scheduled
+// posts are published or scheduled, the published ones archived and the archived ones published
+// again. A public post can't go back to a draft. A publish date is only required to schedule the post.
+func (post *Post) SetStatus(status PostStatus, publishAt *time.Time, now time.Time) error {
+	allowed := map[PostStatus][]PostStatus{
+		DraftPostStatus:     {DraftPostStatus, ScheduledPostStatus},
+		ScheduledPostStatus: {DraftPostStatus, ScheduledPostStatus},
+		PublishedPostStatus: {DraftPostStatus, ScheduledPostStatus, PublishedPostStatus, ArchivedPostStatus},
+		ArchivedPostStatus:  {PublishedPostStatus, ArchivedPostStatus},
+	}
+	from, ok := allowed[status]
+
+	if !ok {
+		return ErrInvalidStatus
+	}
+
+	if !slices.Contains(from, post.Status) {
+		return ErrStatusChange
+	}
+
+	switch status {
+	case DraftPostStatus:
+		post.Status, post.PublishAt = status, nil
+
+	case ScheduledPostStatus:
+		if publishAt == nil || !publishAt.After(now) {
+			return ErrInvalidPublish
+		}
+		post.Status, post.PublishAt = status, publishAt
+
+	case PublishedPostStatus:
+		if post.Status == DraftPostStatus || post.Status == ScheduledPostStatus {
+			post.Publish(now)
+		}
+		post.Status = status
+
+	default:
+		post.Status = status
+	}
+
+	return nil
+}
+
+// Publish publishes the draft or scheduled post. Its creation date becomes
+// the publication one, so that it is listed among the newest posts.
+func (post *Post) Publish(now time.Time) {
+	post.Status = PublishedPostStatus
+	post.PublishAt = &now
+	post.CreatedAt = now
+	post.Hot = HotRank(post.Score, now)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultMaxTagsPerPost = 5
+	// DefaultTagsLimit is the number of tags listed when no limit is given.
+	DefaultTagsLimit = 20
+
+	tagMinLength = 2
+	tagMaxLength = 32
+)
+
+var (
+	ErrInvalidTag  = errors.New("a tag must hold 2 to 32 letters, digits or dashes")
+	ErrTooManyTags = errors.New("the post has too many tags")
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("a tag with this name already exists, merge the tags instead")
+)
+
+// Tag is a topic shared by posts, identified by its normalized name.
+type Tag struct {
+	Name       string `json:"name"`
+	PostsCount int    `json:"posts_count"`
+}
+
+// NormalizeTag lowercases the tag name and replaces its spaces and underscores
+// with dashes, so that "Go Lang" and "go_lang" are the same "go-lang" tag.
+func NormalizeTag(name string) (string, error) {
+	var builder strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			builder.WriteRune(r)
+		case r == '-', r == '_', r == ' ':
+			if s := builder.String(); s != "" && !strings.HasSuffix(s, "-") {
+				builder.WriteRune('-')
+			}
+		default:
+			return "", ErrInvalidTag
+		}
+	}
+	normalized := strings.TrimSuffix(builder.String(), "-")
+
+	if len(normalized) < tagMinLength || len(normalized) > tagMaxLength {
+		return "", ErrInvalidTag
+	}
+
+	return normalized, nil
+}
+
+// NormalizeTags normalizes the tag names and removes the duplicates, keeping their order.
+func NormalizeTags(names []string, max int) ([]string, error) {
+	tags := []string{}
+
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) > max {
+		return nil, ErrTooManyTags
+	}
+
+	return tags, nil
+}
+
+// Admins are the users allowed to rename and merge the tags.
+type Admins []uuid.UUID
+
+func (admins Admins) Contains(userID uuid.UUID) bool {
+	return slices.Contains(admins, userID)
+}
+
+type TagsRepository interface {
+	// Find returns the tag with its posts count. Like in List, only the published posts
+	// out of the trash are counted.
+	Find(ctx context.Context, name string) (*Tag, error)
+	// List returns the tags used by published posts starting with the prefix, the most used first.
+	List(ctx context.Context, prefix string, limit int) ([]Tag, error)
+	Rename(ctx context.Context, name, newName string) error
+	// Merge moves the posts of the source tag to the target one, and deletes the source tag.
+	Merge(ctx context.Context, source, target string) error
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// TrashRetention is how long the deleted posts and comments can be restored before being purged.
+	TrashRetention = time.Hour * 24 * 30
+	// PurgeInterval is how often the trash is purged of what stayed there longer than TrashRetention.
+	PurgeInterval = time.Hour
+)
+
+var ErrRestoreExpired = errors.New("this has been deleted for too long to be restored")
+
+// Trash holds the posts and comments a user deleted, which can still be restored.
+type Trash struct {
+	Posts    []Post    `json:"posts"`
+	Comments []Comment `json:"comments"`
+}
+
+// TrashCutoff returns the date before which the deleted posts and comments can't be restored anymore.
+func TrashCutoff(now time.Time) time.Time {
+	return now.Add(-TrashRetention)
+}
+
+func (post *Post) IsDeleted() bool {
+	return post.DeletedAt != nil
+}
+
+// Delete moves the post to the trash.
+func (post *Post) Delete(now time.Time) {
+	post.DeletedAt = &now
+}
+
+// CanBeRestored tells whether the deleted post is still within the trash retention.
+func (post *Post) CanBeRestored(now time.Time) bool {
+	return post.IsDeleted() && post.DeletedAt.After(TrashCutoff(now))
+}
+
+// Delete moves the comment to the trash. Its content is kept to be restored, and
+// hidden from the threads where the comment stays as a tombstone for its replies.
+func (comment *Comment) Delete(now time.Time) {
+	comment.DeletedAt = &now
+}
+
+// CanBeRestored tells whether the deleted comment is still within the trash
+// retention, and wasn't tombstoned for good by the purge.
+func (comment *Comment) CanBeRestored(now time.Time) bool {
+	return comment.IsDeleted() && comment.Content != "" && comment.DeletedAt.After(TrashCutoff(now))
+}
+
+// Restore takes the comment out of the trash.
+func (comment *Comment) Restore() {
+	comment.DeletedAt = nil
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VoteTarget = string
+
+const (
+	PostVoteTarget    VoteTarget = "post"
+	CommentVoteTarget VoteTarget = "comment"
+)
+
+const (
+	UpVote   = 1
+	DownVote = -1
+	// NoVote withdraws the user vote.
+	NoVote = 0
+)
+
+var ErrInvalidVote = errors.New("a vote must be 1, -1 or 0 to withdraw it")
+
+// Vote is a user up or down vote on a post or a comment. A user has one vote per target,
+// and the target score is the sum of its votes.
+type Vote struct {
+	TargetType VoteTarget
+	TargetID   uuid.UUID
+	UserID     uuid.UUID
+	Value      int
+	CreatedAt  time.Time
+}
+
+func NewVote(targetType VoteTarget, targetID, userID uuid.UUID, value int) (*Vote, error) {
+	if value != UpVote && value != DownVote && value != NoVote {
+		return nil, ErrInvalidVote
+	}
+
+	return &Vote{
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Value:      value,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+type PostSort = string
+
+const (
+	NewPostSort PostSort = "new"
+	TopPostSort PostSort = "top"
+	HotPostSort PostSort = "hot"
+)
+
+var ErrUnknownSort = errors.New("posts can only be sorted by new, top or hot, within a day, week, month, year or all time")
+
+// DefaultTopWindow is the time window of the top posts when none is given.
+const DefaultTopWindow = "week"
+
+// TopWindowSince returns when the given time window of the top posts starts,
+// which is the zero time for the all time window.
+func TopWindowSince(window string, now time.Time) (time.Time, error) {
+	switch window {
+	case "day":
+		return now.AddDate(0, 0, -1), nil
+	case "week":
+		return now.AddDate(0, 0, -7), nil
+	case "month":
+		return now.AddDate(0, -1, 0), nil
+	case "year":
+		return now.AddDate(-1, 0, 0), nil
+	case "all":
+		return time.Time{}, nil
+	}
+
+	return time.Time{}, ErrUnknownSort
+}
+
+// hotEpoch and hotDecay are the reference date and the number of seconds after
+// which a post needs ten times the score to rank as high as an older one.
+var hotEpoch = time.Date(2005, time.December, 8, 7, 46, 43, 0, time.UTC)
+
+const hotDecay = 45000
+
+// HotRank returns the hot ranking of a post, which grows with the logarithm of its
+// score and with its age, so that newer posts need less votes to rank high.
+// The posts mysql repository computes the same ranking when the score changes.
+func HotRank(score int, createdAt time.Time) float64 {
+	order := math.Log10(math.Max(math.Abs(float64(score)), 1))
+	sign := 0.0
+
+	if score > 0 {
+		sign = 1
+	} else if score < 0 {
+		sign = -1
+	}
+	seconds := createdAt.Unix() - hotEpoch.Unix()
+
+	return sign*order + float64(seconds)/hotDecay
+}
+
+type VotesRepository interface {
+	// Cast stores the vote in place of the user previous one, deleting it for NoVote,
+	// and returns the change it makes to the target score.
+	Cast(context.Context, *Vote) (int, error)
+}
+
+// Transactor runs a function inside a transaction, committed when the function succeeds.
+// The repositories join the transaction through the context given to the function.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
+}
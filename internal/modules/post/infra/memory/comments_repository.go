@@ -59,9 +59,11 @@ func (repo *inMemoryCommentsRepository) Update(ctx context.Context, comment *dom
 }
 
 func (repo *inMemoryCommentsRepository) ListAll(ctx context.Context, postID uuid.UUID) ([]domain.Comment, error) {
+	repo.Lock()
 	comments := filterComments(slices.Collect(maps.Values(repo.store)), func(c domain.Comment) bool {
 		return c.PostID == postID
 	})
+	repo.Unlock()
 	sortComments(comments)
 
 	return comments, nil
@@ -73,13 +75,139 @@ func (repo *inMemoryCommentsRepository) List(ctx context.Context, postID uuid.UU
 	if err != nil {
 		return []domain.Comment{}, nil, err
 	}
+	topLevelComments := filterComments(allComments, func(c domain.Comment) bool {
+		return c.ParentID == nil
+	})
+
+	return repo.paginate(withoutEmptyTombstones(repo.withRepliesCount(topLevelComments)), paginator)
+}
+
+func (repo *inMemoryCommentsRepository) ListReplies(ctx context.Context, commentID uuid.UUID, paginator domain.Paginator) ([]domain.Comment, *domain.Cursor, error) {
+	repo.Lock()
+	replies := filterComments(slices.Collect(maps.Values(repo.store)), func(c domain.Comment) bool {
+		return c.ParentID != nil && *c.ParentID == commentID
+	})
+	repo.Unlock()
+	sortComments(replies)
+
+	return repo.paginate(withoutEmptyTombstones(repo.withRepliesCount(replies)), paginator)
+}
+
+func (repo *inMemoryCommentsRepository) ListTrash(ctx context.Context, authorID uuid.UUID, since time.Time) ([]domain.Comment, error) {
+	return repo.listDeleted(func(comment domain.Comment) bool {
+		return comment.UserID == authorID && !comment.DeletedAt.Before(since)
+	}), nil
+}
+
+func (repo *inMemoryCommentsRepository) ListExpired(ctx context.Context, before time.Time) ([]domain.Comment, error) {
+	return repo.listDeleted(func(comment domain.Comment) bool {
+		return comment.DeletedAt.Before(before) && comment.Content != ""
+	}), nil
+}
+
+// listDeleted returns the deleted comments matching the filter, the latest deleted first.
+func (repo *inMemoryCommentsRepository) listDeleted(filter func(domain.Comment) bool) []domain.Comment {
+	repo.Lock()
+	defer repo.Unlock()
+
+	comments := filterComments(slices.Collect(maps.Values(repo.store)), func(c domain.Comment) bool {
+		return c.IsDeleted() && filter(c)
+	})
+	slices.SortFunc(comments, func(a, b domain.Comment) int {
+		return b.DeletedAt.Compare(*a.DeletedAt)
+	})
+
+	return comments
+}
+
+// withoutEmptyTombstones removes the deleted comments which have no replies.
+func withoutEmptyTombstones(comments []domain.Comment) []domain.Comment {
+	return filterComments(comments, func(c domain.Comment) bool {
+		return !c.IsDeleted() || c.RepliesCount > 0
+	})
+}
 
+func (repo *inMemoryCommentsRepository) ListByPosts(
+	ctx context.Context, postIDs []uuid.UUID, since, until time.Time,
+) ([]domain.Comment, error) {
+	repo.Lock()
+	comments := filterComments(slices.Collect(maps.Values(repo.store)), func(c domain.Comment) bool {
+		return slices.Contains(postIDs, c.PostID) && !c.IsDeleted() &&
+			!c.CreatedAt.Before(since) && c.CreatedAt.Before(until)
+	})
+	repo.Unlock()
+	sortComments(comments)
+
+	return comments, nil
+}
+
+func (repo *inMemoryCommentsRepository) CountByPosts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	counts := map[uuid.UUID]int{}
+
+	for _, comment := range repo.store {
+		if slices.Contains(postIDs, comment.PostID) && !comment.IsDeleted() {
+			counts[comment.PostID]++
+		}
+	}
+
+	return counts, nil
+}
+
+func (repo *inMemoryCommentsRepository) CountReplies(ctx context.Context, commentID uuid.UUID) (int, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	count := 0
+
+	for _, comment := range repo.store {
+		if comment.ParentID != nil && *comment.ParentID == commentID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// withRepliesCount counts the replies the listings show: the replies out of the
+// trash, and the tombstones still having one of those.
+func (repo *inMemoryCommentsRepository) withRepliesCount(comments []domain.Comment) []domain.Comment {
+	repo.Lock()
+	defer repo.Unlock()
+
+	hasLiveReply := func(commentID uuid.UUID) bool {
+		for _, reply := range repo.store {
+			if reply.ParentID != nil && *reply.ParentID == commentID && !reply.IsDeleted() {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := range comments {
+		comments[i].RepliesCount = 0
+
+		for _, reply := range repo.store {
+			if reply.ParentID != nil && *reply.ParentID == comments[i].ID &&
+				(!reply.IsDeleted() || hasLiveReply(reply.ID)) {
+				comments[i].RepliesCount++
+			}
+		}
+	}
+
+	return comments
+}
+
+func (repo *inMemoryCommentsRepository) paginate(allComments []domain.Comment, paginator domain.Paginator) ([]domain.Comment, *domain.Cursor, error) {
 	if len(allComments) == 0 {
-		return allComments, nil, nil
+		return []domain.Comment{}, nil, nil
 	}
 
 	if paginator.After == nil {
-		return repo.listReturnValues(allComments[:paginator.Limit])
+		return repo.listReturnValues(allComments[:min(paginator.Limit, len(allComments))])
 	}
 
 	afterIdx := slices.IndexFunc(allComments, func(c domain.Comment) bool {
@@ -87,11 +215,15 @@ func (repo *inMemoryCommentsRepository) List(ctx context.Context, postID uuid.UU
 	})
 
 	if afterIdx == -1 {
-		comments := allComments[:domain.DefaultPaginatorLimit]
+		comments := allComments[:min(domain.DefaultPaginatorLimit, len(allComments))]
 		return repo.listReturnValues(comments)
 	}
 	comments := allComments[afterIdx+1:]
 
+	if len(comments) == 0 {
+		return comments, nil, nil
+	}
+
 	if afterIdx+paginator.Limit > len(comments) {
 		return repo.listReturnValues(comments)
 	}
@@ -115,6 +247,21 @@ func (repo *inMemoryCommentsRepository) Find(ctx context.Context, ID uuid.UUID)
 	return nil, domain.ErrCommentNotFound
 }
 
+func (repo *inMemoryCommentsRepository) AddToScore(ctx context.Context, commentID uuid.UUID, delta int) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	comment, ok := repo.store[commentID]
+
+	if !ok {
+		return domain.ErrCommentNotFound
+	}
+	comment.Score += delta
+	repo.store[commentID] = comment
+
+	return nil
+}
+
 func (repo *inMemoryCommentsRepository) Delete(ctx context.Context, comment *domain.Comment) error {
 	_, err := repo.Find(ctx, comment.ID)
 
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -142,6 +143,156 @@ func TestInMemoryCommentsRepositoryListMethod(t *testing.T) {
 	})
 }
 
+func TestInMemoryCommentsRepositoryThreads(t *testing.T) {
+	repo := NewInMemoryCommentsRepository(nil)
+	ctx := context.Background()
+	postID := uuid.New()
+
+	parent := domain.NewComment(postID, uuid.New(), "Random comment content")
+	repo.Store(ctx, parent)
+	repo.FillWithRandomComments(postID, uuid.Nil, 2)
+
+	replies := []*domain.Comment{}
+
+	for range 3 {
+		reply := domain.NewReply(parent, uuid.New(), "Random reply content")
+		repo.Store(ctx, reply)
+		replies = append(replies, reply)
+	}
+	repo.Store(ctx, domain.NewReply(replies[0], uuid.New(), "Random nested reply content"))
+
+	t.Run("it should list the top-level comments with their replies count", func(t *testing.T) {
+		_assert := assert.New(t)
+		comments, _, err := repo.List(ctx, postID, domain.Paginator{Limit: 10})
+
+		if _assert.NoError(err) && _assert.Len(comments, 3) {
+			_assert.Equal(parent.ID, comments[0].ID)
+			_assert.Equal(3, comments[0].RepliesCount)
+			_assert.Equal(0, comments[1].RepliesCount)
+		}
+	})
+
+	t.Run("it should page through the direct replies of a comment", func(t *testing.T) {
+		_assert := assert.New(t)
+		firstPage, cursor, err := repo.ListReplies(ctx, parent.ID, domain.Paginator{Limit: 2})
+
+		if _assert.NoError(err) && _assert.Len(firstPage, 2) {
+			_assert.Equal(replies[0].ID, firstPage[0].ID)
+			_assert.Equal(1, firstPage[0].Depth)
+			_assert.Equal(1, firstPage[0].RepliesCount)
+
+			secondPage, _, err := repo.ListReplies(ctx, parent.ID, domain.Paginator{Limit: 2, After: cursor})
+
+			if _assert.NoError(err) && _assert.Len(secondPage, 1) {
+				_assert.Equal(replies[2].ID, secondPage[0].ID)
+			}
+		}
+	})
+
+	t.Run("it should count the direct replies of a comment", func(t *testing.T) {
+		count, err := repo.CountReplies(ctx, parent.ID)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 3, count)
+		}
+	})
+	t.Run("it should only count the replies out of the trash and the tombstones of live replies", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryCommentsRepository(nil)
+		postID := uuid.New()
+
+		parent := domain.NewComment(postID, uuid.New(), "Random comment content")
+		repo.Store(ctx, parent)
+		live := domain.NewReply(parent, uuid.New(), "Random reply content")
+		repo.Store(ctx, live)
+		deleted := domain.NewReply(parent, uuid.New(), "Random reply content")
+		deleted.Delete(time.Now())
+		repo.Store(ctx, deleted)
+
+		tombstone := domain.NewReply(parent, uuid.New(), "Random reply content")
+		tombstone.Delete(time.Now())
+		repo.Store(ctx, tombstone)
+		repo.Store(ctx, domain.NewReply(tombstone, uuid.New(), "Random nested reply content"))
+
+		emptyTombstone := domain.NewReply(parent, uuid.New(), "Random reply content")
+		emptyTombstone.Delete(time.Now())
+		repo.Store(ctx, emptyTombstone)
+		deletedNested := domain.NewReply(emptyTombstone, uuid.New(), "Random nested reply content")
+		deletedNested.Delete(time.Now())
+		repo.Store(ctx, deletedNested)
+
+		comments, _, err := repo.List(ctx, postID, domain.Paginator{Limit: 10})
+
+		if _assert.NoError(err) && _assert.Len(comments, 1) {
+			_assert.Equal(2, comments[0].RepliesCount)
+		}
+		replies, _, err := repo.ListReplies(ctx, parent.ID, domain.Paginator{Limit: 10})
+
+		if _assert.NoError(err) && _assert.Len(replies, 2) {
+			_assert.Equal(live.ID, replies[0].ID)
+			_assert.Equal(tombstone.ID, replies[1].ID)
+			_assert.Equal(1, replies[1].RepliesCount)
+		}
+		count, err := repo.CountReplies(ctx, parent.ID)
+
+		if _assert.NoError(err) {
+			_assert.Equal(4, count)
+		}
+	})
+}
+
+func TestInMemoryCommentsRepositoryTrashMethods(t *testing.T) {
+	repo := NewInMemoryCommentsRepository(nil)
+	ctx := context.Background()
+	postID, userID := uuid.New(), uuid.New()
+
+	deleted := domain.NewComment(postID, userID, "Random comment content")
+	deleted.Delete(time.Now())
+	repo.Store(ctx, deleted)
+
+	tombstone := domain.NewComment(postID, uuid.New(), "Random comment content")
+	tombstone.Delete(time.Now().Add(-domain.TrashRetention - time.Hour))
+	repo.Store(ctx, tombstone)
+	repo.Store(ctx, domain.NewReply(tombstone, userID, "Random reply content"))
+
+	t.Run("it should only list the deleted comments having replies", func(t *testing.T) {
+		_assert := assert.New(t)
+		comments, _, err := repo.List(ctx, postID, domain.Paginator{Limit: 10})
+
+		if _assert.NoError(err) && _assert.Len(comments, 1) {
+			_assert.Equal(tombstone.ID, comments[0].ID)
+		}
+	})
+
+	t.Run("it should count and list the comments of the posts out of the deleted ones", func(t *testing.T) {
+		_assert := assert.New(t)
+		counts, err := repo.CountByPosts(ctx, []uuid.UUID{postID, uuid.New()})
+
+		if _assert.NoError(err) {
+			_assert.Equal(map[uuid.UUID]int{postID: 1}, counts)
+		}
+		comments, err := repo.ListByPosts(ctx, []uuid.UUID{postID}, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+		if _assert.NoError(err) && _assert.Len(comments, 1) {
+			_assert.Equal(userID, comments[0].UserID)
+		}
+	})
+
+	t.Run("it should list the trash of the author and the expired comments", func(t *testing.T) {
+		_assert := assert.New(t)
+		trash, err := repo.ListTrash(ctx, userID, domain.TrashCutoff(time.Now()))
+
+		if _assert.NoError(err) && _assert.Len(trash, 1) {
+			_assert.Equal(deleted.ID, trash[0].ID)
+		}
+		expired, err := repo.ListExpired(ctx, domain.TrashCutoff(time.Now()))
+
+		if _assert.NoError(err) && _assert.Len(expired, 1) {
+			_assert.Equal(tombstone.ID, expired[0].ID)
+		}
+	})
+}
+
 func TestInMemoryCommentsRepositoryDeleteMethod(t *testing.T) {
 	repo := NewInMemoryCommentsRepository(nil)
 	ctx := context.Background()
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type followStore map[uuid.UUID][]uuid.UUID
+
+type inMemoryFollowsRepository struct {
+	store followStore
+	sync.Mutex
+}
+
+func NewInMemoryFollowsRepository(initialStore followStore) *inMemoryFollowsRepository {
+	if initialStore == nil {
+		initialStore = make(followStore)
+	}
+
+	return &inMemoryFollowsRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemoryFollowsRepository) Follow(ctx context.Context, postID, userID uuid.UUID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if !slices.Contains(repo.store[postID], userID) {
+		repo.store[postID] = append(repo.store[postID], userID)
+	}
+
+	return nil
+}
+
+func (repo *inMemoryFollowsRepository) Unfollow(ctx context.Context, postID, userID uuid.UUID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.store[postID] = slices.DeleteFunc(repo.store[postID], func(id uuid.UUID) bool {
+		return id == userID
+	})
+
+	return nil
+}
+
+func (repo *inMemoryFollowsRepository) FindFollowers(ctx context.Context, postID uuid.UUID) ([]uuid.UUID, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	return slices.Clone(repo.store[postID]), nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryFollowsRepository(t *testing.T) {
+
+	t.Run("it should only keep a follow once", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryFollowsRepository(nil)
+		ctx := context.Background()
+		postID, userID := uuid.New(), uuid.New()
+
+		_assert.NoError(repo.Follow(ctx, postID, userID))
+		_assert.NoError(repo.Follow(ctx, postID, userID))
+
+		followers, err := repo.FindFollowers(ctx, postID)
+
+		if _assert.NoError(err) {
+			_assert.Equal([]uuid.UUID{userID}, followers)
+		}
+	})
+
+	t.Run("it should remove the follower", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryFollowsRepository(nil)
+		ctx := context.Background()
+		postID, userID := uuid.New(), uuid.New()
+
+		repo.Follow(ctx, postID, userID)
+		repo.Follow(ctx, postID, uuid.New())
+
+		_assert.NoError(repo.Unfollow(ctx, postID, userID))
+		followers, _ := repo.FindFollowers(ctx, postID)
+
+		_assert.Len(followers, 1)
+		_assert.NotContains(followers, userID)
+	})
+}
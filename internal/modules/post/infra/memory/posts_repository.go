@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"cmp"
 	"comu/internal/modules/post/domain"
 	"context"
 	"fmt"
@@ -34,7 +35,9 @@ func (repo *inMemoryPostsRepository) ListAll(ctx context.Context) ([]domain.Post
 	repo.Lock()
 	defer repo.Unlock()
 
-	posts := slices.Collect(maps.Values(repo.store))
+	posts := slices.DeleteFunc(slices.Collect(maps.Values(repo.store)), func(post domain.Post) bool {
+		return post.IsDeleted()
+	})
 	sortPosts(posts)
 
 	return posts, nil
@@ -47,12 +50,63 @@ func (repo *inMemoryPostsRepository) List(ctx context.Context, paginator domain.
 		return []domain.Post{}, nil, err
 	}
 
+	return repo.paginate(slices.DeleteFunc(allPosts, func(post domain.Post) bool {
+		return !post.IsPublished()
+	}), paginator)
+}
+
+func (repo *inMemoryPostsRepository) ListByTag(ctx context.Context, tag string, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
+	allPosts, err := repo.ListAll(ctx)
+
+	if err != nil {
+		return []domain.Post{}, nil, err
+	}
+
+	return repo.paginate(slices.DeleteFunc(allPosts, func(post domain.Post) bool {
+		return !post.IsPublished() || !slices.Contains(post.Tags, tag)
+	}), paginator)
+}
+
+func (repo *inMemoryPostsRepository) ListDrafts(ctx context.Context, authorID uuid.UUID, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
+	allPosts, err := repo.ListAll(ctx)
+
+	if err != nil {
+		return []domain.Post{}, nil, err
+	}
+	paginator.Sort = domain.NewPostSort
+
+	return repo.paginate(slices.DeleteFunc(allPosts, func(post domain.Post) bool {
+		return post.UserID != authorID ||
+			(post.Status != domain.DraftPostStatus && post.Status != domain.ScheduledPostStatus)
+	}), paginator)
+}
+
+func (repo *inMemoryPostsRepository) ListDue(ctx context.Context, now time.Time) ([]domain.Post, error) {
+	allPosts, err := repo.ListAll(ctx)
+
+	if err != nil {
+		return []domain.Post{}, err
+	}
+
+	return slices.DeleteFunc(allPosts, func(post domain.Post) bool {
+		return post.Status != domain.ScheduledPostStatus || post.PublishAt.After(now)
+	}), nil
+}
+
+func (repo *inMemoryPostsRepository) paginate(allPosts []domain.Post, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
+	if !paginator.Since.IsZero() {
+		allPosts = slices.DeleteFunc(allPosts, func(post domain.Post) bool {
+			return post.CreatedAt.Before(paginator.Since)
+		})
+	}
+	sortPostsBy(allPosts, paginator.Sort)
+
 	if len(allPosts) == 0 {
 		return allPosts, nil, nil
 	}
 
 	if paginator.After == nil {
-		return repo.listReturnValues(allPosts[:paginator.Limit])
+		return repo.listReturnValues(allPosts[:min(paginator.Limit, len(allPosts))], paginator.Sort)
 	}
 
 	afterIdx := slices.IndexFunc(allPosts, func(post domain.Post) bool {
@@ -60,40 +114,55 @@ func (repo *inMemoryPostsRepository) List(ctx context.Context, paginator domain.
 	})
 
 	if afterIdx == -1 {
-		posts := allPosts[:domain.DefaultPaginatorLimit]
-		return repo.listReturnValues(posts)
+		posts := allPosts[:min(domain.DefaultPaginatorLimit, len(allPosts))]
+		return repo.listReturnValues(posts, paginator.Sort)
 	}
 	posts := allPosts[afterIdx+1:]
 
-	if afterIdx+paginator.Limit > len(posts) {
-		return repo.listReturnValues(posts)
+	if paginator.Limit > len(posts) {
+		return repo.listReturnValues(posts, paginator.Sort)
 	}
 
-	return repo.listReturnValues(posts[:paginator.Limit])
+	return repo.listReturnValues(posts[:paginator.Limit], paginator.Sort)
 }
 
-func (repo *inMemoryPostsRepository) listReturnValues(posts []domain.Post) ([]domain.Post, *domain.Cursor, error) {
+func (repo *inMemoryPostsRepository) listReturnValues(posts []domain.Post, sort domain.PostSort) ([]domain.Post, *domain.Cursor, error) {
+	if len(posts) == 0 {
+		return posts, nil, nil
+	}
 	last := posts[len(posts)-1]
-	return posts, &domain.Cursor{ID: last.ID, CreatedAt: last.CreatedAt}, nil
+	return posts, last.CursorOf(sort), nil
+}
+
+func (repo *inMemoryPostsRepository) ListByAuthor(ctx context.Context, userID uuid.UUID) ([]domain.Post, error) {
+	allPosts, err := repo.ListAll(ctx)
+
+	if err != nil {
+		return []domain.Post{}, err
+	}
+
+	return slices.DeleteFunc(allPosts, func(post domain.Post) bool {
+		return post.UserID != userID
+	}), nil
 }
 
 func (repo *inMemoryPostsRepository) FindByID(ctx context.Context, ID uuid.UUID) (*domain.Post, error) {
 	repo.Lock()
 	defer repo.Unlock()
 
-	if post, ok := repo.store[ID]; ok {
+	if post, ok := repo.store[ID]; ok && !post.IsDeleted() {
 		return &post, nil
 	}
 
 	return nil, domain.ErrPostNotFound
 }
 
-func (repo *inMemoryPostsRepository) FindBySlug(ctx context.Context, slug string) (*domain.Post, error) {
+func (repo *inMemoryPostsRepository) FindBySlug(ctx context.Context, slug string, viewerID uuid.UUID) (*domain.Post, error) {
 	repo.Lock()
 	defer repo.Unlock()
 
 	for _, post := range repo.store {
-		if post.Slug == slug {
+		if post.Slug == slug && !post.IsDeleted() && post.IsVisibleTo(viewerID) {
 			return &post, nil
 		}
 	}
@@ -129,14 +198,125 @@ func (repo *inMemoryPostsRepository) Update(ctx context.Context, post *domain.Po
 	return nil
 }
 
+func (repo *inMemoryPostsRepository) Publish(ctx context.Context, post *domain.Post) (bool, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	stored, ok := repo.store[post.ID]
+
+	if !ok {
+		return false, domain.ErrPostNotFound
+	}
+
+	if stored.Status != domain.ScheduledPostStatus {
+		return false, nil
+	}
+	copy := *post
+	copy.UpdatedAt = time.Now()
+	repo.store[copy.ID] = copy
+
+	return true, nil
+}
+
+func (repo *inMemoryPostsRepository) AddToScore(ctx context.Context, postID uuid.UUID, delta int) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	post, ok := repo.store[postID]
+
+	if !ok {
+		return domain.ErrPostNotFound
+	}
+	post.Score += delta
+	post.Hot = domain.HotRank(post.Score, post.CreatedAt)
+	repo.store[postID] = post
+
+	return nil
+}
+
 func (repo *inMemoryPostsRepository) Delete(ctx context.Context, post *domain.Post) error {
-	post, err := repo.FindByID(ctx, post.ID)
+	repo.Lock()
+	defer repo.Unlock()
 
-	if err != nil {
-		return err
+	stored, ok := repo.store[post.ID]
+
+	if !ok || stored.IsDeleted() {
+		return domain.ErrPostNotFound
+	}
+
+	if post.DeletedAt == nil {
+		post.Delete(time.Now())
 	}
+	stored.DeletedAt = post.DeletedAt
+	repo.store[post.ID] = stored
+
+	return nil
+}
+
+func (repo *inMemoryPostsRepository) FindInTrash(ctx context.Context, ID uuid.UUID) (*domain.Post, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if post, ok := repo.store[ID]; ok && post.IsDeleted() {
+		return &post, nil
+	}
+
+	return nil, domain.ErrPostNotFound
+}
+
+func (repo *inMemoryPostsRepository) ListTrash(ctx context.Context, authorID uuid.UUID, since time.Time) ([]domain.Post, error) {
+	return repo.listDeleted(func(post domain.Post) bool {
+		return post.UserID == authorID && !post.DeletedAt.Before(since)
+	}), nil
+}
+
+func (repo *inMemoryPostsRepository) ListExpired(ctx context.Context, before time.Time) ([]domain.Post, error) {
+	return repo.listDeleted(func(post domain.Post) bool {
+		return post.DeletedAt.Before(before)
+	}), nil
+}
+
+// listDeleted returns the deleted posts matching the filter, the latest deleted first.
+func (repo *inMemoryPostsRepository) listDeleted(filter func(domain.Post) bool) []domain.Post {
+	repo.Lock()
+	defer repo.Unlock()
+
+	posts := slices.DeleteFunc(slices.Collect(maps.Values(repo.store)), func(post domain.Post) bool {
+		return !post.IsDeleted() || !filter(post)
+	})
+	slices.SortFunc(posts, func(a, b domain.Post) int {
+		return b.DeletedAt.Compare(*a.DeletedAt)
+	})
 
+	return posts
+}
+
+func (repo *inMemoryPostsRepository) Restore(ctx context.Context, post *domain.Post) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	stored, ok := repo.store[post.ID]
+
+	if !ok || !stored.IsDeleted() {
+		return domain.ErrPostNotFound
+	}
+	post.DeletedAt = nil
+	stored.DeletedAt = nil
+	repo.store[post.ID] = stored
+
+	return nil
+}
+
+// Purge only removes the post, the other in-memory repositories keeping their own stores.
+func (repo *inMemoryPostsRepository) Purge(ctx context.Context, post *domain.Post) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if _, ok := repo.store[post.ID]; !ok {
+		return domain.ErrPostNotFound
+	}
 	delete(repo.store, post.ID)
+
 	return nil
 }
 
@@ -169,3 +349,29 @@ func sortPosts(posts []domain.Post) {
 		return 0
 	})
 }
+
+// sortPostsBy sorts the posts by score or hot ranking, highest first, then by
+// creation date and id, and keeps the order of sortPosts for the default sort.
+func sortPostsBy(posts []domain.Post, sort domain.PostSort) {
+	if sort != domain.TopPostSort && sort != domain.HotPostSort {
+		return
+	}
+	rank := func(post domain.Post) float64 {
+		if sort == domain.TopPostSort {
+			return float64(post.Score)
+		}
+		return post.Hot
+	}
+
+	slices.SortFunc(posts, func(a domain.Post, b domain.Post) int {
+		if c := cmp.Compare(rank(b), rank(a)); c != 0 {
+			return c
+		}
+
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+
+		return slices.Compare(b.ID[:], a.ID[:])
+	})
+}
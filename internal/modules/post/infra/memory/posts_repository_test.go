@@ -4,7 +4,9 @@ import (
 	"comu/internal/modules/post/domain"
 	"context"
 	"fmt"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -37,7 +39,7 @@ func TestInMemoryPostsRepositoryFindByIdAndFindBySlugMethod(t *testing.T) {
 		repo := NewInMemoryPostsRepository(nil)
 		slug := "post-#1"
 
-		post, err := repo.FindBySlug(context.Background(), slug)
+		post, err := repo.FindBySlug(context.Background(), slug, uuid.Nil)
 		assert.ErrorIs(t, err, domain.ErrPostNotFound)
 		assert.Nil(t, post)
 	})
@@ -65,7 +67,7 @@ func TestInMemoryPostsRepositoryFindByIdAndFindBySlugMethod(t *testing.T) {
 		post := domain.NewPost(uuid.New(), "Post #34", "Post #34 content")
 		repo.Store(ctx, post)
 
-		retrievedPost, err := repo.FindBySlug(ctx, post.Slug)
+		retrievedPost, err := repo.FindBySlug(ctx, post.Slug, uuid.Nil)
 		_assert := assert.New(t)
 
 		if _assert.NoError(err) {
@@ -73,6 +75,115 @@ func TestInMemoryPostsRepositoryFindByIdAndFindBySlugMethod(t *testing.T) {
 			_assert.Equal(post.Content, retrievedPost.Content)
 		}
 	})
+
+	t.Run("FindBySlug should only find the draft for its author", func(t *testing.T) {
+		repo := NewInMemoryPostsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		post, err := domain.NewPostWithStatus(
+			uuid.New(), "Post #34", "Post #34 content",
+			domain.DraftPostStatus, nil, time.Now(),
+		)
+		_assert.NoError(err)
+		repo.Store(ctx, post)
+
+		_, err = repo.FindBySlug(ctx, post.Slug, uuid.New())
+		_assert.ErrorIs(err, domain.ErrPostNotFound)
+
+		retrievedPost, err := repo.FindBySlug(ctx, post.Slug, post.UserID)
+
+		if _assert.NoError(err) {
+			_assert.Equal(post.ID, retrievedPost.ID)
+		}
+	})
+}
+
+func TestInMemoryPostsRepositoryStatusMethods(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	authorID := uuid.New()
+
+	newRepo := func(t *testing.T) (*inMemoryPostsRepository, *domain.Post, *domain.Post) {
+		repo := NewInMemoryPostsRepository(nil)
+		repo.FillWithRandomPosts(authorID, 3)
+
+		draft, err := domain.NewPostWithStatus(authorID, "Draft", "Draft content", domain.DraftPostStatus, nil, now)
+		assert.NoError(t, err)
+		repo.Store(ctx, draft)
+
+		publishAt := now.Add(time.Hour)
+		scheduled, err := domain.NewPostWithStatus(
+			authorID, "Scheduled", "Scheduled content",
+			domain.ScheduledPostStatus, &publishAt, now,
+		)
+		assert.NoError(t, err)
+		repo.Store(ctx, scheduled)
+
+		return repo, draft, scheduled
+	}
+
+	t.Run("it should only list the published posts", func(t *testing.T) {
+		repo, _, _ := newRepo(t)
+
+		posts, _, err := repo.List(ctx, domain.Paginator{Limit: 10})
+
+		if assert.NoError(t, err) {
+			assert.Len(t, posts, 3)
+		}
+	})
+
+	t.Run("it should list the drafts and scheduled posts of the author", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo, draft, scheduled := newRepo(t)
+
+		posts, _, err := repo.ListDrafts(ctx, authorID, domain.Paginator{Limit: 10})
+
+		if _assert.NoError(err) && _assert.Len(posts, 2) {
+			_assert.ElementsMatch([]uuid.UUID{draft.ID, scheduled.ID}, []uuid.UUID{posts[0].ID, posts[1].ID})
+		}
+
+		posts, _, err = repo.ListDrafts(ctx, uuid.New(), domain.Paginator{Limit: 10})
+
+		if _assert.NoError(err) {
+			_assert.Empty(posts)
+		}
+	})
+
+	t.Run("it should list the due scheduled posts", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo, _, scheduled := newRepo(t)
+
+		posts, err := repo.ListDue(ctx, now)
+
+		if _assert.NoError(err) {
+			_assert.Empty(posts)
+		}
+
+		posts, err = repo.ListDue(ctx, now.Add(2*time.Hour))
+
+		if _assert.NoError(err) && _assert.Len(posts, 1) {
+			_assert.Equal(scheduled.ID, posts[0].ID)
+		}
+	})
+
+	t.Run("it should only publish the post once", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo, _, scheduled := newRepo(t)
+		scheduled.Publish(now.Add(2 * time.Hour))
+
+		published, err := repo.Publish(ctx, scheduled)
+
+		if _assert.NoError(err) {
+			_assert.True(published)
+		}
+
+		published, err = repo.Publish(ctx, scheduled)
+
+		if _assert.NoError(err) {
+			_assert.False(published)
+		}
+	})
 }
 
 func TestInMemoryPostsRepositoryListMethod(t *testing.T) {
@@ -130,6 +241,99 @@ func TestInMemoryPostsRepositoryListMethod(t *testing.T) {
 		}
 	})
 
+	t.Run("it should page through the posts by score", func(t *testing.T) {
+		repo := NewInMemoryPostsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		repo.FillWithRandomPosts(uuid.Nil, 5)
+		posts, _ := repo.ListAll(ctx)
+
+		for i, post := range posts {
+			repo.AddToScore(ctx, post.ID, i%3)
+		}
+
+		first, cursor, err := repo.List(ctx, domain.Paginator{Limit: 3, Sort: domain.TopPostSort})
+
+		if _assert.NoError(err) && _assert.Len(first, 3) {
+			_assert.Equal([]int{2, 1, 1}, []int{first[0].Score, first[1].Score, first[2].Score})
+			_assert.Equal(float64(first[2].Score), cursor.Rank)
+
+			second, _, err := repo.List(ctx, domain.Paginator{Limit: 3, Sort: domain.TopPostSort, After: cursor})
+
+			if _assert.NoError(err) && _assert.Len(second, 2) {
+				_assert.Equal([]int{0, 0}, []int{second[0].Score, second[1].Score})
+			}
+		}
+	})
+
+	t.Run("it should break the score ties on the creation date then on the id", func(t *testing.T) {
+		repo := NewInMemoryPostsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		now := time.Now()
+
+		for i := range 4 {
+			post := domain.NewPost(uuid.New(), fmt.Sprintf("Tied post %d", i), "Random content")
+			post.CreatedAt = now.Add(time.Duration(i%2) * time.Hour)
+			repo.Store(ctx, post)
+		}
+
+		first, cursor, err := repo.List(ctx, domain.Paginator{Limit: 2, Sort: domain.TopPostSort})
+
+		if _assert.NoError(err) && _assert.Len(first, 2) {
+			second, _, err := repo.List(ctx, domain.Paginator{Limit: 2, Sort: domain.TopPostSort, After: cursor})
+
+			if _assert.NoError(err) && _assert.Len(second, 2) {
+				posts := append(first, second...)
+
+				_assert.True(slices.IsSortedFunc(posts, func(a, b domain.Post) int {
+					if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+						return c
+					}
+					return slices.Compare(b.ID[:], a.ID[:])
+				}))
+			}
+		}
+	})
+
+	t.Run("it should keep the posts created since the given time", func(t *testing.T) {
+		repo := NewInMemoryPostsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		old := domain.NewPost(uuid.New(), "Old post", "Random content")
+		old.CreatedAt = time.Now().AddDate(0, -2, 0)
+		repo.Store(ctx, old)
+		repo.FillWithRandomPosts(uuid.Nil, 3)
+
+		posts, _, err := repo.List(ctx, domain.Paginator{
+			Limit: 10, Sort: domain.TopPostSort, Since: time.Now().AddDate(0, -1, 0),
+		})
+
+		if _assert.NoError(err) {
+			_assert.Len(posts, 3)
+		}
+	})
+
+	t.Run("it should rank the voted posts first when sorting by hot", func(t *testing.T) {
+		repo := NewInMemoryPostsRepository(nil)
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		voted := domain.NewPost(uuid.New(), "Voted post", "Random content")
+		repo.Store(ctx, voted)
+		repo.FillWithRandomPosts(uuid.Nil, 3)
+		repo.AddToScore(ctx, voted.ID, 10)
+
+		posts, cursor, err := repo.List(ctx, domain.Paginator{Limit: 10, Sort: domain.HotPostSort})
+
+		if _assert.NoError(err) && _assert.Len(posts, 4) {
+			_assert.Equal(voted.ID, posts[0].ID)
+			_assert.Equal(posts[3].Hot, cursor.Rank)
+		}
+	})
 }
 
 func TestInMemoryPostsRepositoryDeleteMethod(t *testing.T) {
@@ -145,3 +349,62 @@ func TestInMemoryPostsRepositoryDeleteMethod(t *testing.T) {
 		assert.ErrorIs(t, err, domain.ErrPostNotFound)
 	}
 }
+
+func TestInMemoryPostsRepositoryTrashMethods(t *testing.T) {
+	repo := NewInMemoryPostsRepository(nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	post := domain.NewPost(userID, "Random Post", "This is a random content")
+	repo.Store(ctx, post)
+	post.Delete(time.Now())
+	repo.Delete(ctx, post)
+
+	expiredPost := domain.NewPost(userID, "Expired Post", "This is a random content")
+	repo.Store(ctx, expiredPost)
+	expiredPost.Delete(time.Now().Add(-domain.TrashRetention - time.Hour))
+	repo.Delete(ctx, expiredPost)
+
+	t.Run("it should only list the posts out of the trash", func(t *testing.T) {
+		posts, err := repo.ListAll(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Empty(t, posts)
+		}
+	})
+
+	t.Run("it should list the trash of the author and the expired posts", func(t *testing.T) {
+		_assert := assert.New(t)
+		trash, err := repo.ListTrash(ctx, userID, domain.TrashCutoff(time.Now()))
+
+		if _assert.NoError(err) && _assert.Len(trash, 1) {
+			_assert.Equal(post.ID, trash[0].ID)
+		}
+		expired, err := repo.ListExpired(ctx, domain.TrashCutoff(time.Now()))
+
+		if _assert.NoError(err) && _assert.Len(expired, 1) {
+			_assert.Equal(expiredPost.ID, expired[0].ID)
+		}
+	})
+
+	t.Run("it should restore the post out of the trash", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		if _assert.NoError(repo.Restore(ctx, post)) {
+			_, err := repo.FindByID(ctx, post.ID)
+			_assert.NoError(err)
+
+			_, err = repo.FindInTrash(ctx, post.ID)
+			_assert.ErrorIs(err, domain.ErrPostNotFound)
+		}
+	})
+
+	t.Run("it should purge the post for good", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		if _assert.NoError(repo.Purge(ctx, expiredPost)) {
+			_, err := repo.FindInTrash(ctx, expiredPost.ID)
+			_assert.ErrorIs(err, domain.ErrPostNotFound)
+		}
+	})
+}
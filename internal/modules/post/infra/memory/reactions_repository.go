@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type reactionStore []domain.Reaction
+
+type inMemoryReactionsRepository struct {
+	store reactionStore
+	sync.Mutex
+}
+
+func NewInMemoryReactionsRepository(initialStore reactionStore) *inMemoryReactionsRepository {
+	if initialStore == nil {
+		initialStore = reactionStore{}
+	}
+
+	return &inMemoryReactionsRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemoryReactionsRepository) Add(ctx context.Context, reaction *domain.Reaction) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	exists := slices.ContainsFunc(repo.store, func(r domain.Reaction) bool {
+		return r.TargetType == reaction.TargetType && r.TargetID == reaction.TargetID &&
+			r.UserID == reaction.UserID && r.Kind == reaction.Kind
+	})
+
+	if !exists {
+		repo.store = append(repo.store, *reaction)
+	}
+
+	return nil
+}
+
+func (repo *inMemoryReactionsRepository) Remove(
+	ctx context.Context, targetType domain.ReactionTarget,
+	targetID, userID uuid.UUID, kind domain.ReactionKind,
+) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.store = slices.DeleteFunc(repo.store, func(r domain.Reaction) bool {
+		return r.TargetType == targetType && r.TargetID == targetID &&
+			r.UserID == userID && r.Kind == kind
+	})
+
+	return nil
+}
+
+func (repo *inMemoryReactionsRepository) Summarize(
+	ctx context.Context, targetType domain.ReactionTarget,
+	targetIDs []uuid.UUID, viewerID uuid.UUID,
+) (domain.ReactionsSummaries, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	summaries := domain.ReactionsSummaries{}
+
+	for _, reaction := range repo.store {
+		if reaction.TargetType != targetType || !slices.Contains(targetIDs, reaction.TargetID) {
+			continue
+		}
+
+		if _, ok := summaries[reaction.TargetID]; !ok {
+			summaries[reaction.TargetID] = domain.NewReactions()
+		}
+		summaries[reaction.TargetID].Add(reaction.Kind, 1, reaction.UserID == viewerID)
+	}
+
+	return summaries, nil
+}
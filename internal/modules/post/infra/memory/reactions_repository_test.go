@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryReactionsRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should count each reaction of a user once", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryReactionsRepository(nil)
+		postID, userID := uuid.New(), uuid.New()
+
+		for range 2 {
+			reaction, _ := domain.NewReaction(domain.PostReactionTarget, postID, userID, domain.LikeReaction)
+			_assert.NoError(repo.Add(ctx, reaction))
+		}
+
+		summaries, err := repo.Summarize(ctx, domain.PostReactionTarget, []uuid.UUID{postID}, userID)
+
+		if _assert.NoError(err) {
+			_assert.Equal(map[domain.ReactionKind]int{domain.LikeReaction: 1}, summaries.Of(postID).Counts)
+			_assert.Equal([]domain.ReactionKind{domain.LikeReaction}, summaries.Of(postID).Mine)
+		}
+	})
+
+	t.Run("it should summarize the reactions of several targets for the viewer", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryReactionsRepository(nil)
+		firstID, secondID, viewerID := uuid.New(), uuid.New(), uuid.New()
+
+		for _, kind := range []domain.ReactionKind{domain.LikeReaction, domain.LoveReaction} {
+			reaction, _ := domain.NewReaction(domain.CommentReactionTarget, firstID, uuid.New(), kind)
+			repo.Add(ctx, reaction)
+		}
+		reaction, _ := domain.NewReaction(domain.CommentReactionTarget, firstID, viewerID, domain.LikeReaction)
+		repo.Add(ctx, reaction)
+		reaction, _ = domain.NewReaction(domain.PostReactionTarget, secondID, viewerID, domain.LikeReaction)
+		repo.Add(ctx, reaction)
+
+		summaries, err := repo.Summarize(ctx, domain.CommentReactionTarget, []uuid.UUID{firstID, secondID}, viewerID)
+
+		if _assert.NoError(err) {
+			first := summaries.Of(firstID)
+			_assert.Equal(2, first.Counts[domain.LikeReaction])
+			_assert.Equal(1, first.Counts[domain.LoveReaction])
+			_assert.Equal([]domain.ReactionKind{domain.LikeReaction}, first.Mine)
+			_assert.Empty(summaries.Of(secondID).Counts)
+		}
+	})
+
+	t.Run("it should remove the reaction of the user", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryReactionsRepository(nil)
+		postID, userID := uuid.New(), uuid.New()
+
+		reaction, _ := domain.NewReaction(domain.PostReactionTarget, postID, userID, domain.WowReaction)
+		repo.Add(ctx, reaction)
+
+		if _assert.NoError(repo.Remove(ctx, domain.PostReactionTarget, postID, userID, domain.WowReaction)) {
+			summaries, _ := repo.Summarize(ctx, domain.PostReactionTarget, []uuid.UUID{postID}, userID)
+			_assert.Empty(summaries.Of(postID).Counts)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// revisionStore holds the revisions of each post, in their order.
+type revisionStore map[uuid.UUID][]domain.Revision
+
+type inMemoryRevisionsRepository struct {
+	store revisionStore
+	sync.Mutex
+}
+
+func NewInMemoryRevisionsRepository(initialStore revisionStore) *inMemoryRevisionsRepository {
+	if initialStore == nil {
+		initialStore = make(revisionStore)
+	}
+
+	return &inMemoryRevisionsRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemoryRevisionsRepository) Store(ctx context.Context, revision *domain.Revision) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return err
+	}
+	revision.ID = id
+	revision.Number = len(repo.store[revision.PostID]) + 1
+	repo.store[revision.PostID] = append(repo.store[revision.PostID], *revision)
+
+	return nil
+}
+
+func (repo *inMemoryRevisionsRepository) Find(ctx context.Context, postID uuid.UUID, number int) (*domain.Revision, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	revisions := repo.store[postID]
+
+	if number < 1 || number > len(revisions) {
+		return nil, domain.ErrRevisionNotFound
+	}
+	revision := revisions[number-1]
+
+	return &revision, nil
+}
+
+func (repo *inMemoryRevisionsRepository) List(ctx context.Context, postID uuid.UUID) ([]domain.Revision, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	revisions := slices.Clone(repo.store[postID])
+	slices.Reverse(revisions)
+
+	if revisions == nil {
+		return []domain.Revision{}, nil
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryRevisionsRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should number the revisions of each post", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryRevisionsRepository(nil)
+		post := domain.NewPost(uuid.New(), "Post", "Post content")
+		post.ID = uuid.New()
+
+		for range 3 {
+			_assert.NoError(repo.Store(ctx, domain.NewRevision(post, post.UserID)))
+		}
+		other := domain.NewPost(uuid.New(), "Other", "Other content")
+		other.ID = uuid.New()
+		revision := domain.NewRevision(other, other.UserID)
+		_assert.NoError(repo.Store(ctx, revision))
+		_assert.Equal(1, revision.Number)
+
+		revisions, err := repo.List(ctx, post.ID)
+
+		if _assert.NoError(err) && _assert.Len(revisions, 3) {
+			_assert.Equal([]int{3, 2, 1}, []int{revisions[0].Number, revisions[1].Number, revisions[2].Number})
+		}
+	})
+
+	t.Run("it should find the revision by its number", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryRevisionsRepository(nil)
+		post := domain.NewPost(uuid.New(), "Post", "Post content")
+		post.ID = uuid.New()
+		repo.Store(ctx, domain.NewRevision(post, post.UserID))
+
+		revision, err := repo.Find(ctx, post.ID, 1)
+
+		if _assert.NoError(err) {
+			_assert.Equal(post.Content, revision.Content)
+		}
+
+		_, err = repo.Find(ctx, post.ID, 2)
+		_assert.ErrorIs(err, domain.ErrRevisionNotFound)
+
+		revisions, err := repo.List(ctx, uuid.New())
+
+		if _assert.NoError(err) {
+			_assert.Empty(revisions)
+		}
+	})
+}
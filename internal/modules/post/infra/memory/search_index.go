@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"comu/internal/modules/post/domain"
+	"context"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// inMemorySearchIndex is an inverted index, mapping each term to the documents
+// holding it along with its number of occurrences. The title words count twice.
+type inMemorySearchIndex struct {
+	documents map[uuid.UUID]domain.SearchDocument
+	postings  map[string]map[uuid.UUID]int
+	sync.Mutex
+}
+
+func NewInMemorySearchIndex(initialDocuments []domain.SearchDocument) *inMemorySearchIndex {
+	index := &inMemorySearchIndex{
+		documents: make(map[uuid.UUID]domain.SearchDocument),
+		postings:  make(map[string]map[uuid.UUID]int),
+	}
+
+	for _, document := range initialDocuments {
+		index.add(document)
+	}
+
+	return index
+}
+
+func (index *inMemorySearchIndex) Index(ctx context.Context, document domain.SearchDocument) error {
+	index.Lock()
+	defer index.Unlock()
+
+	index.remove(document.ID)
+	index.add(document)
+
+	return nil
+}
+
+func (index *inMemorySearchIndex) Remove(ctx context.Context, documentType domain.SearchDocumentType, ID uuid.UUID) error {
+	index.Lock()
+	defer index.Unlock()
+
+	index.remove(ID)
+
+	if documentType == domain.PostSearchDocument {
+		for id, document := range index.documents {
+			if document.PostID == ID {
+				index.remove(id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Search ranks the documents by the sum of the tf-idf weights of the searched terms.
+func (index *inMemorySearchIndex) Search(ctx context.Context, query domain.SearchQuery) ([]domain.SearchResult, *domain.Cursor, error) {
+	index.Lock()
+	defer index.Unlock()
+
+	terms := query.Terms()
+	scores := map[uuid.UUID]float64{}
+
+	for _, term := range terms {
+		postings := index.postings[term]
+		idf := math.Log(1 + float64(len(index.documents))/float64(max(len(postings), 1)))
+
+		for id, frequency := range postings {
+			scores[id] += float64(frequency) * idf
+		}
+	}
+	results := []domain.SearchResult{}
+
+	for id, score := range scores {
+		document := index.documents[id]
+
+		if query.Matches(document, index.documents[document.PostID].Tags) {
+			results = append(results, domain.NewSearchResult(document, score, terms))
+		}
+	}
+
+	slices.SortFunc(results, compareResults)
+
+	if after := query.After; after != nil {
+		cursor := domain.SearchResult{ID: after.ID, CreatedAt: after.CreatedAt, Score: after.Rank}
+
+		results = slices.DeleteFunc(results, func(result domain.SearchResult) bool {
+			return compareResults(result, cursor) <= 0
+		})
+	}
+
+	if len(results) < query.Limit {
+		return results, nil, nil
+	}
+	results = results[:query.Limit]
+	last := results[len(results)-1]
+
+	return results, &domain.Cursor{ID: last.ID, CreatedAt: last.CreatedAt, Rank: last.Score}, nil
+}
+
+func (index *inMemorySearchIndex) add(document domain.SearchDocument) {
+	index.documents[document.ID] = document
+	title := domain.SearchWords(document.Title)
+	words := append(append(title, title...), domain.SearchWords(document.Content)...)
+
+	for _, tag := range document.Tags {
+		words = append(words, domain.SearchWords(tag)...)
+	}
+
+	for _, word := range words {
+		if index.postings[word] == nil {
+			index.postings[word] = make(map[uuid.UUID]int)
+		}
+		index.postings[word][document.ID]++
+	}
+}
+
+func (index *inMemorySearchIndex) remove(ID uuid.UUID) {
+	if _, ok := index.documents[ID]; !ok {
+		return
+	}
+	delete(index.documents, ID)
+
+	for term, postings := range index.postings {
+		delete(postings, ID)
+
+		if len(postings) == 0 {
+			delete(index.postings, term)
+		}
+	}
+}
+
+// compareResults orders the results by score, then by creation date and id, the
+// highest first, like the mysql index does.
+func compareResults(a, b domain.SearchResult) int {
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+
+	if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+		return c
+	}
+
+	return bytes.Compare(b.ID[:], a.ID[:])
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemorySearchIndex(t *testing.T) {
+	ctx := context.Background()
+
+	newPost := func(title, content string, tags ...string) *domain.Post {
+		post := domain.NewPost(uuid.New(), title, content)
+		post.ID = uuid.New()
+		post.Tags = tags
+
+		return post
+	}
+
+	t.Run("it should rank the documents by relevance and highlight the snippets", func(t *testing.T) {
+		_assert := assert.New(t)
+		index := NewInMemorySearchIndex(nil)
+		golang := newPost("Learning Golang", "Golang channels are <great>, golang rocks")
+		other := newPost("Cooking", "A recipe mentioning golang once")
+		index.Index(ctx, domain.NewPostSearchDocument(golang))
+		index.Index(ctx, domain.NewPostSearchDocument(other))
+		index.Index(ctx, domain.NewPostSearchDocument(newPost("Unrelated", "Nothing to see")))
+
+		results, cursor, err := index.Search(ctx, domain.SearchQuery{Text: "GOLANG", Limit: 10})
+
+		if _assert.NoError(err) && _assert.Len(results, 2) {
+			_assert.Nil(cursor)
+			_assert.Equal(golang.ID, results[0].ID)
+			_assert.Equal(other.ID, results[1].ID)
+			_assert.Greater(results[0].Score, results[1].Score)
+			_assert.Equal("<mark>Golang</mark> channels are &lt;great&gt;, <mark>golang</mark> rocks", results[0].Snippet)
+		}
+	})
+
+	t.Run("it should filter the documents and page through them", func(t *testing.T) {
+		_assert := assert.New(t)
+		index := NewInMemorySearchIndex(nil)
+		tagged := newPost("Tagged post", "About search engines", "search")
+		index.Index(ctx, domain.NewPostSearchDocument(tagged))
+		index.Index(ctx, domain.NewPostSearchDocument(newPost("Untagged post", "About search engines too")))
+
+		comment := domain.NewComment(tagged.ID, uuid.New(), "Search engines are fun")
+		comment.ID = uuid.New()
+		index.Index(ctx, domain.NewCommentSearchDocument(comment))
+
+		results, _, _ := index.Search(ctx, domain.SearchQuery{Text: "search", Tag: "search", Limit: 10})
+		_assert.Len(results, 2)
+
+		results, _, _ = index.Search(ctx, domain.SearchQuery{Text: "search", Type: domain.CommentSearchDocument, Limit: 10})
+		_assert.Len(results, 1)
+
+		results, _, _ = index.Search(ctx, domain.SearchQuery{Text: "search", AuthorID: comment.UserID, Limit: 10})
+		_assert.Len(results, 1)
+
+		results, _, _ = index.Search(ctx, domain.SearchQuery{Text: "search", From: time.Now().Add(time.Hour), Limit: 10})
+		_assert.Empty(results)
+
+		first, cursor, err := index.Search(ctx, domain.SearchQuery{Text: "engines", Limit: 2})
+
+		if _assert.NoError(err) && _assert.NotNil(cursor) {
+			second, cursor, _ := index.Search(ctx, domain.SearchQuery{Text: "engines", Limit: 2, After: cursor})
+
+			_assert.Len(second, 1)
+			_assert.Nil(cursor)
+			_assert.NotContains(first, second[0])
+		}
+	})
+
+	t.Run("it should page through the equal scores by creation date then id", func(t *testing.T) {
+		_assert := assert.New(t)
+		index := NewInMemorySearchIndex(nil)
+		now := time.Now()
+
+		for i := range 4 {
+			post := newPost("Tied post", "Same relevance")
+			post.CreatedAt = now.Add(time.Duration(i%2) * time.Hour)
+			index.Index(ctx, domain.NewPostSearchDocument(post))
+		}
+
+		first, cursor, err := index.Search(ctx, domain.SearchQuery{Text: "relevance", Limit: 2})
+
+		if _assert.NoError(err) && _assert.NotNil(cursor) {
+			second, _, err := index.Search(ctx, domain.SearchQuery{Text: "relevance", Limit: 2, After: cursor})
+
+			if _assert.NoError(err) && _assert.Len(second, 2) {
+				results := append(first, second...)
+
+				_assert.True(slices.IsSortedFunc(results, compareResults))
+				_assert.True(results[1].CreatedAt.After(results[2].CreatedAt))
+			}
+		}
+	})
+
+	t.Run("it should remove the post along with its comments", func(t *testing.T) {
+		_assert := assert.New(t)
+		index := NewInMemorySearchIndex(nil)
+		post := newPost("Removed post", "Some searchable words")
+		index.Index(ctx, domain.NewPostSearchDocument(post))
+
+		comment := domain.NewComment(post.ID, uuid.New(), "More searchable words")
+		comment.ID = uuid.New()
+		index.Index(ctx, domain.NewCommentSearchDocument(comment))
+
+		post.Content = "Updated content"
+		index.Index(ctx, domain.NewPostSearchDocument(post))
+		results, _, _ := index.Search(ctx, domain.SearchQuery{Text: "searchable", Limit: 10})
+		_assert.Len(results, 1)
+
+		_assert.NoError(index.Remove(ctx, domain.PostSearchDocument, post.ID))
+		results, _, _ = index.Search(ctx, domain.SearchQuery{Text: "searchable words updated", Limit: 10})
+		_assert.Empty(results)
+		_assert.Empty(index.postings)
+	})
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// slugStore holds the post of each slug.
+type slugStore map[string]uuid.UUID
+
+type inMemorySlugsRepository struct {
+	store slugStore
+	sync.Mutex
+}
+
+func NewInMemorySlugsRepository(initialStore slugStore) *inMemorySlugsRepository {
+	if initialStore == nil {
+		initialStore = make(slugStore)
+	}
+
+	return &inMemorySlugsRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemorySlugsRepository) Store(ctx context.Context, postID uuid.UUID, slug string) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if ownerID, ok := repo.store[slug]; ok && ownerID != postID {
+		return domain.ErrSlugTaken
+	}
+	repo.store[slug] = postID
+
+	return nil
+}
+
+func (repo *inMemorySlugsRepository) FindPostID(ctx context.Context, slug string) (uuid.UUID, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	postID, ok := repo.store[slug]
+
+	if !ok {
+		return uuid.Nil, domain.ErrPostNotFound
+	}
+
+	return postID, nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemorySlugsRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should find the post of a stored slug", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemorySlugsRepository(nil)
+		postID := uuid.New()
+
+		_assert.NoError(repo.Store(ctx, postID, "first-slug"))
+		_assert.NoError(repo.Store(ctx, postID, "second-slug"))
+
+		for _, slug := range []string{"first-slug", "second-slug"} {
+			foundID, err := repo.FindPostID(ctx, slug)
+
+			if _assert.NoError(err) {
+				_assert.Equal(postID, foundID)
+			}
+		}
+
+		_, err := repo.FindPostID(ctx, "unknown-slug")
+		_assert.ErrorIs(err, domain.ErrPostNotFound)
+	})
+
+	t.Run("it should keep a slug to its post", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemorySlugsRepository(nil)
+		postID := uuid.New()
+
+		_assert.NoError(repo.Store(ctx, postID, "slug"))
+		_assert.NoError(repo.Store(ctx, postID, "slug"))
+		_assert.ErrorIs(repo.Store(ctx, uuid.New(), "slug"), domain.ErrSlugTaken)
+	})
+}
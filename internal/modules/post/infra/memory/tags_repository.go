@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"cmp"
+	"comu/internal/modules/post/domain"
+	"context"
+	"slices"
+	"strings"
+)
+
+// inMemoryTagsRepository reads and changes the tags of the posts stored in
+// an in memory posts repository, as the tags are stored with the posts.
+type inMemoryTagsRepository struct {
+	postsRepo *inMemoryPostsRepository
+}
+
+func NewInMemoryTagsRepository(postsRepo *inMemoryPostsRepository) *inMemoryTagsRepository {
+	if postsRepo == nil {
+		postsRepo = NewInMemoryPostsRepository(nil)
+	}
+
+	return &inMemoryTagsRepository{
+		postsRepo: postsRepo,
+	}
+}
+
+func (repo *inMemoryTagsRepository) Find(ctx context.Context, name string) (*domain.Tag, error) {
+	tags := repo.count()
+
+	if count, ok := tags[name]; ok {
+		return &domain.Tag{Name: name, PostsCount: count}, nil
+	}
+
+	return nil, domain.ErrTagNotFound
+}
+
+func (repo *inMemoryTagsRepository) List(ctx context.Context, prefix string, limit int) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+
+	for name, count := range repo.count() {
+		if strings.HasPrefix(name, prefix) {
+			tags = append(tags, domain.Tag{Name: name, PostsCount: count})
+		}
+	}
+
+	slices.SortFunc(tags, func(a, b domain.Tag) int {
+		if c := cmp.Compare(b.PostsCount, a.PostsCount); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return tags[:min(limit, len(tags))], nil
+}
+
+func (repo *inMemoryTagsRepository) Rename(ctx context.Context, name, newName string) error {
+	repo.replace(name, newName)
+	return nil
+}
+
+func (repo *inMemoryTagsRepository) Merge(ctx context.Context, source, target string) error {
+	repo.replace(source, target)
+	return nil
+}
+
+// count returns the number of published posts of each tag, out of the trash.
+func (repo *inMemoryTagsRepository) count() map[string]int {
+	repo.postsRepo.Lock()
+	defer repo.postsRepo.Unlock()
+
+	tags := map[string]int{}
+
+	for _, post := range repo.postsRepo.store {
+		if post.Status != domain.PublishedPostStatus || post.IsDeleted() {
+			continue
+		}
+
+		for _, tag := range post.Tags {
+			tags[tag]++
+		}
+	}
+
+	return tags
+}
+
+// replace replaces the tag of the posts with another one, which they hold once.
+func (repo *inMemoryTagsRepository) replace(name, newName string) {
+	repo.postsRepo.Lock()
+	defer repo.postsRepo.Unlock()
+
+	for id, post := range repo.postsRepo.store {
+		idx := slices.Index(post.Tags, name)
+
+		if idx == -1 {
+			continue
+		}
+		tags := slices.Clone(post.Tags)
+
+		if slices.Contains(tags, newName) {
+			tags = slices.Delete(tags, idx, idx+1)
+		} else {
+			tags[idx] = newName
+		}
+		post.Tags = tags
+		repo.postsRepo.store[id] = post
+	}
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryTagsRepository(t *testing.T) {
+	ctx := context.Background()
+
+	storePost := func(postsRepo *inMemoryPostsRepository, tags ...string) *domain.Post {
+		post := domain.NewPost(uuid.New(), "Tagged post", "Random content")
+		post.Tags = tags
+		postsRepo.Store(ctx, post)
+
+		return post
+	}
+
+	t.Run("it should list the tags starting with the prefix, the most used first", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := NewInMemoryPostsRepository(nil)
+		repo := NewInMemoryTagsRepository(postsRepo)
+
+		storePost(postsRepo, "golang", "web")
+		storePost(postsRepo, "go", "golang")
+		storePost(postsRepo, "go")
+		storePost(postsRepo, "go")
+
+		tags, err := repo.List(ctx, "go", 10)
+
+		if _assert.NoError(err) {
+			_assert.Equal([]domain.Tag{
+				{Name: "go", PostsCount: 3},
+				{Name: "golang", PostsCount: 2},
+			}, tags)
+		}
+
+		tags, _ = repo.List(ctx, "", 1)
+		_assert.Equal([]domain.Tag{{Name: "go", PostsCount: 3}}, tags)
+	})
+
+	t.Run("it should only count the published posts out of the trash", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := NewInMemoryPostsRepository(nil)
+		repo := NewInMemoryTagsRepository(postsRepo)
+
+		storePost(postsRepo, "go")
+		draft, _ := domain.NewPostWithStatus(uuid.New(), "Draft", "Random content", domain.DraftPostStatus, nil, time.Now())
+		draft.Tags = []string{"go", "secret"}
+		postsRepo.Store(ctx, draft)
+		deleted := storePost(postsRepo, "go")
+		deleted.Delete(time.Now())
+		postsRepo.Delete(ctx, deleted)
+
+		tag, err := repo.Find(ctx, "go")
+
+		if _assert.NoError(err) {
+			_assert.Equal(1, tag.PostsCount)
+		}
+		_, err = repo.Find(ctx, "secret")
+		_assert.ErrorIs(err, domain.ErrTagNotFound)
+
+		tags, _ := repo.List(ctx, "", 10)
+		_assert.Equal([]domain.Tag{{Name: "go", PostsCount: 1}}, tags)
+	})
+
+	t.Run("it should fail and return ErrTagNotFound", func(t *testing.T) {
+		_, err := NewInMemoryTagsRepository(nil).Find(ctx, "go")
+		assert.ErrorIs(t, err, domain.ErrTagNotFound)
+	})
+
+	t.Run("it should merge the tags without duplicating them", func(t *testing.T) {
+		_assert := assert.New(t)
+		postsRepo := NewInMemoryPostsRepository(nil)
+		repo := NewInMemoryTagsRepository(postsRepo)
+
+		both := storePost(postsRepo, "golang", "go")
+		one := storePost(postsRepo, "golang", "web")
+
+		_assert.NoError(repo.Merge(ctx, "golang", "go"))
+
+		post, _ := postsRepo.FindByID(ctx, both.ID)
+		_assert.Equal([]string{"go"}, post.Tags)
+		post, _ = postsRepo.FindByID(ctx, one.ID)
+		_assert.Equal([]string{"go", "web"}, post.Tags)
+
+		_, err := repo.Find(ctx, "golang")
+		_assert.ErrorIs(err, domain.ErrTagNotFound)
+	})
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"slices"
+	"sync"
+)
+
+type voteStore []domain.Vote
+
+type inMemoryVotesRepository struct {
+	store voteStore
+	sync.Mutex
+}
+
+func NewInMemoryVotesRepository(initialStore voteStore) *inMemoryVotesRepository {
+	if initialStore == nil {
+		initialStore = voteStore{}
+	}
+
+	return &inMemoryVotesRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemoryVotesRepository) Cast(ctx context.Context, vote *domain.Vote) (int, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	idx := slices.IndexFunc(repo.store, func(v domain.Vote) bool {
+		return v.TargetType == vote.TargetType && v.TargetID == vote.TargetID && v.UserID == vote.UserID
+	})
+	previous := 0
+
+	if idx != -1 {
+		previous = repo.store[idx].Value
+		repo.store = slices.Delete(repo.store, idx, idx+1)
+	}
+
+	if vote.Value != domain.NoVote {
+		repo.store = append(repo.store, *vote)
+	}
+
+	return vote.Value - previous, nil
+}
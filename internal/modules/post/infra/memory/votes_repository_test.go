@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryVotesRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should return the score change of each vote", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryVotesRepository(nil)
+		postID, userID := uuid.New(), uuid.New()
+
+		for _, tc := range []struct{ value, delta int }{
+			{domain.UpVote, 1},
+			{domain.UpVote, 0},
+			{domain.DownVote, -2},
+			{domain.NoVote, 1},
+			{domain.NoVote, 0},
+		} {
+			vote, _ := domain.NewVote(domain.PostVoteTarget, postID, userID, tc.value)
+			delta, err := repo.Cast(ctx, vote)
+
+			_assert.NoError(err)
+			_assert.Equal(tc.delta, delta)
+		}
+		_assert.Empty(repo.store)
+	})
+
+	t.Run("it should keep the votes of the targets apart", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryVotesRepository(nil)
+		targetID, userID := uuid.New(), uuid.New()
+
+		vote, _ := domain.NewVote(domain.PostVoteTarget, targetID, userID, domain.UpVote)
+		repo.Cast(ctx, vote)
+		vote, _ = domain.NewVote(domain.CommentVoteTarget, targetID, userID, domain.UpVote)
+		delta, err := repo.Cast(ctx, vote)
+
+		_assert.NoError(err)
+		_assert.Equal(1, delta)
+		_assert.Len(repo.store, 2)
+	})
+
+	t.Run("it should count a concurrent first vote of a user once", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryVotesRepository(nil)
+		postID, userID := uuid.New(), uuid.New()
+		deltas := make(chan int, 10)
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Go(func() {
+				vote, _ := domain.NewVote(domain.PostVoteTarget, postID, userID, domain.UpVote)
+				delta, _ := repo.Cast(ctx, vote)
+				deltas <- delta
+			})
+		}
+		wg.Wait()
+		close(deltas)
+		score := 0
+
+		for delta := range deltas {
+			score += delta
+		}
+		_assert.Equal(1, score)
+		_assert.Len(repo.store, 1)
+	})
+}
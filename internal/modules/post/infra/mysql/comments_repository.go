@@ -2,21 +2,35 @@ package mysql
 
 import (
 	"comu/internal/modules/post/domain"
+	"comu/internal/shared/database"
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// visibleRepliesCount counts the replies of the comment c which the listings show:
+// the replies out of the trash, and the tombstones still having one of those.
+const visibleRepliesCount = `(
+	SELECT COUNT(*) FROM comments r
+	WHERE r.parent_id = c.id AND (
+		r.deleted_at IS NULL OR
+		EXISTS (SELECT 1 FROM comments rr WHERE rr.parent_id = r.id AND rr.deleted_at IS NULL)
+	)
+)`
+
 type commentsRepository struct {
-	db *sql.DB
+	db         *sql.DB
+	transactor *database.Transactor
 }
 
 func NewCommentsRepository(db *sql.DB) *commentsRepository {
 	return &commentsRepository{
-		db: db,
+		db:         db,
+		transactor: database.NewTransactor(db),
 	}
 }
 
@@ -24,10 +38,7 @@ func (repo *commentsRepository) Find(ctx context.Context, ID uuid.UUID) (*domain
 	query := "SELECT * FROM comments WHERE id = UUID_TO_BIN(?);"
 	comment := &domain.Comment{}
 
-	err := repo.db.QueryRowContext(ctx, query, ID.String()).Scan(
-		&comment.ID, &comment.PostID, &comment.UserID,
-		&comment.Content, &comment.CreatedAt, &comment.UpdatedAt,
-	)
+	err := database.Executor(ctx, repo.db).QueryRowContext(ctx, query, ID.String()).Scan(commentColumns(comment)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -55,10 +66,12 @@ func (repo *commentsRepository) List(ctx context.Context, postID uuid.UUID, pagi
 
 	if paginator.After == nil {
 		query := `
-			SELECT *
-			FROM comments
-			WHERE post_id = UUID_TO_BIN(?)
-			ORDER BY created_at DESC, id DESC
+			SELECT c.*, ` + visibleRepliesCount + `
+			FROM comments c
+			WHERE
+				c.post_id = UUID_TO_BIN(?) AND c.parent_id IS NULL AND
+				(c.deleted_at IS NULL OR ` + visibleRepliesCount + ` > 0)
+			ORDER BY c.created_at DESC, c.id DESC
 			LIMIT ?;
 		`
 		rows, err := repo.db.QueryContext(ctx, query, postID.String(), paginator.Limit)
@@ -71,20 +84,51 @@ func (repo *commentsRepository) List(ctx context.Context, postID uuid.UUID, pagi
 	}
 
 	query := `
-		SELECT *
-		FROM comments
+		SELECT c.*, ` + visibleRepliesCount + `
+		FROM comments c
 		WHERE
-			post_id = UUID_TO_BIN(?) AND
-			(created_at < ?)
-			OR (created_at = ? AND id < ?)
-		ORDER BY created_at DESC, id DESC
+			c.post_id = UUID_TO_BIN(?) AND c.parent_id IS NULL AND
+			(c.deleted_at IS NULL OR ` + visibleRepliesCount + ` > 0) AND
+			(c.created_at < ? OR (c.created_at = ? AND c.id < UUID_TO_BIN(?)))
+		ORDER BY c.created_at DESC, c.id DESC
 		LIMIT ?;
 	`
 	createdAt := paginator.After.CreatedAt
 
 	rows, err := repo.db.QueryContext(
 		ctx, query, postID.String(), createdAt, createdAt,
-		paginator.After.ID, paginator.Limit,
+		paginator.After.ID.String(), paginator.Limit,
+	)
+
+	if err != nil {
+		return []domain.Comment{}, nil, err
+	}
+
+	return repo.getListResult(rows)
+}
+
+func (repo *commentsRepository) ListReplies(ctx context.Context, commentID uuid.UUID, paginator domain.Paginator) ([]domain.Comment, *domain.Cursor, error) {
+	// The cursor is ignored on the first page by comparing with the zero time.
+	after := domain.Cursor{}
+
+	if paginator.After != nil {
+		after = *paginator.After
+	}
+
+	query := `
+		SELECT c.*, ` + visibleRepliesCount + `
+		FROM comments c
+		WHERE
+			c.parent_id = UUID_TO_BIN(?) AND
+			(c.deleted_at IS NULL OR ` + visibleRepliesCount + ` > 0) AND
+			(c.created_at > ? OR (c.created_at = ? AND c.id > UUID_TO_BIN(?)))
+		ORDER BY c.created_at, c.id
+		LIMIT ?;
+	`
+
+	rows, err := repo.db.QueryContext(
+		ctx, query, commentID.String(), after.CreatedAt, after.CreatedAt,
+		after.ID.String(), paginator.Limit,
 	)
 
 	if err != nil {
@@ -94,6 +138,97 @@ func (repo *commentsRepository) List(ctx context.Context, postID uuid.UUID, pagi
 	return repo.getListResult(rows)
 }
 
+func (repo *commentsRepository) ListTrash(ctx context.Context, authorID uuid.UUID, since time.Time) ([]domain.Comment, error) {
+	query := `
+		SELECT * FROM comments
+		WHERE user_id = UUID_TO_BIN(?) AND deleted_at >= ?
+		ORDER BY deleted_at DESC;
+	`
+	rows, err := repo.db.QueryContext(ctx, query, authorID.String(), since)
+
+	if err != nil {
+		return []domain.Comment{}, err
+	}
+
+	return repo.getCommentsFromRows(rows)
+}
+
+func (repo *commentsRepository) ListExpired(ctx context.Context, before time.Time) ([]domain.Comment, error) {
+	query := "SELECT * FROM comments WHERE deleted_at < ? AND content <> '' ORDER BY deleted_at;"
+	rows, err := repo.db.QueryContext(ctx, query, before)
+
+	if err != nil {
+		return []domain.Comment{}, err
+	}
+
+	return repo.getCommentsFromRows(rows)
+}
+
+// ListByPosts lists the comments of all the posts with a single query.
+func (repo *commentsRepository) ListByPosts(
+	ctx context.Context, postIDs []uuid.UUID, since, until time.Time,
+) ([]domain.Comment, error) {
+
+	if len(postIDs) == 0 {
+		return []domain.Comment{}, nil
+	}
+	query := `
+		SELECT * FROM comments
+		WHERE
+			post_id IN (` + uuidPlaceholders(len(postIDs)) + `) AND deleted_at IS NULL AND
+			created_at >= ? AND created_at < ?
+		ORDER BY created_at, id;
+	`
+	rows, err := repo.db.QueryContext(ctx, query, append(uuidArgs(postIDs), since, until)...)
+
+	if err != nil {
+		return []domain.Comment{}, err
+	}
+
+	return repo.getCommentsFromRows(rows)
+}
+
+// CountByPosts counts the comments of all the posts with a single grouped query.
+func (repo *commentsRepository) CountByPosts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := map[uuid.UUID]int{}
+
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+	query := `
+		SELECT post_id, COUNT(*) FROM comments
+		WHERE post_id IN (` + uuidPlaceholders(len(postIDs)) + `) AND deleted_at IS NULL
+		GROUP BY post_id;
+	`
+	rows, err := repo.db.QueryContext(ctx, query, uuidArgs(postIDs)...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID uuid.UUID
+		var count int
+
+		if err := rows.Scan(&postID, &count); err != nil {
+			return nil, err
+		}
+		counts[postID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func (repo *commentsRepository) CountReplies(ctx context.Context, commentID uuid.UUID) (int, error) {
+	query := "SELECT COUNT(*) FROM comments WHERE parent_id = UUID_TO_BIN(?);"
+	var count int
+
+	err := repo.db.QueryRowContext(ctx, query, commentID.String()).Scan(&count)
+
+	return count, err
+}
+
 func (repo *commentsRepository) Store(ctx context.Context, comment *domain.Comment) error {
 	id, err := uuid.NewV7()
 
@@ -104,57 +239,75 @@ func (repo *commentsRepository) Store(ctx context.Context, comment *domain.Comme
 
 	query := `
 		INSERT INTO comments (
-			id, post_id, user_id, content, created_at, updated_at
-		) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?);
+			id, post_id, user_id, content, created_at, updated_at, parent_id, depth, content_html
+		) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, UUID_TO_BIN(?), ?, ?);
 	`
+	var parentID any
+
+	if comment.ParentID != nil {
+		parentID = comment.ParentID.String()
+	}
 
 	_, err = repo.db.ExecContext(
 		ctx, query, comment.ID.String(), comment.PostID.String(), comment.UserID.String(),
-		comment.Content, comment.CreatedAt, comment.UpdatedAt,
+		comment.Content, comment.CreatedAt, comment.UpdatedAt, parentID, comment.Depth,
+		comment.ContentHTML,
 	)
 
 	return err
 }
 
 func (repo *commentsRepository) Update(ctx context.Context, comment *domain.Comment) error {
-	query := "UPDATE comments SET content = ?, updated_at = ? WHERE id = UUID_TO_BIN(?);"
+	query := `
+		UPDATE comments SET content = ?, content_html = ?, deleted_at = ?, updated_at = ?
+		WHERE id = UUID_TO_BIN(?);
+	`
 	comment.UpdatedAt = time.Now()
 
-	_, err := repo.db.ExecContext(ctx, query, comment.Content, comment.UpdatedAt, comment.ID.String())
+	_, err := repo.db.ExecContext(
+		ctx, query, comment.Content, comment.ContentHTML, comment.DeletedAt, comment.UpdatedAt, comment.ID.String(),
+	)
 
 	return err
 }
 
-func (repo *commentsRepository) Delete(ctx context.Context, comment *domain.Comment) error {
-	query := "DELETE FROM comments WHERE id = UUID_TO_BIN(?);"
-	_, err := repo.db.ExecContext(ctx, query, comment.ID.String())
+func (repo *commentsRepository) AddToScore(ctx context.Context, commentID uuid.UUID, delta int) error {
+	query := "UPDATE comments SET score = score + ? WHERE id = UUID_TO_BIN(?);"
+	_, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, delta, commentID.String())
 
 	return err
 }
 
-func (repo *commentsRepository) getCommentsFromRows(rows *sql.Rows) ([]domain.Comment, error) {
-	comments := []domain.Comment{}
+func (repo *commentsRepository) Delete(ctx context.Context, comment *domain.Comment) error {
+	ID := comment.ID.String()
 
-	for rows.Next() {
-		comment := domain.Comment{}
+	return repo.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := database.Executor(ctx, repo.db)
+		query := "DELETE FROM reactions WHERE target_type = ? AND target_id = UUID_TO_BIN(?);"
 
-		err := rows.Scan(
-			&comment.ID, &comment.PostID, &comment.UserID,
-			&comment.Content, &comment.CreatedAt, &comment.UpdatedAt,
-		)
+		if _, err := conn.ExecContext(ctx, query, domain.CommentReactionTarget, ID); err != nil {
+			return err
+		}
+		query = "DELETE FROM votes WHERE target_type = ? AND target_id = UUID_TO_BIN(?);"
 
-		if err != nil {
-			return []domain.Comment{}, err
+		if _, err := conn.ExecContext(ctx, query, domain.CommentVoteTarget, ID); err != nil {
+			return err
 		}
+		_, err := conn.ExecContext(ctx, "DELETE FROM comments WHERE id = UUID_TO_BIN(?);", ID)
 
-		comments = append(comments, comment)
-	}
+		return err
+	})
+}
 
-	return comments, nil
+func (repo *commentsRepository) getCommentsFromRows(rows *sql.Rows) ([]domain.Comment, error) {
+	return scanComments(rows, commentColumns)
 }
 
+// getListResult reads the listed comments, which are followed by their replies count.
 func (repo *commentsRepository) getListResult(rows *sql.Rows) ([]domain.Comment, *domain.Cursor, error) {
-	comments, err := repo.getCommentsFromRows(rows)
+	comments, err := scanComments(rows, func(comment *domain.Comment) []any {
+		return append(commentColumns(comment), &comment.RepliesCount)
+	})
 
 	if err != nil {
 		return comments, nil, err
@@ -167,3 +320,46 @@ func (repo *commentsRepository) getListResult(rows *sql.Rows) ([]domain.Comment,
 
 	return comments, &domain.Cursor{ID: last.ID, CreatedAt: last.CreatedAt}, nil
 }
+
+// commentColumns returns the destinations of the comments table columns, in their order.
+func commentColumns(comment *domain.Comment) []any {
+	return []any{
+		&comment.ID, &comment.PostID, &comment.UserID,
+		&comment.Content, &comment.CreatedAt, &comment.UpdatedAt,
+		&comment.ParentID, &comment.Depth, &comment.DeletedAt, &comment.Score,
+		&comment.ContentHTML,
+	}
+}
+
+func scanComments(rows *sql.Rows, columns func(*domain.Comment) []any) ([]domain.Comment, error) {
+	defer rows.Close()
+	comments := []domain.Comment{}
+
+	for rows.Next() {
+		comment := domain.Comment{}
+
+		if err := rows.Scan(columns(&comment)...); err != nil {
+			return []domain.Comment{}, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// uuidPlaceholders returns the placeholders of an IN list of ids, which uuidArgs
+// returns the arguments of.
+func uuidPlaceholders(length int) string {
+	return strings.TrimSuffix(strings.Repeat("UUID_TO_BIN(?), ", length), ", ")
+}
+
+func uuidArgs(ids []uuid.UUID) []any {
+	args := make([]any, 0, len(ids))
+
+	for _, id := range ids {
+		args = append(args, id.String())
+	}
+
+	return args
+}
@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+type followsRepository struct {
+	db *sql.DB
+}
+
+func NewFollowsRepository(db *sql.DB) *followsRepository {
+	return &followsRepository{
+		db: db,
+	}
+}
+
+func (repo *followsRepository) Follow(ctx context.Context, postID, userID uuid.UUID) error {
+	query := `
+		INSERT IGNORE INTO post_follows (post_id, user_id)
+		VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?));
+	`
+	_, err := repo.db.ExecContext(ctx, query, postID.String(), userID.String())
+
+	return err
+}
+
+func (repo *followsRepository) Unfollow(ctx context.Context, postID, userID uuid.UUID) error {
+	query := "DELETE FROM post_follows WHERE post_id = UUID_TO_BIN(?) AND user_id = UUID_TO_BIN(?);"
+	_, err := repo.db.ExecContext(ctx, query, postID.String(), userID.String())
+
+	return err
+}
+
+func (repo *followsRepository) FindFollowers(ctx context.Context, postID uuid.UUID) ([]uuid.UUID, error) {
+	query := "SELECT user_id FROM post_follows WHERE post_id = UUID_TO_BIN(?);"
+	rows, err := repo.db.QueryContext(ctx, query, postID.String())
+
+	if err != nil {
+		return []uuid.UUID{}, err
+	}
+	defer rows.Close()
+
+	followers := []uuid.UUID{}
+
+	for rows.Next() {
+		var userID uuid.UUID
+
+		if err := rows.Scan(&userID); err != nil {
+			return []uuid.UUID{}, err
+		}
+		followers = append(followers, userID)
+	}
+
+	return followers, rows.Err()
+}
@@ -2,22 +2,26 @@ package mysql
 
 import (
 	"comu/internal/modules/post/domain"
+	"comu/internal/shared/database"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type postsRepository struct {
-	db *sql.DB
+	db         *sql.DB
+	transactor *database.Transactor
 }
 
 func NewPostRepository(db *sql.DB) *postsRepository {
 	return &postsRepository{
-		db: db,
+		db:         db,
+		transactor: database.NewTransactor(db),
 	}
 }
 
@@ -25,59 +29,143 @@ func (repo *postsRepository) FindByID(ctx context.Context, ID uuid.UUID) (*domai
 	return repo.findQuery(ctx, "id", ID.String())
 }
 
-func (repo *postsRepository) FindBySlug(ctx context.Context, slug string) (*domain.Post, error) {
-	return repo.findQuery(ctx, "slug", slug)
+func (repo *postsRepository) FindBySlug(ctx context.Context, slug string, viewerID uuid.UUID) (*domain.Post, error) {
+	post, err := repo.findQuery(ctx, "slug", slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.IsVisibleTo(viewerID) {
+		return nil, domain.ErrPostNotFound
+	}
+
+	return post, nil
 }
 
 func (repo *postsRepository) ListAll(ctx context.Context) ([]domain.Post, error) {
-	query := "SELECT * FROM posts ORDER BY created_at DESC;"
+	query := "SELECT * FROM posts WHERE deleted_at IS NULL ORDER BY created_at DESC;"
 	rows, err := repo.db.QueryContext(ctx, query)
 
 	if err != nil {
 		return []domain.Post{}, err
 	}
 
-	return repo.getPostFromRows(rows)
+	return repo.getPostFromRows(ctx, rows)
+}
+
+func (repo *postsRepository) ListByAuthor(ctx context.Context, userID uuid.UUID) ([]domain.Post, error) {
+	query := `
+		SELECT * FROM posts
+		WHERE user_id = UUID_TO_BIN(?) AND deleted_at IS NULL
+		ORDER BY created_at DESC;
+	`
+	rows, err := repo.db.QueryContext(ctx, query, userID.String())
+
+	if err != nil {
+		return []domain.Post{}, err
+	}
+
+	return repo.getPostFromRows(ctx, rows)
 }
 
 func (repo *postsRepository) List(ctx context.Context, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
+	return repo.list(ctx, paginator, []string{"status = ?"}, []any{domain.PublishedPostStatus})
+}
 
-	if paginator.After == nil {
-		query := `
-			SELECT *
-			FROM posts
-			ORDER BY created_at DESC, id DESC
-			LIMIT ?;
-		`
-		rows, err := repo.db.QueryContext(ctx, query, paginator.Limit)
+func (repo *postsRepository) ListByTag(ctx context.Context, tag string, paginator domain.Paginator) ([]domain.Post, *domain.Cursor, error) {
+	condition := `
+		id IN (
+			SELECT pt.post_id FROM post_tags pt
+			INNER JOIN tags t ON t.id = pt.tag_id
+			WHERE t.name = ?
+		)
+	`
 
-		if err != nil {
-			return []domain.Post{}, nil, err
-		}
+	return repo.list(
+		ctx, paginator,
+		[]string{"status = ?", condition},
+		[]any{domain.PublishedPostStatus, tag},
+	)
+}
 
-		return repo.getListResult(rows)
-	}
+// ListDrafts pages through the author unpublished posts, always chronologically.
+func (repo *postsRepository) ListDrafts(
+	ctx context.Context, authorID uuid.UUID, paginator domain.Paginator,
+) ([]domain.Post, *domain.Cursor, error) {
 
+	paginator.Sort = domain.NewPostSort
+
+	return repo.list(
+		ctx, paginator,
+		[]string{"user_id = UUID_TO_BIN(?)", "status IN (?, ?)"},
+		[]any{authorID.String(), domain.DraftPostStatus, domain.ScheduledPostStatus},
+	)
+}
+
+func (repo *postsRepository) ListDue(ctx context.Context, now time.Time) ([]domain.Post, error) {
 	query := `
-			SELECT *
-			FROM posts
-			WHERE
-				(created_at < ?)
-				OR (created_at = ? AND id < ?)
-			ORDER BY created_at DESC, id DESC
-			LIMIT ?;
-		`
-	createdAt := paginator.After.CreatedAt
-	rows, err := repo.db.QueryContext(
-		ctx, query, createdAt, createdAt,
-		paginator.After.ID.String(), paginator.Limit,
+		SELECT * FROM posts
+		WHERE status = ? AND publish_at <= ? AND deleted_at IS NULL
+		ORDER BY publish_at;
+	`
+	rows, err := repo.db.QueryContext(ctx, query, domain.ScheduledPostStatus, now)
+
+	if err != nil {
+		return []domain.Post{}, err
+	}
+
+	return repo.getPostFromRows(ctx, rows)
+}
+
+// list pages through the posts matching the conditions, in the paginator sort.
+func (repo *postsRepository) list(
+	ctx context.Context, paginator domain.Paginator, conditions []string, args []any,
+) ([]domain.Post, *domain.Cursor, error) {
+
+	conditions = append(conditions, "deleted_at IS NULL")
+
+	if !paginator.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, paginator.Since)
+	}
+
+	// The rank columns break ties on the creation date then on the id, and the
+	// cursor is compared to them as a row so that equal ranks page deterministically.
+	rank, order := "(created_at, id)", "created_at DESC, id DESC"
+
+	switch paginator.Sort {
+	case domain.TopPostSort:
+		rank, order = "(score, created_at, id)", "score DESC, created_at DESC, id DESC"
+	case domain.HotPostSort:
+		rank, order = "(hot, created_at, id)", "hot DESC, created_at DESC, id DESC"
+	}
+
+	if after := paginator.After; after != nil {
+		switch paginator.Sort {
+		case domain.TopPostSort:
+			conditions = append(conditions, rank+" < (?, ?, UUID_TO_BIN(?))")
+			args = append(args, int(after.Rank), after.CreatedAt, after.ID.String())
+		case domain.HotPostSort:
+			conditions = append(conditions, rank+" < (?, ?, UUID_TO_BIN(?))")
+			args = append(args, after.Rank, after.CreatedAt, after.ID.String())
+		default:
+			conditions = append(conditions, rank+" < (?, UUID_TO_BIN(?))")
+			args = append(args, after.CreatedAt, after.ID.String())
+		}
+	}
+
+	query := fmt.Sprintf(
+		"SELECT * FROM posts WHERE %s ORDER BY %s LIMIT ?;",
+		strings.Join(conditions, " AND "), order,
 	)
+	rows, err := repo.db.QueryContext(ctx, query, append(args, paginator.Limit)...)
 
 	if err != nil {
 		return []domain.Post{}, nil, err
 	}
 
-	return repo.getListResult(rows)
+	return repo.getListResult(ctx, rows, paginator.Sort)
 }
 
 func (repo *postsRepository) Store(ctx context.Context, post *domain.Post) error {
@@ -90,54 +178,295 @@ func (repo *postsRepository) Store(ctx context.Context, post *domain.Post) error
 
 	query := `
 		INSERT INTO posts (
-			id, user_id, title, slug,
-			content, created_at, updated_at
-		) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?, ?);
+			id, user_id, title, slug, content, created_at, updated_at, hot,
+			status, publish_at, content_html, excerpt, word_count, reading_time
+		) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 	`
 
-	_, err = repo.db.ExecContext(
-		ctx, query, post.ID.String(), post.UserID.String(), post.Title,
-		post.Slug, post.Content, post.CreatedAt, post.UpdatedAt,
-	)
+	return repo.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := database.Executor(ctx, repo.db).ExecContext(
+			ctx, query, post.ID.String(), post.UserID.String(), post.Title,
+			post.Slug, post.Content, post.CreatedAt, post.UpdatedAt, post.Hot,
+			post.Status, post.PublishAt, post.ContentHTML, post.Excerpt,
+			post.WordCount, post.ReadingTime,
+		)
 
-	return err
+		if err != nil {
+			return err
+		}
+
+		return repo.storeTags(ctx, post)
+	})
 }
 
+// Update also saves the post status, with the dates and hot ranking its publication changes.
 func (repo *postsRepository) Update(ctx context.Context, post *domain.Post) error {
 	query := `
-		UPDATE posts SET title = ?, slug = ?, content = ?, updated_at = ?
+		UPDATE posts SET
+			title = ?, slug = ?, content = ?, created_at = ?,
+			updated_at = ?, hot = ?, status = ?, publish_at = ?, content_html = ?,
+			excerpt = ?, word_count = ?, reading_time = ?
 		WHERE id = UUID_TO_BIN(?);
 	`
 	post.UpdatedAt = time.Now()
 
-	_, err := repo.db.ExecContext(
-		ctx, query, post.Title, post.Slug,
-		post.Content, post.UpdatedAt, post.ID.String(),
+	return repo.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := database.Executor(ctx, repo.db)
+		_, err := conn.ExecContext(
+			ctx, query, post.Title, post.Slug, post.Content, post.CreatedAt,
+			post.UpdatedAt, post.Hot, post.Status, post.PublishAt, post.ContentHTML,
+			post.Excerpt, post.WordCount, post.ReadingTime, post.ID.String(),
+		)
+
+		if err != nil {
+			return err
+		}
+		query := "DELETE FROM post_tags WHERE post_id = UUID_TO_BIN(?);"
+
+		if _, err := conn.ExecContext(ctx, query, post.ID.String()); err != nil {
+			return err
+		}
+
+		return repo.storeTags(ctx, post)
+	})
+}
+
+// Publish only updates the post while it is still scheduled, so that a single
+// scheduler publishes it.
+func (repo *postsRepository) Publish(ctx context.Context, post *domain.Post) (bool, error) {
+	query := `
+		UPDATE posts SET created_at = ?, updated_at = ?, hot = ?, status = ?, publish_at = ?
+		WHERE id = UUID_TO_BIN(?) AND status = ?;
+	`
+	post.UpdatedAt = time.Now()
+	result, err := database.Executor(ctx, repo.db).ExecContext(
+		ctx, query, post.CreatedAt, post.UpdatedAt, post.Hot, post.Status,
+		post.PublishAt, post.ID.String(), domain.ScheduledPostStatus,
 	)
 
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+
+	return affected == 1, err
+}
+
+// AddToScore computes the hot ranking like domain.HotRank does, from the updated score.
+func (repo *postsRepository) AddToScore(ctx context.Context, postID uuid.UUID, delta int) error {
+	query := `
+		UPDATE posts SET
+			score = score + ?,
+			hot = SIGN(score) * LOG10(GREATEST(ABS(score), 1))
+				+ TIMESTAMPDIFF(SECOND, '2005-12-08 07:46:43', created_at) / 45000
+		WHERE id = UUID_TO_BIN(?);
+	`
+	_, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, delta, postID.String())
+
 	return err
 }
 
+// Delete moves the post to the trash, keeping its comments, tags and reactions until its purge.
 func (repo *postsRepository) Delete(ctx context.Context, post *domain.Post) error {
-	query := "DELETE FROM posts WHERE id = UUID_TO_BIN(?);"
-	_, err := repo.db.ExecContext(ctx, query, post.ID.String())
+	if post.DeletedAt == nil {
+		post.Delete(time.Now())
+	}
+	query := "UPDATE posts SET deleted_at = ? WHERE id = UUID_TO_BIN(?) AND deleted_at IS NULL;"
+	_, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, post.DeletedAt, post.ID.String())
 
 	return err
 }
 
+func (repo *postsRepository) FindInTrash(ctx context.Context, ID uuid.UUID) (*domain.Post, error) {
+	query := "SELECT * FROM posts WHERE id = UUID_TO_BIN(?) AND deleted_at IS NOT NULL;"
+	rows, err := database.Executor(ctx, repo.db).QueryContext(ctx, query, ID.String())
+
+	if err != nil {
+		return nil, err
+	}
+	posts, err := repo.getPostFromRows(ctx, rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(posts) == 0 {
+		return nil, domain.ErrPostNotFound
+	}
+
+	return &posts[0], nil
+}
+
+func (repo *postsRepository) ListTrash(ctx context.Context, authorID uuid.UUID, since time.Time) ([]domain.Post, error) {
+	query := `
+		SELECT * FROM posts
+		WHERE user_id = UUID_TO_BIN(?) AND deleted_at >= ?
+		ORDER BY deleted_at DESC;
+	`
+	rows, err := repo.db.QueryContext(ctx, query, authorID.String(), since)
+
+	if err != nil {
+		return []domain.Post{}, err
+	}
+
+	return repo.getPostFromRows(ctx, rows)
+}
+
+func (repo *postsRepository) ListExpired(ctx context.Context, before time.Time) ([]domain.Post, error) {
+	query := "SELECT * FROM posts WHERE deleted_at < ? ORDER BY deleted_at;"
+	rows, err := repo.db.QueryContext(ctx, query, before)
+
+	if err != nil {
+		return []domain.Post{}, err
+	}
+
+	return repo.getPostFromRows(ctx, rows)
+}
+
+func (repo *postsRepository) Restore(ctx context.Context, post *domain.Post) error {
+	query := "UPDATE posts SET deleted_at = NULL WHERE id = UUID_TO_BIN(?);"
+	_, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, post.ID.String())
+
+	if err != nil {
+		return err
+	}
+	post.DeletedAt = nil
+
+	return nil
+}
+
+// Purge removes the reactions, votes and search documents of the post and of its
+// comments, which have no foreign key. The comments, tags, follows, revisions and
+// slugs are removed along with the post, through their foreign keys.
+func (repo *postsRepository) Purge(ctx context.Context, post *domain.Post) error {
+	queries := []string{
+		`
+			DELETE FROM reactions
+			WHERE (target_type = ? AND target_id = UUID_TO_BIN(?))
+				OR (target_type = ? AND target_id IN (SELECT id FROM comments WHERE post_id = UUID_TO_BIN(?)));
+		`,
+		`
+			DELETE FROM votes
+			WHERE (target_type = ? AND target_id = UUID_TO_BIN(?))
+				OR (target_type = ? AND target_id IN (SELECT id FROM comments WHERE post_id = UUID_TO_BIN(?)));
+		`,
+	}
+	ID := post.ID.String()
+
+	return repo.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := database.Executor(ctx, repo.db)
+
+		if _, err := conn.ExecContext(
+			ctx, queries[0], domain.PostReactionTarget, ID, domain.CommentReactionTarget, ID,
+		); err != nil {
+			return err
+		}
+
+		if _, err := conn.ExecContext(
+			ctx, queries[1], domain.PostVoteTarget, ID, domain.CommentVoteTarget, ID,
+		); err != nil {
+			return err
+		}
+
+		if _, err := conn.ExecContext(ctx, "DELETE FROM search_documents WHERE post_id = UUID_TO_BIN(?);", ID); err != nil {
+			return err
+		}
+		_, err := conn.ExecContext(ctx, "DELETE FROM posts WHERE id = UUID_TO_BIN(?);", ID)
+
+		return err
+	})
+}
+
+// storeTags creates the missing post tags and links them to the post.
+func (repo *postsRepository) storeTags(ctx context.Context, post *domain.Post) error {
+	if len(post.Tags) == 0 {
+		return nil
+	}
+	conn := database.Executor(ctx, repo.db)
+
+	for _, tag := range post.Tags {
+		id, err := uuid.NewV7()
+
+		if err != nil {
+			return err
+		}
+		query := "INSERT IGNORE INTO tags (id, name, created_at) VALUES (UUID_TO_BIN(?), ?, ?);"
+
+		if _, err := conn.ExecContext(ctx, query, id.String(), tag, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO post_tags (post_id, tag_id)
+		SELECT UUID_TO_BIN(?), id FROM tags WHERE name IN (%s);
+	`, strings.TrimSuffix(strings.Repeat("?, ", len(post.Tags)), ", "))
+	args := []any{post.ID.String()}
+
+	for _, tag := range post.Tags {
+		args = append(args, tag)
+	}
+	_, err := conn.ExecContext(ctx, query, args...)
+
+	return err
+}
+
+// withTags fills in the tags of the posts with a single query.
+func (repo *postsRepository) withTags(ctx context.Context, posts []domain.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+	args := []any{}
+
+	for i := range posts {
+		posts[i].Tags = []string{}
+		args = append(args, posts[i].ID.String())
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pt.post_id, t.name
+		FROM post_tags pt
+		INNER JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.post_id IN (%s)
+		ORDER BY t.name;
+	`, strings.TrimSuffix(strings.Repeat("UUID_TO_BIN(?), ", len(posts)), ", "))
+	rows, err := database.Executor(ctx, repo.db).QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	tags := map[uuid.UUID][]string{}
+
+	for rows.Next() {
+		var postID uuid.UUID
+		var name string
+
+		if err := rows.Scan(&postID, &name); err != nil {
+			return err
+		}
+		tags[postID] = append(tags[postID], name)
+	}
+
+	for i := range posts {
+		if postTags, ok := tags[posts[i].ID]; ok {
+			posts[i].Tags = postTags
+		}
+	}
+
+	return rows.Err()
+}
+
 func (repo *postsRepository) findQuery(ctx context.Context, column, value string) (*domain.Post, error) {
 	queryVal := "?"
 
 	if column == "id" {
 		queryVal = "UUID_TO_BIN(?)"
 	}
-	query := fmt.Sprintf("SELECT * FROM posts WHERE %s = %s;", column, queryVal)
+	query := fmt.Sprintf("SELECT * FROM posts WHERE %s = %s AND deleted_at IS NULL;", column, queryVal)
 	post := &domain.Post{}
 
-	err := repo.db.QueryRowContext(ctx, query, value).Scan(
-		&post.ID, &post.UserID, &post.Title, &post.Slug,
-		&post.Content, &post.CreatedAt, &post.UpdatedAt,
-	)
+	err := database.Executor(ctx, repo.db).QueryRowContext(ctx, query, value).Scan(postColumns(post)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -146,19 +475,21 @@ func (repo *postsRepository) findQuery(ctx context.Context, column, value string
 
 		return nil, err
 	}
+	posts := []domain.Post{*post}
 
-	return post, nil
+	if err := repo.withTags(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	return &posts[0], nil
 }
 
-func (repo *postsRepository) getPostFromRows(rows *sql.Rows) ([]domain.Post, error) {
+func (repo *postsRepository) getPostFromRows(ctx context.Context, rows *sql.Rows) ([]domain.Post, error) {
 	posts := []domain.Post{}
 
 	for rows.Next() {
 		post := domain.Post{}
-		err := rows.Scan(
-			&post.ID, &post.UserID, &post.Title, &post.Slug,
-			&post.Content, &post.CreatedAt, &post.UpdatedAt,
-		)
+		err := rows.Scan(postColumns(&post)...)
 
 		if err != nil {
 			return []domain.Post{}, err
@@ -166,12 +497,17 @@ func (repo *postsRepository) getPostFromRows(rows *sql.Rows) ([]domain.Post, err
 
 		posts = append(posts, post)
 	}
+	rows.Close()
+
+	if err := repo.withTags(ctx, posts); err != nil {
+		return []domain.Post{}, err
+	}
 
 	return posts, nil
 }
 
-func (repo *postsRepository) getListResult(rows *sql.Rows) ([]domain.Post, *domain.Cursor, error) {
-	posts, err := repo.getPostFromRows(rows)
+func (repo *postsRepository) getListResult(ctx context.Context, rows *sql.Rows, sort domain.PostSort) ([]domain.Post, *domain.Cursor, error) {
+	posts, err := repo.getPostFromRows(ctx, rows)
 
 	if err != nil {
 		return posts, nil, err
@@ -182,5 +518,16 @@ func (repo *postsRepository) getListResult(rows *sql.Rows) ([]domain.Post, *doma
 	}
 	last := posts[len(posts)-1]
 
-	return posts, &domain.Cursor{ID: last.ID, CreatedAt: last.CreatedAt}, nil
+	return posts, last.CursorOf(sort), nil
+}
+
+// postColumns returns the destinations of the posts table columns, in their order.
+func postColumns(post *domain.Post) []any {
+	return []any{
+		&post.ID, &post.UserID, &post.Title, &post.Slug,
+		&post.Content, &post.CreatedAt, &post.UpdatedAt,
+		&post.Score, &post.Hot, &post.Status, &post.PublishAt,
+		&post.ContentHTML, &post.Excerpt, &post.WordCount, &post.ReadingTime,
+		&post.DeletedAt,
+	}
 }
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type reactionsRepository struct {
+	db *sql.DB
+}
+
+func NewReactionsRepository(db *sql.DB) *reactionsRepository {
+	return &reactionsRepository{
+		db: db,
+	}
+}
+
+func (repo *reactionsRepository) Add(ctx context.Context, reaction *domain.Reaction) error {
+	query := `
+		INSERT IGNORE INTO reactions (target_type, target_id, user_id, kind, created_at)
+		VALUES (?, UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?);
+	`
+	_, err := repo.db.ExecContext(
+		ctx, query, reaction.TargetType, reaction.TargetID.String(),
+		reaction.UserID.String(), reaction.Kind, reaction.CreatedAt,
+	)
+
+	return err
+}
+
+func (repo *reactionsRepository) Remove(
+	ctx context.Context, targetType domain.ReactionTarget,
+	targetID, userID uuid.UUID, kind domain.ReactionKind,
+) error {
+	query := `
+		DELETE FROM reactions
+		WHERE target_type = ? AND target_id = UUID_TO_BIN(?) AND user_id = UUID_TO_BIN(?) AND kind = ?;
+	`
+	_, err := repo.db.ExecContext(ctx, query, targetType, targetID.String(), userID.String(), kind)
+
+	return err
+}
+
+// Summarize counts the reactions of the whole page with a single grouped query.
+func (repo *reactionsRepository) Summarize(
+	ctx context.Context, targetType domain.ReactionTarget,
+	targetIDs []uuid.UUID, viewerID uuid.UUID,
+) (domain.ReactionsSummaries, error) {
+	summaries := domain.ReactionsSummaries{}
+
+	if len(targetIDs) == 0 {
+		return summaries, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("UUID_TO_BIN(?), ", len(targetIDs)), ", ")
+	query := `
+		SELECT target_id, kind, COUNT(*), SUM(user_id = UUID_TO_BIN(?))
+		FROM reactions
+		WHERE target_type = ? AND target_id IN (` + placeholders + `)
+		GROUP BY target_id, kind;
+	`
+	args := []any{viewerID.String(), targetType}
+
+	for _, id := range targetIDs {
+		args = append(args, id.String())
+	}
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var targetID uuid.UUID
+		var kind domain.ReactionKind
+		var count, mine int
+
+		if err := rows.Scan(&targetID, &kind, &count, &mine); err != nil {
+			return nil, err
+		}
+
+		if _, ok := summaries[targetID]; !ok {
+			summaries[targetID] = domain.NewReactions()
+		}
+		summaries[targetID].Add(kind, count, mine > 0)
+	}
+
+	return summaries, rows.Err()
+}
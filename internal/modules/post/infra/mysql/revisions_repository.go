@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type revisionsRepository struct {
+	db *sql.DB
+}
+
+func NewRevisionsRepository(db *sql.DB) *revisionsRepository {
+	return &revisionsRepository{
+		db: db,
+	}
+}
+
+// Store numbers the revision from the locked post row, so that the concurrent
+// updates of the post get their own numbers. It must run within the transaction
+// updating the post.
+func (repo *revisionsRepository) Store(ctx context.Context, revision *domain.Revision) error {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return err
+	}
+	conn := database.Executor(ctx, repo.db)
+	query := "SELECT id FROM posts WHERE id = UUID_TO_BIN(?) FOR UPDATE;"
+	var postID uuid.UUID
+
+	if err := conn.QueryRowContext(ctx, query, revision.PostID.String()).Scan(&postID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrPostNotFound
+		}
+
+		return err
+	}
+	query = "SELECT COALESCE(MAX(number), 0) + 1 FROM post_revisions WHERE post_id = UUID_TO_BIN(?);"
+
+	if err := conn.QueryRowContext(ctx, query, revision.PostID.String()).Scan(&revision.Number); err != nil {
+		return err
+	}
+	revision.ID = id
+
+	query = `
+		INSERT INTO post_revisions (
+			id, post_id, number, author_id, title, content, created_at, content_html
+		) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, UUID_TO_BIN(?), ?, ?, ?, ?);
+	`
+	_, err = conn.ExecContext(
+		ctx, query, revision.ID.String(), revision.PostID.String(), revision.Number,
+		revision.AuthorID.String(), revision.Title, revision.Content, revision.CreatedAt,
+		revision.ContentHTML,
+	)
+
+	return err
+}
+
+func (repo *revisionsRepository) Find(ctx context.Context, postID uuid.UUID, number int) (*domain.Revision, error) {
+	query := "SELECT * FROM post_revisions WHERE post_id = UUID_TO_BIN(?) AND number = ?;"
+	revision := &domain.Revision{}
+
+	err := database.Executor(ctx, repo.db).QueryRowContext(ctx, query, postID.String(), number).
+		Scan(revisionColumns(revision)...)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRevisionNotFound
+		}
+
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (repo *revisionsRepository) List(ctx context.Context, postID uuid.UUID) ([]domain.Revision, error) {
+	query := "SELECT * FROM post_revisions WHERE post_id = UUID_TO_BIN(?) ORDER BY number DESC;"
+	rows, err := repo.db.QueryContext(ctx, query, postID.String())
+
+	if err != nil {
+		return []domain.Revision{}, err
+	}
+	defer rows.Close()
+	revisions := []domain.Revision{}
+
+	for rows.Next() {
+		revision := domain.Revision{}
+
+		if err := rows.Scan(revisionColumns(&revision)...); err != nil {
+			return []domain.Revision{}, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// revisionColumns returns the destinations of the post_revisions table columns, in their order.
+func revisionColumns(revision *domain.Revision) []any {
+	return []any{
+		&revision.ID, &revision.PostID, &revision.Number, &revision.AuthorID,
+		&revision.Title, &revision.Content, &revision.CreatedAt, &revision.ContentHTML,
+	}
+}
@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"comu/internal/modules/post/domain"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// searchIndex ranks the search_documents rows with their FULLTEXT index,
+// in natural language mode.
+type searchIndex struct {
+	db *sql.DB
+}
+
+func NewSearchIndex(db *sql.DB) *searchIndex {
+	return &searchIndex{
+		db: db,
+	}
+}
+
+func (index *searchIndex) Index(ctx context.Context, document domain.SearchDocument) error {
+	query := `
+		INSERT INTO search_documents (
+			id, doc_type, post_id, user_id, title, content, tags, created_at
+		) VALUES (UUID_TO_BIN(?), ?, UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE title = VALUES(title), content = VALUES(content), tags = VALUES(tags);
+	`
+	_, err := index.db.ExecContext(
+		ctx, query, document.ID.String(), document.Type, document.PostID.String(),
+		document.AuthorID.String(), document.Title, document.Content,
+		strings.Join(document.Tags, " "), document.CreatedAt,
+	)
+
+	return err
+}
+
+func (index *searchIndex) Remove(ctx context.Context, documentType domain.SearchDocumentType, ID uuid.UUID) error {
+	query := "DELETE FROM search_documents WHERE id = UUID_TO_BIN(?);"
+
+	if documentType == domain.PostSearchDocument {
+		query = "DELETE FROM search_documents WHERE post_id = UUID_TO_BIN(?);"
+	}
+	_, err := index.db.ExecContext(ctx, query, ID.String())
+
+	return err
+}
+
+func (index *searchIndex) Search(ctx context.Context, query domain.SearchQuery) ([]domain.SearchResult, *domain.Cursor, error) {
+	terms := query.Terms()
+	text := strings.Join(terms, " ")
+	conditions := []string{"MATCH(title, content, tags) AGAINST (? IN NATURAL LANGUAGE MODE)"}
+	args := []any{text, text}
+
+	if query.Type != "" {
+		conditions = append(conditions, "doc_type = ?")
+		args = append(args, query.Type)
+	}
+
+	if query.AuthorID != uuid.Nil {
+		conditions = append(conditions, "user_id = UUID_TO_BIN(?)")
+		args = append(args, query.AuthorID.String())
+	}
+
+	if query.Tag != "" {
+		conditions = append(conditions, `
+			post_id IN (
+				SELECT pt.post_id FROM post_tags pt
+				INNER JOIN tags t ON t.id = pt.tag_id
+				WHERE t.name = ?
+			)
+		`)
+		args = append(args, query.Tag)
+	}
+
+	if !query.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, query.From)
+	}
+
+	if !query.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, query.To)
+	}
+	having := "TRUE"
+
+	// The equal scores are ordered by creation date then id, and the cursor is
+	// compared to them as a row so that the ties page deterministically.
+	if after := query.After; after != nil {
+		having = "(score, created_at, id) < (?, ?, UUID_TO_BIN(?))"
+		args = append(args, after.Rank, after.CreatedAt, after.ID.String())
+	}
+
+	sqlQuery := `
+		SELECT
+			doc_type, id, post_id, user_id, title, content, created_at,
+			MATCH(title, content, tags) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+		FROM search_documents
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		HAVING ` + having + `
+		ORDER BY score DESC, created_at DESC, id DESC
+		LIMIT ?;
+	`
+	rows, err := index.db.QueryContext(ctx, sqlQuery, append(args, query.Limit)...)
+
+	if err != nil {
+		return []domain.SearchResult{}, nil, err
+	}
+	defer rows.Close()
+	results := []domain.SearchResult{}
+
+	for rows.Next() {
+		document := domain.SearchDocument{}
+		var score float64
+
+		err := rows.Scan(
+			&document.Type, &document.ID, &document.PostID, &document.AuthorID,
+			&document.Title, &document.Content, &document.CreatedAt, &score,
+		)
+
+		if err != nil {
+			return []domain.SearchResult{}, nil, err
+		}
+		results = append(results, domain.NewSearchResult(document, score, terms))
+	}
+
+	if err := rows.Err(); err != nil || len(results) < query.Limit {
+		return results, nil, err
+	}
+	last := results[len(results)-1]
+
+	return results, &domain.Cursor{ID: last.ID, CreatedAt: last.CreatedAt, Rank: last.Score}, nil
+}
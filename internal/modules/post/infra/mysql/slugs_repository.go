@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type slugsRepository struct {
+	db *sql.DB
+}
+
+func NewSlugsRepository(db *sql.DB) *slugsRepository {
+	return &slugsRepository{
+		db: db,
+	}
+}
+
+// Store keeps the slug for the post, and fails with ErrSlugTaken
+// when another post has or had it.
+func (repo *slugsRepository) Store(ctx context.Context, postID uuid.UUID, slug string) error {
+	query := "INSERT IGNORE INTO post_slugs (slug, post_id, created_at) VALUES (?, UUID_TO_BIN(?), ?);"
+	result, err := database.Executor(ctx, repo.db).ExecContext(ctx, query, slug, postID.String(), time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	if inserted, err := result.RowsAffected(); err != nil || inserted > 0 {
+		return err
+	}
+	ownerID, err := repo.FindPostID(ctx, slug)
+
+	if err != nil {
+		return err
+	}
+
+	if ownerID != postID {
+		return domain.ErrSlugTaken
+	}
+
+	return nil
+}
+
+func (repo *slugsRepository) FindPostID(ctx context.Context, slug string) (uuid.UUID, error) {
+	query := "SELECT post_id FROM post_slugs WHERE slug = ?;"
+	var postID uuid.UUID
+
+	err := database.Executor(ctx, repo.db).QueryRowContext(ctx, query, slug).Scan(&postID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, domain.ErrPostNotFound
+	}
+
+	return postID, err
+}
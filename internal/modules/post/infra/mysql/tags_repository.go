@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type tagsRepository struct {
+	db         *sql.DB
+	transactor *database.Transactor
+}
+
+func NewTagsRepository(db *sql.DB) *tagsRepository {
+	return &tagsRepository{
+		db:         db,
+		transactor: database.NewTransactor(db),
+	}
+}
+
+func (repo *tagsRepository) Find(ctx context.Context, name string) (*domain.Tag, error) {
+	// The tags left without published posts are not found, like they are not listed.
+	query := `
+		SELECT t.name, COUNT(*)
+		FROM tags t
+		INNER JOIN post_tags pt ON pt.tag_id = t.id
+		INNER JOIN posts p ON p.id = pt.post_id
+		WHERE t.name = ? AND p.status = ? AND p.deleted_at IS NULL
+		GROUP BY t.id, t.name;
+	`
+	tag := &domain.Tag{}
+
+	err := repo.db.QueryRowContext(ctx, query, name, domain.PublishedPostStatus).Scan(&tag.Name, &tag.PostsCount)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTagNotFound
+		}
+
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (repo *tagsRepository) List(ctx context.Context, prefix string, limit int) ([]domain.Tag, error) {
+	query := `
+		SELECT t.name, COUNT(*) AS posts_count
+		FROM tags t
+		INNER JOIN post_tags pt ON pt.tag_id = t.id
+		INNER JOIN posts p ON p.id = pt.post_id
+		WHERE t.name LIKE CONCAT(?, '%') AND p.status = ? AND p.deleted_at IS NULL
+		GROUP BY t.id, t.name
+		ORDER BY posts_count DESC, t.name
+		LIMIT ?;
+	`
+	rows, err := repo.db.QueryContext(ctx, query, likeEscaper.Replace(prefix), domain.PublishedPostStatus, limit)
+
+	if err != nil {
+		return []domain.Tag{}, err
+	}
+	defer rows.Close()
+	tags := []domain.Tag{}
+
+	for rows.Next() {
+		tag := domain.Tag{}
+
+		if err := rows.Scan(&tag.Name, &tag.PostsCount); err != nil {
+			return []domain.Tag{}, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// Rename replaces the tag left without posts holding the new name, if any.
+func (repo *tagsRepository) Rename(ctx context.Context, name, newName string) error {
+	return repo.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := database.Executor(ctx, repo.db)
+		query := `
+			DELETE FROM tags
+			WHERE name = ? AND NOT EXISTS (SELECT 1 FROM post_tags pt WHERE pt.tag_id = tags.id);
+		`
+
+		if _, err := conn.ExecContext(ctx, query, newName); err != nil {
+			return err
+		}
+		query = "UPDATE tags SET name = ? WHERE name = ?;"
+		_, err := conn.ExecContext(ctx, query, newName, name)
+
+		return err
+	})
+}
+
+// Merge keeps a single link between the target tag and the posts having both tags.
+func (repo *tagsRepository) Merge(ctx context.Context, source, target string) error {
+	return repo.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := database.Executor(ctx, repo.db)
+		query := `
+			INSERT IGNORE INTO post_tags (post_id, tag_id)
+			SELECT pt.post_id, (SELECT id FROM tags WHERE name = ?)
+			FROM post_tags pt
+			INNER JOIN tags t ON t.id = pt.tag_id
+			WHERE t.name = ?;
+		`
+
+		if _, err := conn.ExecContext(ctx, query, target, source); err != nil {
+			return err
+		}
+		// The source tag links are deleted with it, through the post_tags foreign key.
+		query = "DELETE FROM tags WHERE name = ?;"
+		_, err := conn.ExecContext(ctx, query, source)
+
+		return err
+	})
+}
+
+// likeEscaper escapes the LIKE wildcards of a searched prefix.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type votesRepository struct {
+	db *sql.DB
+}
+
+func NewVotesRepository(db *sql.DB) *votesRepository {
+	return &votesRepository{
+		db: db,
+	}
+}
+
+// Cast upserts the vote, which locks its row even when it is the user first vote on the
+// target, so that the concurrent votes of a user are applied one after the other. It must
+// run within the transaction updating the target score.
+func (repo *votesRepository) Cast(ctx context.Context, vote *domain.Vote) (int, error) {
+	conn := database.Executor(ctx, repo.db)
+
+	if vote.Value == domain.NoVote {
+		return repo.withdraw(ctx, conn, vote)
+	}
+	query := `
+		INSERT INTO votes (target_type, target_id, user_id, value, created_at)
+		VALUES (?, UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?)
+		ON DUPLICATE KEY UPDATE value = VALUES(value);
+	`
+	result, err := conn.ExecContext(
+		ctx, query, vote.TargetType, vote.TargetID.String(),
+		vote.UserID.String(), vote.Value, vote.CreatedAt,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return upsertedVoteDelta(vote.Value, rowsAffected), nil
+}
+
+// withdraw deletes the user vote. A vote withdrawn when there is none changes nothing,
+// so only an existing vote needs to be locked to read its value.
+func (repo *votesRepository) withdraw(ctx context.Context, conn database.Conn, vote *domain.Vote) (int, error) {
+	query := `
+		SELECT value FROM votes
+		WHERE target_type = ? AND target_id = UUID_TO_BIN(?) AND user_id = UUID_TO_BIN(?)
+		FOR UPDATE;
+	`
+	var previous int
+
+	err := conn.QueryRowContext(
+		ctx, query, vote.TargetType, vote.TargetID.String(), vote.UserID.String(),
+	).Scan(&previous)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+	query = `
+		DELETE FROM votes
+		WHERE target_type = ? AND target_id = UUID_TO_BIN(?) AND user_id = UUID_TO_BIN(?);
+	`
+	_, err = conn.ExecContext(ctx, query, vote.TargetType, vote.TargetID.String(), vote.UserID.String())
+
+	return -previous, err
+}
+
+// upsertedVoteDelta returns the score change made by an upserted vote from the number of
+// rows the upsert affected: 1 when the vote was inserted, 2 when it replaced the opposite
+// vote and 0 when the user had already cast it, the votes being either 1 or -1. That is
+// only true as long as the database connections don't set clientFoundRows.
+func upsertedVoteDelta(value int, rowsAffected int64) int {
+	switch rowsAffected {
+	case 1:
+		return value
+	case 2:
+		return 2 * value
+	default:
+		return 0
+	}
+}
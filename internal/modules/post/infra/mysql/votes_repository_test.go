@@ -0,0 +1,28 @@
+package mysql
+
+import (
+	"comu/internal/modules/post/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertedVoteDelta(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        int
+		rowsAffected int64
+		delta        int
+	}{
+		{"it should count the whole vote when it is the user first one", domain.UpVote, 1, 1},
+		{"it should count the whole down vote when it is the user first one", domain.DownVote, 1, -1},
+		{"it should count twice a vote replacing the opposite one", domain.DownVote, 2, -2},
+		{"it should not count a vote the user had already cast", domain.UpVote, 0, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.delta, upsertedVoteDelta(tc.value, tc.rowsAffected))
+		})
+	}
+}
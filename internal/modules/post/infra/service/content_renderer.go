@@ -0,0 +1,15 @@
+package service
+
+import "comu/internal/shared/markdown"
+
+type markdownRenderer struct{}
+
+func NewMarkdownRenderer() *markdownRenderer {
+	return &markdownRenderer{}
+}
+
+func (renderer *markdownRenderer) Render(content string) (string, string) {
+	html := markdown.Render(content)
+
+	return html, markdown.PlainText(html)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"comu/internal/modules/notifications"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PostCommentedNotification = "post_commented"
+
+	CommentCreatedEvent = "comment_created"
+	PostUpdatedEvent    = "post_updated"
+)
+
+type notificationService struct {
+	api    notifications.PublicApi
+	logger *logger.Log
+}
+
+func NewNotificationService(api notifications.PublicApi, logger *logger.Log) *notificationService {
+	return &notificationService{
+		api:    api,
+		logger: logger,
+	}
+}
+
+func (service *notificationService) NotifyPostCommented(ctx context.Context, post *domain.Post, comment *domain.Comment) {
+	err := service.api.Notify(ctx, notifications.NotifyRequest{
+		UserID:   post.UserID,
+		Type:     PostCommentedNotification,
+		Category: notifications.CommentsCategory,
+		Data: map[string]any{
+			"post_id":      post.ID,
+			"post_slug":    post.Slug,
+			"post_title":   post.Title,
+			"comment_id":   comment.ID,
+			"commenter_id": comment.UserID,
+		},
+	})
+
+	if err != nil {
+		service.logger.Error.Println(err)
+	}
+}
+
+func (service *notificationService) PublishCommentCreated(ctx context.Context, followerIDs []uuid.UUID, comment *domain.Comment) {
+	service.api.Publish(ctx, notifications.PublishRequest{
+		UserIDs: followerIDs,
+		Type:    CommentCreatedEvent,
+		Data:    *comment,
+	})
+}
+
+func (service *notificationService) PublishPostUpdated(ctx context.Context, followerIDs []uuid.UUID, post *domain.Post) {
+	service.api.Publish(ctx, notifications.PublishRequest{
+		UserIDs: followerIDs,
+		Type:    PostUpdatedEvent,
+		Data:    *post,
+	})
+}
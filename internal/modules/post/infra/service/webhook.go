@@ -0,0 +1,51 @@
+package service
+
+import (
+	"comu/internal/modules/post/domain"
+	"comu/internal/modules/webhooks"
+	"comu/internal/shared/logger"
+	"context"
+)
+
+type webhookService struct {
+	api    webhooks.PublicApi
+	logger *logger.Log
+}
+
+func NewWebhookService(api webhooks.PublicApi, logger *logger.Log) *webhookService {
+	return &webhookService{
+		api:    api,
+		logger: logger,
+	}
+}
+
+func (service *webhookService) PostCreated(ctx context.Context, post *domain.Post) {
+	service.dispatch(ctx, webhooks.PostCreatedEvent, *post)
+}
+
+func (service *webhookService) PostUpdated(ctx context.Context, post *domain.Post) {
+	service.dispatch(ctx, webhooks.PostUpdatedEvent, *post)
+}
+
+func (service *webhookService) PostDeleted(ctx context.Context, post *domain.Post) {
+	service.dispatch(ctx, webhooks.PostDeletedEvent, *post)
+}
+
+func (service *webhookService) PostRestored(ctx context.Context, post *domain.Post) {
+	service.dispatch(ctx, webhooks.PostRestoredEvent, *post)
+}
+
+func (service *webhookService) CommentCreated(ctx context.Context, comment *domain.Comment) {
+	service.dispatch(ctx, webhooks.CommentCreatedEvent, *comment)
+}
+
+func (service *webhookService) dispatch(ctx context.Context, event string, data any) {
+	err := service.api.Dispatch(ctx, webhooks.DispatchRequest{
+		Event: event,
+		Data:  data,
+	})
+
+	if err != nil {
+		service.logger.Error.Println(err)
+	}
+}
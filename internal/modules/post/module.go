@@ -1,31 +1,70 @@
 package post
 
 import (
+	"comu/config"
 	"comu/internal/modules/auth"
+	"comu/internal/modules/notifications"
 	"comu/internal/modules/post/application"
+	"comu/internal/modules/post/domain"
 	"comu/internal/modules/post/infra/mysql"
+	"comu/internal/modules/post/infra/service"
 	"comu/internal/modules/post/presentation/handlers"
+	"comu/internal/modules/webhooks"
+	"comu/internal/shared/database"
+	"comu/internal/shared/jobs"
 	"comu/internal/shared/logger"
+	"context"
 	"database/sql"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type postModule struct {
-	authApi  auth.PublicApi
-	handlers []handlers.Handlers
+	authApi      auth.PublicApi
+	digestSource notifications.DigestSource
+	handlers     []handlers.Handlers
+	publishJob   *jobs.Job
+	purgeJob     *jobs.Job
 }
 
-func NewModule(db *sql.DB, authApi auth.PublicApi, logger *logger.Log) *postModule {
+func NewModule(
+	db *sql.DB, config *config.Config, authApi auth.PublicApi,
+	notificationsApi notifications.PublicApi, webhooksApi webhooks.PublicApi,
+	logger *logger.Log,
+) *postModule {
 	postsRepo := mysql.NewPostRepository(db)
 	commentsRepo := mysql.NewCommentsRepository(db)
+	followsRepo := mysql.NewFollowsRepository(db)
+	reactionsRepo := mysql.NewReactionsRepository(db)
+	votesRepo := mysql.NewVotesRepository(db)
+	tagsRepo := mysql.NewTagsRepository(db)
+	revisionsRepo := mysql.NewRevisionsRepository(db)
+	slugsRepo := mysql.NewSlugsRepository(db)
+	searchIndex := mysql.NewSearchIndex(db)
 
-	useCases := application.InitUseCases(postsRepo, commentsRepo)
+	notificationService := service.NewNotificationService(notificationsApi, logger)
+	webhookService := service.NewWebhookService(webhooksApi, logger)
+
+	useCases := application.InitUseCases(
+		postsRepo, commentsRepo, followsRepo, reactionsRepo, votesRepo, tagsRepo, revisionsRepo, slugsRepo,
+		searchIndex, service.NewMarkdownRenderer(), database.NewTransactor(db), notificationService, webhookService,
+		config.CommentMaxDepth, config.PostMaxTags, getAdmins(config, logger),
+	)
 	handlers := handlers.GetHandlers(useCases, logger)
 
 	return &postModule{
-		authApi:  authApi,
-		handlers: handlers,
+		authApi:      authApi,
+		digestSource: newDigestSource(useCases.ListNewCommentsUC, useCases.ListTopNewPostsUC),
+		handlers:     handlers,
+		publishJob: jobs.Every(domain.PublishInterval, func(ctx context.Context) error {
+			_, err := useCases.PublishDueUC.Execute(ctx)
+			return err
+		}, logger),
+		purgeJob: jobs.Every(domain.PurgeInterval, func(ctx context.Context) error {
+			_, err := useCases.PurgeTrashUC.Execute(ctx)
+			return err
+		}, logger),
 	}
 }
 
@@ -38,3 +77,38 @@ func (module *postModule) RegisterRoutes(echo *echo.Echo) {
 		)
 	}
 }
+
+// StartJobs publishes the scheduled posts and purges the trash in the background
+// until the context is done.
+func (module *postModule) StartJobs(ctx context.Context) {
+	module.publishJob.Start(ctx)
+	module.purgeJob.Start(ctx)
+}
+
+// WaitJobs blocks until the jobs stopped, after their context is done.
+func (module *postModule) WaitJobs() {
+	module.publishJob.Wait()
+	module.purgeJob.Wait()
+}
+
+// getAdmins returns the users allowed to rename and merge the tags.
+func getAdmins(config *config.Config, logger *logger.Log) domain.Admins {
+	admins := domain.Admins{}
+
+	for _, id := range config.AdminUserIDs {
+		userID, err := uuid.Parse(id)
+
+		if err != nil {
+			logger.Error.Printf("invalid admin user id %q\n", id)
+			continue
+		}
+		admins = append(admins, userID)
+	}
+
+	return admins
+}
+
+// GetDigestSource returns the posts activity to register in the notifications digests.
+func (module *postModule) GetDigestSource() notifications.DigestSource {
+	return module.digestSource
+}
@@ -8,6 +8,7 @@ import (
 	"comu/internal/shared/logger"
 	echoRes "comu/internal/shared/utils/echo_res"
 	"errors"
+	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,8 +19,11 @@ var (
 	msgCommentDeleted = "Your comment has been successfully deleted."
 )
 
+var maxDepthReached echoRes.ErrorResponseType = "max_depth_reached"
+
 type commentHandlers struct {
 	listCommentsUC  *comments.ListCommentsUC
+	listRepliesUC   *comments.ListRepliesUC
 	createCommentUC *comments.CreateCommentUC
 	updateCommentUC *comments.UpdateCommentUC
 	deleteCommentUC *comments.DeleteCommentUC
@@ -29,6 +33,7 @@ type commentHandlers struct {
 
 func newCommentsHandler(
 	listCommentsUC *comments.ListCommentsUC,
+	listRepliesUC *comments.ListRepliesUC,
 	createCommentUC *comments.CreateCommentUC,
 	updateCommentUC *comments.UpdateCommentUC,
 	deleteCommentUC *comments.DeleteCommentUC,
@@ -37,6 +42,7 @@ func newCommentsHandler(
 ) *commentHandlers {
 	return &commentHandlers{
 		listCommentsUC:  listCommentsUC,
+		listRepliesUC:   listRepliesUC,
 		createCommentUC: createCommentUC,
 		updateCommentUC: updateCommentUC,
 		deleteCommentUC: deleteCommentUC,
@@ -49,14 +55,16 @@ func (h *commentHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFu
 	group := echo.Group("/comments", m...)
 
 	group.GET("/list/:post_id", h.list)
+	group.GET("/replies/:comment_id", h.replies)
 	group.POST("/create", h.create)
 	group.PUT("/update/:comment_id", h.update)
 	group.DELETE("/delete/:comment_id", h.delete)
 }
 
 type createCommentFormData struct {
-	PostId  string `form:"post_id" json:"post_id"`
-	Content string `form:"content" json:"content"`
+	PostId   string `form:"post_id" json:"post_id"`
+	ParentId string `form:"parent_id" json:"parent_id"`
+	Content  string `form:"content" json:"content"`
 }
 
 type updateCommentFormData struct {
@@ -77,21 +85,51 @@ func (h *commentHandlers) list(ctx echo.Context) error {
 
 	comments, next, err := h.listCommentsUC.Execute(
 		ctx.Request().Context(),
+		getViewerID(ctx),
 		postID,
 		paginator,
 	)
 
-	if next == nil {
-		return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
-			"comments": comments,
-			"cursor":   "",
-		})
+	return h.listResponse(ctx, comments, next, err)
+}
+
+func (h *commentHandlers) replies(ctx echo.Context) error {
+	paginator := getPaginatorFromCtx(ctx)
+
+	commentID, err := uuid.Parse(ctx.Param("comment_id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(
+			ctx, domain.ErrCommentNotFound.Error(),
+		)
+	}
+
+	replies, next, err := h.listRepliesUC.Execute(
+		ctx.Request().Context(),
+		getViewerID(ctx),
+		commentID,
+		paginator,
+	)
+
+	if errors.Is(err, domain.ErrCommentNotFound) {
+		return echoRes.JsonNotFoundResponse(ctx, err.Error())
 	}
 
+	return h.listResponse(ctx, replies, next, err)
+}
+
+func (h *commentHandlers) listResponse(ctx echo.Context, comments []domain.Comment, next *domain.Cursor, err error) error {
 	if err != nil {
 		h.logger.Error.Println(err)
 		return echoRes.JsonInternalErrorResponse(ctx)
 	}
+
+	if next == nil {
+		return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+			"comments": comments,
+			"cursor":   "",
+		})
+	}
 	cursor, err := next.ToBase64()
 
 	if err != nil {
@@ -134,18 +172,39 @@ func (h *commentHandlers) create(ctx echo.Context) error {
 		)
 	}
 
+	var parentID *uuid.UUID
+
+	if data.ParentId != "" {
+		id, err := uuid.Parse(data.ParentId)
+
+		if err != nil {
+			return echoRes.JsonNotFoundResponse(ctx, domain.ErrCommentNotFound.Error())
+		}
+		parentID = &id
+	}
+
 	comment, err := h.createCommentUC.Execute(
 		ctx.Request().Context(),
 		comments.CreateCommentInput{
 			PostID:   postID,
+			ParentID: parentID,
 			AuthorID: userID,
 			Content:  data.Content,
 		},
 	)
 
 	if err != nil {
-		h.logger.Error.Println(err)
-		return echoRes.JsonInternalErrorResponse(ctx)
+		switch {
+		case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrCommentNotFound):
+			return echoRes.JsonNotFoundResponse(ctx, err.Error())
+
+		case errors.Is(err, domain.ErrMaxDepthReached):
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, maxDepthReached, err.Error())
+
+		default:
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
 	}
 
 	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth"
+	"comu/internal/modules/post/application/follows"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	msgPostFollowed   = "You now follow this post."
+	msgPostUnfollowed = "You no longer follow this post."
+)
+
+type followHandlers struct {
+	followPostUC   *follows.FollowPostUC
+	unfollowPostUC *follows.UnfollowPostUC
+
+	logger *logger.Log
+}
+
+func newFollowHandlers(
+	followPostUC *follows.FollowPostUC,
+	unfollowPostUC *follows.UnfollowPostUC,
+
+	logger *logger.Log,
+) *followHandlers {
+	return &followHandlers{
+		followPostUC:   followPostUC,
+		unfollowPostUC: unfollowPostUC,
+
+		logger: logger,
+	}
+}
+
+func (h *followHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/posts", m...)
+
+	group.POST("/follow/:post_id", h.follow)
+	group.DELETE("/unfollow/:post_id", h.unfollow)
+}
+
+func (h *followHandlers) follow(ctx echo.Context) error {
+	handler := followPreHandler(func(postID, userID uuid.UUID) error {
+		if err := h.followPostUC.Execute(ctx.Request().Context(), postID, userID); err != nil {
+			if errors.Is(err, domain.ErrPostNotFound) {
+				return echoRes.JsonNotFoundResponse(ctx, err.Error())
+			}
+
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+
+		return echoRes.JsonSuccessMessageResponse(ctx, msgPostFollowed)
+	})
+
+	return handler(ctx)
+}
+
+func (h *followHandlers) unfollow(ctx echo.Context) error {
+	handler := followPreHandler(func(postID, userID uuid.UUID) error {
+		if err := h.unfollowPostUC.Execute(ctx.Request().Context(), postID, userID); err != nil {
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+
+		return echoRes.JsonSuccessMessageResponse(ctx, msgPostUnfollowed)
+	})
+
+	return handler(ctx)
+}
+
+func followPreHandler(afterFunc func(postID, userID uuid.UUID) error) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+		userID, err := uuid.Parse(id)
+
+		if err != nil {
+			return echoRes.JsonUnauthorizedResponse(
+				ctx, unauthorized,
+				domain.ErrUnauthorized.Error(),
+			)
+		}
+
+		postID, err := uuid.Parse(ctx.Param("post_id"))
+
+		if err != nil {
+			return echoRes.JsonNotFoundResponse(
+				ctx, domain.ErrPostNotFound.Error(),
+			)
+		}
+
+		return afterFunc(postID, userID)
+	}
+}
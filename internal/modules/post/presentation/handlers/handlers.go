@@ -14,12 +14,30 @@ type Handlers interface {
 func GetHandlers(ucs application.UseCases, logger *logger.Log) []Handlers {
 	postsHandlers := newPostHandlers(
 		ucs.ListPostsUC, ucs.ReadPostUC, ucs.CreatePostUC,
-		ucs.UpdatePostUC, ucs.DeletePostUC, logger,
+		ucs.UpdatePostUC, ucs.DeletePostUC, ucs.ListDraftsUC, ucs.PreviewPostUC, logger,
 	)
 	commentHandlers := newCommentsHandler(
-		ucs.ListCommentUC, ucs.CreateCommentUC,
+		ucs.ListCommentUC, ucs.ListRepliesUC, ucs.CreateCommentUC,
 		ucs.UpdateCommentUC, ucs.DeleteCommentUC, logger,
 	)
 
-	return []Handlers{postsHandlers, commentHandlers}
+	followHandlers := newFollowHandlers(ucs.FollowPostUC, ucs.UnfollowPostUC, logger)
+	reactionHandlers := newReactionHandlers(ucs.AddReactionUC, ucs.RemoveReactionUC, logger)
+	voteHandlers := newVoteHandlers(ucs.VoteUC, logger)
+	tagHandlers := newTagHandlers(
+		ucs.ListTagsUC, ucs.ListTagPostsUC,
+		ucs.RenameTagUC, ucs.MergeTagsUC, logger,
+	)
+	searchHandlers := newSearchHandlers(ucs.SearchUC, logger)
+	revisionHandlers := newRevisionHandlers(
+		ucs.ListRevisionsUC, ucs.DiffRevisionsUC, ucs.RestoreRevisionUC, logger,
+	)
+	trashHandlers := newTrashHandlers(
+		ucs.ListTrashUC, ucs.RestorePostUC, ucs.RestoreCommentUC, logger,
+	)
+
+	return []Handlers{
+		postsHandlers, commentHandlers, followHandlers, reactionHandlers,
+		voteHandlers, tagHandlers, searchHandlers, revisionHandlers, trashHandlers,
+	}
 }
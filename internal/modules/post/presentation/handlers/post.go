@@ -10,22 +10,30 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 var (
-	unauthorized echoRes.ErrorResponseType = "unauthorized"
+	unauthorized  echoRes.ErrorResponseType = "unauthorized"
+	unknownSort   echoRes.ErrorResponseType = "unknown_sort"
+	invalidTags   echoRes.ErrorResponseType = "invalid_tags"
+	invalidStatus echoRes.ErrorResponseType = "invalid_status"
+	invalidSlug   echoRes.ErrorResponseType = "invalid_slug"
 )
 
 type postHandlers struct {
-	listPostsUC  *posts.ListPostsUC
-	readPostUC   *posts.ReadPostUC
-	createPostUC *posts.CreatePostUC
-	updatePostUC *posts.UpdatePostUC
-	deletePostUC *posts.DeletePostUC
+	listPostsUC   *posts.ListPostsUC
+	readPostUC    *posts.ReadPostUC
+	createPostUC  *posts.CreatePostUC
+	updatePostUC  *posts.UpdatePostUC
+	deletePostUC  *posts.DeletePostUC
+	listDraftsUC  *posts.ListDraftsUC
+	previewPostUC *posts.PreviewPostUC
 
 	logger *logger.Log
 }
@@ -36,15 +44,19 @@ func newPostHandlers(
 	createPostUC *posts.CreatePostUC,
 	updatePostUC *posts.UpdatePostUC,
 	deletePostUC *posts.DeletePostUC,
+	listDraftsUC *posts.ListDraftsUC,
+	previewPostUC *posts.PreviewPostUC,
 
 	logger *logger.Log,
 ) *postHandlers {
 	return &postHandlers{
-		listPostsUC:  listPostsUC,
-		readPostUC:   readPostUC,
-		createPostUC: createPostUC,
-		updatePostUC: updatePostUC,
-		deletePostUC: deletePostUC,
+		listPostsUC:   listPostsUC,
+		readPostUC:    readPostUC,
+		createPostUC:  createPostUC,
+		updatePostUC:  updatePostUC,
+		deletePostUC:  deletePostUC,
+		listDraftsUC:  listDraftsUC,
+		previewPostUC: previewPostUC,
 
 		logger: logger,
 	}
@@ -55,21 +67,59 @@ func (h *postHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc)
 
 	group.GET("", h.list)
 	group.POST("/create", h.create)
+	group.POST("/preview", h.preview)
 	group.GET("/read/:slug", h.read)
 	group.PUT("/update/:post_id", h.update)
 	group.DELETE("/delete/:post_id", h.delete)
+
+	echo.GET("/me/drafts", h.drafts, m...)
 }
 
 type postFormData struct {
-	Title   string `form:"title" json:"title"`
+	Title   string   `form:"title" json:"title"`
+	Content string   `form:"content" json:"content"`
+	Tags    []string `form:"tags" json:"tags"`
+	Slug    string   `form:"slug" json:"slug"`
+	Status  string   `form:"status" json:"status"`
+	// PublishAt is the RFC 3339 date a scheduled post gets published at.
+	PublishAt string `form:"publish_at" json:"publish_at"`
+}
+
+type previewFormData struct {
 	Content string `form:"content" json:"content"`
 }
 
+// publishAt parses the publish date of the post, nil when there is none.
+func (data postFormData) publishAt() (*time.Time, error) {
+	if data.PublishAt == "" {
+		return nil, nil
+	}
+	publishAt, err := time.Parse(time.RFC3339, data.PublishAt)
+
+	if err != nil {
+		return nil, domain.ErrInvalidPublish
+	}
+
+	return &publishAt, nil
+}
+
+// isStatusError tells whether the error comes from an invalid post status or publish date.
+func isStatusError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidStatus) ||
+		errors.Is(err, domain.ErrStatusChange) ||
+		errors.Is(err, domain.ErrInvalidPublish)
+}
+
 func (h *postHandlers) list(ctx echo.Context) error {
 	paginator := getPaginatorFromCtx(ctx)
 
+	if err := setPostSortFromCtx(ctx, &paginator); err != nil {
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, unknownSort, err.Error())
+	}
+
 	posts, next, err := h.listPostsUC.Execute(
 		ctx.Request().Context(),
+		getViewerID(ctx),
 		paginator,
 	)
 
@@ -84,6 +134,7 @@ func (h *postHandlers) list(ctx echo.Context) error {
 			"cursor": "",
 		})
 	}
+	next.Since = paginator.Since
 
 	cursor, err := next.ToBase64()
 
@@ -98,6 +149,32 @@ func (h *postHandlers) list(ctx echo.Context) error {
 	})
 }
 
+func (h *postHandlers) drafts(ctx echo.Context) error {
+	posts, next, err := h.listDraftsUC.Execute(
+		ctx.Request().Context(),
+		getViewerID(ctx),
+		getPaginatorFromCtx(ctx),
+	)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+	cursor := ""
+
+	if next != nil {
+		if cursor, err = next.ToBase64(); err != nil {
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"posts":  posts,
+		"cursor": cursor,
+	})
+}
+
 func (h *postHandlers) read(ctx echo.Context) error {
 	slug := ctx.Param("slug")
 
@@ -107,7 +184,7 @@ func (h *postHandlers) read(ctx echo.Context) error {
 		)
 	}
 
-	post, err := h.readPostUC.Execute(ctx.Request().Context(), slug)
+	post, err := h.readPostUC.Execute(ctx.Request().Context(), getViewerID(ctx), slug)
 
 	if err != nil {
 		if errors.Is(err, domain.ErrPostNotFound) {
@@ -118,23 +195,50 @@ func (h *postHandlers) read(ctx echo.Context) error {
 		return echoRes.JsonInternalErrorResponse(ctx)
 	}
 
+	// The former slugs of the post lead to its current one.
+	if post.Slug != slug {
+		return ctx.Redirect(http.StatusMovedPermanently, "/posts/read/"+post.Slug)
+	}
+
 	return echoRes.JsonSuccessWithDataResponse(ctx, *post)
 }
 
 func (h *postHandlers) create(ctx echo.Context) error {
 	handler := postPreHandler(func(validated postFormData, userID uuid.UUID) error {
+		publishAt, err := validated.publishAt()
+
+		if err != nil {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidStatus, err.Error())
+		}
+
 		post, err := h.createPostUC.Execute(
 			ctx.Request().Context(),
 			posts.CreatePostInput{
-				UserID:  userID,
-				Title:   validated.Title,
-				Content: validated.Content,
+				UserID:    userID,
+				Title:     validated.Title,
+				Content:   validated.Content,
+				Tags:      validated.Tags,
+				Slug:      validated.Slug,
+				Status:    validated.Status,
+				PublishAt: publishAt,
 			},
 		)
 
 		if err != nil {
-			h.logger.Error.Println(err)
-			return echoRes.JsonInternalErrorResponse(ctx)
+			switch {
+			case errors.Is(err, domain.ErrInvalidTag), errors.Is(err, domain.ErrTooManyTags):
+				return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidTags, err.Error())
+
+			case isStatusError(err):
+				return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidStatus, err.Error())
+
+			case errors.Is(err, domain.ErrInvalidSlug), errors.Is(err, domain.ErrSlugTaken):
+				return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidSlug, err.Error())
+
+			default:
+				h.logger.Error.Println(err)
+				return echoRes.JsonInternalErrorResponse(ctx)
+			}
 		}
 
 		return echoRes.JsonSuccessWithDataResponse(ctx, *post)
@@ -143,6 +247,21 @@ func (h *postHandlers) create(ctx echo.Context) error {
 	return handler(ctx)
 }
 
+// preview returns the rendered HTML of the content, with its excerpt, word count and reading time.
+func (h *postHandlers) preview(ctx echo.Context) error {
+	var data previewFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	if errList := validation.PreviewPostValidator.Validate(&data); errList != nil {
+		return echoRes.JsonValidationErrorResponse(ctx, errList)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, h.previewPostUC.Execute(data.Content))
+}
+
 func (h *postHandlers) update(ctx echo.Context) error {
 	handler := postPreHandler(func(validated postFormData, userID uuid.UUID) error {
 		postID, err := uuid.Parse(ctx.Param("post_id"))
@@ -153,13 +272,23 @@ func (h *postHandlers) update(ctx echo.Context) error {
 			)
 		}
 
+		publishAt, err := validated.publishAt()
+
+		if err != nil {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidStatus, err.Error())
+		}
+
 		slug, err := h.updatePostUC.Execute(
 			ctx.Request().Context(),
 			posts.UpdatePostInput{
-				PostID:   postID,
-				AuthorID: userID,
-				Title:    validated.Title,
-				Content:  validated.Content,
+				PostID:    postID,
+				AuthorID:  userID,
+				Title:     validated.Title,
+				Content:   validated.Content,
+				Tags:      validated.Tags,
+				Slug:      validated.Slug,
+				Status:    validated.Status,
+				PublishAt: publishAt,
 			},
 		)
 
@@ -168,6 +297,15 @@ func (h *postHandlers) update(ctx echo.Context) error {
 			case errors.Is(err, domain.ErrPostNotFound):
 				return echoRes.JsonNotFoundResponse(ctx, err.Error())
 
+			case errors.Is(err, domain.ErrInvalidTag), errors.Is(err, domain.ErrTooManyTags):
+				return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidTags, err.Error())
+
+			case isStatusError(err):
+				return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidStatus, err.Error())
+
+			case errors.Is(err, domain.ErrInvalidSlug), errors.Is(err, domain.ErrSlugTaken):
+				return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidSlug, err.Error())
+
 			case errors.Is(err, domain.ErrUnauthorized):
 				return echoRes.JsonForbiddenResponse(ctx, err.Error())
 
@@ -249,6 +387,48 @@ func postPreHandler(afterFunc func(validated postFormData, userID uuid.UUID) err
 	}
 }
 
+// getViewerID returns the id of the authenticated user, or uuid.Nil when there is none.
+func getViewerID(ctx echo.Context) uuid.UUID {
+	id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+	viewerID, _ := uuid.Parse(id)
+
+	return viewerID
+}
+
+// setPostSortFromCtx sets the sort query param of the posts list, new by default,
+// and the time window param of the top posts. The window start of the first page
+// is kept by the cursor, so the next pages don't slide with the current time.
+func setPostSortFromCtx(ctx echo.Context, paginator *domain.Paginator) error {
+	switch sort := ctx.QueryParam("sort"); sort {
+	case "", domain.NewPostSort:
+		paginator.Sort = domain.NewPostSort
+	case domain.HotPostSort:
+		paginator.Sort = sort
+	case domain.TopPostSort:
+		paginator.Sort = sort
+
+		if paginator.After != nil && !paginator.After.Since.IsZero() {
+			paginator.Since = paginator.After.Since
+			return nil
+		}
+		window := ctx.QueryParam("window")
+
+		if window == "" {
+			window = domain.DefaultTopWindow
+		}
+		since, err := domain.TopWindowSince(window, time.Now())
+
+		if err != nil {
+			return err
+		}
+		paginator.Since = since
+	default:
+		return domain.ErrUnknownSort
+	}
+
+	return nil
+}
+
 func getPaginatorFromCtx(ctx echo.Context) domain.Paginator {
 
 	paginator := domain.Paginator{
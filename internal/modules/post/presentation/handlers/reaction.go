@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth"
+	"comu/internal/modules/post/application/reactions"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	msgReactionAdded   = "Your reaction has been added."
+	msgReactionRemoved = "Your reaction has been removed."
+)
+
+var unknownReaction echoRes.ErrorResponseType = "unknown_reaction"
+
+type reactionHandlers struct {
+	addReactionUC    *reactions.AddReactionUC
+	removeReactionUC *reactions.RemoveReactionUC
+
+	logger *logger.Log
+}
+
+func newReactionHandlers(
+	addReactionUC *reactions.AddReactionUC,
+	removeReactionUC *reactions.RemoveReactionUC,
+
+	logger *logger.Log,
+) *reactionHandlers {
+	return &reactionHandlers{
+		addReactionUC:    addReactionUC,
+		removeReactionUC: removeReactionUC,
+
+		logger: logger,
+	}
+}
+
+func (h *reactionHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	posts := echo.Group("/posts/reactions", m...)
+
+	posts.POST("/:target_id", h.add(domain.PostReactionTarget))
+	posts.DELETE("/:target_id/:reaction", h.remove(domain.PostReactionTarget))
+
+	comments := echo.Group("/comments/reactions", m...)
+
+	comments.POST("/:target_id", h.add(domain.CommentReactionTarget))
+	comments.DELETE("/:target_id/:reaction", h.remove(domain.CommentReactionTarget))
+}
+
+type reactionFormData struct {
+	Reaction string `form:"reaction" json:"reaction"`
+}
+
+func (h *reactionHandlers) add(targetType domain.ReactionTarget) echo.HandlerFunc {
+	return reactionPreHandler(targetType, func(ctx echo.Context, input reactions.ReactionInput) error {
+		var data reactionFormData
+
+		if err := ctx.Bind(&data); err != nil {
+			return echoRes.JsonInvalidRequestResponse(ctx)
+		}
+		input.Kind = data.Reaction
+
+		if err := h.addReactionUC.Execute(ctx.Request().Context(), input); err != nil {
+			return h.errorResponse(ctx, err)
+		}
+
+		return echoRes.JsonSuccessMessageResponse(ctx, msgReactionAdded)
+	})
+}
+
+func (h *reactionHandlers) remove(targetType domain.ReactionTarget) echo.HandlerFunc {
+	return reactionPreHandler(targetType, func(ctx echo.Context, input reactions.ReactionInput) error {
+		input.Kind = ctx.Param("reaction")
+
+		if err := h.removeReactionUC.Execute(ctx.Request().Context(), input); err != nil {
+			return h.errorResponse(ctx, err)
+		}
+
+		return echoRes.JsonSuccessMessageResponse(ctx, msgReactionRemoved)
+	})
+}
+
+func (h *reactionHandlers) errorResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnknownReaction):
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, unknownReaction, err.Error())
+
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrCommentNotFound):
+		return echoRes.JsonNotFoundResponse(ctx, err.Error())
+
+	default:
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+}
+
+func reactionPreHandler(
+	targetType domain.ReactionTarget,
+	afterFunc func(ctx echo.Context, input reactions.ReactionInput) error,
+) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+		userID, err := uuid.Parse(id)
+
+		if err != nil {
+			return echoRes.JsonUnauthorizedResponse(
+				ctx, unauthorized,
+				domain.ErrUnauthorized.Error(),
+			)
+		}
+
+		targetID, err := uuid.Parse(ctx.Param("target_id"))
+
+		if err != nil {
+			notFound := domain.ErrPostNotFound
+
+			if targetType == domain.CommentReactionTarget {
+				notFound = domain.ErrCommentNotFound
+			}
+
+			return echoRes.JsonNotFoundResponse(ctx, notFound.Error())
+		}
+
+		return afterFunc(ctx, reactions.ReactionInput{
+			TargetType: targetType,
+			TargetID:   targetID,
+			UserID:     userID,
+		})
+	}
+}
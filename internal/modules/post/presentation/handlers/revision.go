@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth"
+	"comu/internal/modules/post/application/revisions"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var invalidRevision echoRes.ErrorResponseType = "invalid_revision"
+
+type revisionHandlers struct {
+	listRevisionsUC   *revisions.ListRevisionsUC
+	diffRevisionsUC   *revisions.DiffRevisionsUC
+	restoreRevisionUC *revisions.RestoreRevisionUC
+
+	logger *logger.Log
+}
+
+func newRevisionHandlers(
+	listRevisionsUC *revisions.ListRevisionsUC,
+	diffRevisionsUC *revisions.DiffRevisionsUC,
+	restoreRevisionUC *revisions.RestoreRevisionUC,
+
+	logger *logger.Log,
+) *revisionHandlers {
+	return &revisionHandlers{
+		listRevisionsUC:   listRevisionsUC,
+		diffRevisionsUC:   diffRevisionsUC,
+		restoreRevisionUC: restoreRevisionUC,
+
+		logger: logger,
+	}
+}
+
+func (h *revisionHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/posts", m...)
+
+	group.GET("/:post_id/revisions", h.list)
+	group.GET("/:post_id/revisions/diff", h.diff)
+	group.POST("/:post_id/revisions/:rev/restore", h.restore)
+}
+
+func (h *revisionHandlers) list(ctx echo.Context) error {
+	postID, err := uuid.Parse(ctx.Param("post_id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrPostNotFound.Error())
+	}
+
+	revisions, err := h.listRevisionsUC.Execute(ctx.Request().Context(), getViewerID(ctx), postID)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"revisions": revisions,
+	})
+}
+
+// diff responds with the line changes between the from and to revisions, given as query params.
+func (h *revisionHandlers) diff(ctx echo.Context) error {
+	postID, err := uuid.Parse(ctx.Param("post_id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrPostNotFound.Error())
+	}
+	from, fromErr := strconv.Atoi(ctx.QueryParam("from"))
+	to, toErr := strconv.Atoi(ctx.QueryParam("to"))
+
+	if fromErr != nil || toErr != nil {
+		return echoRes.JsonErrorMessageResponse(
+			ctx, http.StatusUnprocessableEntity, invalidRevision,
+			"the from and to revision numbers are required",
+		)
+	}
+
+	diff, err := h.diffRevisionsUC.Execute(ctx.Request().Context(), getViewerID(ctx), postID, from, to)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, *diff)
+}
+
+func (h *revisionHandlers) restore(ctx echo.Context) error {
+	id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+	userID, err := uuid.Parse(id)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(
+			ctx, unauthorized,
+			domain.ErrUnauthorized.Error(),
+		)
+	}
+	postID, err := uuid.Parse(ctx.Param("post_id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrPostNotFound.Error())
+	}
+	number, err := strconv.Atoi(ctx.Param("rev"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrRevisionNotFound.Error())
+	}
+
+	post, err := h.restoreRevisionUC.Execute(ctx.Request().Context(), revisions.RestoreRevisionInput{
+		PostID: postID,
+		Number: number,
+		UserID: userID,
+	})
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, *post)
+}
+
+func (h *revisionHandlers) errorResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrRevisionNotFound):
+		return echoRes.JsonNotFoundResponse(ctx, err.Error())
+
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echoRes.JsonForbiddenResponse(ctx, err.Error())
+
+	default:
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+}
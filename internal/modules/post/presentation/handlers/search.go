@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"comu/internal/modules/post/application/search"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	emptySearch   echoRes.ErrorResponseType = "empty_search"
+	invalidSearch echoRes.ErrorResponseType = "invalid_search"
+)
+
+var errInvalidSearchFilter = errors.New("the type must be post or comment, the author an id, and the dates like 2006-01-02")
+
+type searchHandlers struct {
+	searchUC *search.SearchUC
+
+	logger *logger.Log
+}
+
+func newSearchHandlers(searchUC *search.SearchUC, logger *logger.Log) *searchHandlers {
+	return &searchHandlers{
+		searchUC: searchUC,
+
+		logger: logger,
+	}
+}
+
+func (h *searchHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	echo.Group("/search", m...).GET("", h.search)
+}
+
+func (h *searchHandlers) search(ctx echo.Context) error {
+	query, err := getSearchQueryFromCtx(ctx)
+
+	if err != nil {
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidSearch, err.Error())
+	}
+	results, next, err := h.searchUC.Execute(ctx.Request().Context(), query)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptySearch) {
+			return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, emptySearch, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+	cursor := ""
+
+	if next != nil {
+		if cursor, err = next.ToBase64(); err != nil {
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"results": results,
+		"cursor":  cursor,
+	})
+}
+
+// getSearchQueryFromCtx reads the q, type, author, tag, from and to query params,
+// along with the limit and cursor ones. The to date is included in the range.
+func getSearchQueryFromCtx(ctx echo.Context) (domain.SearchQuery, error) {
+	paginator := getPaginatorFromCtx(ctx)
+	query := domain.SearchQuery{
+		Text:  ctx.QueryParam("q"),
+		Type:  ctx.QueryParam("type"),
+		Tag:   ctx.QueryParam("tag"),
+		Limit: paginator.Limit,
+		After: paginator.After,
+	}
+
+	if query.Type != "" && query.Type != domain.PostSearchDocument && query.Type != domain.CommentSearchDocument {
+		return query, errInvalidSearchFilter
+	}
+
+	if author := ctx.QueryParam("author"); author != "" {
+		authorID, err := uuid.Parse(author)
+
+		if err != nil {
+			return query, errInvalidSearchFilter
+		}
+		query.AuthorID = authorID
+	}
+	var err error
+
+	if query.From, err = parseSearchDate(ctx.QueryParam("from")); err != nil {
+		return query, errInvalidSearchFilter
+	}
+
+	if query.To, err = parseSearchDate(ctx.QueryParam("to")); err != nil {
+		return query, errInvalidSearchFilter
+	}
+
+	if !query.To.IsZero() {
+		query.To = query.To.AddDate(0, 0, 1)
+	}
+
+	return query, nil
+}
+
+func parseSearchDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.DateOnly, value)
+}
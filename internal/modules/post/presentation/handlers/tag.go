@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth"
+	"comu/internal/modules/post/application/tags"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	tagExists  echoRes.ErrorResponseType = "tag_exists"
+	invalidTag echoRes.ErrorResponseType = "invalid_tag"
+)
+
+type tagHandlers struct {
+	listTagsUC     *tags.ListTagsUC
+	listTagPostsUC *tags.ListTagPostsUC
+	renameTagUC    *tags.RenameTagUC
+	mergeTagsUC    *tags.MergeTagsUC
+
+	logger *logger.Log
+}
+
+func newTagHandlers(
+	listTagsUC *tags.ListTagsUC,
+	listTagPostsUC *tags.ListTagPostsUC,
+	renameTagUC *tags.RenameTagUC,
+	mergeTagsUC *tags.MergeTagsUC,
+
+	logger *logger.Log,
+) *tagHandlers {
+	return &tagHandlers{
+		listTagsUC:     listTagsUC,
+		listTagPostsUC: listTagPostsUC,
+		renameTagUC:    renameTagUC,
+		mergeTagsUC:    mergeTagsUC,
+
+		logger: logger,
+	}
+}
+
+func (h *tagHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/tags", m...)
+
+	group.GET("", h.list)
+	group.GET("/:name/posts", h.posts)
+	group.PUT("/rename/:name", h.rename)
+	group.POST("/merge/:name", h.merge)
+}
+
+type renameTagFormData struct {
+	Name string `form:"name" json:"name"`
+}
+
+type mergeTagsFormData struct {
+	Into string `form:"into" json:"into"`
+}
+
+func (h *tagHandlers) list(ctx echo.Context) error {
+	limit, _ := strconv.Atoi(ctx.QueryParam("limit"))
+	tags, err := h.listTagsUC.Execute(ctx.Request().Context(), ctx.QueryParam("prefix"), limit)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"tags": tags,
+	})
+}
+
+func (h *tagHandlers) posts(ctx echo.Context) error {
+	paginator := getPaginatorFromCtx(ctx)
+
+	if err := setPostSortFromCtx(ctx, &paginator); err != nil {
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, unknownSort, err.Error())
+	}
+
+	tag, posts, next, err := h.listTagPostsUC.Execute(
+		ctx.Request().Context(),
+		getViewerID(ctx),
+		ctx.Param("name"),
+		paginator,
+	)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+	cursor := ""
+
+	if next != nil {
+		next.Since = paginator.Since
+
+		if cursor, err = next.ToBase64(); err != nil {
+			h.logger.Error.Println(err)
+			return echoRes.JsonInternalErrorResponse(ctx)
+		}
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"tag":    tag,
+		"posts":  posts,
+		"cursor": cursor,
+	})
+}
+
+func (h *tagHandlers) rename(ctx echo.Context) error {
+	var data renameTagFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	return h.adminAction(ctx, func(userID uuid.UUID) (*domain.Tag, error) {
+		return h.renameTagUC.Execute(ctx.Request().Context(), userID, ctx.Param("name"), data.Name)
+	})
+}
+
+func (h *tagHandlers) merge(ctx echo.Context) error {
+	var data mergeTagsFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	return h.adminAction(ctx, func(userID uuid.UUID) (*domain.Tag, error) {
+		return h.mergeTagsUC.Execute(ctx.Request().Context(), userID, ctx.Param("name"), data.Into)
+	})
+}
+
+// adminAction runs the tag change of the authenticated user, responding with the changed tag.
+func (h *tagHandlers) adminAction(ctx echo.Context, action func(userID uuid.UUID) (*domain.Tag, error)) error {
+	id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+	userID, err := uuid.Parse(id)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(
+			ctx, unauthorized,
+			domain.ErrUnauthorized.Error(),
+		)
+	}
+	tag, err := action(userID)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, *tag)
+}
+
+func (h *tagHandlers) errorResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrTagNotFound):
+		return echoRes.JsonNotFoundResponse(ctx, err.Error())
+
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echoRes.JsonForbiddenResponse(ctx, err.Error())
+
+	case errors.Is(err, domain.ErrInvalidTag):
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidTag, err.Error())
+
+	case errors.Is(err, domain.ErrTagExists):
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, tagExists, err.Error())
+
+	default:
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth"
+	"comu/internal/modules/post/application/trash"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var restoreExpired echoRes.ErrorResponseType = "restore_expired"
+
+type trashHandlers struct {
+	listTrashUC      *trash.ListTrashUC
+	restorePostUC    *trash.RestorePostUC
+	restoreCommentUC *trash.RestoreCommentUC
+
+	logger *logger.Log
+}
+
+func newTrashHandlers(
+	listTrashUC *trash.ListTrashUC,
+	restorePostUC *trash.RestorePostUC,
+	restoreCommentUC *trash.RestoreCommentUC,
+
+	logger *logger.Log,
+) *trashHandlers {
+	return &trashHandlers{
+		listTrashUC:      listTrashUC,
+		restorePostUC:    restorePostUC,
+		restoreCommentUC: restoreCommentUC,
+
+		logger: logger,
+	}
+}
+
+func (h *trashHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	echo.GET("/me/trash", h.list, m...)
+	echo.POST("/posts/restore/:post_id", h.restorePost, m...)
+	echo.POST("/comments/restore/:comment_id", h.restoreComment, m...)
+}
+
+func (h *trashHandlers) list(ctx echo.Context) error {
+	trash, err := h.listTrashUC.Execute(ctx.Request().Context(), getViewerID(ctx))
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, *trash)
+}
+
+func (h *trashHandlers) restorePost(ctx echo.Context) error {
+	id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+	userID, err := uuid.Parse(id)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(
+			ctx, unauthorized,
+			domain.ErrUnauthorized.Error(),
+		)
+	}
+	postID, err := uuid.Parse(ctx.Param("post_id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrPostNotFound.Error())
+	}
+
+	post, err := h.restorePostUC.Execute(ctx.Request().Context(), postID, userID)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, *post)
+}
+
+func (h *trashHandlers) restoreComment(ctx echo.Context) error {
+	id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+	userID, err := uuid.Parse(id)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(
+			ctx, unauthorized,
+			domain.ErrUnauthorized.Error(),
+		)
+	}
+	commentID, err := uuid.Parse(ctx.Param("comment_id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrCommentNotFound.Error())
+	}
+
+	comment, err := h.restoreCommentUC.Execute(ctx.Request().Context(), commentID, userID)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, *comment)
+}
+
+func (h *trashHandlers) errorResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrCommentNotFound):
+		return echoRes.JsonNotFoundResponse(ctx, err.Error())
+
+	case errors.Is(err, domain.ErrUnauthorized):
+		return echoRes.JsonForbiddenResponse(ctx, err.Error())
+
+	case errors.Is(err, domain.ErrRestoreExpired):
+		return echoRes.JsonErrorMessageResponse(
+			ctx, http.StatusUnprocessableEntity, restoreExpired, err.Error(),
+		)
+
+	default:
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth"
+	"comu/internal/modules/post/application/votes"
+	"comu/internal/modules/post/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var msgVoteCast = "Your vote has been taken into account."
+
+var invalidVote echoRes.ErrorResponseType = "invalid_vote"
+
+type voteHandlers struct {
+	voteUC *votes.VoteUC
+
+	logger *logger.Log
+}
+
+func newVoteHandlers(voteUC *votes.VoteUC, logger *logger.Log) *voteHandlers {
+	return &voteHandlers{
+		voteUC: voteUC,
+
+		logger: logger,
+	}
+}
+
+func (h *voteHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	echo.Group("/posts/vote", m...).POST("/:target_id", h.vote(domain.PostVoteTarget))
+	echo.Group("/comments/vote", m...).POST("/:target_id", h.vote(domain.CommentVoteTarget))
+}
+
+type voteFormData struct {
+	// Value is 1 or -1, and 0 withdraws the vote.
+	Value *int `form:"value" json:"value"`
+}
+
+func (h *voteHandlers) vote(targetType domain.VoteTarget) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		id, _ := ctx.Get(auth.AuthUserIdCtxKey).(string)
+		userID, err := uuid.Parse(id)
+
+		if err != nil {
+			return echoRes.JsonUnauthorizedResponse(
+				ctx, unauthorized,
+				domain.ErrUnauthorized.Error(),
+			)
+		}
+
+		targetID, err := uuid.Parse(ctx.Param("target_id"))
+
+		if err != nil {
+			return echoRes.JsonNotFoundResponse(ctx, voteTargetNotFound(targetType).Error())
+		}
+		var data voteFormData
+
+		if err := ctx.Bind(&data); err != nil {
+			return echoRes.JsonInvalidRequestResponse(ctx)
+		}
+
+		if data.Value == nil {
+			return h.errorResponse(ctx, domain.ErrInvalidVote)
+		}
+
+		score, err := h.voteUC.Execute(ctx.Request().Context(), votes.VoteInput{
+			TargetType: targetType,
+			TargetID:   targetID,
+			UserID:     userID,
+			Value:      *data.Value,
+		})
+
+		if err != nil {
+			return h.errorResponse(ctx, err)
+		}
+
+		return echoRes.JsonSuccessResponse(ctx, msgVoteCast, map[string]int{"score": score})
+	}
+}
+
+func (h *voteHandlers) errorResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidVote):
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidVote, err.Error())
+
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrCommentNotFound):
+		return echoRes.JsonNotFoundResponse(ctx, err.Error())
+
+	default:
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+}
+
+func voteTargetNotFound(targetType domain.VoteTarget) error {
+	if targetType == domain.CommentVoteTarget {
+		return domain.ErrCommentNotFound
+	}
+
+	return domain.ErrPostNotFound
+}
@@ -25,6 +25,11 @@ var PostValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
 		Min(10, zog.Message(msgPostContentTooShort)).Max(620, zog.Message(msgPostContentTooShort)),
 }))
 
+var PreviewPostValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
+	"content": zog.String().Required(zog.Message(msgContentRequired)).
+		Max(620, zog.Message(msgPostCommentTooLong)),
+}))
+
 var CreateCommentValidator = validator.NewStructValidator(zog.Struct(zog.Shape{
 	"postId": zog.String().Required(zog.Message(msgPostIdRequired)),
 	"content": zog.String().Required(zog.Message(msgContentRequired)).
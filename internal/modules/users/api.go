@@ -13,6 +13,11 @@ type CreateUserRequest struct {
 	Name     string
 	Email    string
 	Password string
+	// EmailVerified creates the user with an already verified email address.
+	// It is meant for identities that were verified by a trusted third party.
+	EmailVerified bool
+	// Locale is the language the user is sent messages in.
+	Locale string
 }
 
 type CreateUserResponse struct {
@@ -28,6 +33,9 @@ type GetUserResponse struct {
 	Active          bool
 	Avatar          string
 	Password        string
+	Locale          string
+	Phone           string
+	PhoneVerifiedAt *time.Time
 	CreatedAt       time.Time
 	DeletedAt       *time.Time
 }
@@ -43,6 +51,7 @@ type publicApi struct {
 	getUserByEmailUC          *application.GetUserByEmailUC
 	updateUserPasswordUC      *application.UpdateUserPasswordUC
 	markUserEmailAsVerifiedUC *application.MarkUserEmailAsVerifiedUC
+	setUserVerifiedPhoneUC    *application.SetUserVerifiedPhoneUC
 }
 
 func newApi(
@@ -51,6 +60,7 @@ func newApi(
 	getUserByEmailUC *application.GetUserByEmailUC,
 	updateUserPasswordUC *application.UpdateUserPasswordUC,
 	markUserEmailAsVerifiedUC *application.MarkUserEmailAsVerifiedUC,
+	setUserVerifiedPhoneUC *application.SetUserVerifiedPhoneUC,
 ) *publicApi {
 	return &publicApi{
 		createUserUC:              createUserUC,
@@ -58,15 +68,18 @@ func newApi(
 		getUserByEmailUC:          getUserByEmailUC,
 		updateUserPasswordUC:      updateUserPasswordUC,
 		markUserEmailAsVerifiedUC: markUserEmailAsVerifiedUC,
+		setUserVerifiedPhoneUC:    setUserVerifiedPhoneUC,
 	}
 }
 
 func (api *publicApi) CreateUser(ctx context.Context, req CreateUserRequest) (*CreateUserResponse, error) {
 	user, err := api.createUserUC.Execute(
 		ctx, application.CreateUserInput{
-			Name:     req.Name,
-			Email:    req.Email,
-			Password: req.Password,
+			Name:          req.Name,
+			Email:         req.Email,
+			Password:      req.Password,
+			EmailVerified: req.EmailVerified,
+			Locale:        req.Locale,
 		},
 	)
 
@@ -104,6 +117,10 @@ func (api *publicApi) MarkEmailAsVerified(ctx context.Context, email string) err
 	return api.markUserEmailAsVerifiedUC.Execute(ctx, email)
 }
 
+func (api *publicApi) SetVerifiedPhone(ctx context.Context, ID uuid.UUID, phone string) error {
+	return api.setUserVerifiedPhoneUC.Execute(ctx, ID, phone)
+}
+
 func (api *publicApi) UpdateUserPassword(ctx context.Context, req UpdateUserPasswordRequest) error {
 	return api.updateUserPasswordUC.Execute(ctx, req.ID, req.NewPassword)
 }
@@ -117,6 +134,9 @@ func (api *publicApi) newGetUserResponse(user *domain.User) *GetUserResponse {
 		Avatar:          user.Avatar,
 		Active:          user.Active,
 		Password:        user.Password,
+		Locale:          user.Locale,
+		Phone:           user.Phone,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
 		CreatedAt:       user.CreatedAt,
 		DeletedAt:       user.DeletedAt,
 	}
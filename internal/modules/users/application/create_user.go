@@ -3,31 +3,46 @@ package application
 import (
 	"comu/internal/modules/users/domain"
 	"context"
+	"time"
 )
 
 type CreateUserUC struct {
-	repo domain.Repository
+	repo           domain.Repository
+	webhookService domain.WebhookService
 }
 
 type CreateUserInput struct {
-	Name     string
-	Email    string
-	Password string
+	Name          string
+	Email         string
+	Password      string
+	EmailVerified bool
+	Locale        string
 }
 
-func NewCreateUserUseCase(repo domain.Repository) *CreateUserUC {
+func NewCreateUserUseCase(repo domain.Repository, webhookService domain.WebhookService) *CreateUserUC {
 	return &CreateUserUC{
-		repo: repo,
+		repo:           repo,
+		webhookService: webhookService,
 	}
 }
 
 func (useCase *CreateUserUC) Execute(ctx context.Context, input CreateUserInput) (*domain.User, error) {
 	newUser := domain.NewUser(input.Name, input.Email, input.Password)
+
+	if input.Locale != "" {
+		newUser.Locale = input.Locale
+	}
+
+	if input.EmailVerified {
+		now := time.Now()
+		newUser.EmailVerifiedAt = &now
+	}
 	err := useCase.repo.Store(ctx, newUser)
 
 	if err != nil {
 		return nil, err
 	}
+	useCase.webhookService.UserRegistered(ctx, newUser)
 
 	return newUser, nil
 }
@@ -11,11 +11,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+type webhookServiceSpy struct {
+	registered []uuid.UUID
+}
+
+func (spy *webhookServiceSpy) UserRegistered(ctx context.Context, user *domain.User) {
+	spy.registered = append(spy.registered, user.ID)
+}
+
 func TestCreateUserUseCase(t *testing.T) {
 
 	t.Run("it should successfully create a new user to the repository", func(t *testing.T) {
 		repo := memory.NewInMemoryRepository(nil)
-		useCase := application.NewCreateUserUseCase(repo)
+		spy := &webhookServiceSpy{}
+		useCase := application.NewCreateUserUseCase(repo, spy)
 
 		result, _ := useCase.Execute(
 			context.Background(),
@@ -28,11 +37,60 @@ func TestCreateUserUseCase(t *testing.T) {
 
 		assert.NotNil(t, result)
 		assert.NotEqual(t, uuid.Nil.String(), result.ID)
+		assert.Equal(t, []uuid.UUID{result.ID}, spy.registered)
+	})
+
+	t.Run("it should create the user with a verified email when asked to", func(t *testing.T) {
+		repo := memory.NewInMemoryRepository(nil)
+		useCase := application.NewCreateUserUseCase(repo, &webhookServiceSpy{})
+		_assert := assert.New(t)
+
+		result, err := useCase.Execute(
+			context.Background(),
+			application.CreateUserInput{
+				Name:          "John Doe",
+				Email:         "johndoe@gmail.com",
+				Password:      "7ySavUthqq1QeQ7XvghiWC4CtV",
+				EmailVerified: true,
+			},
+		)
+
+		if _assert.NoError(err) {
+			_assert.True(result.EmailIsVerified())
+		}
+	})
+
+	t.Run("it should create the user with the requested locale or the default one", func(t *testing.T) {
+		repo := memory.NewInMemoryRepository(nil)
+		useCase := application.NewCreateUserUseCase(repo, &webhookServiceSpy{})
+		ctx := context.Background()
+		_assert := assert.New(t)
+
+		withLocale, err := useCase.Execute(ctx, application.CreateUserInput{
+			Name:     "John Doe",
+			Email:    "johndoe@gmail.com",
+			Password: "7ySavUthqq1QeQ7XvghiWC4CtV",
+			Locale:   "fr",
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal("fr", withLocale.Locale)
+		}
+
+		withoutLocale, err := useCase.Execute(ctx, application.CreateUserInput{
+			Name:     "Jane Doe",
+			Email:    "janedoe@gmail.com",
+			Password: "7ySavUthqq1QeQ7XvghiWC4CtV",
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal(domain.DefaultLocale, withoutLocale.Locale)
+		}
 	})
 
 	t.Run("it should failed and return ErrEmailUserTaken", func(t *testing.T) {
 		repo := memory.NewInMemoryRepository(nil)
-		useCase := application.NewCreateUserUseCase(repo)
+		useCase := application.NewCreateUserUseCase(repo, &webhookServiceSpy{})
 		ctx := context.Background()
 
 		repo.Store(
@@ -16,6 +16,7 @@ type UpdateUserInfoInput struct {
 	NewName   string
 	NewEmail  string
 	NewAvatar string
+	NewLocale string
 }
 
 func NewUpdateUserInfoUseCase(repo domain.Repository) *UpdateUserInfoUC {
@@ -43,6 +44,10 @@ func (useCase *UpdateUserInfoUC) Execute(ctx context.Context, input UpdateUserIn
 		user.Avatar = input.NewAvatar
 	}
 
+	if input.NewLocale != "" {
+		user.Locale = input.NewLocale
+	}
+
 	err = useCase.repo.Update(ctx, user)
 
 	if err != nil {
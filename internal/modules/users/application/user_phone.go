@@ -0,0 +1,33 @@
+package application
+
+import (
+	"comu/internal/modules/users/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SetUserVerifiedPhoneUC struct {
+	repo domain.Repository
+}
+
+func NewSetUserVerifiedPhoneUseCase(repo domain.Repository) *SetUserVerifiedPhoneUC {
+	return &SetUserVerifiedPhoneUC{
+		repo: repo,
+	}
+}
+
+// Execute replaces the user phone number with a number which was just verified.
+func (useCase *SetUserVerifiedPhoneUC) Execute(ctx context.Context, userID uuid.UUID, phone string) error {
+	user, err := useCase.repo.FindByID(ctx, userID)
+
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	user.Phone = phone
+	user.PhoneVerifiedAt = &now
+
+	return useCase.repo.Update(ctx, user)
+}
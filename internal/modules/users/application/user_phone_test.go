@@ -0,0 +1,38 @@
+package application_test
+
+import (
+	"comu/internal/modules/users/application"
+	"comu/internal/modules/users/domain"
+	"comu/internal/modules/users/infra/memory"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetUserVerifiedPhoneUseCase(t *testing.T) {
+
+	t.Run("it should replace the phone number with the verified one", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemoryRepository(nil)
+		ctx := context.Background()
+
+		user := domain.NewUser("John Doe", "johndoe@gmail.com", "secret#pass1234")
+		user.Phone = "+33601020304"
+		repo.Store(ctx, user)
+
+		_assert.NoError(application.NewSetUserVerifiedPhoneUseCase(repo).Execute(ctx, user.ID, "+22501020304"))
+
+		retrievedUser, _ := repo.FindByID(ctx, user.ID)
+		_assert.Equal("+22501020304", retrievedUser.Phone)
+		_assert.True(retrievedUser.PhoneIsVerified())
+	})
+
+	t.Run("it should fail and return ErrUserNotFound", func(t *testing.T) {
+		repo := memory.NewInMemoryRepository(nil)
+
+		err := application.NewSetUserVerifiedPhoneUseCase(repo).Execute(context.Background(), uuid.New(), "+22501020304")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
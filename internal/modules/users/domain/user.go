@@ -13,6 +13,8 @@ var (
 	ErrUserEmailTaken = errors.New("the provided email is already taken")
 )
 
+const DefaultLocale = "en"
+
 type User struct {
 	ID              uuid.UUID
 	Name            string
@@ -21,6 +23,9 @@ type User struct {
 	Avatar          string
 	Active          bool
 	Password        string
+	Locale          string
+	Phone           string
+	PhoneVerifiedAt *time.Time
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       *time.Time
@@ -41,6 +46,7 @@ func NewUser(name, email, password string) *User {
 		Avatar:          "",
 		Active:          true,
 		Password:        password,
+		Locale:          DefaultLocale,
 	}
 }
 
@@ -48,6 +54,10 @@ func (user *User) EmailIsVerified() bool {
 	return user.EmailVerifiedAt != nil
 }
 
+func (user *User) PhoneIsVerified() bool {
+	return user.Phone != "" && user.PhoneVerifiedAt != nil
+}
+
 type Repository interface {
 	FindByID(ctx context.Context, ID uuid.UUID) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
@@ -55,3 +65,9 @@ type Repository interface {
 	Update(context.Context, *User) error
 	Delete(context.Context, *User) error
 }
+
+// WebhookService tells the integrators webhooks about the new users. It is best
+// effort and never makes the registration fail.
+type WebhookService interface {
+	UserRegistered(ctx context.Context, user *User)
+}
@@ -30,10 +30,12 @@ func (repo *repository) findQuery(ctx context.Context, column, value string) (*d
 
 	query := fmt.Sprintf("SELECT * FROM users WHERE %s = %s", column, queryVal)
 	user := &domain.User{}
+	var phone sql.NullString
 
 	err := repo.db.QueryRowContext(ctx, query, value).Scan(
 		&user.ID, &user.Name, &user.Email, &user.EmailVerifiedAt, &user.Avatar,
 		&user.Active, &user.Password, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.Locale, &phone, &user.PhoneVerifiedAt,
 	)
 
 	if err != nil {
@@ -42,6 +44,7 @@ func (repo *repository) findQuery(ctx context.Context, column, value string) (*d
 		}
 		return nil, err
 	}
+	user.Phone = phone.String
 
 	return user, nil
 }
@@ -62,8 +65,9 @@ func (repo *repository) Store(ctx context.Context, user *domain.User) error {
 	query := `
 	INSERT INTO users (
 		id, name, email, email_verified_at, avatar, active,
-		password, created_at, updated_at, deleted_at
-	) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		password, created_at, updated_at, deleted_at, locale,
+		phone, phone_verified_at
+	) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	id, err := uuid.NewV7()
@@ -78,7 +82,8 @@ func (repo *repository) Store(ctx context.Context, user *domain.User) error {
 	_, err = repo.db.ExecContext(
 		ctx, query, user.ID, user.Name, user.Email, user.EmailVerifiedAt,
 		user.Avatar, user.Active, user.Password, user.CreatedAt,
-		user.UpdatedAt, user.DeletedAt,
+		user.UpdatedAt, user.DeletedAt, user.Locale,
+		nullablePhone(user.Phone), user.PhoneVerifiedAt,
 	)
 
 	return err
@@ -96,14 +101,15 @@ func (repo *repository) Update(ctx context.Context, user *domain.User) error {
 	}
 
 	query := `UPDATE users SET name = ?, email = ?, email_verified_at = ?,
-	avatar = ?, active = ?, password = ?, updated_at = ?,
-	deleted_at = ? WHERE id = UUID_TO_BIN(?)`
+	avatar = ?, active = ?, password = ?, locale = ?, phone = ?,
+	phone_verified_at = ?, updated_at = ?, deleted_at = ? WHERE id = UUID_TO_BIN(?)`
 
 	user.UpdatedAt = time.Now()
 
 	_, err = repo.db.ExecContext(
 		ctx, query, user.Name, user.Email, user.EmailVerifiedAt,
-		user.Avatar, user.Active, user.Password,
+		user.Avatar, user.Active, user.Password, user.Locale,
+		nullablePhone(user.Phone), user.PhoneVerifiedAt,
 		user.UpdatedAt, user.DeletedAt, user.ID,
 	)
 
@@ -129,3 +135,8 @@ func (repo *repository) emailIsTaken(ctx context.Context, email string) bool {
 
 	return user != nil
 }
+
+// nullablePhone stores the users without phone number as NULL.
+func nullablePhone(phone string) sql.NullString {
+	return sql.NullString{String: phone, Valid: phone != ""}
+}
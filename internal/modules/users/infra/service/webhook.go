@@ -0,0 +1,48 @@
+package service
+
+import (
+	"comu/internal/modules/users/domain"
+	"comu/internal/modules/webhooks"
+	"comu/internal/shared/logger"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// registeredUser is what the webhooks are told about the new users, whose
+// credentials and contact details are kept out of it.
+type registeredUser struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Locale    string    `json:"locale"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type webhookService struct {
+	api    webhooks.PublicApi
+	logger *logger.Log
+}
+
+func NewWebhookService(api webhooks.PublicApi, logger *logger.Log) *webhookService {
+	return &webhookService{
+		api:    api,
+		logger: logger,
+	}
+}
+
+func (service *webhookService) UserRegistered(ctx context.Context, user *domain.User) {
+	err := service.api.Dispatch(ctx, webhooks.DispatchRequest{
+		Event: webhooks.UserRegisteredEvent,
+		Data: registeredUser{
+			ID:        user.ID,
+			Name:      user.Name,
+			Locale:    user.Locale,
+			CreatedAt: user.CreatedAt,
+		},
+	})
+
+	if err != nil {
+		service.logger.Error.Println(err)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"comu/internal/modules/users/application"
 	"comu/internal/modules/users/domain"
 	"comu/internal/modules/users/infra/mysql"
+	"comu/internal/modules/users/infra/service"
+	"comu/internal/modules/webhooks"
+	"comu/internal/shared/logger"
 	"context"
 	"database/sql"
 
@@ -21,25 +24,30 @@ type PublicApi interface {
 	GetUserByEmail(context.Context, string) (*GetUserResponse, error)
 	MarkEmailAsVerified(context.Context, string) error
 	UpdateUserPassword(context.Context, UpdateUserPasswordRequest) error
+	// SetVerifiedPhone replaces the user phone number with a number which was just verified.
+	SetVerifiedPhone(ctx context.Context, ID uuid.UUID, phone string) error
 }
 
 type UserModule struct {
 	api PublicApi
 }
 
-func NewModule(db *sql.DB) *UserModule {
+func NewModule(db *sql.DB, webhooksApi webhooks.PublicApi, logger *logger.Log) *UserModule {
 	repo := mysql.NewRepository(db)
+	webhookService := service.NewWebhookService(webhooksApi, logger)
 
 	//usecases
-	createUserUC := application.NewCreateUserUseCase(repo)
+	createUserUC := application.NewCreateUserUseCase(repo, webhookService)
 	getUserByIdUC := application.NewGetUserByIdUseCase(repo)
 	getUserByEmailUC := application.NewGetUserByEmailUseCase(repo)
 	updateUserPasswordUC := application.NewUpdateUserPasswordUseCase(repo)
 	markUserEmailAsVerifiedUC := application.NewMarkUserEmailAsVerifiedUseCase(repo)
+	setUserVerifiedPhoneUC := application.NewSetUserVerifiedPhoneUseCase(repo)
 
 	api := newApi(
 		createUserUC, getUserByIdUC, getUserByEmailUC,
 		updateUserPasswordUC, markUserEmailAsVerifiedUC,
+		setUserVerifiedPhoneUC,
 	)
 
 	return &UserModule{
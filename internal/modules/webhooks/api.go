@@ -0,0 +1,27 @@
+package webhooks
+
+import (
+	"comu/internal/modules/webhooks/application/deliveries"
+	"context"
+)
+
+// DispatchRequest is an event sent to the webhooks subscribing to it. Its data
+// is sent as JSON.
+type DispatchRequest struct {
+	Event string
+	Data  any
+}
+
+type publicApi struct {
+	dispatchEventUC *deliveries.DispatchEventUC
+}
+
+func newApi(dispatchEventUC *deliveries.DispatchEventUC) *publicApi {
+	return &publicApi{
+		dispatchEventUC: dispatchEventUC,
+	}
+}
+
+func (api *publicApi) Dispatch(ctx context.Context, req DispatchRequest) error {
+	return api.dispatchEventUC.Execute(ctx, req.Event, req.Data)
+}
@@ -0,0 +1,42 @@
+package application
+
+import (
+	"comu/internal/modules/webhooks/application/deliveries"
+	"comu/internal/modules/webhooks/application/subscriptions"
+	"comu/internal/modules/webhooks/domain"
+)
+
+type UseCases struct {
+	CreateSubscriptionUC *subscriptions.CreateSubscriptionUC
+	ListSubscriptionsUC  *subscriptions.ListSubscriptionsUC
+	UpdateSubscriptionUC *subscriptions.UpdateSubscriptionUC
+	DeleteSubscriptionUC *subscriptions.DeleteSubscriptionUC
+
+	DispatchEventUC  *deliveries.DispatchEventUC
+	DeliverDueUC     *deliveries.DeliverDueUC
+	ListDeliveriesUC *deliveries.ListDeliveriesUC
+	RedeliverUC      *deliveries.RedeliverUC
+}
+
+func InitUseCases(
+	subscriptionsRepository domain.SubscriptionsRepository,
+	deliveriesRepository domain.DeliveriesRepository,
+	client domain.Client,
+	urlGuard domain.URLGuard,
+	transactor domain.Transactor,
+	deliverOptions deliveries.DeliverOptions,
+) UseCases {
+	return UseCases{
+		CreateSubscriptionUC: subscriptions.NewCreateSubscriptionUseCase(subscriptionsRepository, urlGuard),
+		ListSubscriptionsUC:  subscriptions.NewListSubscriptionsUseCase(subscriptionsRepository),
+		UpdateSubscriptionUC: subscriptions.NewUpdateSubscriptionUseCase(subscriptionsRepository, urlGuard),
+		DeleteSubscriptionUC: subscriptions.NewDeleteSubscriptionUseCase(subscriptionsRepository),
+
+		DispatchEventUC: deliveries.NewDispatchEventUseCase(subscriptionsRepository, deliveriesRepository, transactor),
+		DeliverDueUC: deliveries.NewDeliverDueUseCase(
+			subscriptionsRepository, deliveriesRepository, client, deliverOptions,
+		),
+		ListDeliveriesUC: deliveries.NewListDeliveriesUseCase(subscriptionsRepository, deliveriesRepository),
+		RedeliverUC:      deliveries.NewRedeliverUseCase(subscriptionsRepository, deliveriesRepository),
+	}
+}
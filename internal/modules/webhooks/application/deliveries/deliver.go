@@ -0,0 +1,96 @@
+package deliveries
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"errors"
+	"time"
+)
+
+type DeliverOptions struct {
+	BatchSize   int
+	Lease       time.Duration
+	MaxAttempts int
+	Backoff     domain.Backoff
+}
+
+func DefaultDeliverOptions() DeliverOptions {
+	return DeliverOptions{
+		BatchSize:   20,
+		Lease:       domain.DeliveryLease,
+		MaxAttempts: domain.DefaultMaxAttempts,
+		Backoff: domain.Backoff{
+			Base: domain.DefaultBaseBackoff,
+			Max:  domain.DefaultMaxBackoff,
+		},
+	}
+}
+
+type DeliverDueUC struct {
+	subscriptionsRepo domain.SubscriptionsRepository
+	deliveriesRepo    domain.DeliveriesRepository
+	client            domain.Client
+	options           DeliverOptions
+}
+
+func NewDeliverDueUseCase(
+	subscriptionsRepository domain.SubscriptionsRepository,
+	deliveriesRepository domain.DeliveriesRepository,
+	client domain.Client,
+	options DeliverOptions,
+) *DeliverDueUC {
+	return &DeliverDueUC{
+		subscriptionsRepo: subscriptionsRepository,
+		deliveriesRepo:    deliveriesRepository,
+		client:            client,
+		options:           options,
+	}
+}
+
+// Execute sends the deliveries which are due, batch after batch, and returns how
+// many of them were attempted. The failed ones are tried again after a backoff.
+func (useCase *DeliverDueUC) Execute(ctx context.Context) (int, error) {
+	attempted := 0
+
+	for {
+		claimed, err := useCase.deliveriesRepo.Claim(ctx, useCase.options.BatchSize, useCase.options.Lease)
+
+		if err != nil {
+			return attempted, err
+		}
+
+		for _, delivery := range claimed {
+			if err := useCase.deliver(ctx, &delivery); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+
+		if len(claimed) < useCase.options.BatchSize {
+			return attempted, nil
+		}
+	}
+}
+
+func (useCase *DeliverDueUC) deliver(ctx context.Context, delivery *domain.Delivery) error {
+	subscription, err := useCase.subscriptionsRepo.Find(ctx, delivery.SubscriptionID)
+
+	if err != nil && !errors.Is(err, domain.ErrSubscriptionNotFound) {
+		return err
+	}
+
+	// The deliveries left to a removed or disabled webhook are given up on.
+	if subscription == nil || !subscription.Active {
+		delivery.GiveUp(domain.ErrRemovedSubscription)
+		return useCase.deliveriesRepo.Update(ctx, delivery)
+	}
+	responseCode, sendErr := useCase.client.Send(ctx, subscription, delivery)
+
+	if sendErr == nil {
+		delivery.MarkDelivered(responseCode)
+	} else {
+		delivery.MarkAttemptFailed(responseCode, sendErr, useCase.options.MaxAttempts, useCase.options.Backoff)
+	}
+
+	return useCase.deliveriesRepo.Update(ctx, delivery)
+}
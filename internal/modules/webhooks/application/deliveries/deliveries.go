@@ -0,0 +1,75 @@
+package deliveries
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type ListDeliveriesUC struct {
+	subscriptionsRepo domain.SubscriptionsRepository
+	deliveriesRepo    domain.DeliveriesRepository
+}
+
+type RedeliverUC struct {
+	subscriptionsRepo domain.SubscriptionsRepository
+	deliveriesRepo    domain.DeliveriesRepository
+}
+
+func NewListDeliveriesUseCase(
+	subscriptionsRepository domain.SubscriptionsRepository,
+	deliveriesRepository domain.DeliveriesRepository,
+) *ListDeliveriesUC {
+	return &ListDeliveriesUC{
+		subscriptionsRepo: subscriptionsRepository,
+		deliveriesRepo:    deliveriesRepository,
+	}
+}
+
+func NewRedeliverUseCase(
+	subscriptionsRepository domain.SubscriptionsRepository,
+	deliveriesRepository domain.DeliveriesRepository,
+) *RedeliverUC {
+	return &RedeliverUC{
+		subscriptionsRepo: subscriptionsRepository,
+		deliveriesRepo:    deliveriesRepository,
+	}
+}
+
+// Execute returns the delivery log of the webhook, latest deliveries first.
+func (useCase *ListDeliveriesUC) Execute(ctx context.Context, actor domain.Actor, subscriptionID uuid.UUID) ([]domain.Delivery, error) {
+	subscription, err := domain.FindManageableSubscription(ctx, useCase.subscriptionsRepo, actor, subscriptionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return useCase.deliveriesRepo.ListBySubscription(ctx, subscription.ID, domain.DefaultDeliveriesLimit)
+}
+
+// Execute queues the payload of a past delivery again, as a new delivery which
+// gets its own attempts and log entry.
+func (useCase *RedeliverUC) Execute(ctx context.Context, actor domain.Actor, deliveryID uuid.UUID) (*domain.Delivery, error) {
+	delivery, err := useCase.deliveriesRepo.Find(ctx, deliveryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = domain.FindManageableSubscription(ctx, useCase.subscriptionsRepo, actor, delivery.SubscriptionID); err != nil {
+		if errors.Is(err, domain.ErrSubscriptionNotFound) {
+			return nil, domain.ErrDeliveryNotFound
+		}
+
+		return nil, err
+	}
+	redelivery := delivery.Redeliver()
+
+	if err = useCase.deliveriesRepo.Store(ctx, redelivery); err != nil {
+		return nil, err
+	}
+
+	return redelivery, nil
+}
@@ -0,0 +1,322 @@
+package deliveries
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"comu/internal/modules/webhooks/infra/memory"
+	"comu/internal/modules/webhooks/infra/service"
+	"comu/internal/shared/database"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// receiver is a webhook endpoint answering with the given status codes in turn,
+// the last one once they are exhausted.
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		status := r.statuses[min(len(r.requests), len(r.statuses)-1)]
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		r.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+// testClientOptions lets the client reach the local receivers.
+func testClientOptions() service.ClientOptions {
+	options := service.DefaultClientOptions()
+	options.AllowInternalAddresses = true
+
+	return options
+}
+
+func testOptions() DeliverOptions {
+	options := DefaultDeliverOptions()
+	options.MaxAttempts = 3
+	options.Backoff = domain.Backoff{Base: time.Minute, Max: time.Hour}
+
+	return options
+}
+
+func subscribe(t *testing.T, repo domain.SubscriptionsRepository, url string, events ...domain.Event) *domain.Subscription {
+	subscription, err := domain.NewSubscription(uuid.New(), url, events)
+	assert.NoError(t, err)
+	repo.Store(context.Background(), subscription)
+
+	return subscription
+}
+
+// makeDue lets the delivery be attempted again right away.
+func makeDue(repo domain.DeliveriesRepository, delivery *domain.Delivery) {
+	delivery.NextAttemptAt = time.Now()
+	repo.Update(context.Background(), delivery)
+}
+
+func TestDispatchEventUseCase(t *testing.T) {
+
+	t.Run("it should queue a delivery to every active webhook subscribing to the event", func(t *testing.T) {
+		_assert := assert.New(t)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		useCase := NewDispatchEventUseCase(subscriptionsRepo, deliveriesRepo, database.NoopTransactor{})
+		ctx := context.Background()
+
+		posts := subscribe(t, subscriptionsRepo, "https://example.com/posts", domain.PostCreated)
+		users := subscribe(t, subscriptionsRepo, "https://example.com/users", domain.UserRegistered)
+
+		err := useCase.Execute(ctx, domain.PostCreated, map[string]string{"title": "Hello"})
+
+		if _assert.NoError(err) {
+			deliveries, _ := deliveriesRepo.ListBySubscription(ctx, posts.ID, 10)
+
+			if _assert.Len(deliveries, 1) {
+				var payload map[string]any
+				json.Unmarshal(deliveries[0].Payload, &payload)
+
+				_assert.Equal(domain.DeliveryPending, deliveries[0].Status)
+				_assert.Equal(domain.PostCreated, payload["event"])
+				_assert.Equal(map[string]any{"title": "Hello"}, payload["data"])
+			}
+
+			deliveries, _ = deliveriesRepo.ListBySubscription(ctx, users.ID, 10)
+			_assert.Empty(deliveries)
+		}
+	})
+
+	t.Run("it should fail and return ErrUnknownEvent", func(t *testing.T) {
+		useCase := NewDispatchEventUseCase(
+			memory.NewInMemorySubscriptionsRepository(nil),
+			memory.NewInMemoryDeliveriesRepository(nil),
+			database.NoopTransactor{},
+		)
+
+		err := useCase.Execute(context.Background(), "post.liked", nil)
+		assert.ErrorIs(t, err, domain.ErrUnknownEvent)
+	})
+}
+
+func TestDeliverDueUseCase(t *testing.T) {
+
+	t.Run("it should post the signed payload to the webhook", func(t *testing.T) {
+		_assert := assert.New(t)
+		webhook := newReceiver(t, http.StatusNoContent)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, webhook.URL, domain.CommentCreated)
+		NewDispatchEventUseCase(subscriptionsRepo, deliveriesRepo, database.NoopTransactor{}).
+			Execute(ctx, domain.CommentCreated, map[string]string{"content": "Nice post"})
+
+		useCase := NewDeliverDueUseCase(subscriptionsRepo, deliveriesRepo, service.NewHttpClient(testClientOptions()), testOptions())
+		attempted, err := useCase.Execute(ctx)
+
+		if _assert.NoError(err) && _assert.Equal(1, attempted) && _assert.Len(webhook.requests, 1) {
+			request, body := webhook.requests[0], webhook.bodies[0]
+			unix, _ := strconv.ParseInt(request.Header.Get(domain.TimestampHeader), 10, 64)
+
+			_assert.Equal(domain.CommentCreated, request.Header.Get(domain.EventHeader))
+			_assert.Equal(subscription.Sign(time.Unix(unix, 0), body), request.Header.Get(domain.SignatureHeader))
+
+			deliveries, _ := deliveriesRepo.ListBySubscription(ctx, subscription.ID, 10)
+			_assert.Equal(request.Header.Get(domain.DeliveryHeader), deliveries[0].ID.String())
+			_assert.Equal(domain.DeliveryDelivered, deliveries[0].Status)
+			_assert.Equal(http.StatusNoContent, deliveries[0].ResponseCode)
+			_assert.NotNil(deliveries[0].DeliveredAt)
+		}
+	})
+
+	t.Run("it should refuse to reach an internal address", func(t *testing.T) {
+		_assert := assert.New(t)
+		webhook := newReceiver(t, http.StatusOK)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		client := service.NewHttpClient(service.DefaultClientOptions())
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, webhook.URL, domain.PostCreated)
+		delivery := domain.NewDelivery(subscription.ID, domain.PostCreated, []byte(`{}`))
+		deliveriesRepo.Store(ctx, delivery)
+
+		NewDeliverDueUseCase(subscriptionsRepo, deliveriesRepo, client, testOptions()).Execute(ctx)
+		failed, _ := deliveriesRepo.Find(ctx, delivery.ID)
+
+		_assert.Empty(webhook.requests)
+		_assert.Equal(0, failed.ResponseCode)
+		_assert.Contains(failed.LastError, domain.ErrInternalURL.Error())
+	})
+
+	t.Run("it should not follow the redirects", func(t *testing.T) {
+		_assert := assert.New(t)
+		webhook := newReceiver(t, http.StatusTemporaryRedirect)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, webhook.URL, domain.PostCreated)
+		delivery := domain.NewDelivery(subscription.ID, domain.PostCreated, []byte(`{}`))
+		deliveriesRepo.Store(ctx, delivery)
+
+		NewDeliverDueUseCase(
+			subscriptionsRepo, deliveriesRepo, service.NewHttpClient(testClientOptions()), testOptions(),
+		).Execute(ctx)
+		failed, _ := deliveriesRepo.Find(ctx, delivery.ID)
+
+		_assert.Len(webhook.requests, 1)
+		_assert.Equal(http.StatusTemporaryRedirect, failed.ResponseCode)
+	})
+
+	t.Run("it should retry with a backoff until the webhook answers successfully", func(t *testing.T) {
+		_assert := assert.New(t)
+		webhook := newReceiver(t, http.StatusInternalServerError, http.StatusOK)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		useCase := NewDeliverDueUseCase(subscriptionsRepo, deliveriesRepo, service.NewHttpClient(testClientOptions()), testOptions())
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, webhook.URL, domain.PostCreated)
+		delivery := domain.NewDelivery(subscription.ID, domain.PostCreated, []byte(`{}`))
+		deliveriesRepo.Store(ctx, delivery)
+
+		useCase.Execute(ctx)
+		failed, _ := deliveriesRepo.Find(ctx, delivery.ID)
+
+		_assert.Equal(domain.DeliveryPending, failed.Status)
+		_assert.Equal(1, failed.Attempts)
+		_assert.Equal(http.StatusInternalServerError, failed.ResponseCode)
+		_assert.NotEmpty(failed.LastError)
+		_assert.WithinDuration(time.Now().Add(time.Minute), failed.NextAttemptAt, time.Second*5)
+
+		// The delivery isn't attempted again before the backoff ends.
+		attempted, _ := useCase.Execute(ctx)
+		_assert.Zero(attempted)
+
+		makeDue(deliveriesRepo, failed)
+		useCase.Execute(ctx)
+		delivered, _ := deliveriesRepo.Find(ctx, delivery.ID)
+
+		_assert.Equal(domain.DeliveryDelivered, delivered.Status)
+		_assert.Equal(2, delivered.Attempts)
+		_assert.Equal(http.StatusOK, delivered.ResponseCode)
+		_assert.Empty(delivered.LastError)
+	})
+
+	t.Run("it should give up after the last attempt", func(t *testing.T) {
+		_assert := assert.New(t)
+		webhook := newReceiver(t, http.StatusBadGateway)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		useCase := NewDeliverDueUseCase(subscriptionsRepo, deliveriesRepo, service.NewHttpClient(testClientOptions()), testOptions())
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, webhook.URL, domain.PostCreated)
+		delivery := domain.NewDelivery(subscription.ID, domain.PostCreated, []byte(`{}`))
+		deliveriesRepo.Store(ctx, delivery)
+
+		for range testOptions().MaxAttempts {
+			useCase.Execute(ctx)
+			delivery, _ = deliveriesRepo.Find(ctx, delivery.ID)
+			makeDue(deliveriesRepo, delivery)
+		}
+		delivery, _ = deliveriesRepo.Find(ctx, delivery.ID)
+
+		_assert.Equal(domain.DeliveryFailed, delivery.Status)
+		_assert.Equal(testOptions().MaxAttempts, delivery.Attempts)
+		_assert.Len(webhook.requests, testOptions().MaxAttempts)
+
+		attempted, _ := useCase.Execute(ctx)
+		_assert.Zero(attempted)
+	})
+
+	t.Run("it should give up on the deliveries to a disabled webhook", func(t *testing.T) {
+		_assert := assert.New(t)
+		webhook := newReceiver(t, http.StatusOK)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		useCase := NewDeliverDueUseCase(subscriptionsRepo, deliveriesRepo, service.NewHttpClient(testClientOptions()), testOptions())
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, webhook.URL, domain.PostCreated)
+		delivery := domain.NewDelivery(subscription.ID, domain.PostCreated, []byte(`{}`))
+		deliveriesRepo.Store(ctx, delivery)
+		subscription.Active = false
+		subscriptionsRepo.Update(ctx, subscription)
+
+		useCase.Execute(ctx)
+		delivery, _ = deliveriesRepo.Find(ctx, delivery.ID)
+
+		_assert.Equal(domain.DeliveryFailed, delivery.Status)
+		_assert.Empty(webhook.requests)
+	})
+}
+
+func TestRedeliverUseCase(t *testing.T) {
+
+	t.Run("it should queue the payload again as a new delivery", func(t *testing.T) {
+		_assert := assert.New(t)
+		webhook := newReceiver(t, http.StatusOK)
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, webhook.URL, domain.PostDeleted)
+		delivery := domain.NewDelivery(subscription.ID, domain.PostDeleted, []byte(`{"id":"1"}`))
+		delivery.GiveUp(domain.ErrRemovedSubscription)
+		deliveriesRepo.Store(ctx, delivery)
+		actor := domain.Actor{UserID: subscription.UserID}
+
+		redelivery, err := NewRedeliverUseCase(subscriptionsRepo, deliveriesRepo).Execute(ctx, actor, delivery.ID)
+
+		if _assert.NoError(err) {
+			_assert.NotEqual(delivery.ID, redelivery.ID)
+			_assert.Equal(domain.DeliveryPending, redelivery.Status)
+
+			NewDeliverDueUseCase(subscriptionsRepo, deliveriesRepo, service.NewHttpClient(testClientOptions()), testOptions()).Execute(ctx)
+
+			if _assert.Len(webhook.bodies, 1) {
+				_assert.JSONEq(`{"id":"1"}`, string(webhook.bodies[0]))
+			}
+
+			deliveries, _ := NewListDeliveriesUseCase(subscriptionsRepo, deliveriesRepo).Execute(ctx, actor, subscription.ID)
+			_assert.Len(deliveries, 2)
+		}
+	})
+
+	t.Run("it should fail and return ErrDeliveryNotFound for the others' deliveries", func(t *testing.T) {
+		subscriptionsRepo := memory.NewInMemorySubscriptionsRepository(nil)
+		deliveriesRepo := memory.NewInMemoryDeliveriesRepository(nil)
+		ctx := context.Background()
+
+		subscription := subscribe(t, subscriptionsRepo, "https://example.com/hook", domain.PostDeleted)
+		delivery := domain.NewDelivery(subscription.ID, domain.PostDeleted, []byte(`{}`))
+		deliveriesRepo.Store(ctx, delivery)
+
+		_, err := NewRedeliverUseCase(subscriptionsRepo, deliveriesRepo).Execute(ctx, domain.Actor{UserID: uuid.New()}, delivery.ID)
+		assert.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+	})
+}
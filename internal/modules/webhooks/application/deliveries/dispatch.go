@@ -0,0 +1,56 @@
+package deliveries
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"slices"
+)
+
+type DispatchEventUC struct {
+	subscriptionsRepo domain.SubscriptionsRepository
+	deliveriesRepo    domain.DeliveriesRepository
+	transactor        domain.Transactor
+}
+
+func NewDispatchEventUseCase(
+	subscriptionsRepository domain.SubscriptionsRepository,
+	deliveriesRepository domain.DeliveriesRepository,
+	transactor domain.Transactor,
+) *DispatchEventUC {
+	return &DispatchEventUC{
+		subscriptionsRepo: subscriptionsRepository,
+		deliveriesRepo:    deliveriesRepository,
+		transactor:        transactor,
+	}
+}
+
+// Execute queues a delivery of the event to every webhook subscribing to it. The
+// deliveries are sent in the background, so that the webhooks don't slow the action
+// the event is about down.
+func (useCase *DispatchEventUC) Execute(ctx context.Context, event domain.Event, data any) error {
+	if !slices.Contains(domain.Events, event) {
+		return domain.ErrUnknownEvent
+	}
+	subscriptions, err := useCase.subscriptionsRepo.ListByEvent(ctx, event)
+
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+	payload, err := domain.NewPayload(event, data)
+
+	if err != nil {
+		return err
+	}
+
+	return useCase.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, subscription := range subscriptions {
+			delivery := domain.NewDelivery(subscription.ID, event, payload)
+
+			if err := useCase.deliveriesRepo.Store(ctx, delivery); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
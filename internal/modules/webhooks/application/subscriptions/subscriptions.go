@@ -0,0 +1,136 @@
+package subscriptions
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateSubscriptionInput struct {
+	UserID uuid.UUID
+	URL    string
+	Events []domain.Event
+}
+
+type UpdateSubscriptionInput struct {
+	Actor          domain.Actor
+	SubscriptionID uuid.UUID
+	URL            string
+	Events         []domain.Event
+	Active         bool
+}
+
+type CreateSubscriptionUC struct {
+	repo     domain.SubscriptionsRepository
+	urlGuard domain.URLGuard
+}
+
+type ListSubscriptionsUC struct {
+	repo domain.SubscriptionsRepository
+}
+
+type UpdateSubscriptionUC struct {
+	repo     domain.SubscriptionsRepository
+	urlGuard domain.URLGuard
+}
+
+type DeleteSubscriptionUC struct {
+	repo domain.SubscriptionsRepository
+}
+
+func NewCreateSubscriptionUseCase(repository domain.SubscriptionsRepository, urlGuard domain.URLGuard) *CreateSubscriptionUC {
+	return &CreateSubscriptionUC{
+		repo:     repository,
+		urlGuard: urlGuard,
+	}
+}
+
+func NewListSubscriptionsUseCase(repository domain.SubscriptionsRepository) *ListSubscriptionsUC {
+	return &ListSubscriptionsUC{
+		repo: repository,
+	}
+}
+
+func NewUpdateSubscriptionUseCase(repository domain.SubscriptionsRepository, urlGuard domain.URLGuard) *UpdateSubscriptionUC {
+	return &UpdateSubscriptionUC{
+		repo:     repository,
+		urlGuard: urlGuard,
+	}
+}
+
+func NewDeleteSubscriptionUseCase(repository domain.SubscriptionsRepository) *DeleteSubscriptionUC {
+	return &DeleteSubscriptionUC{
+		repo: repository,
+	}
+}
+
+// Execute returns the created webhook, whose secret can't be read afterwards.
+func (useCase *CreateSubscriptionUC) Execute(ctx context.Context, input CreateSubscriptionInput) (*domain.Subscription, error) {
+	subscription, err := domain.NewSubscription(input.UserID, input.URL, input.Events)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = useCase.urlGuard.Check(ctx, subscription.URL); err != nil {
+		return nil, err
+	}
+
+	if err = useCase.repo.Store(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Execute returns the webhooks of the actor, or every webhook for the admins.
+func (useCase *ListSubscriptionsUC) Execute(ctx context.Context, actor domain.Actor) ([]domain.Subscription, error) {
+	if actor.IsAdmin {
+		return useCase.repo.ListAll(ctx)
+	}
+
+	return useCase.repo.ListByUser(ctx, actor.UserID)
+}
+
+func (useCase *UpdateSubscriptionUC) Execute(ctx context.Context, input UpdateSubscriptionInput) (*domain.Subscription, error) {
+	subscription, err := domain.FindManageableSubscription(ctx, useCase.repo, input.Actor, input.SubscriptionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.ValidateURL(input.URL); err != nil {
+		return nil, err
+	}
+
+	if err = useCase.urlGuard.Check(ctx, input.URL); err != nil {
+		return nil, err
+	}
+
+	if err = domain.ValidateEvents(input.Events); err != nil {
+		return nil, err
+	}
+	subscription.URL = input.URL
+	subscription.Events = slices.Compact(slices.Sorted(slices.Values(input.Events)))
+	subscription.Active = input.Active
+	subscription.UpdatedAt = time.Now()
+
+	if err = useCase.repo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (useCase *DeleteSubscriptionUC) Execute(ctx context.Context, actor domain.Actor, subscriptionID uuid.UUID) error {
+	subscription, err := domain.FindManageableSubscription(ctx, useCase.repo, actor, subscriptionID)
+
+	if err != nil {
+		return err
+	}
+
+	return useCase.repo.Delete(ctx, subscription.ID)
+}
@@ -0,0 +1,171 @@
+package subscriptions
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"comu/internal/modules/webhooks/infra/memory"
+	"comu/internal/modules/webhooks/infra/service"
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// resolver resolves the test hosts without a network, to a public or an internal address.
+type resolver map[string]string
+
+func (r resolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addr, err := netip.ParseAddr(r[host])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []netip.Addr{addr}, nil
+}
+
+func testURLGuard() domain.URLGuard {
+	return service.NewURLGuard(resolver{"example.com": "93.184.215.14", "internal.example.com": "10.0.0.1"})
+}
+
+func TestCreateSubscriptionUseCase(t *testing.T) {
+
+	t.Run("it should create an active webhook with a secret", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemorySubscriptionsRepository(nil)
+		useCase := NewCreateSubscriptionUseCase(repo, testURLGuard())
+		userID := uuid.New()
+
+		subscription, err := useCase.Execute(context.Background(), CreateSubscriptionInput{
+			UserID: userID,
+			URL:    "https://example.com/hook",
+			Events: []domain.Event{domain.PostUpdated, domain.PostCreated, domain.PostCreated},
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal(userID, subscription.UserID)
+			_assert.True(subscription.Active)
+			_assert.Len(subscription.Secret, 64)
+			_assert.Equal([]domain.Event{domain.PostCreated, domain.PostUpdated}, subscription.Events)
+
+			_, err = repo.Find(context.Background(), subscription.ID)
+			_assert.NoError(err)
+		}
+	})
+
+	t.Run("it should fail with an invalid url or event", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase := NewCreateSubscriptionUseCase(memory.NewInMemorySubscriptionsRepository(nil), testURLGuard())
+		ctx := context.Background()
+
+		_, err := useCase.Execute(ctx, CreateSubscriptionInput{
+			UserID: uuid.New(), URL: "ftp://example.com", Events: []domain.Event{domain.PostCreated},
+		})
+		_assert.ErrorIs(err, domain.ErrInvalidURL)
+
+		_, err = useCase.Execute(ctx, CreateSubscriptionInput{
+			UserID: uuid.New(), URL: "https://example.com/hook", Events: []domain.Event{"post.liked"},
+		})
+		_assert.ErrorIs(err, domain.ErrUnknownEvent)
+
+		_, err = useCase.Execute(ctx, CreateSubscriptionInput{UserID: uuid.New(), URL: "https://example.com/hook"})
+		_assert.ErrorIs(err, domain.ErrNoEvents)
+	})
+
+	t.Run("it should refuse the urls pointing to an internal address", func(t *testing.T) {
+		_assert := assert.New(t)
+		useCase := NewCreateSubscriptionUseCase(memory.NewInMemorySubscriptionsRepository(nil), testURLGuard())
+		ctx := context.Background()
+
+		for _, url := range []string{
+			"http://127.0.0.1:8080/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]/hook",
+			"http://0.0.0.0/hook",
+			"https://internal.example.com/hook",
+		} {
+			_, err := useCase.Execute(ctx, CreateSubscriptionInput{
+				UserID: uuid.New(), URL: url, Events: []domain.Event{domain.PostCreated},
+			})
+			_assert.ErrorIs(err, domain.ErrInternalURL, url)
+		}
+
+		_, err := useCase.Execute(ctx, CreateSubscriptionInput{
+			UserID: uuid.New(), URL: "https://unknown.example.com/hook", Events: []domain.Event{domain.PostCreated},
+		})
+		_assert.ErrorIs(err, domain.ErrInvalidURL)
+	})
+}
+
+func TestManageSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	newSubscription := func(repo domain.SubscriptionsRepository, userID uuid.UUID) *domain.Subscription {
+		subscription, _ := domain.NewSubscription(userID, "https://example.com/hook", []domain.Event{domain.PostCreated})
+		repo.Store(ctx, subscription)
+
+		return subscription
+	}
+
+	t.Run("it should list the user webhooks, or all of them for the admins", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemorySubscriptionsRepository(nil)
+		useCase := NewListSubscriptionsUseCase(repo)
+		userID, adminID := uuid.New(), uuid.New()
+		admins := domain.Admins{adminID}
+
+		mine := newSubscription(repo, userID)
+		newSubscription(repo, uuid.New())
+
+		subscriptions, err := useCase.Execute(ctx, admins.Actor(userID))
+
+		if _assert.NoError(err) && _assert.Len(subscriptions, 1) {
+			_assert.Equal(mine.ID, subscriptions[0].ID)
+		}
+
+		subscriptions, _ = useCase.Execute(ctx, admins.Actor(adminID))
+		_assert.Len(subscriptions, 2)
+	})
+
+	t.Run("it should update the webhook of its owner", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemorySubscriptionsRepository(nil)
+		useCase := NewUpdateSubscriptionUseCase(repo, testURLGuard())
+		userID := uuid.New()
+		subscription := newSubscription(repo, userID)
+
+		updated, err := useCase.Execute(ctx, UpdateSubscriptionInput{
+			Actor:          domain.Actor{UserID: userID},
+			SubscriptionID: subscription.ID,
+			URL:            "https://example.com/other",
+			Events:         []domain.Event{domain.CommentCreated},
+			Active:         false,
+		})
+
+		if _assert.NoError(err) {
+			_assert.Equal("https://example.com/other", updated.URL)
+			_assert.Equal([]domain.Event{domain.CommentCreated}, updated.Events)
+			_assert.False(updated.Active)
+			_assert.Equal(subscription.Secret, updated.Secret)
+		}
+	})
+
+	t.Run("it should hide the others' webhooks from the users but not from the admins", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := memory.NewInMemorySubscriptionsRepository(nil)
+		useCase := NewDeleteSubscriptionUseCase(repo)
+		adminID := uuid.New()
+		admins := domain.Admins{adminID}
+		subscription := newSubscription(repo, uuid.New())
+
+		err := useCase.Execute(ctx, admins.Actor(uuid.New()), subscription.ID)
+		_assert.ErrorIs(err, domain.ErrSubscriptionNotFound)
+
+		err = useCase.Execute(ctx, admins.Actor(adminID), subscription.ID)
+
+		if _assert.NoError(err) {
+			_, err = repo.Find(ctx, subscription.ID)
+			_assert.ErrorIs(err, domain.ErrSubscriptionNotFound)
+		}
+	})
+}
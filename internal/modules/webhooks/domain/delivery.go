@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryStatus = string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed is the status of the deliveries which failed too many times.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+const (
+	DefaultMaxAttempts = 8
+	DefaultBaseBackoff = time.Second * 30
+	DefaultMaxBackoff  = time.Hour
+	// DeliveryInterval is how often the due deliveries are looked for.
+	DeliveryInterval = time.Second * 5
+	DeliveryLease    = time.Minute
+
+	DefaultDeliveriesLimit = 50
+	maxErrorLength         = 1024
+)
+
+var ErrRemovedSubscription = errors.New("the webhook was removed or disabled")
+
+// Delivery is an event posted to a webhook. It keeps the outcome of its last
+// attempt, which makes up the delivery log of the webhook.
+type Delivery struct {
+	ID             uuid.UUID       `json:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	Event          Event           `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	// ResponseCode is the status code of the last response, 0 when none was received.
+	ResponseCode  int        `json:"response_code"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeliveredAt   *time.Time `json:"delivered_at"`
+}
+
+func NewDelivery(subscriptionID uuid.UUID, event Event, payload []byte) *Delivery {
+	return &Delivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		Event:          event,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  time.Now(),
+		CreatedAt:      time.Now(),
+	}
+}
+
+// Redeliver returns a new delivery of the same payload to the same webhook.
+func (delivery *Delivery) Redeliver() *Delivery {
+	return NewDelivery(delivery.SubscriptionID, delivery.Event, delivery.Payload)
+}
+
+func (delivery *Delivery) MarkDelivered(responseCode int) {
+	now := time.Now()
+
+	delivery.Attempts++
+	delivery.Status = DeliveryDelivered
+	delivery.ResponseCode = responseCode
+	delivery.LastError = ""
+	delivery.DeliveredAt = &now
+}
+
+// MarkAttemptFailed records a failed attempt. The delivery is tried again after
+// the backoff, until it was attempted maxAttempts times.
+func (delivery *Delivery) MarkAttemptFailed(responseCode int, err error, maxAttempts int, backoff Backoff) {
+	delivery.Attempts++
+	delivery.ResponseCode = responseCode
+	delivery.LastError = err.Error()
+
+	if len(delivery.LastError) > maxErrorLength {
+		delivery.LastError = delivery.LastError[:maxErrorLength]
+	}
+
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = DeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = time.Now().Add(backoff.Delay(delivery.Attempts))
+}
+
+// GiveUp fails the delivery without attempting it again.
+func (delivery *Delivery) GiveUp(err error) {
+	delivery.Status = DeliveryFailed
+	delivery.LastError = err.Error()
+}
+
+// Backoff doubles the delay before the next attempt after each failed one.
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (backoff Backoff) Delay(attempts int) time.Duration {
+	delay := backoff.Base
+
+	for i := 1; i < attempts && delay < backoff.Max; i++ {
+		delay *= 2
+	}
+
+	return min(delay, backoff.Max)
+}
+
+// UnexpectedStatusError is returned by the client when the webhook answers
+// with another status code than a 2xx one.
+type UnexpectedStatusError struct {
+	StatusCode int
+}
+
+func (err *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("the webhook answered with the status code %d", err.StatusCode)
+}
+
+type DeliveriesRepository interface {
+	Find(context.Context, uuid.UUID) (*Delivery, error)
+	// ListBySubscription returns the latest deliveries to the webhook first.
+	ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]Delivery, error)
+	Store(context.Context, *Delivery) error
+	// Claim locks up to limit pending deliveries which are due until the lease ends,
+	// so that they aren't sent twice by concurrent workers or application instances.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error)
+	// Update saves the outcome of an attempt and releases the delivery.
+	Update(context.Context, *Delivery) error
+}
+
+// Client posts the deliveries to the webhooks. It returns the response status
+// code, and an UnexpectedStatusError when it isn't a 2xx one.
+type Client interface {
+	Send(context.Context, *Subscription, *Delivery) (int, error)
+}
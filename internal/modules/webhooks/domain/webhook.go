@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("the webhook you're looking for doesn't exist")
+	ErrDeliveryNotFound     = errors.New("the webhook delivery you're looking for doesn't exist")
+	ErrUnknownEvent         = errors.New("unknown webhook event")
+	ErrNoEvents             = errors.New("a webhook must subscribe to at least one event")
+	ErrInvalidURL           = errors.New("the webhook url must be an absolute http or https url")
+	ErrInternalURL          = errors.New("the webhook url must not point to an internal address")
+)
+
+type Event = string
+
+const (
+	PostCreated    Event = "post.created"
+	PostUpdated    Event = "post.updated"
+	PostDeleted    Event = "post.deleted"
+	PostRestored   Event = "post.restored"
+	CommentCreated Event = "comment.created"
+	UserRegistered Event = "user.registered"
+)
+
+var Events = []Event{PostCreated, PostUpdated, PostDeleted, PostRestored, CommentCreated, UserRegistered}
+
+// The headers sent along with the deliveries. The signature is the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret, so that
+// the receivers can check where the delivery comes from and reject the replayed ones.
+const (
+	EventHeader     = "X-Comu-Event"
+	DeliveryHeader  = "X-Comu-Delivery"
+	TimestampHeader = "X-Comu-Timestamp"
+	SignatureHeader = "X-Comu-Signature"
+)
+
+const secretLength = 32
+
+// Subscription is a webhook: the url the events it subscribes to are posted to.
+type Subscription struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	URL    string    `json:"url"`
+	// Secret signs the deliveries. It is only shown when the webhook is created.
+	Secret    string    `json:"-"`
+	Events    []Event   `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewSubscription(userID uuid.UUID, url string, events []Event) (*Subscription, error) {
+	if err := ValidateURL(url); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateEvents(events); err != nil {
+		return nil, err
+	}
+	secret, err := newSecret()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscription{
+		UserID:    userID,
+		URL:       url,
+		Secret:    secret,
+		Events:    slices.Compact(slices.Sorted(slices.Values(events))),
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (subscription *Subscription) Subscribes(event Event) bool {
+	return subscription.Active && slices.Contains(subscription.Events, event)
+}
+
+// IsManageableBy tells whether the actor can see and change the webhook. The admins
+// manage every webhook, the users only theirs.
+func (subscription *Subscription) IsManageableBy(actor Actor) bool {
+	return actor.IsAdmin || subscription.UserID == actor.UserID
+}
+
+// Sign returns the signature of a delivery body sent at the given time.
+func (subscription *Subscription) Sign(timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(subscription.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10) + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func ValidateURL(value string) error {
+	parsed, err := url.Parse(value)
+
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidURL
+	}
+
+	return nil
+}
+
+func ValidateEvents(events []Event) error {
+	if len(events) == 0 {
+		return ErrNoEvents
+	}
+
+	for _, event := range events {
+		if !slices.Contains(Events, event) {
+			return ErrUnknownEvent
+		}
+	}
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	bytes := make([]byte, secretLength)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// Actor is the user managing the webhooks.
+type Actor struct {
+	UserID  uuid.UUID
+	IsAdmin bool
+}
+
+// Admins are the users allowed to manage every webhook.
+type Admins []uuid.UUID
+
+func (admins Admins) Actor(userID uuid.UUID) Actor {
+	return Actor{UserID: userID, IsAdmin: slices.Contains(admins, userID)}
+}
+
+type SubscriptionsRepository interface {
+	Find(context.Context, uuid.UUID) (*Subscription, error)
+	ListAll(context.Context) ([]Subscription, error)
+	ListByUser(context.Context, uuid.UUID) ([]Subscription, error)
+	// ListByEvent returns the active subscriptions to the event.
+	ListByEvent(context.Context, Event) ([]Subscription, error)
+	Store(context.Context, *Subscription) error
+	Update(context.Context, *Subscription) error
+	Delete(context.Context, uuid.UUID) error
+}
+
+// URLGuard keeps the webhooks from reaching the server network. Check returns
+// ErrInternalURL when the url host resolves to a loopback, private, link-local
+// or unspecified address, and ErrInvalidURL when it doesn't resolve.
+type URLGuard interface {
+	Check(ctx context.Context, url string) error
+}
+
+// FindManageableSubscription returns the webhook when the actor can manage it. The
+// others' webhooks are reported as not found, so that their ids can't be guessed.
+func FindManageableSubscription(
+	ctx context.Context, repo SubscriptionsRepository,
+	actor Actor, subscriptionID uuid.UUID,
+) (*Subscription, error) {
+	subscription, err := repo.Find(ctx, subscriptionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !subscription.IsManageableBy(actor) {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+// Payload is the body of the deliveries. Its id is shared by the deliveries of the
+// same event to every webhook, so that the receivers can tell them apart from the
+// redeliveries, which keep it.
+type Payload struct {
+	ID        uuid.UUID `json:"id"`
+	Event     Event     `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+func NewPayload(event Event, data any) ([]byte, error) {
+	return json.Marshal(Payload{
+		ID:        uuid.New(),
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+}
+
+// Transactor runs a function inside a transaction, committed when the function succeeds.
+// The repositories join the transaction through the context given to the function.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type deliveryStore map[uuid.UUID]domain.Delivery
+
+type inMemoryDeliveriesRepository struct {
+	store       deliveryStore
+	lockedUntil map[uuid.UUID]time.Time
+	sync.Mutex
+}
+
+func NewInMemoryDeliveriesRepository(initialStore deliveryStore) *inMemoryDeliveriesRepository {
+	if initialStore == nil {
+		initialStore = make(deliveryStore)
+	}
+
+	return &inMemoryDeliveriesRepository{
+		store:       initialStore,
+		lockedUntil: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (repo *inMemoryDeliveriesRepository) Find(ctx context.Context, ID uuid.UUID) (*domain.Delivery, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if delivery, ok := repo.store[ID]; ok {
+		return &delivery, nil
+	}
+
+	return nil, domain.ErrDeliveryNotFound
+}
+
+func (repo *inMemoryDeliveriesRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]domain.Delivery, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	deliveries := slices.DeleteFunc(slices.Collect(maps.Values(repo.store)), func(delivery domain.Delivery) bool {
+		return delivery.SubscriptionID != subscriptionID
+	})
+	slices.SortFunc(deliveries, func(a, b domain.Delivery) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return deliveries[:min(limit, len(deliveries))], nil
+}
+
+func (repo *inMemoryDeliveriesRepository) Store(ctx context.Context, delivery *domain.Delivery) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.store[delivery.ID] = *delivery
+	return nil
+}
+
+func (repo *inMemoryDeliveriesRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.Delivery, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	now := time.Now()
+	due := slices.DeleteFunc(slices.Collect(maps.Values(repo.store)), func(delivery domain.Delivery) bool {
+		lockedUntil, locked := repo.lockedUntil[delivery.ID]
+
+		return delivery.Status != domain.DeliveryPending || delivery.NextAttemptAt.After(now) ||
+			(locked && lockedUntil.After(now))
+	})
+	slices.SortFunc(due, func(a, b domain.Delivery) int {
+		return a.NextAttemptAt.Compare(b.NextAttemptAt)
+	})
+	due = due[:min(limit, len(due))]
+
+	for _, delivery := range due {
+		repo.lockedUntil[delivery.ID] = now.Add(lease)
+	}
+
+	return due, nil
+}
+
+func (repo *inMemoryDeliveriesRepository) Update(ctx context.Context, delivery *domain.Delivery) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if _, ok := repo.store[delivery.ID]; !ok {
+		return domain.ErrDeliveryNotFound
+	}
+	repo.store[delivery.ID] = *delivery
+	delete(repo.lockedUntil, delivery.ID)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryDeliveriesRepository(t *testing.T) {
+
+	t.Run("it should claim the due pending deliveries only once per lease", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryDeliveriesRepository(nil)
+		ctx := context.Background()
+		subscriptionID := uuid.New()
+
+		due := domain.NewDelivery(subscriptionID, domain.PostCreated, []byte("{}"))
+		later := domain.NewDelivery(subscriptionID, domain.PostCreated, []byte("{}"))
+		later.NextAttemptAt = time.Now().Add(time.Hour)
+		delivered := domain.NewDelivery(subscriptionID, domain.PostCreated, []byte("{}"))
+		delivered.MarkDelivered(200)
+
+		repo.Store(ctx, due)
+		repo.Store(ctx, later)
+		repo.Store(ctx, delivered)
+
+		claimed, err := repo.Claim(ctx, 10, time.Minute)
+
+		if _assert.NoError(err) && _assert.Len(claimed, 1) {
+			_assert.Equal(due.ID, claimed[0].ID)
+		}
+
+		claimed, _ = repo.Claim(ctx, 10, time.Minute)
+		_assert.Empty(claimed)
+
+		// Saving the outcome of the attempt releases the delivery.
+		repo.Update(ctx, due)
+		claimed, _ = repo.Claim(ctx, 10, time.Minute)
+		_assert.Len(claimed, 1)
+	})
+
+	t.Run("it should list the latest deliveries of the subscription first", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemoryDeliveriesRepository(nil)
+		ctx := context.Background()
+		subscriptionID := uuid.New()
+
+		first := domain.NewDelivery(subscriptionID, domain.PostCreated, []byte("{}"))
+		second := domain.NewDelivery(subscriptionID, domain.PostUpdated, []byte("{}"))
+		second.CreatedAt = first.CreatedAt.Add(time.Second)
+
+		repo.Store(ctx, first)
+		repo.Store(ctx, second)
+		repo.Store(ctx, domain.NewDelivery(uuid.New(), domain.PostCreated, []byte("{}")))
+
+		deliveries, err := repo.ListBySubscription(ctx, subscriptionID, 10)
+
+		if _assert.NoError(err) && _assert.Len(deliveries, 2) {
+			_assert.Equal(second.ID, deliveries[0].ID)
+			_assert.Equal(first.ID, deliveries[1].ID)
+		}
+	})
+}
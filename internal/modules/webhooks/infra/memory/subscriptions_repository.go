@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type subscriptionStore map[uuid.UUID]domain.Subscription
+
+type inMemorySubscriptionsRepository struct {
+	store subscriptionStore
+	sync.Mutex
+}
+
+func NewInMemorySubscriptionsRepository(initialStore subscriptionStore) *inMemorySubscriptionsRepository {
+	if initialStore == nil {
+		initialStore = make(subscriptionStore)
+	}
+
+	return &inMemorySubscriptionsRepository{
+		store: initialStore,
+	}
+}
+
+func (repo *inMemorySubscriptionsRepository) Find(ctx context.Context, ID uuid.UUID) (*domain.Subscription, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if subscription, ok := repo.store[ID]; ok {
+		return &subscription, nil
+	}
+
+	return nil, domain.ErrSubscriptionNotFound
+}
+
+func (repo *inMemorySubscriptionsRepository) ListAll(ctx context.Context) ([]domain.Subscription, error) {
+	return repo.list(func(domain.Subscription) bool { return true }), nil
+}
+
+func (repo *inMemorySubscriptionsRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Subscription, error) {
+	return repo.list(func(subscription domain.Subscription) bool {
+		return subscription.UserID == userID
+	}), nil
+}
+
+func (repo *inMemorySubscriptionsRepository) ListByEvent(ctx context.Context, event domain.Event) ([]domain.Subscription, error) {
+	return repo.list(func(subscription domain.Subscription) bool {
+		return subscription.Subscribes(event)
+	}), nil
+}
+
+func (repo *inMemorySubscriptionsRepository) Store(ctx context.Context, subscription *domain.Subscription) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return err
+	}
+	subscription.ID = id
+	repo.store[id] = *subscription
+
+	return nil
+}
+
+func (repo *inMemorySubscriptionsRepository) Update(ctx context.Context, subscription *domain.Subscription) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if _, ok := repo.store[subscription.ID]; !ok {
+		return domain.ErrSubscriptionNotFound
+	}
+	repo.store[subscription.ID] = *subscription
+
+	return nil
+}
+
+func (repo *inMemorySubscriptionsRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	delete(repo.store, ID)
+	return nil
+}
+
+// list returns the subscriptions matching the filter, oldest first.
+func (repo *inMemorySubscriptionsRepository) list(filter func(domain.Subscription) bool) []domain.Subscription {
+	repo.Lock()
+	defer repo.Unlock()
+
+	subscriptions := slices.DeleteFunc(slices.Collect(maps.Values(repo.store)), func(subscription domain.Subscription) bool {
+		return !filter(subscription)
+	})
+	slices.SortFunc(subscriptions, func(a, b domain.Subscription) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return subscriptions
+}
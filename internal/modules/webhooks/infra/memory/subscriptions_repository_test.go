@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemorySubscriptionsRepository(t *testing.T) {
+
+	t.Run("it should list the active subscriptions to the event", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemorySubscriptionsRepository(nil)
+		ctx := context.Background()
+
+		posts, _ := domain.NewSubscription(uuid.New(), "https://example.com/hook", []domain.Event{domain.PostCreated})
+		users, _ := domain.NewSubscription(uuid.New(), "https://example.com/hook", []domain.Event{domain.UserRegistered})
+		disabled, _ := domain.NewSubscription(uuid.New(), "https://example.com/hook", []domain.Event{domain.PostCreated})
+		disabled.Active = false
+
+		repo.Store(ctx, posts)
+		repo.Store(ctx, users)
+		repo.Store(ctx, disabled)
+
+		subscriptions, err := repo.ListByEvent(ctx, domain.PostCreated)
+
+		if _assert.NoError(err) && _assert.Len(subscriptions, 1) {
+			_assert.Equal(posts.ID, subscriptions[0].ID)
+		}
+	})
+
+	t.Run("it should list the subscriptions of the user", func(t *testing.T) {
+		_assert := assert.New(t)
+		repo := NewInMemorySubscriptionsRepository(nil)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		mine, _ := domain.NewSubscription(userID, "https://example.com/hook", []domain.Event{domain.PostCreated})
+		others, _ := domain.NewSubscription(uuid.New(), "https://example.com/hook", []domain.Event{domain.PostCreated})
+
+		repo.Store(ctx, mine)
+		repo.Store(ctx, others)
+
+		subscriptions, err := repo.ListByUser(ctx, userID)
+
+		if _assert.NoError(err) && _assert.Len(subscriptions, 1) {
+			_assert.Equal(mine.ID, subscriptions[0].ID)
+		}
+
+		all, _ := repo.ListAll(ctx)
+		_assert.Len(all, 2)
+	})
+}
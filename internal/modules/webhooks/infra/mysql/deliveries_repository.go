@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type deliveriesRepository struct {
+	db *sql.DB
+}
+
+func NewDeliveriesRepository(db *sql.DB) *deliveriesRepository {
+	return &deliveriesRepository{
+		db: db,
+	}
+}
+
+func (repo *deliveriesRepository) Find(ctx context.Context, ID uuid.UUID) (*domain.Delivery, error) {
+	deliveries, err := repo.list(ctx, "SELECT * FROM webhook_deliveries WHERE id = UUID_TO_BIN(?);", ID.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, domain.ErrDeliveryNotFound
+	}
+
+	return &deliveries[0], nil
+}
+
+func (repo *deliveriesRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]domain.Delivery, error) {
+	query := `
+		SELECT * FROM webhook_deliveries WHERE subscription_id = UUID_TO_BIN(?)
+		ORDER BY created_at DESC LIMIT ?;
+	`
+	return repo.list(ctx, query, subscriptionID.String(), limit)
+}
+
+// Store joins the transaction carried by the context, if any.
+func (repo *deliveriesRepository) Store(ctx context.Context, delivery *domain.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (
+			id, subscription_id, event, payload, status, attempts,
+			response_code, last_error, next_attempt_at, created_at
+		) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	_, err := database.Executor(ctx, repo.db).ExecContext(
+		ctx, query, delivery.ID.String(), delivery.SubscriptionID.String(), delivery.Event,
+		[]byte(delivery.Payload), delivery.Status, delivery.Attempts, delivery.ResponseCode,
+		delivery.LastError, delivery.NextAttemptAt, delivery.CreatedAt,
+	)
+
+	return err
+}
+
+// Claim locks the deliveries with a single update, which MySQL runs atomically, then
+// reads back the ones holding the claim id.
+func (repo *deliveriesRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.Delivery, error) {
+	claimID := uuid.NewString()
+	now := time.Now()
+
+	query := `
+		UPDATE webhook_deliveries SET locked_until = ?, claim_id = ?
+		WHERE status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)
+		ORDER BY next_attempt_at LIMIT ?;
+	`
+	result, err := repo.db.ExecContext(ctx, query, now.Add(lease), claimID, domain.DeliveryPending, now, now, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if count, err := result.RowsAffected(); err != nil || count == 0 {
+		return []domain.Delivery{}, err
+	}
+
+	return repo.list(ctx, "SELECT * FROM webhook_deliveries WHERE claim_id = ? ORDER BY next_attempt_at;", claimID)
+}
+
+func (repo *deliveriesRepository) Update(ctx context.Context, delivery *domain.Delivery) error {
+	query := `
+		UPDATE webhook_deliveries SET status = ?, attempts = ?, response_code = ?, last_error = ?,
+		next_attempt_at = ?, delivered_at = ?, locked_until = NULL, claim_id = NULL
+		WHERE id = UUID_TO_BIN(?);
+	`
+	_, err := repo.db.ExecContext(
+		ctx, query, delivery.Status, delivery.Attempts, delivery.ResponseCode, delivery.LastError,
+		delivery.NextAttemptAt, delivery.DeliveredAt, delivery.ID.String(),
+	)
+
+	return err
+}
+
+func (repo *deliveriesRepository) list(ctx context.Context, query string, args ...any) ([]domain.Delivery, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return []domain.Delivery{}, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.Delivery{}
+
+	for rows.Next() {
+		var delivery domain.Delivery
+		var payload []byte
+		var lockedUntil sql.NullTime
+		var claimID sql.NullString
+
+		err := rows.Scan(
+			&delivery.ID, &delivery.SubscriptionID, &delivery.Event, &payload, &delivery.Status,
+			&delivery.Attempts, &delivery.ResponseCode, &delivery.LastError, &delivery.NextAttemptAt,
+			&lockedUntil, &claimID, &delivery.CreatedAt, &delivery.DeliveredAt,
+		)
+
+		if err != nil {
+			return []domain.Delivery{}, err
+		}
+		delivery.Payload = payload
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
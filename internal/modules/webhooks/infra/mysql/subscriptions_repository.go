@@ -0,0 +1,134 @@
+package mysql
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type subscriptionsRepository struct {
+	db *sql.DB
+}
+
+func NewSubscriptionsRepository(db *sql.DB) *subscriptionsRepository {
+	return &subscriptionsRepository{
+		db: db,
+	}
+}
+
+func (repo *subscriptionsRepository) Find(ctx context.Context, ID uuid.UUID) (*domain.Subscription, error) {
+	query := "SELECT * FROM webhook_subscriptions WHERE id = UUID_TO_BIN(?);"
+	subscriptions, err := repo.list(ctx, query, ID.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, domain.ErrSubscriptionNotFound
+	}
+
+	return &subscriptions[0], nil
+}
+
+func (repo *subscriptionsRepository) ListAll(ctx context.Context) ([]domain.Subscription, error) {
+	return repo.list(ctx, "SELECT * FROM webhook_subscriptions ORDER BY created_at;")
+}
+
+func (repo *subscriptionsRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Subscription, error) {
+	query := "SELECT * FROM webhook_subscriptions WHERE user_id = UUID_TO_BIN(?) ORDER BY created_at;"
+	return repo.list(ctx, query, userID.String())
+}
+
+func (repo *subscriptionsRepository) ListByEvent(ctx context.Context, event domain.Event) ([]domain.Subscription, error) {
+	query := `
+		SELECT * FROM webhook_subscriptions
+		WHERE active = TRUE AND JSON_CONTAINS(events, JSON_QUOTE(?))
+		ORDER BY created_at;
+	`
+	return repo.list(ctx, query, event)
+}
+
+func (repo *subscriptionsRepository) Store(ctx context.Context, subscription *domain.Subscription) error {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return err
+	}
+	events, err := json.Marshal(subscription.Events)
+
+	if err != nil {
+		return err
+	}
+	subscription.ID = id
+
+	query := `
+		INSERT INTO webhook_subscriptions (id, user_id, url, secret, events, active, created_at, updated_at)
+		VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?);
+	`
+	_, err = repo.db.ExecContext(
+		ctx, query, subscription.ID.String(), subscription.UserID.String(), subscription.URL,
+		subscription.Secret, events, subscription.Active, subscription.CreatedAt, subscription.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repo *subscriptionsRepository) Update(ctx context.Context, subscription *domain.Subscription) error {
+	events, err := json.Marshal(subscription.Events)
+
+	if err != nil {
+		return err
+	}
+	subscription.UpdatedAt = time.Now()
+
+	query := "UPDATE webhook_subscriptions SET url = ?, events = ?, active = ?, updated_at = ? WHERE id = UUID_TO_BIN(?);"
+	_, err = repo.db.ExecContext(
+		ctx, query, subscription.URL, events, subscription.Active,
+		subscription.UpdatedAt, subscription.ID.String(),
+	)
+
+	return err
+}
+
+// Delete removes the subscription along with its deliveries.
+func (repo *subscriptionsRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = UUID_TO_BIN(?);", ID.String())
+	return err
+}
+
+func (repo *subscriptionsRepository) list(ctx context.Context, query string, args ...any) ([]domain.Subscription, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return []domain.Subscription{}, err
+	}
+	defer rows.Close()
+
+	subscriptions := []domain.Subscription{}
+
+	for rows.Next() {
+		var subscription domain.Subscription
+		var events []byte
+
+		err := rows.Scan(
+			&subscription.ID, &subscription.UserID, &subscription.URL, &subscription.Secret,
+			&events, &subscription.Active, &subscription.CreatedAt, &subscription.UpdatedAt,
+		)
+
+		if err != nil {
+			return []domain.Subscription{}, err
+		}
+
+		if err := json.Unmarshal(events, &subscription.Events); err != nil {
+			return []domain.Subscription{}, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"bytes"
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultClientTimeout = time.Second * 10
+	userAgent            = "Comu-Webhooks/1.0"
+)
+
+type ClientOptions struct {
+	// Timeout is how long the webhooks have to answer.
+	Timeout time.Duration
+	// AllowInternalAddresses lets the client reach the loopback and private addresses,
+	// which only the tests need for their local receivers.
+	AllowInternalAddresses bool
+}
+
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		Timeout: DefaultClientTimeout,
+	}
+}
+
+type httpClient struct {
+	client *http.Client
+}
+
+// NewHttpClient returns the webhooks client. It refuses to connect to the internal
+// addresses, whatever the webhooks hosts resolve to, and doesn't follow the redirects,
+// the webhooks answering with one getting an UnexpectedStatusError.
+func NewHttpClient(options ClientOptions) *httpClient {
+	dialer := &net.Dialer{Timeout: options.Timeout}
+
+	if !options.AllowInternalAddresses {
+		dialer.Control = controlDial
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the webhooks, out of the control of the dialer.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &httpClient{
+		client: &http.Client{
+			Timeout:   options.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (c *httpClient) Send(ctx context.Context, subscription *domain.Subscription, delivery *domain.Delivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now()
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(domain.EventHeader, delivery.Event)
+	request.Header.Set(domain.DeliveryHeader, delivery.ID.String())
+	request.Header.Set(domain.TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	request.Header.Set(domain.SignatureHeader, subscription.Sign(timestamp, delivery.Payload))
+
+	response, err := c.client.Do(request)
+
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// The body is drained for the connection to be reused.
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, &domain.UnexpectedStatusError{StatusCode: response.StatusCode}
+	}
+
+	return response.StatusCode, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"comu/internal/modules/webhooks/domain"
+	"context"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+// Resolver looks up the addresses of the webhooks hosts.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+type urlGuard struct {
+	resolver Resolver
+}
+
+// NewURLGuard returns the guard checking the webhooks urls when they are subscribed.
+// The default resolver is used when resolver is nil.
+func NewURLGuard(resolver Resolver) *urlGuard {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &urlGuard{
+		resolver: resolver,
+	}
+}
+
+func (guard *urlGuard) Check(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+
+	if err != nil || parsed.Hostname() == "" {
+		return domain.ErrInvalidURL
+	}
+	host := parsed.Hostname()
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(addr)
+	}
+	addrs, err := guard.resolver.LookupNetIP(ctx, "ip", host)
+
+	if err != nil || len(addrs) == 0 {
+		return domain.ErrInvalidURL
+	}
+
+	for _, addr := range addrs {
+		if err := checkAddr(addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// controlDial refuses the connections to the internal addresses. It runs once the
+// host is resolved, so that a host resolving to another address than when it was
+// subscribed can't reach the server network either.
+func controlDial(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+
+	if err != nil {
+		return domain.ErrInternalURL
+	}
+
+	return checkAddr(addrPort.Addr())
+}
+
+func checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsUnspecified() {
+		return domain.ErrInternalURL
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"comu/config"
+	"comu/internal/modules/webhooks/application"
+	"comu/internal/modules/webhooks/application/deliveries"
+	"comu/internal/modules/webhooks/domain"
+	"comu/internal/modules/webhooks/infra/mysql"
+	"comu/internal/modules/webhooks/infra/service"
+	"comu/internal/modules/webhooks/presentation/handlers"
+	"comu/internal/shared/database"
+	"comu/internal/shared/jobs"
+	"comu/internal/shared/logger"
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// The events the other modules dispatch.
+const (
+	PostCreatedEvent    = domain.PostCreated
+	PostUpdatedEvent    = domain.PostUpdated
+	PostDeletedEvent    = domain.PostDeleted
+	PostRestoredEvent   = domain.PostRestored
+	CommentCreatedEvent = domain.CommentCreated
+	UserRegisteredEvent = domain.UserRegistered
+)
+
+type PublicApi interface {
+	// Dispatch queues a delivery of the event to the webhooks subscribing to it,
+	// within the transaction carried by the context if any.
+	Dispatch(context.Context, DispatchRequest) error
+}
+
+type webhooksModule struct {
+	api        PublicApi
+	deliverJob *jobs.Job
+	handlers   []handlers.Handlers
+}
+
+func NewModule(db *sql.DB, config *config.Config, logger *logger.Log) *webhooksModule {
+	useCases := application.InitUseCases(
+		mysql.NewSubscriptionsRepository(db),
+		mysql.NewDeliveriesRepository(db),
+		service.NewHttpClient(service.DefaultClientOptions()),
+		service.NewURLGuard(nil),
+		database.NewTransactor(db),
+		deliveries.DefaultDeliverOptions(),
+	)
+
+	return &webhooksModule{
+		api: newApi(useCases.DispatchEventUC),
+		deliverJob: jobs.Every(domain.DeliveryInterval, func(ctx context.Context) error {
+			_, err := useCases.DeliverDueUC.Execute(ctx)
+			return err
+		}, logger),
+		handlers: handlers.GetHandlers(useCases, getAdmins(config, logger), logger),
+	}
+}
+
+// RegisterRoutes registers the webhooks routes behind the given middlewares,
+// which must authenticate the user.
+func (module *webhooksModule) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	for _, h := range module.handlers {
+		h.RegisterRoutes(echo, m...)
+	}
+}
+
+// StartJobs sends the webhook deliveries in the background until the context is done.
+func (module *webhooksModule) StartJobs(ctx context.Context) {
+	module.deliverJob.Start(ctx)
+}
+
+// WaitJobs blocks until the jobs stopped, after their context is done.
+func (module *webhooksModule) WaitJobs() {
+	module.deliverJob.Wait()
+}
+
+func (module *webhooksModule) GetPublicApi() PublicApi {
+	return module.api
+}
+
+func getAdmins(config *config.Config, logger *logger.Log) domain.Admins {
+	admins := domain.Admins{}
+
+	for _, id := range config.AdminUserIDs {
+		userID, err := uuid.Parse(id)
+
+		if err != nil {
+			logger.Error.Printf("invalid admin user id %q\n", id)
+			continue
+		}
+		admins = append(admins, userID)
+	}
+
+	return admins
+}
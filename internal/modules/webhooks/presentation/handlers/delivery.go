@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"comu/internal/modules/webhooks/application/deliveries"
+	"comu/internal/modules/webhooks/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var msgRedeliveryQueued = "The delivery has been queued again."
+
+type deliveryHandlers struct {
+	listDeliveriesUC *deliveries.ListDeliveriesUC
+	redeliverUC      *deliveries.RedeliverUC
+
+	admins domain.Admins
+	logger *logger.Log
+}
+
+func newDeliveryHandlers(
+	listDeliveriesUC *deliveries.ListDeliveriesUC,
+	redeliverUC *deliveries.RedeliverUC,
+
+	admins domain.Admins,
+	logger *logger.Log,
+) *deliveryHandlers {
+	return &deliveryHandlers{
+		listDeliveriesUC: listDeliveriesUC,
+		redeliverUC:      redeliverUC,
+
+		admins: admins,
+		logger: logger,
+	}
+}
+
+func (h *deliveryHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/webhooks", m...)
+
+	group.GET("/:id/deliveries", h.list)
+	group.POST("/deliveries/:delivery_id/redeliver", h.redeliver)
+}
+
+func (h *deliveryHandlers) list(ctx echo.Context) error {
+	actor, err := getActor(ctx, h.admins)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	subscriptionID, err := uuid.Parse(ctx.Param("id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrSubscriptionNotFound.Error())
+	}
+	deliveries, err := h.listDeliveriesUC.Execute(ctx.Request().Context(), actor, subscriptionID)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrSubscriptionNotFound) {
+			return echoRes.JsonNotFoundResponse(ctx, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"deliveries": deliveries,
+	})
+}
+
+func (h *deliveryHandlers) redeliver(ctx echo.Context) error {
+	actor, err := getActor(ctx, h.admins)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	deliveryID, err := uuid.Parse(ctx.Param("delivery_id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrDeliveryNotFound.Error())
+	}
+	delivery, err := h.redeliverUC.Execute(ctx.Request().Context(), actor, deliveryID)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrDeliveryNotFound) {
+			return echoRes.JsonNotFoundResponse(ctx, err.Error())
+		}
+
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessResponse(ctx, msgRedeliveryQueued, map[string]any{
+		"delivery": delivery,
+	})
+}
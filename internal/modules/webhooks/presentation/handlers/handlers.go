@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"comu/internal/modules/webhooks/application"
+	"comu/internal/modules/webhooks/domain"
+	"comu/internal/shared/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	RegisterRoutes(*echo.Echo, ...echo.MiddlewareFunc)
+}
+
+func GetHandlers(ucs application.UseCases, admins domain.Admins, logger *logger.Log) []Handlers {
+	subscriptionHandlers := newSubscriptionHandlers(
+		ucs.CreateSubscriptionUC, ucs.ListSubscriptionsUC,
+		ucs.UpdateSubscriptionUC, ucs.DeleteSubscriptionUC,
+		admins, logger,
+	)
+	deliveryHandlers := newDeliveryHandlers(ucs.ListDeliveriesUC, ucs.RedeliverUC, admins, logger)
+
+	return []Handlers{subscriptionHandlers, deliveryHandlers}
+}
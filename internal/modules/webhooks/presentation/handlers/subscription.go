@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"comu/internal/modules/auth/presentation/session"
+	"comu/internal/modules/webhooks/application/subscriptions"
+	"comu/internal/modules/webhooks/domain"
+	"comu/internal/shared/logger"
+	echoRes "comu/internal/shared/utils/echo_res"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	unauthenticated echoRes.ErrorResponseType = "unauthenticated"
+	invalidWebhook  echoRes.ErrorResponseType = "invalid_webhook"
+
+	msgWebhookCreated        = "The webhook has been created. Keep its secret, it won't be shown again."
+	msgWebhookUpdated        = "The webhook has been updated."
+	msgWebhookDeleted        = "The webhook has been deleted."
+	errInvalidAuthentication = errors.New("you must be authenticated")
+)
+
+type subscriptionHandlers struct {
+	createSubscriptionUC *subscriptions.CreateSubscriptionUC
+	listSubscriptionsUC  *subscriptions.ListSubscriptionsUC
+	updateSubscriptionUC *subscriptions.UpdateSubscriptionUC
+	deleteSubscriptionUC *subscriptions.DeleteSubscriptionUC
+
+	admins domain.Admins
+	logger *logger.Log
+}
+
+func newSubscriptionHandlers(
+	createSubscriptionUC *subscriptions.CreateSubscriptionUC,
+	listSubscriptionsUC *subscriptions.ListSubscriptionsUC,
+	updateSubscriptionUC *subscriptions.UpdateSubscriptionUC,
+	deleteSubscriptionUC *subscriptions.DeleteSubscriptionUC,
+
+	admins domain.Admins,
+	logger *logger.Log,
+) *subscriptionHandlers {
+	return &subscriptionHandlers{
+		createSubscriptionUC: createSubscriptionUC,
+		listSubscriptionsUC:  listSubscriptionsUC,
+		updateSubscriptionUC: updateSubscriptionUC,
+		deleteSubscriptionUC: deleteSubscriptionUC,
+
+		admins: admins,
+		logger: logger,
+	}
+}
+
+func (h *subscriptionHandlers) RegisterRoutes(echo *echo.Echo, m ...echo.MiddlewareFunc) {
+	group := echo.Group("/webhooks", m...)
+
+	group.GET("", h.list)
+	group.POST("", h.create)
+	group.PUT("/:id", h.update)
+	group.DELETE("/:id", h.delete)
+}
+
+type subscriptionFormData struct {
+	URL    string   `form:"url" json:"url"`
+	Events []string `form:"events" json:"events"`
+	// Active is only read on update, where it defaults to false when missing.
+	Active bool `form:"active" json:"active"`
+}
+
+func (h *subscriptionHandlers) list(ctx echo.Context) error {
+	actor, err := h.getActor(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	subscriptions, err := h.listSubscriptionsUC.Execute(ctx.Request().Context(), actor)
+
+	if err != nil {
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+
+	return echoRes.JsonSuccessWithDataResponse(ctx, map[string]any{
+		"webhooks": subscriptions,
+		"events":   domain.Events,
+	})
+}
+
+func (h *subscriptionHandlers) create(ctx echo.Context) error {
+	actor, err := h.getActor(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	var data subscriptionFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	subscription, err := h.createSubscriptionUC.Execute(
+		ctx.Request().Context(),
+		subscriptions.CreateSubscriptionInput{
+			UserID: actor.UserID,
+			URL:    data.URL,
+			Events: data.Events,
+		},
+	)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessResponse(ctx, msgWebhookCreated, map[string]any{
+		"webhook": subscription,
+		"secret":  subscription.Secret,
+	})
+}
+
+func (h *subscriptionHandlers) update(ctx echo.Context) error {
+	actor, err := h.getActor(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	subscriptionID, err := uuid.Parse(ctx.Param("id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrSubscriptionNotFound.Error())
+	}
+	var data subscriptionFormData
+
+	if err := ctx.Bind(&data); err != nil {
+		return echoRes.JsonInvalidRequestResponse(ctx)
+	}
+
+	subscription, err := h.updateSubscriptionUC.Execute(
+		ctx.Request().Context(),
+		subscriptions.UpdateSubscriptionInput{
+			Actor:          actor,
+			SubscriptionID: subscriptionID,
+			URL:            data.URL,
+			Events:         data.Events,
+			Active:         data.Active,
+		},
+	)
+
+	if err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessResponse(ctx, msgWebhookUpdated, map[string]any{
+		"webhook": subscription,
+	})
+}
+
+func (h *subscriptionHandlers) delete(ctx echo.Context) error {
+	actor, err := h.getActor(ctx)
+
+	if err != nil {
+		return echoRes.JsonUnauthorizedResponse(ctx, unauthenticated, err.Error())
+	}
+	subscriptionID, err := uuid.Parse(ctx.Param("id"))
+
+	if err != nil {
+		return echoRes.JsonNotFoundResponse(ctx, domain.ErrSubscriptionNotFound.Error())
+	}
+
+	if err := h.deleteSubscriptionUC.Execute(ctx.Request().Context(), actor, subscriptionID); err != nil {
+		return h.errorResponse(ctx, err)
+	}
+
+	return echoRes.JsonSuccessMessageResponse(ctx, msgWebhookDeleted)
+}
+
+func (h *subscriptionHandlers) errorResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		return echoRes.JsonNotFoundResponse(ctx, err.Error())
+
+	case errors.Is(err, domain.ErrInvalidURL),
+		errors.Is(err, domain.ErrInternalURL),
+		errors.Is(err, domain.ErrUnknownEvent),
+		errors.Is(err, domain.ErrNoEvents):
+		return echoRes.JsonErrorMessageResponse(ctx, http.StatusUnprocessableEntity, invalidWebhook, err.Error())
+
+	default:
+		h.logger.Error.Println(err)
+		return echoRes.JsonInternalErrorResponse(ctx)
+	}
+}
+
+func (h *subscriptionHandlers) getActor(ctx echo.Context) (domain.Actor, error) {
+	return getActor(ctx, h.admins)
+}
+
+// getActor returns the authenticated user, who manages every webhook when they are an admin.
+func getActor(ctx echo.Context, admins domain.Admins) (domain.Actor, error) {
+	id, _ := ctx.Get(session.UserIDCtxKey).(string)
+	userID, err := uuid.Parse(id)
+
+	if err != nil {
+		return domain.Actor{}, errInvalidAuthentication
+	}
+
+	return admins.Actor(userID), nil
+}
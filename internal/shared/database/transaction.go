@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Conn is implemented by both *sql.DB and *sql.Tx.
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txCtxKey struct{}
+
+// transaction is the transaction carried by a context, along with the functions
+// to run once it is committed.
+type transaction struct {
+	tx          *sql.Tx
+	afterCommit []func()
+}
+
+// Transactor runs functions inside a database transaction. The transaction is carried
+// by the context given to the function, and joined by the repositories using Executor.
+type Transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{
+		db: db,
+	}
+}
+
+// WithinTransaction commits the transaction when fn succeeds and rolls it back otherwise.
+// A function called within an existing transaction joins it.
+func (transactor *Transactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) (err error) {
+	if _, ok := ctx.Value(txCtxKey{}).(*transaction); ok {
+		return fn(ctx)
+	}
+	tx, err := transactor.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+	txn := &transaction{tx: tx}
+
+	if err = fn(context.WithValue(ctx, txCtxKey{}, txn)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, afterCommit := range txn.afterCommit {
+		afterCommit()
+	}
+
+	return nil
+}
+
+// AfterCommit runs fn once the transaction carried by the context is committed, or right
+// away when there is none. The functions of a rolled back transaction are never run. It is
+// meant for the side effects which mustn't be seen before the data they are about.
+func (transactor *Transactor) AfterCommit(ctx context.Context, fn func()) {
+	if txn, ok := ctx.Value(txCtxKey{}).(*transaction); ok {
+		txn.afterCommit = append(txn.afterCommit, fn)
+		return
+	}
+
+	fn()
+}
+
+// Executor returns the transaction carried by the context, or db when there is none.
+func Executor(ctx context.Context, db *sql.DB) Conn {
+	if txn, ok := ctx.Value(txCtxKey{}).(*transaction); ok {
+		return txn.tx
+	}
+
+	return db
+}
+
+// NoopTransactor runs the functions without transaction.
+// It is meant to be used with the in memory repositories.
+type NoopTransactor struct{}
+
+func (NoopTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (NoopTransactor) AfterCommit(ctx context.Context, fn func()) {
+	fn()
+}
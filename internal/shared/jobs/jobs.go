@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"sync"
+	"time"
+)
+
+// Job runs a task in the background, when it starts and then every interval,
+// until its context is done.
+type Job struct {
+	interval time.Duration
+	task     func(context.Context) error
+	logger   *logger.Log
+	wg       sync.WaitGroup
+}
+
+// Every returns the job running the task every interval. The task errors are
+// logged, except those happening once the context is done.
+func Every(interval time.Duration, task func(context.Context) error, logger *logger.Log) *Job {
+	return &Job{
+		interval: interval,
+		task:     task,
+		logger:   logger,
+	}
+}
+
+// Start runs the job in the background until the context is done.
+func (job *Job) Start(ctx context.Context) {
+	job.wg.Go(func() {
+		ticker := time.NewTicker(job.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job.task(ctx); err != nil && ctx.Err() == nil {
+				job.logger.Error.Println(err)
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// Wait blocks until the job stopped, after its context is done.
+func (job *Job) Wait() {
+	job.wg.Wait()
+}
@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJob(t *testing.T) {
+
+	t.Run("it should run the task when it starts and then every interval, until the context is done", func(t *testing.T) {
+		var runs atomic.Int32
+		ctx, cancel := context.WithCancel(context.Background())
+
+		job := Every(time.Millisecond, func(ctx context.Context) error {
+			if runs.Add(1) == 3 {
+				cancel()
+			}
+
+			return nil
+		}, logger.NewSpyLogger())
+
+		job.Start(ctx)
+		job.Wait()
+
+		assert.Equal(t, int32(3), runs.Load())
+	})
+
+	t.Run("it should keep running when the task fails", func(t *testing.T) {
+		var runs atomic.Int32
+		ctx, cancel := context.WithCancel(context.Background())
+
+		job := Every(time.Millisecond, func(ctx context.Context) error {
+			if runs.Add(1) == 2 {
+				cancel()
+			}
+
+			return errors.New("task failed")
+		}, logger.NewSpyLogger())
+
+		job.Start(ctx)
+		job.Wait()
+
+		assert.Equal(t, int32(2), runs.Load())
+	})
+}
@@ -0,0 +1,163 @@
+package mailer
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SmtpDriver   = "smtp"
+	LogDriver    = "log"
+	FileDriver   = "file"
+	MemoryDriver = "memory"
+)
+
+var ErrUnknownDriver = errors.New("unknown mail driver")
+
+type DriverConfig struct {
+	Driver    string
+	From      string
+	Host      string
+	Port      int
+	Auth      SmtpAuth
+	EnableTLS bool
+	// FileDir is the directory the file driver writes the emails to.
+	FileDir string
+}
+
+// NewSender returns the sender of the configured driver. Only the smtp driver
+// needs a mail server, the others are meant for development and testing.
+func NewSender(config DriverConfig, logger *logger.Log) (Sender, error) {
+	switch config.Driver {
+	case SmtpDriver:
+		return NewSmtpSender(config.Host, config.Port, config.From, config.Auth, config.EnableTLS)
+	case LogDriver:
+		return NewLogSender(logger), nil
+	case FileDriver:
+		return NewFileSender(config.FileDir, config.From)
+	case MemoryDriver:
+		return NewMemorySender(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, config.Driver)
+	}
+}
+
+type logSender struct {
+	logger *logger.Log
+}
+
+func NewLogSender(logger *logger.Log) *logSender {
+	return &logSender{
+		logger: logger,
+	}
+}
+
+// Send prints the text version of the email.
+func (sender *logSender) Send(ctx context.Context, email *Email) error {
+	sender.logger.Info.Printf("mail to %s\nSubject: %s\n\n%s\n", email.To, email.Subject, email.Text)
+	return nil
+}
+
+type fileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) (*fileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &fileSender{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+// Send writes the email in a .eml file, which can be opened by most mail clients.
+func (sender *fileSender) Send(ctx context.Context, email *Email) error {
+	msg, err := newMultipartMsg(sender.from, email)
+
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405"), uuid.NewString())
+
+	return msg.WriteToFile(filepath.Join(sender.dir, name))
+}
+
+// MemorySender keeps the emails instead of sending them, so that
+// the tests can inspect what would have been sent.
+type MemorySender struct {
+	mu   sync.Mutex
+	sent []Email
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (sender *MemorySender) Send(ctx context.Context, email *Email) error {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	sender.sent = append(sender.sent, *email)
+
+	return nil
+}
+
+// Sent returns the emails sent so far, from the oldest to the newest.
+func (sender *MemorySender) Sent() []Email {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	return append([]Email{}, sender.sent...)
+}
+
+// LastSentTo returns the newest email sent to the given address.
+func (sender *MemorySender) LastSentTo(to string) (Email, bool) {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	for i := len(sender.sent) - 1; i >= 0; i-- {
+		if sender.sent[i].To == to {
+			return sender.sent[i], true
+		}
+	}
+
+	return Email{}, false
+}
+
+// FindInLastSentTo matches the pattern against the text of the newest email sent to the
+// given address, and returns its first group, e.g. the code of an OTP message with `(\d{6})`.
+func (sender *MemorySender) FindInLastSentTo(to string, pattern *regexp.Regexp) (string, bool) {
+	email, ok := sender.LastSentTo(to)
+
+	if !ok {
+		return "", false
+	}
+	matches := pattern.FindStringSubmatch(email.Text)
+
+	if len(matches) < 2 {
+		return "", false
+	}
+
+	return matches[1], true
+}
+
+// Reset forgets the emails sent so far.
+func (sender *MemorySender) Reset() {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	sender.sent = nil
+}
@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSender(t *testing.T) {
+
+	t.Run("it should return the sender of the configured driver", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		sender, err := NewSender(DriverConfig{Driver: LogDriver}, logger.NewSpyLogger())
+		_assert.NoError(err)
+		_assert.IsType(&logSender{}, sender)
+
+		sender, err = NewSender(DriverConfig{Driver: FileDriver, FileDir: t.TempDir()}, logger.NewSpyLogger())
+		_assert.NoError(err)
+		_assert.IsType(&fileSender{}, sender)
+
+		sender, err = NewSender(DriverConfig{Driver: MemoryDriver}, logger.NewSpyLogger())
+		_assert.NoError(err)
+		_assert.IsType(&MemorySender{}, sender)
+	})
+
+	t.Run("it should fail and return ErrUnknownDriver", func(t *testing.T) {
+		_, err := NewSender(DriverConfig{Driver: "pigeon"}, logger.NewSpyLogger())
+		assert.ErrorIs(t, err, ErrUnknownDriver)
+	})
+}
+
+func TestFileSender(t *testing.T) {
+
+	t.Run("it should write the email in an eml file", func(t *testing.T) {
+		dir := t.TempDir()
+		sender, _ := NewFileSender(dir, "noreply@comu.com")
+
+		err := sender.Send(context.Background(), &Email{
+			To:      "johndoe@gmail.com",
+			Subject: "Login verification",
+			Text:    "Your verification code is: 482913",
+			HTML:    "<p>Your verification code is: 482913</p>",
+		})
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+
+			if _assert.Len(files, 1) {
+				content, _ := os.ReadFile(files[0])
+				_assert.Contains(string(content), "Subject: Login verification")
+				_assert.Contains(string(content), "To: <johndoe@gmail.com>")
+				_assert.True(strings.Contains(string(content), "multipart/alternative"))
+			}
+		}
+	})
+}
+
+func TestMemorySender(t *testing.T) {
+
+	t.Run("it should keep the sent emails for inspection", func(t *testing.T) {
+		sender := NewMemorySender()
+		ctx := context.Background()
+
+		sender.Send(ctx, &Email{To: "johndoe@gmail.com", Text: "Your verification code is: 111111"})
+		sender.Send(ctx, &Email{To: "janedoe@gmail.com", Text: "Your verification code is: 222222"})
+		sender.Send(ctx, &Email{To: "johndoe@gmail.com", Text: "Your verification code is: 333333"})
+		_assert := assert.New(t)
+
+		_assert.Len(sender.Sent(), 3)
+
+		code, ok := sender.FindInLastSentTo("johndoe@gmail.com", regexp.MustCompile(`(\d{6})`))
+		_assert.True(ok)
+		_assert.Equal("333333", code)
+
+		_, ok = sender.LastSentTo("unknown@gmail.com")
+		_assert.False(ok)
+
+		sender.Reset()
+		_assert.Empty(sender.Sent())
+	})
+}
@@ -0,0 +1,18 @@
+package mailer
+
+import "context"
+
+// Email is a message ready to be delivered.
+type Email struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	// Headers are added to the standard ones, like the List-Unsubscribe header.
+	Headers map[string]string
+}
+
+// Sender delivers the emails.
+type Sender interface {
+	Send(context.Context, *Email) error
+}
@@ -0,0 +1,280 @@
+package mailer
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxStatus = string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxSent    OutboxStatus = "sent"
+	// OutboxDead is the status of the messages which failed to be sent too many times.
+	OutboxDead OutboxStatus = "dead"
+)
+
+const maxOutboxErrorLength = 1024
+
+// OutboxMessage is an email waiting in the outbox to be sent.
+type OutboxMessage struct {
+	ID            uuid.UUID
+	Email         Email
+	Status        OutboxStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	LockedUntil   *time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+func NewOutboxMessage(email *Email) *OutboxMessage {
+	return &OutboxMessage{
+		ID:            uuid.New(),
+		Email:         *email,
+		Status:        OutboxPending,
+		NextAttemptAt: time.Now(),
+		CreatedAt:     time.Now(),
+	}
+}
+
+type OutboxRepository interface {
+	Store(context.Context, *OutboxMessage) error
+	// Claim locks up to limit pending messages due to be sent until the lease ends,
+	// so that they aren't sent twice by concurrent workers or application instances.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error)
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	// Reschedule releases a message which failed to be sent, with its next attempt time.
+	Reschedule(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error
+	MarkDead(ctx context.Context, id uuid.UUID, attempts int, lastError string) error
+}
+
+type OutboxOptions struct {
+	Workers      int
+	BatchSize    int
+	PollInterval time.Duration
+	Lease        time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+func DefaultOutboxOptions() OutboxOptions {
+	return OutboxOptions{
+		Workers:      2,
+		BatchSize:    20,
+		PollInterval: time.Second * 2,
+		Lease:        time.Minute,
+		MaxAttempts:  8,
+		BaseBackoff:  time.Second * 30,
+		MaxBackoff:   time.Hour,
+	}
+}
+
+// OutboxMetrics counts the outbox messages by outcome since the application started.
+type OutboxMetrics struct {
+	Enqueued int64 `json:"enqueued"`
+	Sent     int64 `json:"sent"`
+	Retried  int64 `json:"retried"`
+	Dead     int64 `json:"dead"`
+}
+
+// Outbox stores the emails to send and delivers them in the background. Storing them
+// in the database lets an email be enqueued in the same transaction as the records it
+// is about, and keeps it from being lost when the mail server is unavailable.
+type Outbox struct {
+	repository OutboxRepository
+	sender     Sender
+	options    OutboxOptions
+	logger     *logger.Log
+
+	enqueued atomic.Int64
+	sent     atomic.Int64
+	retried  atomic.Int64
+	dead     atomic.Int64
+
+	wg sync.WaitGroup
+}
+
+func NewOutbox(repository OutboxRepository, sender Sender, options OutboxOptions, logger *logger.Log) *Outbox {
+	defaults := DefaultOutboxOptions()
+
+	if options.Workers <= 0 {
+		options.Workers = defaults.Workers
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaults.BatchSize
+	}
+
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaults.PollInterval
+	}
+
+	if options.Lease <= 0 {
+		options.Lease = defaults.Lease
+	}
+
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaults.MaxAttempts
+	}
+
+	if options.BaseBackoff <= 0 {
+		options.BaseBackoff = defaults.BaseBackoff
+	}
+
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = defaults.MaxBackoff
+	}
+
+	return &Outbox{
+		repository: repository,
+		sender:     sender,
+		options:    options,
+		logger:     logger,
+	}
+}
+
+// Enqueue stores the email to be sent by the workers. It joins the transaction
+// carried by the context, if any.
+func (outbox *Outbox) Enqueue(ctx context.Context, email *Email) error {
+	if err := outbox.repository.Store(ctx, NewOutboxMessage(email)); err != nil {
+		return err
+	}
+	outbox.enqueued.Add(1)
+
+	return nil
+}
+
+// Start launches the workers, which send the due messages until the context is done.
+func (outbox *Outbox) Start(ctx context.Context) {
+	messages := make(chan OutboxMessage)
+
+	for range outbox.options.Workers {
+		outbox.wg.Go(func() {
+			for message := range messages {
+				outbox.deliver(context.WithoutCancel(ctx), message)
+			}
+		})
+	}
+
+	outbox.wg.Go(func() {
+		defer close(messages)
+		ticker := time.NewTicker(outbox.options.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			claimed, err := outbox.repository.Claim(ctx, outbox.options.BatchSize, outbox.options.Lease)
+
+			if err != nil && ctx.Err() == nil {
+				outbox.logger.Error.Println(err)
+			}
+
+			for _, message := range claimed {
+				select {
+				case messages <- message:
+				case <-ctx.Done():
+					// The messages left are claimed again when their lease ends.
+					return
+				}
+			}
+
+			// Polls again right away while the batches are full.
+			if len(claimed) == outbox.options.BatchSize {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// Wait blocks until the workers started by Start have stopped.
+func (outbox *Outbox) Wait() {
+	outbox.wg.Wait()
+}
+
+// Flush sends the messages which are due right away, without the workers.
+func (outbox *Outbox) Flush(ctx context.Context) error {
+	for {
+		claimed, err := outbox.repository.Claim(ctx, outbox.options.BatchSize, outbox.options.Lease)
+
+		if err != nil {
+			return err
+		}
+
+		for _, message := range claimed {
+			outbox.deliver(ctx, message)
+		}
+
+		if len(claimed) < outbox.options.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (outbox *Outbox) Metrics() OutboxMetrics {
+	return OutboxMetrics{
+		Enqueued: outbox.enqueued.Load(),
+		Sent:     outbox.sent.Load(),
+		Retried:  outbox.retried.Load(),
+		Dead:     outbox.dead.Load(),
+	}
+}
+
+func (outbox *Outbox) deliver(ctx context.Context, message OutboxMessage) {
+	sendErr := outbox.sender.Send(ctx, &message.Email)
+
+	if sendErr == nil {
+		if err := outbox.repository.MarkSent(ctx, message.ID); err != nil {
+			outbox.logger.Error.Println(err)
+		}
+		outbox.sent.Add(1)
+
+		return
+	}
+	attempts := message.Attempts + 1
+	lastError := sendErr.Error()
+
+	if len(lastError) > maxOutboxErrorLength {
+		lastError = lastError[:maxOutboxErrorLength]
+	}
+
+	if attempts >= outbox.options.MaxAttempts {
+		outbox.logger.Error.Printf("outbox: giving up on message %s after %d attempts: %s\n", message.ID, attempts, lastError)
+
+		if err := outbox.repository.MarkDead(ctx, message.ID, attempts, lastError); err != nil {
+			outbox.logger.Error.Println(err)
+		}
+		outbox.dead.Add(1)
+
+		return
+	}
+	nextAttemptAt := time.Now().Add(outbox.backoff(attempts))
+
+	if err := outbox.repository.Reschedule(ctx, message.ID, attempts, nextAttemptAt, lastError); err != nil {
+		outbox.logger.Error.Println(err)
+	}
+	outbox.retried.Add(1)
+}
+
+// backoff doubles the delay before the next attempt after each failed one.
+func (outbox *Outbox) backoff(attempts int) time.Duration {
+	delay := outbox.options.BaseBackoff
+
+	for i := 1; i < attempts && delay < outbox.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, outbox.options.MaxBackoff)
+}
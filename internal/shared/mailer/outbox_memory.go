@@ -0,0 +1,138 @@
+package mailer
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type outboxStore map[uuid.UUID]OutboxMessage
+
+type inMemoryOutboxRepository struct {
+	messages outboxStore
+	sync.Mutex
+}
+
+func NewInMemoryOutboxRepository(initialStore outboxStore) *inMemoryOutboxRepository {
+	if initialStore == nil {
+		initialStore = make(outboxStore)
+	}
+
+	return &inMemoryOutboxRepository{
+		messages: initialStore,
+	}
+}
+
+func (repo *inMemoryOutboxRepository) Store(ctx context.Context, message *OutboxMessage) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.messages[message.ID] = *message
+
+	return nil
+}
+
+func (repo *inMemoryOutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	now := time.Now()
+	claimed := []OutboxMessage{}
+
+	for _, message := range repo.messages {
+		if message.Status != OutboxPending || message.NextAttemptAt.After(now) {
+			continue
+		}
+
+		if message.LockedUntil != nil && message.LockedUntil.After(now) {
+			continue
+		}
+		claimed = append(claimed, message)
+	}
+
+	slices.SortFunc(claimed, func(a, b OutboxMessage) int {
+		return a.NextAttemptAt.Compare(b.NextAttemptAt)
+	})
+
+	if len(claimed) > limit {
+		claimed = claimed[:limit]
+	}
+	lockedUntil := now.Add(lease)
+
+	for i := range claimed {
+		claimed[i].LockedUntil = &lockedUntil
+		repo.messages[claimed[i].ID] = claimed[i]
+	}
+
+	return claimed, nil
+}
+
+func (repo *inMemoryOutboxRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	return repo.update(id, func(message *OutboxMessage) {
+		now := time.Now()
+		message.Status = OutboxSent
+		message.SentAt = &now
+	})
+}
+
+func (repo *inMemoryOutboxRepository) Reschedule(
+	ctx context.Context, id uuid.UUID, attempts int,
+	nextAttemptAt time.Time, lastError string,
+) error {
+	return repo.update(id, func(message *OutboxMessage) {
+		message.Attempts = attempts
+		message.NextAttemptAt = nextAttemptAt
+		message.LastError = lastError
+	})
+}
+
+func (repo *inMemoryOutboxRepository) MarkDead(ctx context.Context, id uuid.UUID, attempts int, lastError string) error {
+	return repo.update(id, func(message *OutboxMessage) {
+		message.Status = OutboxDead
+		message.Attempts = attempts
+		message.LastError = lastError
+	})
+}
+
+// Find returns a stored message. It allows the tests to check the messages state.
+func (repo *inMemoryOutboxRepository) Find(id uuid.UUID) (OutboxMessage, bool) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	message, ok := repo.messages[id]
+
+	return message, ok
+}
+
+// All returns the stored messages.
+func (repo *inMemoryOutboxRepository) All() []OutboxMessage {
+	repo.Lock()
+	defer repo.Unlock()
+
+	messages := []OutboxMessage{}
+
+	for _, message := range repo.messages {
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+func (repo *inMemoryOutboxRepository) update(id uuid.UUID, fn func(*OutboxMessage)) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	message, ok := repo.messages[id]
+
+	if !ok {
+		return nil
+	}
+	fn(&message)
+	message.LockedUntil = nil
+	repo.messages[id] = message
+
+	return nil
+}
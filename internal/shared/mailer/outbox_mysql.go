@@ -0,0 +1,139 @@
+package mailer
+
+import (
+	"comu/internal/shared/database"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mysqlOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewMysqlOutboxRepository(db *sql.DB) *mysqlOutboxRepository {
+	return &mysqlOutboxRepository{
+		db: db,
+	}
+}
+
+func (repo *mysqlOutboxRepository) Store(ctx context.Context, message *OutboxMessage) error {
+	query := `
+		INSERT INTO mail_outbox (
+			id, recipient, subject, html_body, text_body, status,
+			attempts, last_error, next_attempt_at, created_at, headers
+		) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	headers, err := json.Marshal(message.Email.Headers)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = database.Executor(ctx, repo.db).ExecContext(
+		ctx, query, message.ID.String(), message.Email.To, message.Email.Subject,
+		message.Email.HTML, message.Email.Text, message.Status, message.Attempts,
+		message.LastError, message.NextAttemptAt, message.CreatedAt, headers,
+	)
+
+	return err
+}
+
+// Claim locks the messages with a single update, which MySQL runs atomically, then
+// reads back the ones holding the claim id.
+func (repo *mysqlOutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error) {
+	claimID := uuid.NewString()
+	now := time.Now()
+
+	query := `
+		UPDATE mail_outbox SET locked_until = ?, claim_id = ?
+		WHERE status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)
+		ORDER BY next_attempt_at LIMIT ?
+	`
+
+	result, err := repo.db.ExecContext(ctx, query, now.Add(lease), claimID, OutboxPending, now, now, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if count, err := result.RowsAffected(); err != nil || count == 0 {
+		return []OutboxMessage{}, err
+	}
+
+	rows, err := repo.db.QueryContext(
+		ctx, "SELECT * FROM mail_outbox WHERE claim_id = ? ORDER BY next_attempt_at", claimID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []OutboxMessage{}
+
+	for rows.Next() {
+		var message OutboxMessage
+		var claim sql.NullString
+		var headers []byte
+
+		err := rows.Scan(
+			&message.ID, &message.Email.To, &message.Email.Subject, &message.Email.HTML,
+			&message.Email.Text, &message.Status, &message.Attempts, &message.LastError,
+			&message.NextAttemptAt, &message.LockedUntil, &claim, &message.CreatedAt,
+			&message.SentAt, &headers,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(headers) > 0 {
+			if err := json.Unmarshal(headers, &message.Email.Headers); err != nil {
+				return nil, err
+			}
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+func (repo *mysqlOutboxRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE mail_outbox SET status = ?, sent_at = ?, locked_until = NULL, claim_id = NULL
+		WHERE id = UUID_TO_BIN(?)
+	`
+
+	_, err := repo.db.ExecContext(ctx, query, OutboxSent, time.Now(), id.String())
+
+	return err
+}
+
+func (repo *mysqlOutboxRepository) Reschedule(
+	ctx context.Context, id uuid.UUID, attempts int,
+	nextAttemptAt time.Time, lastError string,
+) error {
+	query := `
+		UPDATE mail_outbox SET attempts = ?, next_attempt_at = ?, last_error = ?,
+		locked_until = NULL, claim_id = NULL WHERE id = UUID_TO_BIN(?)
+	`
+
+	_, err := repo.db.ExecContext(ctx, query, attempts, nextAttemptAt, lastError, id.String())
+
+	return err
+}
+
+func (repo *mysqlOutboxRepository) MarkDead(ctx context.Context, id uuid.UUID, attempts int, lastError string) error {
+	query := `
+		UPDATE mail_outbox SET status = ?, attempts = ?, last_error = ?,
+		locked_until = NULL, claim_id = NULL WHERE id = UUID_TO_BIN(?)
+	`
+
+	_, err := repo.db.ExecContext(ctx, query, OutboxDead, attempts, lastError, id.String())
+
+	return err
+}
@@ -0,0 +1,151 @@
+package mailer
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type spySender struct {
+	mu    sync.Mutex
+	sent  []Email
+	fails int
+}
+
+func (sender *spySender) Send(ctx context.Context, email *Email) error {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	if sender.fails > 0 {
+		sender.fails--
+		return errors.New("connection refused")
+	}
+	sender.sent = append(sender.sent, *email)
+
+	return nil
+}
+
+func (sender *spySender) count() int {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	return len(sender.sent)
+}
+
+func newTestEmail() *Email {
+	return &Email{
+		To:      "johndoe@gmail.com",
+		Subject: "Login verification",
+		Text:    "Your verification code is: 482913",
+	}
+}
+
+func TestOutbox(t *testing.T) {
+
+	t.Run("it should send the enqueued emails and mark them as sent", func(t *testing.T) {
+		repository := NewInMemoryOutboxRepository(nil)
+		sender := &spySender{}
+		outbox := NewOutbox(repository, sender, DefaultOutboxOptions(), logger.NewSpyLogger())
+		ctx := context.Background()
+
+		outbox.Enqueue(ctx, newTestEmail())
+		err := outbox.Flush(ctx)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) && _assert.Equal(1, sender.count()) {
+			message := repository.All()[0]
+			_assert.Equal(OutboxSent, message.Status)
+			_assert.NotNil(message.SentAt)
+			_assert.Equal(OutboxMetrics{Enqueued: 1, Sent: 1}, outbox.Metrics())
+		}
+	})
+
+	t.Run("it should reschedule a failed email with an exponential backoff", func(t *testing.T) {
+		repository := NewInMemoryOutboxRepository(nil)
+		sender := &spySender{fails: 2}
+		options := DefaultOutboxOptions()
+		outbox := NewOutbox(repository, sender, options, logger.NewSpyLogger())
+		ctx := context.Background()
+
+		outbox.Enqueue(ctx, newTestEmail())
+		outbox.Flush(ctx)
+		message := repository.All()[0]
+		_assert := assert.New(t)
+
+		_assert.Equal(OutboxPending, message.Status)
+		_assert.Equal(1, message.Attempts)
+		_assert.Equal("connection refused", message.LastError)
+		_assert.WithinDuration(time.Now().Add(options.BaseBackoff), message.NextAttemptAt, time.Second)
+
+		// The message isn't due yet
+		outbox.Flush(ctx)
+		_assert.Equal(0, sender.count())
+
+		_assert.Equal(options.BaseBackoff*2, outbox.backoff(2))
+		_assert.Equal(options.BaseBackoff*4, outbox.backoff(3))
+		_assert.Equal(options.MaxBackoff, outbox.backoff(20))
+	})
+
+	t.Run("it should dead letter an email after too many failed attempts", func(t *testing.T) {
+		repository := NewInMemoryOutboxRepository(nil)
+		sender := &spySender{fails: 10}
+		outbox := NewOutbox(repository, sender, OutboxOptions{
+			MaxAttempts: 3,
+			BaseBackoff: time.Nanosecond,
+			MaxBackoff:  time.Nanosecond,
+		}, logger.NewSpyLogger())
+		ctx := context.Background()
+
+		outbox.Enqueue(ctx, newTestEmail())
+
+		for range 5 {
+			time.Sleep(time.Millisecond)
+			outbox.Flush(ctx)
+		}
+		message := repository.All()[0]
+		_assert := assert.New(t)
+
+		_assert.Equal(OutboxDead, message.Status)
+		_assert.Equal(3, message.Attempts)
+		_assert.Equal(OutboxMetrics{Enqueued: 1, Retried: 2, Dead: 1}, outbox.Metrics())
+	})
+
+	t.Run("it should not claim a message twice while its lease is running", func(t *testing.T) {
+		repository := NewInMemoryOutboxRepository(nil)
+		ctx := context.Background()
+
+		repository.Store(ctx, NewOutboxMessage(newTestEmail()))
+
+		first, _ := repository.Claim(ctx, 10, time.Minute)
+		second, _ := repository.Claim(ctx, 10, time.Minute)
+
+		assert.Len(t, first, 1)
+		assert.Empty(t, second)
+	})
+
+	t.Run("it should send the emails in the background until the context is done", func(t *testing.T) {
+		repository := NewInMemoryOutboxRepository(nil)
+		sender := &spySender{}
+		outbox := NewOutbox(repository, sender, OutboxOptions{
+			Workers:      3,
+			BatchSize:    2,
+			PollInterval: time.Millisecond * 10,
+		}, logger.NewSpyLogger())
+		ctx, cancel := context.WithCancel(context.Background())
+
+		for range 5 {
+			outbox.Enqueue(ctx, newTestEmail())
+		}
+		outbox.Start(ctx)
+
+		assert.Eventually(t, func() bool { return sender.count() == 5 }, time.Second, time.Millisecond*10)
+
+		cancel()
+		outbox.Wait()
+	})
+}
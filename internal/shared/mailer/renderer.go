@@ -0,0 +1,187 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmlTemplate "html/template"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	textTemplate "text/template"
+)
+
+const DefaultLocale = "en"
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// Message is a rendered email, ready to be sent as a multipart message.
+type Message struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type templateData struct {
+	AppName        string
+	AppURL         string
+	UnsubscribeURL string
+	Data           any
+}
+
+type templateSet struct {
+	html *htmlTemplate.Template
+	text *textTemplate.Template
+}
+
+// Renderer renders the email templates. Each template is made of a "<name>.txt" file
+// defining the "subject" and "content" blocks, and a "<name>.html" file defining the
+// "content" block, both stored in a directory per locale and wrapped in the layouts.
+// The "footer" files of each locale define the "unsubscribe" block of the layouts.
+type Renderer struct {
+	appName string
+	appURL  string
+	fsys    fs.FS
+
+	mu    sync.Mutex
+	cache map[string]*templateSet
+}
+
+// NewRenderer returns a renderer of the embedded templates. When overrideDir is
+// not empty, the templates found in that directory are used instead.
+func NewRenderer(appName, appURL, overrideDir string) *Renderer {
+	templates, _ := fs.Sub(embeddedTemplates, "templates")
+	var fsys fs.FS = templates
+
+	if overrideDir != "" {
+		fsys = overlayFS{upper: os.DirFS(overrideDir), lower: templates}
+	}
+
+	return &Renderer{
+		appName: appName,
+		appURL:  appURL,
+		fsys:    fsys,
+		cache:   make(map[string]*templateSet),
+	}
+}
+
+// Render renders the named template in the given locale, falling back
+// to the default locale when the template isn't translated.
+func (renderer *Renderer) Render(name, locale string, data any) (*Message, error) {
+	return renderer.render(name, locale, templateData{Data: data})
+}
+
+// RenderUnsubscribable renders the named template like Render, with a footer
+// holding the link to unsubscribe from this kind of emails.
+func (renderer *Renderer) RenderUnsubscribable(name, locale string, data any, unsubscribeURL string) (*Message, error) {
+	return renderer.render(name, locale, templateData{Data: data, UnsubscribeURL: unsubscribeURL})
+}
+
+func (renderer *Renderer) render(name, locale string, values templateData) (*Message, error) {
+	set, err := renderer.load(name, renderer.resolveLocale(name, locale))
+
+	if err != nil {
+		return nil, err
+	}
+	values.AppName = renderer.appName
+	values.AppURL = renderer.appURL
+
+	var subject, text, html bytes.Buffer
+
+	if err := set.text.ExecuteTemplate(&subject, "subject", values); err != nil {
+		return nil, err
+	}
+
+	if err := set.text.ExecuteTemplate(&text, "layout", values); err != nil {
+		return nil, err
+	}
+
+	if err := set.html.ExecuteTemplate(&html, "layout", values); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// URL returns the absolute url of the given application path.
+func (renderer *Renderer) URL(path string) string {
+	return renderer.appURL + path
+}
+
+func (renderer *Renderer) resolveLocale(name, locale string) string {
+	locale = normalizeLocale(locale)
+
+	if locale == "" {
+		return DefaultLocale
+	}
+
+	if _, err := fs.Stat(renderer.fsys, locale+"/"+name+".txt"); err != nil {
+		return DefaultLocale
+	}
+
+	return locale
+}
+
+func (renderer *Renderer) load(name, locale string) (*templateSet, error) {
+	key := locale + "/" + name
+
+	renderer.mu.Lock()
+	defer renderer.mu.Unlock()
+
+	if set, ok := renderer.cache[key]; ok {
+		return set, nil
+	}
+	footer := locale + "/footer"
+	text, err := textTemplate.ParseFS(renderer.fsys, "layout.txt", footer+".txt", key+".txt")
+
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmlTemplate.ParseFS(renderer.fsys, "layout.html", footer+".html", key+".html")
+
+	if err != nil {
+		return nil, err
+	}
+	set := &templateSet{html: html, text: text}
+	renderer.cache[key] = set
+
+	return set, nil
+}
+
+// normalizeLocale keeps the language of a locale, so that "fr-FR" and "fr_CA" both give "fr".
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+
+	// Prevents reading files outside of the templates directory.
+	if strings.ContainsAny(locale, "./\\") {
+		return ""
+	}
+
+	return locale
+}
+
+// overlayFS reads the files from the upper file system first,
+// and from the lower one when they don't exist in the upper one.
+type overlayFS struct {
+	upper fs.FS
+	lower fs.FS
+}
+
+func (overlay overlayFS) Open(name string) (fs.File, error) {
+	file, err := overlay.upper.Open(name)
+
+	if err == nil {
+		return file, nil
+	}
+
+	return overlay.lower.Open(name)
+}
@@ -0,0 +1,116 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRendererRender(t *testing.T) {
+	data := map[string]any{"Code": "482913", "Minutes": 10}
+
+	t.Run("it should render the subject, html and text parts of a template", func(t *testing.T) {
+		renderer := NewRenderer("Comu", "http://localhost:4000", "")
+
+		msg, err := renderer.Render("login_otp", "en", data)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal("Login verification", msg.Subject)
+			_assert.Contains(msg.Text, "Your verification code is: 482913")
+			_assert.Contains(msg.Text, "Comu")
+			_assert.Contains(msg.HTML, "<!DOCTYPE html>")
+			_assert.Contains(msg.HTML, "482913")
+			_assert.Contains(msg.HTML, `href="http://localhost:4000"`)
+		}
+	})
+
+	t.Run("it should render the template of the user locale", func(t *testing.T) {
+		renderer := NewRenderer("Comu", "http://localhost:4000", "")
+
+		msg, err := renderer.Render("login_otp", "fr-FR", data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Vérification de connexion", msg.Subject)
+		}
+	})
+
+	t.Run("it should fall back to the default locale when the locale isn't supported", func(t *testing.T) {
+		renderer := NewRenderer("Comu", "http://localhost:4000", "")
+
+		for _, locale := range []string{"de", "", "../en"} {
+			msg, err := renderer.Render("login_otp", locale, data)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, "Login verification", msg.Subject)
+			}
+		}
+	})
+
+	t.Run("it should escape the html part values", func(t *testing.T) {
+		renderer := NewRenderer("Comu", "http://localhost:4000", "")
+
+		msg, err := renderer.Render("new_sign_in", "en", map[string]string{
+			"Date":      "Mon, 02 Jan 2006 15:04:05 UTC",
+			"IP":        "192.168.1.10",
+			"UserAgent": "<script>alert(1)</script>",
+			"RevokeURL": "http://localhost:4000/sessions/revoke/token",
+		})
+
+		if assert.NoError(t, err) {
+			assert.NotContains(t, msg.HTML, "<script>")
+			assert.Contains(t, msg.Text, "<script>alert(1)</script>")
+		}
+	})
+
+	t.Run("it should use the templates of the override directory", func(t *testing.T) {
+		dir := t.TempDir()
+		os.Mkdir(filepath.Join(dir, "en"), 0o755)
+		os.WriteFile(
+			filepath.Join(dir, "en", "login_otp.txt"),
+			[]byte(`{{define "subject"}}Your {{.AppName}} code{{end}}{{define "content"}}Code: {{.Data.Code}}{{end}}`),
+			0o644,
+		)
+		renderer := NewRenderer("Comu", "http://localhost:4000", dir)
+
+		msg, err := renderer.Render("login_otp", "en", data)
+		_assert := assert.New(t)
+
+		if _assert.NoError(err) {
+			_assert.Equal("Your Comu code", msg.Subject)
+			_assert.Contains(msg.Text, "Code: 482913")
+			// The html part isn't overridden
+			_assert.Contains(msg.HTML, "Your verification code is:")
+		}
+	})
+
+	t.Run("it should only add the unsubscribe footer when asked to", func(t *testing.T) {
+		_assert := assert.New(t)
+		renderer := NewRenderer("Comu", "http://localhost:4000", "")
+		unsubscribeURL := "http://localhost:4000/notifications/unsubscribe?token=abc"
+		postData := map[string]any{"post_title": "Hello", "post_slug": "hello"}
+
+		msg, err := renderer.Render("post_commented", "en", postData)
+
+		if _assert.NoError(err) {
+			_assert.NotContains(msg.Text, "unsubscribe")
+			_assert.NotContains(msg.HTML, "Unsubscribe")
+		}
+
+		msg, err = renderer.RenderUnsubscribable("post_commented", "fr", postData, unsubscribeURL)
+
+		if _assert.NoError(err) {
+			_assert.Contains(msg.Text, unsubscribeURL)
+			_assert.Contains(msg.HTML, "Se désabonner")
+		}
+	})
+
+	t.Run("it should fail when the template doesn't exist", func(t *testing.T) {
+		renderer := NewRenderer("Comu", "http://localhost:4000", "")
+
+		_, err := renderer.Render("unknown", "en", data)
+		assert.Error(t, err)
+	})
+}
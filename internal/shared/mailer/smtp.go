@@ -0,0 +1,85 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/wneessen/go-mail"
+)
+
+type SmtpAuth struct {
+	Username string
+	Password string
+}
+
+type smtpSender struct {
+	host    string
+	from    string
+	options []mail.Option
+}
+
+func NewSmtpSender(host string, port int, from string, auth SmtpAuth, enableTLS bool) (*smtpSender, error) {
+	mailOptions := []mail.Option{
+		mail.WithPort(port),
+		mail.WithUsername(auth.Username),
+		mail.WithPassword(auth.Password),
+	}
+
+	// The default TLSPolicy is TLSMandatory
+	if !enableTLS {
+		mailOptions = append(mailOptions, mail.WithTLSPolicy(mail.NoTLS))
+	}
+
+	// Checks the options now, so that a misconfiguration is caught at startup.
+	if _, err := mail.NewClient(host, mailOptions...); err != nil {
+		return nil, err
+	}
+
+	return &smtpSender{
+		host:    host,
+		from:    from,
+		options: mailOptions,
+	}, nil
+}
+
+func (sender *smtpSender) Send(ctx context.Context, email *Email) error {
+	msg, err := newMultipartMsg(sender.from, email)
+
+	if err != nil {
+		return err
+	}
+
+	// A client holds its connection, so each send uses its own to be safe for concurrent use.
+	client, err := mail.NewClient(sender.host, sender.options...)
+
+	if err != nil {
+		return err
+	}
+
+	return client.DialAndSendWithContext(ctx, msg)
+}
+
+// newMultipartMsg builds the email as a multipart message, with the text version as fallback.
+func newMultipartMsg(from string, email *Email) (*mail.Msg, error) {
+	msg := mail.NewMsg()
+
+	if err := msg.From(from); err != nil {
+		return nil, err
+	}
+
+	if err := msg.To(email.To); err != nil {
+		return nil, err
+	}
+
+	msg.Subject(email.Subject)
+
+	for name, value := range email.Headers {
+		msg.SetGenHeader(mail.Header(name), value)
+	}
+	msg.SetBodyString(mail.TypeTextPlain, email.Text)
+
+	if email.HTML != "" {
+		msg.AddAlternativeString(mail.TypeTextHTML, email.HTML)
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,584 @@
+// Package markdown renders the Markdown written by the users to HTML which is safe
+// to display. The raw HTML of the source is escaped rather than passed through, and
+// the rendered HTML goes through an allowlist sanitizer.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingRegex     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	ruleRegex        = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceRegex       = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	bulletRegex      = regexp.MustCompile(`^( {0,3})([-*+])([ \t]+|$)`)
+	orderedRegex     = regexp.MustCompile(`^( {0,3})(\d{1,9})([.)])([ \t]+|$)`)
+	quoteRegex       = regexp.MustCompile(`^ {0,3}> ?`)
+	languageRegex    = regexp.MustCompile(`^[A-Za-z0-9_+#-]+$`)
+	autolinkRegex    = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]+)>`)
+	punctuationRunes = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+)
+
+// Render returns the sanitized HTML of the Markdown source.
+func Render(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	return Sanitize(renderBlocks(lines, false))
+}
+
+// renderBlocks renders the block elements of the lines. The paragraphs of the tight
+// list items are not wrapped in <p> tags.
+func renderBlocks(lines []string, tight bool) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case isBlank(line):
+			i++
+
+		case fenceRegex.MatchString(line):
+			i = renderFencedCode(&builder, lines, i)
+
+		case isIndentedCode(line):
+			i = renderIndentedCode(&builder, lines, i)
+
+		case headingRegex.MatchString(line):
+			match := headingRegex.FindStringSubmatch(line)
+			level := len(match[1])
+			fmt.Fprintf(&builder, "<h%d>%s</h%d>\n", level, renderInline(match[2]), level)
+			i++
+
+		case ruleRegex.MatchString(line):
+			builder.WriteString("<hr>\n")
+			i++
+
+		case quoteRegex.MatchString(line):
+			i = renderQuote(&builder, lines, i)
+
+		case bulletRegex.MatchString(line) || orderedRegex.MatchString(line):
+			i = renderList(&builder, lines, i)
+
+		default:
+			i = renderParagraph(&builder, lines, i, tight)
+		}
+	}
+
+	return builder.String()
+}
+
+func renderFencedCode(builder *strings.Builder, lines []string, start int) int {
+	match := fenceRegex.FindStringSubmatch(lines[start])
+	fence, language := match[1], match[2]
+	code := []string{}
+	i := start + 1
+
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		if strings.HasPrefix(trimmed, fence[:3]) && strings.Trim(trimmed, fence[:1]) == "" && len(trimmed) >= len(fence) {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+	builder.WriteString("<pre><code")
+
+	if languageRegex.MatchString(language) {
+		fmt.Fprintf(builder, ` class="language-%s"`, html.EscapeString(language))
+	}
+	builder.WriteString(">")
+
+	for _, line := range code {
+		builder.WriteString(html.EscapeString(line) + "\n")
+	}
+	builder.WriteString("</code></pre>\n")
+
+	return i
+}
+
+func isIndentedCode(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+func renderIndentedCode(builder *strings.Builder, lines []string, start int) int {
+	code := []string{}
+	i := start
+
+	for ; i < len(lines) && (isIndentedCode(lines[i]) || isBlank(lines[i])); i++ {
+		code = append(code, removeIndent(lines[i], 4))
+	}
+
+	// The trailing blank lines are not part of the code.
+	for len(code) > 0 && isBlank(code[len(code)-1]) {
+		code = code[:len(code)-1]
+	}
+	builder.WriteString("<pre><code>")
+
+	for _, line := range code {
+		builder.WriteString(html.EscapeString(line) + "\n")
+	}
+	builder.WriteString("</code></pre>\n")
+
+	return i
+}
+
+func renderQuote(builder *strings.Builder, lines []string, start int) int {
+	quoted := []string{}
+	i := start
+
+	for ; i < len(lines); i++ {
+		if quoteRegex.MatchString(lines[i]) {
+			quoted = append(quoted, quoteRegex.ReplaceAllString(lines[i], ""))
+			continue
+		}
+
+		// A paragraph goes on in the quote until a blank line.
+		if isBlank(lines[i]) || len(quoted) == 0 || isBlank(quoted[len(quoted)-1]) || startsBlock(lines[i]) {
+			break
+		}
+		quoted = append(quoted, lines[i])
+	}
+	builder.WriteString("<blockquote>\n" + renderBlocks(quoted, false) + "</blockquote>\n")
+
+	return i
+}
+
+// listMarker returns the kind of list the line starts an item of, with the number of the
+// ordered items and the width of the marker, the item content being indented by it.
+func listMarker(line string) (ordered bool, number int, width int, ok bool) {
+	if match := bulletRegex.FindStringSubmatch(line); match != nil {
+		return false, 0, len(match[0]), true
+	}
+
+	if match := orderedRegex.FindStringSubmatch(line); match != nil {
+		number, _ := strconv.Atoi(match[2])
+		return true, number, len(match[0]), true
+	}
+
+	return false, 0, 0, false
+}
+
+func renderList(builder *strings.Builder, lines []string, start int) int {
+	ordered, number, _, _ := listMarker(lines[start])
+	items := [][]string{}
+	tight := true
+	i := start
+
+	for i < len(lines) {
+		itemOrdered, _, width, ok := listMarker(lines[i])
+
+		if !ok || itemOrdered != ordered {
+			break
+		}
+		item := []string{strings.TrimLeft(lines[i][width:], " \t")}
+		i++
+
+		for i < len(lines) {
+			line := lines[i]
+
+			if isBlank(line) {
+				// A blank line ends the list, unless the item or the list goes on after it.
+				next := i + 1
+
+				for next < len(lines) && isBlank(lines[next]) {
+					next++
+				}
+
+				if next == len(lines) {
+					i = next
+					break
+				}
+
+				if indentOf(lines[next]) >= width {
+					item = append(item, "")
+					tight = false
+					i = next
+					continue
+				}
+
+				if nextOrdered, _, _, ok := listMarker(lines[next]); ok && nextOrdered == ordered {
+					tight = false
+					i = next
+				}
+				break
+			}
+
+			if indentOf(line) >= width {
+				item = append(item, removeIndent(line, width))
+				i++
+				continue
+			}
+
+			// The lines of a paragraph can go on without being indented.
+			if _, _, _, ok := listMarker(line); ok || startsBlock(line) {
+				break
+			}
+			item = append(item, line)
+			i++
+		}
+		items = append(items, item)
+
+		if i < len(lines) && isBlank(lines[i]) {
+			break
+		}
+	}
+
+	switch {
+	case !ordered:
+		builder.WriteString("<ul>\n")
+	case number != 1:
+		fmt.Fprintf(builder, "<ol start=\"%d\">\n", number)
+	default:
+		builder.WriteString("<ol>\n")
+	}
+
+	for _, item := range items {
+		builder.WriteString("<li>" + strings.TrimSuffix(renderBlocks(item, tight), "\n") + "</li>\n")
+	}
+
+	if ordered {
+		builder.WriteString("</ol>\n")
+	} else {
+		builder.WriteString("</ul>\n")
+	}
+
+	return i
+}
+
+func renderParagraph(builder *strings.Builder, lines []string, start int, tight bool) int {
+	paragraph := []string{}
+	i := start
+
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		if i > start && (startsBlock(lines[i]) || bulletRegex.MatchString(lines[i]) || orderedRegex.MatchString(lines[i])) {
+			break
+		}
+		paragraph = append(paragraph, lines[i])
+	}
+	var text strings.Builder
+
+	for j, line := range paragraph {
+		last := j == len(paragraph)-1
+		trimmed := strings.TrimLeft(line, " \t")
+
+		// A line ending with two spaces or a backslash breaks the paragraph line.
+		switch {
+		case !last && strings.HasSuffix(trimmed, "  "):
+			text.WriteString(renderInline(strings.TrimRight(trimmed, " ")) + "<br>\n")
+		case !last && strings.HasSuffix(trimmed, "\\"):
+			text.WriteString(renderInline(strings.TrimSuffix(trimmed, "\\")) + "<br>\n")
+		case last:
+			text.WriteString(renderInline(strings.TrimRight(trimmed, " \t")))
+		default:
+			text.WriteString(renderInline(trimmed) + "\n")
+		}
+	}
+
+	if tight {
+		builder.WriteString(text.String() + "\n")
+	} else {
+		builder.WriteString("<p>" + text.String() + "</p>\n")
+	}
+
+	return i
+}
+
+// startsBlock tells whether the line starts a block interrupting a paragraph.
+func startsBlock(line string) bool {
+	return fenceRegex.MatchString(line) || headingRegex.MatchString(line) ||
+		ruleRegex.MatchString(line) || quoteRegex.MatchString(line)
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// indentOf returns the indentation width of the line, a tab counting as 4 spaces.
+func indentOf(line string) int {
+	width := 0
+
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+
+	return width
+}
+
+// removeIndent removes up to width columns of indentation from the line.
+func removeIndent(line string, width int) string {
+	removed := 0
+
+	for i, r := range line {
+		if removed >= width || (r != ' ' && r != '\t') {
+			return line[i:]
+		}
+
+		if r == '\t' {
+			removed += 4 - removed%4
+		} else {
+			removed++
+		}
+	}
+
+	return ""
+}
+
+// renderInline renders the emphasis, code spans, links and images of the text,
+// escaping everything else.
+func renderInline(text string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(punctuationRunes, text[i+1]) >= 0:
+			builder.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if code, next, ok := codeSpan(text, i); ok {
+				builder.WriteString(code)
+				i = next
+				continue
+			}
+
+		case c == '<':
+			if match := autolinkRegex.FindStringSubmatch(text[i:]); match != nil {
+				fmt.Fprintf(&builder, `<a href="%s">%s</a>`, html.EscapeString(match[1]), html.EscapeString(match[1]))
+				i += len(match[0])
+				continue
+			}
+
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if label, url, title, next, ok := link(text, i+1); ok {
+				builder.WriteString(image(label, url, title))
+				i = next
+				continue
+			}
+
+		case c == '[':
+			if label, url, title, next, ok := link(text, i); ok {
+				builder.WriteString(anchor(label, url, title))
+				i = next
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if rendered, next, ok := emphasis(text, i); ok {
+				builder.WriteString(rendered)
+				i = next
+				continue
+			}
+		}
+		builder.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+
+	return builder.String()
+}
+
+// codeSpan renders the code span starting at i, closed by a backtick run of the same length.
+func codeSpan(text string, i int) (string, int, bool) {
+	run := 0
+
+	for i+run < len(text) && text[i+run] == '`' {
+		run++
+	}
+	fence := text[i : i+run]
+	end := i + run
+
+	for {
+		next := strings.Index(text[end:], fence)
+
+		if next == -1 {
+			return "", 0, false
+		}
+		end += next
+
+		// The closing run must have the same length.
+		if end+run < len(text) && text[end+run] == '`' {
+			for end < len(text) && text[end] == '`' {
+				end++
+			}
+			continue
+		}
+		code := text[i+run : end]
+
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+			code = code[1 : len(code)-1]
+		}
+
+		return "<code>" + html.EscapeString(code) + "</code>", end + run, true
+	}
+}
+
+// link parses the [label](url "title") link starting at i.
+func link(text string, i int) (label, url, title string, next int, ok bool) {
+	depth := 0
+	end := -1
+
+	for j := i; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+
+		if depth == 0 {
+			end = j
+			break
+		}
+	}
+
+	if end == -1 || end+1 >= len(text) || text[end+1] != '(' {
+		return "", "", "", 0, false
+	}
+	closing := closingParenthesis(text[end+2:])
+
+	if closing == -1 {
+		return "", "", "", 0, false
+	}
+	destination := strings.TrimSpace(text[end+2 : end+2+closing])
+	url = destination
+
+	if space := strings.IndexAny(destination, " \t"); space != -1 {
+		url = destination[:space]
+		title = strings.TrimSpace(destination[space:])
+
+		if len(title) < 2 || title[0] != title[len(title)-1] || !strings.ContainsRune(`"'`, rune(title[0])) {
+			return "", "", "", 0, false
+		}
+		title = title[1 : len(title)-1]
+	}
+	url = strings.TrimSuffix(strings.TrimPrefix(url, "<"), ">")
+
+	return text[i+1 : end], url, title, end + 3 + closing, true
+}
+
+// closingParenthesis returns the index of the parenthesis closing the link destination,
+// which can hold balanced parentheses, or -1.
+func closingParenthesis(text string) int {
+	depth := 0
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+
+	return -1
+}
+
+// anchor renders the link, or only its label when the url is not a safe one.
+func anchor(label, url, title string) string {
+	if !IsSafeURL(url) {
+		return renderInline(label)
+	}
+	attributes := fmt.Sprintf(`href="%s"`, html.EscapeString(url))
+
+	if title != "" {
+		attributes += fmt.Sprintf(` title="%s"`, html.EscapeString(title))
+	}
+
+	return fmt.Sprintf("<a %s>%s</a>", attributes, renderInline(label))
+}
+
+// image renders the image, or only its alternative text when the url is not a safe one.
+func image(alt, url, title string) string {
+	if !IsSafeURL(url) {
+		return html.EscapeString(alt)
+	}
+	attributes := fmt.Sprintf(`src="%s" alt="%s"`, html.EscapeString(url), html.EscapeString(alt))
+
+	if title != "" {
+		attributes += fmt.Sprintf(` title="%s"`, html.EscapeString(title))
+	}
+
+	return fmt.Sprintf("<img %s>", attributes)
+}
+
+// emphasis renders the emphasis starting at i: ** and __ for <strong>, * and _ for <em>
+// and ~~ for <del>. The closing delimiter must follow a non space character.
+func emphasis(text string, i int) (string, int, bool) {
+	c := text[i]
+	width := 1
+
+	if i+1 < len(text) && text[i+1] == c {
+		width = 2
+	}
+
+	if c == '~' && width != 2 {
+		return "", 0, false
+	}
+	start := i + width
+
+	// The opening delimiter must be followed by a non space character, and an underscore
+	// must not be within a word.
+	if start >= len(text) || text[start] == ' ' || (c == '_' && i > 0 && isAlphanumeric(text[i-1])) {
+		return "", 0, false
+	}
+
+	for end := start + 1; end <= len(text)-width; end++ {
+		if text[end] != c {
+			continue
+		}
+		run := 1
+
+		for end+run < len(text) && text[end+run] == c {
+			run++
+		}
+
+		// A run of another width belongs to a nested emphasis, and the closing
+		// delimiter can't follow a space or be escaped.
+		if run != width || text[end-1] == ' ' || text[end-1] == '\\' {
+			end += run - 1
+			continue
+		}
+
+		if c == '_' && end+width < len(text) && isAlphanumeric(text[end+width]) {
+			continue
+		}
+		tag := "em"
+
+		switch {
+		case c == '~':
+			tag = "del"
+		case width == 2:
+			tag = "strong"
+		}
+
+		return fmt.Sprintf("<%s>%s</%s>", tag, renderInline(text[start:end]), tag), end + width, true
+	}
+
+	return "", 0, false
+}
+
+func isAlphanumeric(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
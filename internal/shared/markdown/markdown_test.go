@@ -0,0 +1,75 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("it should render the blocks", func(t *testing.T) {
+		for _, tc := range []struct{ source, html string }{
+			{"# Title", "<h1>Title</h1>\n"},
+			{"First\nparagraph\n\nSecond", "<p>First\nparagraph</p>\n<p>Second</p>\n"},
+			{"line one  \nline two", "<p>line one<br>\nline two</p>\n"},
+			{"> quoted\n> text", "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>\n"},
+			{"- one\n- two\n  - nested", "<ul>\n<li>one</li>\n<li>two\n<ul>\n<li>nested</li>\n</ul></li>\n</ul>\n"},
+			{"3. three\n4. four", "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n"},
+			{"- loose\n\n- list", "<ul>\n<li><p>loose</p></li>\n<li><p>list</p></li>\n</ul>\n"},
+			{"```go\nif a < b {}\n```", "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n"},
+			{"    indented code", "<pre><code>indented code\n</code></pre>\n"},
+			{"---", "<hr>\n"},
+		} {
+			assert.Equal(t, tc.html, Render(tc.source), tc.source)
+		}
+	})
+
+	t.Run("it should render the inline elements", func(t *testing.T) {
+		for _, tc := range []struct{ source, html string }{
+			{"*em* and **strong** and ~~del~~", "<p><em>em</em> and <strong>strong</strong> and <del>del</del></p>\n"},
+			{"*a **b** c*", "<p><em>a <strong>b</strong> c</em></p>\n"},
+			{"snake_case_word and 5 * 3 * 2", "<p>snake_case_word and 5 * 3 * 2</p>\n"},
+			{"`a <b>` and \\*not em\\*", "<p><code>a &lt;b&gt;</code> and *not em*</p>\n"},
+			{
+				`[link](https://example.com/a_(b) "Title")`,
+				`<p><a href="https://example.com/a_(b)" title="Title" rel="nofollow noopener noreferrer">link</a></p>` + "\n",
+			},
+			{"![alt](/image.png)", `<p><img src="/image.png" alt="alt"></p>` + "\n"},
+			{
+				"<https://example.com?a=1&b=2>",
+				`<p><a href="https://example.com?a=1&amp;b=2" rel="nofollow noopener noreferrer">https://example.com?a=1&amp;b=2</a></p>` + "\n",
+			},
+		} {
+			assert.Equal(t, tc.html, Render(tc.source), tc.source)
+		}
+	})
+
+	t.Run("it should escape the raw html and drop the unsafe links", func(t *testing.T) {
+		for _, tc := range []struct{ source, html string }{
+			{"<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+			{`<iframe src="https://example.com"></iframe>`, "<p>&lt;iframe src=&#34;https://example.com&#34;&gt;&lt;/iframe&gt;</p>\n"},
+			{"[click](javascript:alert(1))", "<p>click</p>\n"},
+			{"![x](data:text/html;base64,PHNjcmlwdD4=)", "<p>x</p>\n"},
+		} {
+			assert.Equal(t, tc.html, Render(tc.source), tc.source)
+		}
+	})
+}
+
+func TestSanitize(t *testing.T) {
+	t.Run("it should only keep the allowed elements and attributes", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		_assert.Equal("<p>text</p>", Sanitize(`<p onclick="alert(1)">text</p>`))
+		_assert.Equal("<p>before after</p>", Sanitize(`<p>before <script>alert(1)</script><iframe src="x"></iframe>after</p>`))
+		_assert.Equal("<p>text</p>", Sanitize(`<p><span style="color: red">text</span></p>`))
+		_assert.Equal(`<a rel="nofollow noopener noreferrer">x</a>`, Sanitize(`<a href="JaVaScRiPt:alert(1)">x</a>`))
+		_assert.Equal(`<a rel="nofollow noopener noreferrer">x</a>`, Sanitize(`<a href="&#106;avascript:alert(1)">x</a>`))
+		_assert.Equal(`<img alt="x">`, Sanitize(`<img src="data:image/png;base64,AAAA" alt="x" onerror="alert(1)">`))
+		_assert.Equal(`<code>x</code>`, Sanitize(`<code class="evil">x</code>`))
+	})
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "Title\nSome emphasis & code.\none\ntwo", PlainText(Render("# Title\n\nSome *emphasis* & `code`.\n\n- one\n- two")))
+}
@@ -0,0 +1,158 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	htmlParser "golang.org/x/net/html"
+)
+
+// allowedTags are the elements kept by Sanitize, with their allowed attributes.
+var allowedTags = map[string][]string{
+	"p": {}, "br": {}, "hr": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"strong": {}, "em": {}, "del": {}, "code": {"class"}, "pre": {},
+	"blockquote": {}, "ul": {}, "ol": {"start"}, "li": {},
+	"a":   {"href", "title"},
+	"img": {"src", "alt", "title"},
+}
+
+// inlineTags are the allowed elements which don't separate the text in blocks.
+var inlineTags = []string{"strong", "em", "del", "code", "a", "img"}
+
+// droppedTags are the elements removed along with their content, instead of only their tags.
+var droppedTags = []string{"script", "style", "iframe", "object", "embed", "template", "noscript", "textarea", "title"}
+
+var (
+	safeSchemes     = []string{"http", "https", "mailto"}
+	codeClassRegex  = regexp.MustCompile(`^language-[A-Za-z0-9_+#-]+$`)
+	startValueRegex = regexp.MustCompile(`^\d{1,9}$`)
+)
+
+// Sanitize keeps the allowed elements and attributes of the HTML, and escapes
+// the text of the others. The links are made nofollow.
+func Sanitize(source string) string {
+	tokenizer := htmlParser.NewTokenizer(strings.NewReader(source))
+	var builder strings.Builder
+	dropping := ""
+
+	for {
+		tokenType := tokenizer.Next()
+
+		if tokenType == htmlParser.ErrorToken {
+			return builder.String()
+		}
+		token := tokenizer.Token()
+
+		if dropping != "" {
+			if tokenType == htmlParser.EndTagToken && token.Data == dropping {
+				dropping = ""
+			}
+			continue
+		}
+
+		switch tokenType {
+		case htmlParser.TextToken:
+			builder.WriteString(html.EscapeString(token.Data))
+
+		case htmlParser.StartTagToken, htmlParser.SelfClosingTagToken:
+			if slices.Contains(droppedTags, token.Data) {
+				if tokenType == htmlParser.StartTagToken {
+					dropping = token.Data
+				}
+				continue
+			}
+
+			if attributes, ok := allowedTags[token.Data]; ok {
+				builder.WriteString(startTag(token, attributes))
+			}
+
+		case htmlParser.EndTagToken:
+			if _, ok := allowedTags[token.Data]; ok && token.Data != "br" && token.Data != "hr" && token.Data != "img" {
+				builder.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+func startTag(token htmlParser.Token, allowed []string) string {
+	var builder strings.Builder
+	builder.WriteString("<" + token.Data)
+
+	for _, attribute := range token.Attr {
+		if attribute.Namespace != "" || !slices.Contains(allowed, attribute.Key) || !isSafeAttribute(token.Data, attribute) {
+			continue
+		}
+		builder.WriteString(" " + attribute.Key + `="` + html.EscapeString(attribute.Val) + `"`)
+	}
+
+	if token.Data == "a" {
+		builder.WriteString(` rel="nofollow noopener noreferrer"`)
+	}
+	builder.WriteString(">")
+
+	return builder.String()
+}
+
+func isSafeAttribute(tag string, attribute htmlParser.Attribute) bool {
+	switch attribute.Key {
+	case "href", "src":
+		return IsSafeURL(attribute.Val)
+	case "class":
+		return tag == "code" && codeClassRegex.MatchString(attribute.Val)
+	case "start":
+		return startValueRegex.MatchString(attribute.Val)
+	}
+
+	return true
+}
+
+// IsSafeURL tells whether the url is a relative one or uses the http, https or mailto scheme.
+func IsSafeURL(rawURL string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+
+	if err != nil {
+		return false
+	}
+
+	if parsed.Scheme == "" {
+		// A relative url can't hide a scheme before its first slash.
+		return !strings.Contains(strings.SplitN(parsed.Path, "/", 2)[0], ":")
+	}
+
+	return slices.Contains(safeSchemes, strings.ToLower(parsed.Scheme))
+}
+
+// PlainText returns the text of the HTML, its blocks separated by new lines.
+func PlainText(source string) string {
+	tokenizer := htmlParser.NewTokenizer(strings.NewReader(source))
+	var builder strings.Builder
+
+	for {
+		tokenType := tokenizer.Next()
+
+		switch tokenType {
+		case htmlParser.ErrorToken:
+			lines := strings.Split(builder.String(), "\n")
+
+			for i := range lines {
+				lines[i] = strings.TrimSpace(lines[i])
+			}
+
+			return strings.Join(slices.DeleteFunc(lines, func(line string) bool { return line == "" }), "\n")
+
+		case htmlParser.TextToken:
+			builder.WriteString(string(tokenizer.Text()))
+
+		case htmlParser.StartTagToken, htmlParser.EndTagToken, htmlParser.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+
+			if _, ok := allowedTags[string(name)]; ok && !slices.Contains(inlineTags, string(name)) {
+				builder.WriteString("\n")
+			}
+		}
+	}
+}
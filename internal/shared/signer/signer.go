@@ -0,0 +1,62 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidToken = errors.New("the signed token is invalid")
+
+// Signer signs payloads in tokens with the "<payload>.<signature>" format, both base64
+// url encoded. The signature is the HMAC-SHA256 of the purpose and the payload, so that
+// a token signed for a purpose can't be used for another one with the same key.
+type Signer struct {
+	key     []byte
+	purpose string
+}
+
+func NewSigner(key, purpose string) *Signer {
+	return &Signer{
+		key:     []byte(key),
+		purpose: purpose,
+	}
+}
+
+// Sign returns the token holding the payload and its signature.
+func (signer *Signer) Sign(payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signer.mac(payload))
+}
+
+// Verify returns the payload of the token, or fails with ErrInvalidToken when the token
+// is malformed or its signature doesn't match.
+func (signer *Signer) Verify(token string) ([]byte, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+
+	if err != nil || !hmac.Equal(signature, signer.mac(payload)) {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+func (signer *Signer) mac(payload []byte) []byte {
+	mac := hmac.New(sha256.New, signer.key)
+	mac.Write([]byte(signer.purpose + ":"))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,44 @@
+package signer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner(t *testing.T) {
+
+	t.Run("it should return the payload of the tokens it signed", func(t *testing.T) {
+		_assert := assert.New(t)
+		signer := NewSigner("secret", "unsubscribe")
+
+		payload, err := signer.Verify(signer.Sign([]byte("signed payload")))
+
+		if _assert.NoError(err) {
+			_assert.Equal([]byte("signed payload"), payload)
+		}
+	})
+
+	t.Run("it should fail and return ErrInvalidToken when the token was tampered with", func(t *testing.T) {
+		signer := NewSigner("secret", "unsubscribe")
+		token := signer.Sign([]byte("signed payload"))
+		_, signature, _ := strings.Cut(token, ".")
+		tampered := NewSigner("secret", "unsubscribe").Sign([]byte("forged payload"))
+		encodedPayload, _, _ := strings.Cut(tampered, ".")
+
+		for _, token := range []string{"", "no-signature", token + "x", "!." + signature, encodedPayload + "." + signature} {
+			_, err := signer.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+
+	t.Run("it should fail and return ErrInvalidToken when the token was signed with another key or purpose", func(t *testing.T) {
+		signer := NewSigner("secret", "unsubscribe")
+
+		for _, other := range []*Signer{NewSigner("other", "unsubscribe"), NewSigner("secret", "verification_link")} {
+			_, err := signer.Verify(other.Sign([]byte("signed payload")))
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		}
+	})
+}
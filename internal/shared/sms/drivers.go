@@ -0,0 +1,84 @@
+package sms
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	HttpDriver   = "http"
+	LogDriver    = "log"
+	MemoryDriver = "memory"
+)
+
+var ErrUnknownDriver = errors.New("unknown sms driver")
+
+type DriverConfig struct {
+	Driver string
+	From   string
+	// GatewayURL is the endpoint the http driver posts the messages to.
+	GatewayURL string
+	// GatewayToken is sent by the http driver as a bearer token.
+	GatewayToken string
+}
+
+// NewSender returns the sender of the configured driver. Only the http driver
+// needs a gateway, the others are meant for development and testing.
+func NewSender(config DriverConfig, logger *logger.Log) (Sender, error) {
+	switch config.Driver {
+	case HttpDriver:
+		return NewHttpSender(config.GatewayURL, config.GatewayToken, config.From, nil)
+	case LogDriver:
+		return NewLogSender(logger), nil
+	case MemoryDriver:
+		return NewMemorySender(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, config.Driver)
+	}
+}
+
+type logSender struct {
+	logger *logger.Log
+}
+
+func NewLogSender(logger *logger.Log) *logSender {
+	return &logSender{
+		logger: logger,
+	}
+}
+
+func (sender *logSender) Send(ctx context.Context, message *Message) error {
+	sender.logger.Info.Printf("sms to %s\n\n%s\n", message.To, message.Body)
+	return nil
+}
+
+// MemorySender keeps the messages instead of sending them, so that
+// the tests can inspect what would have been sent.
+type MemorySender struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (sender *MemorySender) Send(ctx context.Context, message *Message) error {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	sender.sent = append(sender.sent, *message)
+
+	return nil
+}
+
+// Sent returns the messages sent so far, from the oldest to the newest.
+func (sender *MemorySender) Sent() []Message {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	return append([]Message{}, sender.sent...)
+}
@@ -0,0 +1,77 @@
+package sms
+
+import (
+	"comu/internal/shared/logger"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSender(t *testing.T) {
+
+	t.Run("it should return the sender of the configured driver", func(t *testing.T) {
+		_assert := assert.New(t)
+
+		sender, err := NewSender(DriverConfig{Driver: LogDriver}, logger.NewSpyLogger())
+		_assert.NoError(err)
+		_assert.IsType(&logSender{}, sender)
+
+		sender, err = NewSender(DriverConfig{Driver: HttpDriver, GatewayURL: "http://localhost:9090"}, logger.NewSpyLogger())
+		_assert.NoError(err)
+		_assert.IsType(&httpSender{}, sender)
+
+		sender, err = NewSender(DriverConfig{Driver: MemoryDriver}, logger.NewSpyLogger())
+		_assert.NoError(err)
+		_assert.IsType(&MemorySender{}, sender)
+	})
+
+	t.Run("it should fail and return ErrUnknownDriver", func(t *testing.T) {
+		_, err := NewSender(DriverConfig{Driver: "pigeon"}, logger.NewSpyLogger())
+		assert.ErrorIs(t, err, ErrUnknownDriver)
+	})
+}
+
+func TestHttpSender(t *testing.T) {
+
+	t.Run("it should post the message to the gateway", func(t *testing.T) {
+		_assert := assert.New(t)
+		var received map[string]string
+		var authorization string
+
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer gateway.Close()
+
+		sender, _ := NewHttpSender(gateway.URL, "secret-token", "Comu", gateway.Client())
+		err := sender.Send(context.Background(), &Message{To: "+33601020304", Body: "Your code is 482913"})
+
+		if _assert.NoError(err) {
+			_assert.Equal("Bearer secret-token", authorization)
+			_assert.Equal(map[string]string{
+				"from": "Comu",
+				"to":   "+33601020304",
+				"body": "Your code is 482913",
+			}, received)
+		}
+	})
+
+	t.Run("it should fail and return ErrGatewayRejected", func(t *testing.T) {
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid phone number", http.StatusBadRequest)
+		}))
+		defer gateway.Close()
+
+		sender, _ := NewHttpSender(gateway.URL, "", "Comu", gateway.Client())
+		err := sender.Send(context.Background(), &Message{To: "+0", Body: "Your code is 482913"})
+
+		assert.ErrorIs(t, err, ErrGatewayRejected)
+		assert.ErrorContains(t, err, "invalid phone number")
+	})
+}
@@ -0,0 +1,80 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultHttpTimeout = 10 * time.Second
+
+var ErrGatewayRejected = errors.New("the sms gateway rejected the message")
+
+type httpSender struct {
+	url    string
+	token  string
+	from   string
+	client *http.Client
+}
+
+// NewHttpSender returns a sender posting the messages as JSON to the gateway url:
+//
+//	{"from": "Comu", "to": "+33601020304", "body": "..."}
+//
+// Any status other than a 2xx one is an error. A nil client uses a default one.
+func NewHttpSender(url, token, from string, client *http.Client) (*httpSender, error) {
+	if url == "" {
+		return nil, errors.New("the sms gateway url is missing")
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: defaultHttpTimeout}
+	}
+
+	return &httpSender{
+		url:    url,
+		token:  token,
+		from:   from,
+		client: client,
+	}, nil
+}
+
+func (sender *httpSender) Send(ctx context.Context, message *Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"from": sender.from,
+		"to":   message.To,
+		"body": message.Body,
+	})
+
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sender.url, bytes.NewReader(payload))
+
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if sender.token != "" {
+		req.Header.Set("Authorization", "Bearer "+sender.token)
+	}
+	res, err := sender.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%w: %s %s", ErrGatewayRejected, res.Status, bytes.TrimSpace(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package sms
+
+import "context"
+
+// Message is a text message ready to be delivered to a phone number in the
+// E.164 format.
+type Message struct {
+	To   string
+	Body string
+}
+
+// Sender delivers the text messages.
+type Sender interface {
+	Send(context.Context, *Message) error
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS oidc_states (
+    value VARCHAR(255) PRIMARY KEY,
+    nonce VARCHAR(255) NOT NULL,
+    provider VARCHAR(100) NOT NULL,
+    expired_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE oidc_states;
+-- +goose StatementEnd
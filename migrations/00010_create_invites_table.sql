@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS invites (
+    code VARCHAR(64) PRIMARY KEY,
+    created_by BINARY(16) NOT NULL,
+    max_uses INT UNSIGNED NOT NULL DEFAULT 1,
+    uses INT UNSIGNED NOT NULL DEFAULT 0,
+    expired_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX invite_created_by_idx (created_by)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invites;
+-- +goose StatementEnd
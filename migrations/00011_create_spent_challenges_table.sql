@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS spent_challenges (
+    id VARCHAR(64) PRIMARY KEY,
+    expired_at DATETIME NOT NULL,
+
+    INDEX spent_challenge_expired_at_idx (expired_at)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE spent_challenges;
+-- +goose StatementEnd
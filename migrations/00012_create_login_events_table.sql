@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS login_events (
+    id BINARY(16) PRIMARY KEY,
+    user_id BINARY(16) NULL,
+    email VARCHAR(250) NOT NULL,
+    success BOOLEAN NOT NULL,
+    method VARCHAR(50) NOT NULL,
+    ip VARCHAR(45) NOT NULL DEFAULT "",
+    user_agent VARCHAR(512) NOT NULL DEFAULT "",
+    refresh_token VARCHAR(255) NOT NULL DEFAULT "",
+    revoke_token VARCHAR(64) NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX login_event_user_id_created_at_idx (user_id, created_at)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE login_events;
+-- +goose StatementEnd
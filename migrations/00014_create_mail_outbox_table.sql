@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS mail_outbox (
+    id BINARY(16) PRIMARY KEY,
+    recipient VARCHAR(250) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    html_body MEDIUMTEXT NOT NULL,
+    text_body MEDIUMTEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT "pending",
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL,
+    next_attempt_at DATETIME NOT NULL,
+    locked_until DATETIME NULL,
+    claim_id CHAR(36) NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at DATETIME NULL,
+
+    INDEX mail_outbox_due_idx (status, next_attempt_at),
+    INDEX mail_outbox_claim_idx (claim_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS mail_outbox;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id BINARY(16) PRIMARY KEY,
+    frequency VARCHAR(16) NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_digest_subscriptions_frequency (frequency)
+);
+
+CREATE TABLE IF NOT EXISTS sent_digests (
+    user_id BINARY(16) NOT NULL,
+    period_key VARCHAR(32) NOT NULL,
+    sent_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, period_key)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE sent_digests;
+DROP TABLE digest_subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN phone VARCHAR(20) NULL,
+    ADD COLUMN phone_verified_at DATETIME NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN phone_verified_at,
+    DROP COLUMN phone;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE otp_codes
+    ADD COLUMN link_nonce VARCHAR(64) NOT NULL DEFAULT "",
+    ADD INDEX otp_code_link_nonce_idx (link_nonce);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE otp_codes
+    DROP INDEX otp_code_link_nonce_idx,
+    DROP COLUMN link_nonce;
+-- +goose StatementEnd
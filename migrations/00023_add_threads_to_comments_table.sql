@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE comments
+    ADD COLUMN parent_id BINARY(16) NULL,
+    ADD COLUMN depth INT NOT NULL DEFAULT 0,
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD INDEX comments_post_parent_idx (post_id, parent_id, created_at),
+    ADD INDEX comments_parent_idx (parent_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE comments
+    DROP INDEX comments_parent_idx,
+    DROP INDEX comments_post_parent_idx,
+    DROP COLUMN deleted_at,
+    DROP COLUMN depth,
+    DROP COLUMN parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reactions (
+    target_type VARCHAR(16) NOT NULL,
+    target_id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (target_type, target_id, user_id, kind)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reactions;
+-- +goose StatementEnd
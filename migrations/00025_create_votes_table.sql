@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS votes (
+    target_type VARCHAR(16) NOT NULL,
+    target_id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    value TINYINT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (target_type, target_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE posts
+    ADD COLUMN score INT NOT NULL DEFAULT 0,
+    ADD COLUMN hot DOUBLE NOT NULL DEFAULT 0,
+    ADD INDEX posts_score_idx (score, id),
+    ADD INDEX posts_hot_idx (hot, id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE posts SET hot = TIMESTAMPDIFF(SECOND, '2005-12-08 07:46:43', created_at) / 45000;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE comments ADD COLUMN score INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE comments DROP COLUMN score;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE posts
+    DROP INDEX posts_hot_idx,
+    DROP INDEX posts_score_idx,
+    DROP COLUMN hot,
+    DROP COLUMN score;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE votes;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS search_documents (
+    id BINARY(16) PRIMARY KEY,
+    doc_type VARCHAR(16) NOT NULL,
+    post_id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    tags VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+
+    INDEX search_documents_post_idx (post_id),
+    FULLTEXT INDEX search_documents_fulltext_idx (title, content, tags)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO search_documents (id, doc_type, post_id, user_id, title, content, tags, created_at)
+SELECT p.id, 'post', p.id, p.user_id, p.title, p.content, COALESCE((
+    SELECT GROUP_CONCAT(t.name SEPARATOR ' ')
+    FROM post_tags pt INNER JOIN tags t ON t.id = pt.tag_id
+    WHERE pt.post_id = p.id
+), ''), p.created_at
+FROM posts p;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO search_documents (id, doc_type, post_id, user_id, content, created_at)
+SELECT id, 'comment', post_id, user_id, content, created_at
+FROM comments
+WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS search_documents;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'published',
+    ADD COLUMN publish_at DATETIME NULL,
+    ADD INDEX posts_status_publish_at_idx (status, publish_at),
+    ADD INDEX posts_user_status_idx (user_id, status, created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE posts SET publish_at = created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts
+    DROP INDEX posts_user_status_idx,
+    DROP INDEX posts_status_publish_at_idx,
+    DROP COLUMN publish_at,
+    DROP COLUMN status;
+-- +goose StatementEnd
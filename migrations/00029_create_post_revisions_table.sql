@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id BINARY(16) PRIMARY KEY,
+    post_id BINARY(16) NOT NULL,
+    number INT NOT NULL,
+    author_id BINARY(16) NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    content TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY post_revisions_number_unique (post_id, number),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO post_revisions (id, post_id, number, author_id, title, content, created_at)
+SELECT UUID_TO_BIN(UUID()), id, 1, user_id, title, content, updated_at FROM posts;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS post_revisions;
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+    ADD COLUMN content_html MEDIUMTEXT NOT NULL,
+    ADD COLUMN excerpt VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN word_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN reading_time INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE comments ADD COLUMN content_html TEXT NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE post_revisions ADD COLUMN content_html MEDIUMTEXT NOT NULL;
+-- +goose StatementEnd
+
+-- The existing contents were plain text: they're rendered as an escaped paragraph,
+-- and their words counted from the spaces, until they're edited.
+-- +goose StatementBegin
+UPDATE posts SET
+    content_html = CONCAT('<p>', REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(
+        content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '\n', '<br>\n'), '</p>'),
+    excerpt = IF(CHAR_LENGTH(content) > 200, CONCAT(LEFT(content, 200), '…'), content),
+    word_count = IF(TRIM(content) = '', 0, CHAR_LENGTH(TRIM(content)) - CHAR_LENGTH(REPLACE(TRIM(content), ' ', '')) + 1),
+    reading_time = CEIL(word_count / 200);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE comments SET
+    content_html = IF(content = '', '', CONCAT('<p>', REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(
+        content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '\n', '<br>\n'), '</p>'));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE post_revisions SET
+    content_html = CONCAT('<p>', REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(
+        content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '\n', '<br>\n'), '</p>');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE post_revisions DROP COLUMN content_html;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE comments DROP COLUMN content_html;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE posts
+    DROP COLUMN reading_time,
+    DROP COLUMN word_count,
+    DROP COLUMN excerpt,
+    DROP COLUMN content_html;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD INDEX posts_deleted_at_idx (deleted_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM comments WHERE post_id NOT IN (SELECT id FROM posts);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM post_follows WHERE post_id NOT IN (SELECT id FROM posts);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE comments
+    ADD CONSTRAINT comments_post_fk FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE post_follows
+    ADD CONSTRAINT post_follows_post_fk FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE post_follows DROP FOREIGN KEY post_follows_post_fk;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE comments DROP FOREIGN KEY comments_post_fk;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE posts
+    DROP INDEX posts_deleted_at_idx,
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS phone_verifications (
+    user_id BINARY(16) PRIMARY KEY,
+    phone VARCHAR(20) NOT NULL DEFAULT "",
+    count INT UNSIGNED NOT NULL DEFAULT 0,
+    last_sent_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE phone_verifications;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE oidc_states
+    ADD COLUMN code_verifier VARCHAR(128) NOT NULL DEFAULT '' AFTER nonce;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE oidc_states
+    DROP COLUMN code_verifier;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+    DROP INDEX posts_score_idx,
+    DROP INDEX posts_hot_idx,
+    ADD INDEX posts_score_idx (score, created_at, id),
+    ADD INDEX posts_hot_idx (hot, created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts
+    DROP INDEX posts_hot_idx,
+    DROP INDEX posts_score_idx,
+    ADD INDEX posts_score_idx (score, id),
+    ADD INDEX posts_hot_idx (hot, id);
+-- +goose StatementEnd